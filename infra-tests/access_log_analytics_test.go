@@ -0,0 +1,167 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	logstypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/aws-sdk-go-v2/service/firehose"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// accessLogDeliveryTimeout bounds how long a request may take to reach the API's access log group
+const accessLogDeliveryTimeout = 3 * time.Minute
+
+// logsInsightsQueryTimeout bounds how long a Logs Insights query may run before the test gives up
+const logsInsightsQueryTimeout = 2 * time.Minute
+
+// validateAccessLogAnalytics validates that API access logs reach the analytics destination
+func validateAccessLogAnalytics(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+
+	apiClient := apigatewayv2.NewFromConfig(cfg)
+	logsClient := cloudwatchlogs.NewFromConfig(cfg)
+
+	deployedAPI := requireAPI(t, resolver)
+	apiId := deployedAPI.ID
+	apiEndpoint := deployedAPI.Endpoint
+
+	stage, err := apiClient.GetStage(context.TODO(), &apigatewayv2.GetStageInput{
+		ApiId:     aws.String(apiId),
+		StageName: aws.String("$default"),
+	})
+	require.NoError(t, err)
+
+	if stage.AccessLogSettings == nil || stage.AccessLogSettings.DestinationArn == nil {
+		t.Skipf("Stage $default of %s has no access logging configured", resolver.APIName())
+	}
+
+	// HTTP APIs deliver access logs to CloudWatch Logs; analytics pipelines hang off that log group
+	logGroup := logGroupNameFromArn(*stage.AccessLogSettings.DestinationArn)
+	require.NotEmpty(t, logGroup, "Access log destination %s is not a log group", *stage.AccessLogSettings.DestinationArn)
+	require.NotNil(t, stage.AccessLogSettings.Format)
+	assert.Contains(t, *stage.AccessLogSettings.Format, "$context.requestId", "Access log format must include the request ID")
+	assert.Contains(t, *stage.AccessLogSettings.Format, `"userAgent":"$context.identity.userAgent"`, "Access log format must include the user agent tagged requests are found by")
+
+	t.Run("Analytics_Destination", func(t *testing.T) {
+		filters, err := logsClient.DescribeSubscriptionFilters(context.TODO(), &cloudwatchlogs.DescribeSubscriptionFiltersInput{
+			LogGroupName: aws.String(logGroup),
+		})
+		require.NoError(t, err)
+
+		var streamNames []string
+		for _, filter := range filters.SubscriptionFilters {
+			if strings.Contains(aws.ToString(filter.DestinationArn), ":firehose:") {
+				arnParts := strings.Split(*filter.DestinationArn, "/")
+				streamNames = append(streamNames, arnParts[len(arnParts)-1])
+			}
+		}
+
+		// Without a Firehose pipeline the log group itself is the Logs Insights analytics store
+		if len(streamNames) == 0 {
+			t.Logf("No Firehose subscription on %s; Logs Insights is the analytics store", logGroup)
+			return
+		}
+
+		firehoseClient := firehose.NewFromConfig(cfg)
+		for _, streamName := range streamNames {
+			stream, err := firehoseClient.DescribeDeliveryStream(context.TODO(), &firehose.DescribeDeliveryStreamInput{
+				DeliveryStreamName: aws.String(streamName),
+			})
+			require.NoError(t, err, "Failed to describe delivery stream %s", streamName)
+
+			description := stream.DeliveryStreamDescription
+			assert.Equal(t, "ACTIVE", string(description.DeliveryStreamStatus), "Delivery stream %s is not active", streamName)
+			require.NotEmpty(t, description.Destinations)
+
+			s3Destination := description.Destinations[0].ExtendedS3DestinationDescription
+			require.NotNil(t, s3Destination, "Delivery stream %s does not deliver to S3", streamName)
+
+			// Partitioning keeps the analytics tables queryable by date
+			prefix := aws.ToString(s3Destination.Prefix)
+			partitioned := strings.Contains(prefix, "!{timestamp:") || strings.Contains(prefix, "!{partitionKeyFrom")
+			if s3Destination.DynamicPartitioningConfiguration != nil && aws.ToBool(s3Destination.DynamicPartitioningConfiguration.Enabled) {
+				partitioned = true
+			}
+			assert.True(t, partitioned, "Delivery stream %s writes to S3 without partitioning (prefix %q)", streamName, prefix)
+		}
+	})
+
+	t.Run("Tagged_Requests_Delivered", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("Skipping access log delivery check in short mode")
+		}
+		start := time.Now().Add(-time.Minute)
+		tag := fmt.Sprintf("infra-tests-%d", time.Now().UnixNano())
+
+		var requestIds []string
+		for i := 0; i < 3; i++ {
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/health", apiEndpoint), nil)
+			require.NoError(t, err)
+			req.Header.Set("User-Agent", tag)
+
+			resp, err := suiteHTTP.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			requestId := resp.Header.Get("Apigw-Requestid")
+			require.NotEmpty(t, requestId, "Response is missing the API Gateway request ID")
+			requestIds = append(requestIds, requestId)
+		}
+
+		// Firehose ships from the log group, so the tag finds the same entries in both analytics stores
+		var logged []string
+		for _, entry := range awaitAccessLogEntries(t, logsClient, logGroup, fmt.Sprintf(`{ $.userAgent = %q }`, tag), len(requestIds), start) {
+			logged = append(logged, fmt.Sprint(entry["requestId"]))
+		}
+		assert.ElementsMatch(t, requestIds, logged, "Access log entries tagged %s in %s", tag, logGroup)
+	})
+}
+
+// logGroupNameFromArn extracts the log group name from a CloudWatch Logs ARN
+func logGroupNameFromArn(arn string) string {
+	_, name, found := strings.Cut(arn, ":log-group:")
+	if !found {
+		return ""
+	}
+	return strings.TrimSuffix(name, ":*")
+}
+
+// runLogsInsightsQuery runs a Logs Insights query and waits for its results
+func runLogsInsightsQuery(t *testing.T, client *cloudwatchlogs.Client, logGroup, query string, start, end time.Time) [][]logstypes.ResultField {
+	started, err := client.StartQuery(context.TODO(), &cloudwatchlogs.StartQueryInput{
+		LogGroupName: aws.String(logGroup),
+		QueryString:  aws.String(query),
+		StartTime:    aws.Int64(start.Unix()),
+		EndTime:      aws.Int64(end.Unix()),
+	})
+	require.NoError(t, err, "Failed to start Logs Insights query on %s", logGroup)
+
+	var results [][]logstypes.ResultField
+	waitFor(t, logsInsightsQueryTimeout, fmt.Sprintf("Logs Insights query on %s", logGroup), func(ctx context.Context) (bool, error) {
+		output, err := client.GetQueryResults(ctx, &cloudwatchlogs.GetQueryResultsInput{
+			QueryId: started.QueryId,
+		})
+		if err != nil {
+			return false, err
+		}
+
+		switch output.Status {
+		case logstypes.QueryStatusComplete:
+			results = output.Results
+			return true, nil
+		case logstypes.QueryStatusFailed, logstypes.QueryStatusCancelled, logstypes.QueryStatusTimeout:
+			return false, fmt.Errorf("query ended with status %s", output.Status)
+		}
+		return false, nil
+	})
+	return results
+}
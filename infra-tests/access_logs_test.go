@@ -0,0 +1,90 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/expectations"
+)
+
+// validateAccessLogs checks the $default stage writes JSON access logs with the required fields to the
+// API's log group, and that a request made by the test shows up there with its route and status
+func validateAccessLogs(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+	logsClient := cloudwatchlogs.NewFromConfig(cfg)
+	api := requireAPI(t, resolver)
+
+	stage, err := apigatewayv2.NewFromConfig(cfg).GetStage(context.TODO(), &apigatewayv2.GetStageInput{
+		ApiId:     aws.String(api.ID),
+		StageName: aws.String("$default"),
+	})
+	require.NoError(t, err)
+
+	settings := stage.AccessLogSettings
+	require.True(t, settings != nil && settings.DestinationArn != nil, "Stage $default of %s has access logging disabled", resolver.APIName())
+	expectedGroup := fmt.Sprintf("/aws/apigateway/%s", resolver.APIName())
+	logGroup := logGroupNameFromArn(aws.ToString(settings.DestinationArn))
+	require.Equal(t, expectedGroup, logGroup, "Access log destination of %s", resolver.APIName())
+
+	t.Run("Log_Group", func(t *testing.T) {
+		validateLogGroup(t, logsClient, logGroup, expectations.For(environment).LogRetentionDays)
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		for _, gap := range checks.AccessLogFormatGaps(aws.ToString(settings.Format), checks.AccessLogFields) {
+			assert.Fail(t, "Incomplete access log format", "Stage $default of %s: %s", resolver.APIName(), gap)
+		}
+	})
+
+	t.Run("Request_Logged", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("Skipping access log delivery in short mode")
+		}
+		start := time.Now().Add(-time.Minute)
+
+		resp, err := suiteHTTP.Get(api.Endpoint + "/health")
+		require.NoError(t, err)
+		resp.Body.Close()
+		requestId := resp.Header.Get("Apigw-Requestid")
+		require.NotEmpty(t, requestId, "Response is missing the API Gateway request ID")
+
+		entry := awaitAccessLogEntries(t, logsClient, logGroup, fmt.Sprintf(`{ $.requestId = %q }`, requestId), 1, start)[0]
+		assert.Equal(t, "GET /health", entry["routeKey"], "Access log entry %v", entry)
+		assert.Equal(t, fmt.Sprint(resp.StatusCode), fmt.Sprint(entry["status"]), "Access log entry %v", entry)
+		assert.NotEmpty(t, entry["integrationLatency"], "Access log entry %v has no integration latency", entry)
+	})
+}
+
+// awaitAccessLogEntries waits until count access log entries matching filterPattern have reached logGroup since
+// start, and returns them decoded
+func awaitAccessLogEntries(t *testing.T, client *cloudwatchlogs.Client, logGroup, filterPattern string, count int, start time.Time) []map[string]any {
+	var entries []map[string]any
+	waitFor(t, accessLogDeliveryTimeout, fmt.Sprintf("%d access log entries matching %s", count, filterPattern), func(ctx context.Context) (bool, error) {
+		events, err := client.FilterLogEvents(ctx, &cloudwatchlogs.FilterLogEventsInput{
+			LogGroupName:  aws.String(logGroup),
+			FilterPattern: aws.String(filterPattern),
+			StartTime:     aws.Int64(start.UnixMilli()),
+		})
+		if err != nil || len(events.Events) < count {
+			return false, err
+		}
+		entries = make([]map[string]any, len(events.Events))
+		for i, event := range events.Events {
+			if err := json.Unmarshal([]byte(aws.ToString(event.Message)), &entries[i]); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	})
+	return entries
+}
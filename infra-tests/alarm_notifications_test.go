@@ -0,0 +1,66 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/discovery"
+)
+
+// listAlarms returns every metric alarm in the deployment's account and region, listed once per run since
+// DescribeAlarms pages through every stack's alarms
+func listAlarms(cfg aws.Config, resolver *discovery.Resolver) ([]cwtypes.MetricAlarm, error) {
+	return discovery.Cached(resolver, "alarms", func() ([]cwtypes.MetricAlarm, error) {
+		return checks.ListAlarms(context.TODO(), cloudwatch.NewFromConfig(cfg))
+	})
+}
+
+// validateAlarmNotifications checks that every alarm of the deployment notifies an existing SNS topic
+// and that each of those topics has a confirmed subscriber to page
+func validateAlarmNotifications(t *testing.T, cfg aws.Config, resolver *discovery.Resolver) {
+	alarms, err := listAlarms(cfg, resolver)
+	require.NoError(t, err)
+
+	// Other stacks in the account are not this deployment's to judge
+	var owned []cwtypes.MetricAlarm
+	for _, alarm := range alarms {
+		if strings.HasPrefix(aws.ToString(alarm.AlarmName), resolver.Settings().BaseName()+"-") {
+			owned = append(owned, alarm)
+		}
+	}
+	require.NotEmpty(t, owned, "No alarms named %s-*", resolver.Settings().BaseName())
+
+	topics := checks.AlarmTopics(owned)
+	assert.Empty(t, topics[""], "Alarms without an SNS alarm action")
+	delete(topics, "")
+
+	snsClient := sns.NewFromConfig(cfg)
+	for topicArn, alarmNames := range topics {
+		recordResource(t, "sns", topicArn)
+
+		_, err := snsClient.GetTopicAttributes(context.TODO(), &sns.GetTopicAttributesInput{TopicArn: aws.String(topicArn)})
+		if !assert.NoError(t, err, "Topic %s notified by %v does not exist", topicArn, alarmNames) {
+			continue
+		}
+
+		var subscriptions []snstypes.Subscription
+		paginator := sns.NewListSubscriptionsByTopicPaginator(snsClient, &sns.ListSubscriptionsByTopicInput{TopicArn: aws.String(topicArn)})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(context.TODO())
+			require.NoError(t, err)
+			subscriptions = append(subscriptions, page.Subscriptions...)
+		}
+		assert.Positive(t, checks.ConfirmedSubscriptions(subscriptions),
+			"Topic %s has no confirmed subscriptions, so %d alarms page nobody (set alert_email in Terraform)", topicArn, len(alarmNames))
+	}
+}
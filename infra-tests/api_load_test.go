@@ -0,0 +1,77 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/load"
+)
+
+// loadRequestTimeout fails a single request under load that takes longer than any SLO allows
+const loadRequestTimeout = 30 * time.Second
+
+// validateAPILoad drives /health and /products at -load-rps for -load-duration and fails when either
+// endpoint misses the -slo-* latency or error rate objectives
+func validateAPILoad(t *testing.T, apiEndpoint string) {
+	opts := load.Options{RPS: *loadRPS, Duration: *loadDuration, Concurrency: *loadConcurrency}
+	slo := load.SLO{P50: *sloP50, P90: *sloP90, P99: *sloP99, MaxErrorRate: *sloErrorRate}
+	client := &http.Client{Timeout: loadRequestTimeout}
+
+	targets := []load.Target{
+		{Name: "GET /health", Do: loadRequest(client, apiEndpoint+"/health")},
+		{Name: "GET /products", Do: loadRequest(client, apiEndpoint+"/products")},
+	}
+
+	samples, err := load.Run(context.TODO(), opts, targets)
+	require.NoError(t, err)
+	stats := load.Summarize(samples)
+
+	for _, target := range targets {
+		t.Run(target.Name, func(t *testing.T) {
+			endpoint, ok := stats[target.Name]
+			require.True(t, ok, "No requests were sent to %s", target.Name)
+
+			t.Logf("%s: %d requests, %.2f%% errors, p50 %s, p90 %s, p99 %s",
+				target.Name, endpoint.Requests, endpoint.ErrorRate()*100, endpoint.P50, endpoint.P90, endpoint.P99)
+			suiteResults.RecordLatency(target.Name+" p50", endpoint.P50)
+			suiteResults.RecordLatency(target.Name+" p99", endpoint.P99)
+			suiteResults.RecordHistogram(target.Name, endpoint.Latencies)
+
+			for _, violation := range slo.Violations(endpoint) {
+				assert.Fail(t, "SLO missed", "%s under %d rps: %s", target.Name, opts.RPS, violation)
+			}
+		})
+	}
+}
+
+// loadRequest returns a request to url with the suite's API key that fails on any non-2xx status
+func loadRequest(client *http.Client, url string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("x-api-key", fmt.Sprintf("infra-tests-%s", suiteConfig.Environment))
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		// Reading the body keeps the connection reusable and counts the transfer in the latency
+		if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+			return err
+		}
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("GET %s: %s", url, resp.Status)
+		}
+		return nil
+	}
+}
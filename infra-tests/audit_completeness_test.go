@@ -0,0 +1,101 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	sfntypes "github.com/aws/aws-sdk-go-v2/service/sfn/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/discovery"
+	"github.com/lambda-java-template/tests/internal/expectations"
+	"github.com/lambda-java-template/tests/internal/payloads"
+	"github.com/lambda-java-template/tests/internal/responses"
+)
+
+// validateAuditCompleteness creates, updates, and deletes a product through the API and runs one order
+// through the workflow, then requires exactly one complete audit record per mutation: event_id, timestamp,
+// and actor on each, and the product's state before and after the change where the action has one.
+// A record that is never written, written twice, or written without these fails, so a silently broken
+// audit pipeline cannot pass for a working one.
+func validateAuditCompleteness(t *testing.T, cfg aws.Config, projectName, environment string) {
+	if testing.Short() {
+		t.Skip("Skipping audit completeness in short mode")
+	}
+	if !expectations.For(environment).AuditedMutations {
+		t.Skipf("The %s profile does not declare AuditedMutations: the product service writes no audit records yet", environment)
+	}
+	resolver := discover(cfg, projectName, environment)
+
+	apiEndpoint := requireAPI(t, resolver).Endpoint
+	dynamoClient := dynamodb.NewFromConfig(cfg)
+	auditTable := resolver.TableName("audit-logs")
+
+	started := time.Now().UTC()
+	name := fmt.Sprintf("%saudit-%d", testNamespace(), started.UnixNano())
+
+	status, body := callAPI(t, http.MethodPost, apiEndpoint+"/products", map[string]any{"name": name, "price": 7.5})
+	require.Equal(t, http.StatusCreated, status, "POST /products: %s", body)
+	var created responses.Product
+	decodeResponse(t, body, &created)
+	t.Cleanup(func() {
+		_, err := dynamoClient.DeleteItem(context.TODO(), &dynamodb.DeleteItemInput{
+			TableName: aws.String(resolver.TableName("products")),
+			Key:       map[string]dynamodbtypes.AttributeValue{"id": &dynamodbtypes.AttributeValueMemberS{Value: created.ID}},
+		})
+		assert.NoError(t, err, "Failed to remove product %s", created.ID)
+	})
+	productURL := fmt.Sprintf("%s/products/%s", apiEndpoint, created.ID)
+
+	status, body = callAPI(t, http.MethodPut, productURL, map[string]any{"name": name + "-updated", "price": 8})
+	require.Equal(t, http.StatusOK, status, "PUT %s: %s", productURL, body)
+	status, body = callAPI(t, http.MethodDelete, productURL, nil)
+	require.Contains(t, []int{http.StatusOK, http.StatusNoContent}, status, "DELETE %s: %s", productURL, body)
+
+	actions := []checks.AuditedAction{
+		{Entity: created.ID, Action: "create", After: true},
+		{Entity: created.ID, Action: "update", Before: true, After: true},
+		{Entity: created.ID, Action: "delete", Before: true},
+	}
+	entities := []string{created.ID}
+
+	stateMachineArn, err := resolver.StateMachineARN(context.TODO(), "order-processing")
+	switch {
+	case errors.Is(err, discovery.ErrNotFound):
+		t.Logf("Auditing the product API only: %v", err)
+	case assert.NoError(t, err):
+		client := sfn.NewFromConfig(cfg)
+		orderId := fmt.Sprintf("%saudit-%d", testNamespace(), started.UnixNano())
+		execution := waitForExecution(t, client, executeWorkflow(t, client, stateMachineArn, payloads.SampleOrder(testNamespace(), orderId)), workflowExecutionTimeout)
+		require.Equal(t, sfntypes.ExecutionStatusSucceeded, execution.status, "Order %s failed: %s %s", execution.arn, execution.error, execution.cause)
+
+		for _, action := range sideEffectActions {
+			actions = append(actions, checks.AuditedAction{Entity: orderId, Action: action})
+		}
+		entities = append(entities, orderId)
+	}
+
+	// Records arrive asynchronously, so the log is read until it is complete or the time runs out
+	var gaps []string
+	assertEventually(t, auditWriteTimeout, func() bool {
+		var records []map[string]dynamodbtypes.AttributeValue
+		for _, entity := range entities {
+			records = append(records, auditRecords(t, dynamoClient, auditTable, entity, started.Add(-time.Minute))...)
+		}
+		gaps = checks.AuditGaps(actions, records)
+		return len(gaps) == 0
+	}, "Audit log %s is incomplete", auditTable)
+	for _, gap := range gaps {
+		t.Errorf("Audit log %s: %s", auditTable, gap)
+	}
+}
@@ -0,0 +1,117 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Expected authorizer caching configuration, as set in terraform/api-gateway.tf
+const (
+	authorizerIdentitySource = "$request.header.x-api-key"
+	authorizerResultTTL      = 300 * time.Second
+)
+
+// authorizerCacheGrace is how long after the TTL a cached decision may take to be evicted
+const authorizerCacheGrace = 2 * time.Minute
+
+// validateAuthorizerCache checks the API key authorizer caches its decisions by the x-api-key header.
+// With -authorizer-cache it also revokes every key by stopping the authorizer from running, and checks a
+// key allowed just before is still allowed from the cache while new keys are refused, until the TTL ends.
+func validateAuthorizerCache(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+	api := requireAPI(t, resolver)
+
+	authorizers, err := apigatewayv2.NewFromConfig(cfg).GetAuthorizers(context.TODO(), &apigatewayv2.GetAuthorizersInput{
+		ApiId: aws.String(api.ID),
+	})
+	require.NoError(t, err)
+
+	var authorizer *types.Authorizer
+	for i, candidate := range authorizers.Items {
+		if aws.ToString(candidate.Name) == fmt.Sprintf("%s-key-authorizer", resolver.APIName()) {
+			authorizer = &authorizers.Items[i]
+		}
+	}
+	require.NotNil(t, authorizer, "API key authorizer not found")
+	ttl := time.Duration(aws.ToInt32(authorizer.AuthorizerResultTtlInSeconds)) * time.Second
+
+	t.Run("Identity_Source", func(t *testing.T) {
+		// Decisions are cached per identity source value, so a key is only ever judged once per TTL
+		assert.Equal(t, []string{authorizerIdentitySource}, authorizer.IdentitySource)
+		assert.Equal(t, authorizerResultTTL, ttl, "Authorizer result TTL")
+		assert.True(t, aws.ToBool(authorizer.EnableSimpleResponses), "Authorizer must use simple responses")
+	})
+
+	t.Run("Cached_Decision", func(t *testing.T) {
+		if !*authorizerCache {
+			t.Skip("Skipping authorizer cache expiry; set -authorizer-cache to run it")
+		}
+		if ttl <= 0 {
+			t.Skip("Authorizer results are not cached")
+		}
+		lambdaClient := lambda.NewFromConfig(cfg)
+		authorizerName := resolver.FunctionName("authorizer-service")
+		requireFunction(t, lambdaClient, authorizerName)
+		productsURL := api.Endpoint + "/products"
+
+		// A key never sent before has no cached decision, so the first request invokes the authorizer
+		key := fmt.Sprintf("%scache-%d", testNamespace(), time.Now().UnixNano())
+		status, body := getWithKey(t, productsURL, "x-api-key", key)
+		require.Equal(t, http.StatusOK, status, "First request with a valid key: %s", body)
+		cachedAt := time.Now()
+
+		revokeAuthorizer(t, lambdaClient, authorizerName)
+
+		status, body = getWithKey(t, productsURL, "x-api-key", key)
+		assert.Equal(t, http.StatusOK, status, "Key allowed %s ago was not served from the cache: %s", time.Since(cachedAt).Round(time.Second), body)
+
+		status, _ = getWithKey(t, productsURL, "x-api-key", key+"-uncached")
+		require.NotEqual(t, http.StatusOK, status, "A key with no cached decision was allowed while the authorizer could not run")
+
+		waitFor(t, ttl+authorizerCacheGrace, "cached decision for "+key+" to expire", func(ctx context.Context) (bool, error) {
+			status, _ := getWithKey(t, productsURL, "x-api-key", key)
+			return status != http.StatusOK, nil
+		})
+		t.Logf("Cached decision expired %s after it was made (TTL %s)", time.Since(cachedAt).Round(time.Second), ttl)
+	})
+}
+
+// revokeAuthorizer sets the authorizer's reserved concurrency to 0, so API Gateway can only allow
+// requests it has a cached decision for. The previous concurrency is restored when the test ends.
+func revokeAuthorizer(t *testing.T, client *lambda.Client, functionName string) {
+	previous, err := client.GetFunctionConcurrency(context.TODO(), &lambda.GetFunctionConcurrencyInput{
+		FunctionName: aws.String(functionName),
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		var err error
+		if previous.ReservedConcurrentExecutions == nil {
+			_, err = client.DeleteFunctionConcurrency(context.TODO(), &lambda.DeleteFunctionConcurrencyInput{
+				FunctionName: aws.String(functionName),
+			})
+		} else {
+			_, err = client.PutFunctionConcurrency(context.TODO(), &lambda.PutFunctionConcurrencyInput{
+				FunctionName:                 aws.String(functionName),
+				ReservedConcurrentExecutions: previous.ReservedConcurrentExecutions,
+			})
+		}
+		assert.NoError(t, err, "Failed to restore the reserved concurrency of %s", functionName)
+	})
+
+	_, err = client.PutFunctionConcurrency(context.TODO(), &lambda.PutFunctionConcurrencyInput{
+		FunctionName:                 aws.String(functionName),
+		ReservedConcurrentExecutions: aws.Int32(0),
+	})
+	require.NoError(t, err, "Failed to revoke %s", functionName)
+}
@@ -0,0 +1,133 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/payloads"
+	"github.com/lambda-java-template/tests/internal/responses"
+)
+
+// authorizerCase is one way of presenting an API key and the outcome the API must give it
+type authorizerCase struct {
+	name string
+	// header and key are sent as is; an empty header sends no key at all
+	header string
+	key    string
+	// status is the API's response: 200 when the authorizer allows the request, 401 when API Gateway
+	// rejects it before invoking the authorizer, 403 when the authorizer denies it
+	status int
+}
+
+// authorizerCases returns the contract of the API key authorizer. The revoked key case is only
+// included when TEST_REVOKED_API_KEY names one.
+func authorizerCases() []authorizerCase {
+	validKey := fmt.Sprintf("infra-tests-%s", suiteConfig.Environment)
+	cases := []authorizerCase{
+		{"Missing_Key", "", "", http.StatusUnauthorized},
+		{"Empty_Key", "x-api-key", "", http.StatusUnauthorized},
+		{"Whitespace_Key", "x-api-key", "   ", http.StatusUnauthorized},
+		{"Valid_Key", "x-api-key", validKey, http.StatusOK},
+		// Header names are case-insensitive and HTTP APIs lower-case them before the identity source is read
+		{"Upper_Case_Header", "X-API-KEY", validKey, http.StatusOK},
+		{"Mixed_Case_Header", "X-Api-Key", validKey, http.StatusOK},
+		// Surrounding whitespace is not part of a header value
+		{"Padded_Key", "x-api-key", "  " + validKey + "  ", http.StatusOK},
+	}
+	if suiteConfig.RevokedAPIKey != "" {
+		cases = append(cases, authorizerCase{"Revoked_Key", "x-api-key", suiteConfig.RevokedAPIKey, http.StatusForbidden})
+	}
+	return cases
+}
+
+// validateAuthorizerContract checks the exact response of a protected route for each way of presenting
+// an API key, and the simple response the authorizer function returns for the same key
+func validateAuthorizerContract(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+
+	productsURL := requireAPI(t, resolver).Endpoint + "/products"
+	lambdaClient := lambda.NewFromConfig(cfg)
+	authorizerName := resolver.FunctionName("authorizer-service")
+
+	for _, tc := range authorizerCases() {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Run("API", func(t *testing.T) {
+				status, body := getWithKey(t, productsURL, tc.header, tc.key)
+				require.Equal(t, tc.status, status, "GET %s: %s", productsURL, body)
+
+				switch status {
+				case http.StatusOK:
+					decodeResponse(t, body, &responses.ProductList{})
+				case http.StatusUnauthorized:
+					assert.JSONEq(t, `{"message":"Unauthorized"}`, string(body))
+				case http.StatusForbidden:
+					assert.JSONEq(t, `{"message":"Forbidden"}`, string(body))
+				}
+			})
+
+			t.Run("Simple_Response", func(t *testing.T) {
+				requireFunction(t, lambdaClient, authorizerName)
+
+				var headers map[string]string
+				if tc.header != "" {
+					headers = map[string]string{tc.header: tc.key}
+				}
+				output := invokeFunction(t, lambdaClient, authorizerName, payloads.NewAuthorizerRequest("GET /products", "/products", headers))
+				assertSimpleResponse(t, output.Payload, tc.status == http.StatusOK, tc.key)
+			})
+		})
+	}
+}
+
+// assertSimpleResponse checks an authorizer result is exactly an HTTP API simple response with the
+// expected decision, and that a denied key is not passed on to the integration
+func assertSimpleResponse(t *testing.T, payload []byte, authorized bool, key string) {
+	t.Helper()
+
+	response, err := payloads.ParseAuthorizerResponse(payload)
+	require.NoError(t, err)
+	assert.Equal(t, authorized, *response.IsAuthorized, "isAuthorized in %s", payload)
+
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(payload, &fields))
+	var names []string
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"context", "isAuthorized"}, names, "Simple responses hold only isAuthorized and context: %s", payload)
+
+	if authorized {
+		assert.Equal(t, key, response.Context["apiKey"], "Allowed requests pass their key to the integration")
+	} else if key != "" {
+		assert.NotEqual(t, key, response.Context["apiKey"], "Denied keys must not reach the integration context")
+	}
+}
+
+// getWithKey sends GET url with the API key under exactly the header name given, or without a key
+// when header is empty
+func getWithKey(t *testing.T, url, header, key string) (int, []byte) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if header != "" {
+		// Assigning the map entry keeps the header name as written instead of canonicalizing it
+		req.Header[header] = []string{key}
+	}
+
+	resp, err := suiteHTTP.Do(req)
+	require.NoError(t, err, "GET %s", url)
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, data
+}
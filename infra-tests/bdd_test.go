@@ -0,0 +1,273 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	sfntypes "github.com/aws/aws-sdk-go-v2/service/sfn/types"
+	"github.com/cucumber/godog"
+
+	"github.com/lambda-java-template/tests/internal/discovery"
+	"github.com/lambda-java-template/tests/internal/testconfig"
+	"github.com/lambda-java-template/tests/internal/tier"
+	"github.com/lambda-java-template/tests/internal/wait"
+)
+
+// metricsBackoff polls CloudWatch metrics, which are published once a minute, at a fixed interval
+var metricsBackoff = wait.Backoff{Initial: 30 * time.Second, Max: 30 * time.Second, Factor: 1}
+
+// godogOptions configures the BDD scenarios; every field can be overridden with -godog.* flags
+var godogOptions = godog.Options{
+	Format: "pretty",
+	Paths:  []string{"features"},
+	Strict: true,
+}
+
+func init() {
+	godog.BindCommandLineFlags("godog.", &godogOptions)
+}
+
+// TestFeatures runs the Gherkin acceptance scenarios in features/ against a deployed environment.
+// It is opt-in through TEST_BDD=true because the scenarios reuse the TestLambdaIntegration validators.
+func TestFeatures(t *testing.T) {
+	if os.Getenv("TEST_BDD") != "true" {
+		t.Skip("BDD scenarios are disabled; set TEST_BDD=true to enable")
+	}
+	if !selectedSuite.Includes(tier.Full) {
+		t.Skipf("BDD scenarios run end to end, which the %s suite leaves out", selectedSuite)
+	}
+
+	options := godogOptions
+	options.Output = os.Stdout
+
+	suite := godog.TestSuite{
+		Name: "lambda-java-template",
+		ScenarioInitializer: func(sc *godog.ScenarioContext) {
+			// Scenarios run sequentially on the test goroutine, so validators can be run as subtests of t
+			initializeScenario(sc, &bddScenario{t: t})
+		},
+		Options: &options,
+	}
+	if suite.Run() != 0 {
+		t.Fatal("BDD scenarios failed")
+	}
+}
+
+// bddScenario holds the state shared by the steps of one scenario
+type bddScenario struct {
+	t           *testing.T
+	cfg         aws.Config
+	projectName string
+	environment string
+	resolver    *discovery.Resolver
+	apiEndpoint string
+
+	statusCode   int
+	responseBody []byte
+
+	startedAt    time.Time
+	executionArn string
+}
+
+// initializeScenario maps Given/When/Then steps onto environment lookups, API calls, and validators
+func initializeScenario(sc *godog.ScenarioContext, s *bddScenario) {
+	sc.Given(`^the "([^"]*)" environment of "([^"]*)" is deployed in "([^"]*)"$`, s.environmentIsDeployed)
+	sc.Given(`^the configured environment is deployed$`, s.configuredEnvironmentIsDeployed)
+
+	sc.When(`^I send a (GET|POST|PUT|DELETE) request to "([^"]*)"$`, func(method, path string) error {
+		return s.sendRequest(method, path, false)
+	})
+	sc.When(`^I send a (GET|POST|PUT|DELETE) request to "([^"]*)" with an API key$`, func(method, path string) error {
+		return s.sendRequest(method, path, true)
+	})
+	sc.When(`^I start the "([^"]*)" workflow with input:$`, s.startWorkflow)
+
+	sc.Then(`^the response status should be (\d+)$`, s.responseStatusShouldBe)
+	sc.Then(`^the response JSON field "([^"]*)" should be "([^"]*)"$`, s.responseFieldShouldBe)
+	sc.Then(`^the "([^"]*)" validator passes$`, s.validatorPasses)
+	sc.Then(`^the workflow execution succeeds within (\d+) minutes?$`, s.workflowSucceeds)
+	sc.Then(`^a notification is published to the "([^"]*)" topic within (\d+) minutes?$`, s.notificationPublished)
+}
+
+// environmentIsDeployed loads AWS configuration and finds the environment's API endpoint
+func (s *bddScenario) environmentIsDeployed(environment, projectName, region string) error {
+	settings := suiteConfig
+	settings.ProjectName, settings.Environment, settings.Region = projectName, environment, region
+
+	cfg, err := settings.AWSConfig(context.TODO())
+	if err != nil {
+		return err
+	}
+	s.cfg, s.projectName, s.environment = cfg, projectName, environment
+	s.resolver = discover(cfg, projectName, environment)
+	s.startedAt = time.Now()
+
+	api, err := s.resolver.API(context.TODO())
+	if err != nil {
+		return err
+	}
+	s.apiEndpoint = api.Endpoint
+	return nil
+}
+
+// configuredEnvironmentIsDeployed targets the deployment selected by the test config
+func (s *bddScenario) configuredEnvironmentIsDeployed() error {
+	return s.environmentIsDeployed(suiteConfig.Environment, suiteConfig.ProjectName, suiteConfig.Region)
+}
+
+// sendRequest calls the deployed API and records the response
+func (s *bddScenario) sendRequest(method, path string, withAPIKey bool) error {
+	req, err := http.NewRequest(method, s.apiEndpoint+path, nil)
+	if err != nil {
+		return err
+	}
+	if withAPIKey {
+		req.Header.Set("x-api-key", fmt.Sprintf("bdd-%s", s.environment))
+	}
+
+	resp, err := suiteHTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	s.statusCode = resp.StatusCode
+	s.responseBody, err = io.ReadAll(resp.Body)
+	return err
+}
+
+// responseStatusShouldBe checks the status code of the last response
+func (s *bddScenario) responseStatusShouldBe(expected int) error {
+	if s.statusCode != expected {
+		return fmt.Errorf("expected status %d, got %d: %s", expected, s.statusCode, s.responseBody)
+	}
+	return nil
+}
+
+// responseFieldShouldBe checks a top-level field of the last JSON response
+func (s *bddScenario) responseFieldShouldBe(field, expected string) error {
+	var body map[string]interface{}
+	if err := json.Unmarshal(s.responseBody, &body); err != nil {
+		return fmt.Errorf("response is not a JSON object: %w", err)
+	}
+	if actual := fmt.Sprint(body[field]); actual != expected {
+		return fmt.Errorf("expected %s to be %q, got %q", field, expected, actual)
+	}
+	return nil
+}
+
+// validatorPasses runs a TestLambdaIntegration validator by name as a subtest
+func (s *bddScenario) validatorPasses(name string) error {
+	for _, validator := range integrationValidators(s.cfg, s.projectName, s.environment) {
+		if validator.name == name {
+			if !s.t.Run(name, validator.run) {
+				return fmt.Errorf("validator %s failed", name)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown validator %s", name)
+}
+
+// startWorkflow starts an execution of the "<project>-<environment>-<workflow>" state machine.
+// The scenario is skipped when the environment does not deploy that workflow.
+func (s *bddScenario) startWorkflow(workflow string, input *godog.DocString) error {
+	stateMachineArn, err := s.resolver.StateMachineARN(context.TODO(), workflow)
+	if errors.Is(err, discovery.ErrNotFound) {
+		s.t.Logf("%v; skipping scenario", err)
+		return godog.ErrSkip
+	}
+	if err != nil {
+		return err
+	}
+
+	client := sfn.NewFromConfig(s.cfg)
+	execution, err := client.StartExecution(context.TODO(), &sfn.StartExecutionInput{
+		StateMachineArn: aws.String(stateMachineArn),
+		Name:            aws.String(fmt.Sprintf("bdd-%d", time.Now().UnixNano())),
+		Input:           aws.String(strings.TrimSpace(input.Content)),
+	})
+	if err != nil {
+		return err
+	}
+	s.executionArn = *execution.ExecutionArn
+	return nil
+}
+
+// workflowSucceeds waits for the started execution to finish successfully
+func (s *bddScenario) workflowSucceeds(minutes int) error {
+	client := sfn.NewFromConfig(s.cfg)
+	timeout := time.Duration(minutes) * time.Minute
+
+	err := wait.Until(context.TODO(), timeout, suiteConfig.Backoff(), func(ctx context.Context) (bool, error) {
+		execution, err := client.DescribeExecution(ctx, &sfn.DescribeExecutionInput{
+			ExecutionArn: aws.String(s.executionArn),
+		})
+		if err != nil {
+			return false, err
+		}
+
+		switch execution.Status {
+		case sfntypes.ExecutionStatusSucceeded:
+			return true, nil
+		case sfntypes.ExecutionStatusRunning:
+			return false, nil
+		default:
+			return false, fmt.Errorf("execution %s ended with status %s: %s", s.executionArn, execution.Status, aws.ToString(execution.Cause))
+		}
+	})
+	if errors.Is(err, wait.ErrTimeout) {
+		return fmt.Errorf("execution %s did not finish within %d minutes", s.executionArn, minutes)
+	}
+	return err
+}
+
+// notificationPublished waits until the SNS topic reports a published message since the scenario started
+func (s *bddScenario) notificationPublished(topic string, minutes int) error {
+	if !suiteConfig.Supports(testconfig.FeatureServiceMetrics) {
+		s.t.Logf("%s is not available on the %s target; skipping scenario", testconfig.FeatureServiceMetrics, suiteConfig.Target)
+		return godog.ErrSkip
+	}
+
+	client := cloudwatch.NewFromConfig(s.cfg)
+	topicName := s.resolver.ResourceName(topic)
+	timeout := time.Duration(minutes) * time.Minute
+
+	err := wait.Until(context.TODO(), timeout, metricsBackoff, func(ctx context.Context) (bool, error) {
+		stats, err := client.GetMetricStatistics(ctx, &cloudwatch.GetMetricStatisticsInput{
+			Namespace:  aws.String("AWS/SNS"),
+			MetricName: aws.String("NumberOfMessagesPublished"),
+			Dimensions: []cwtypes.Dimension{{Name: aws.String("TopicName"), Value: aws.String(topicName)}},
+			StartTime:  aws.Time(s.startedAt.Add(-time.Minute)),
+			EndTime:    aws.Time(time.Now().Add(time.Minute)),
+			Period:     aws.Int32(60),
+			Statistics: []cwtypes.Statistic{cwtypes.StatisticSum},
+		})
+		if err != nil {
+			return false, err
+		}
+
+		for _, datapoint := range stats.Datapoints {
+			if aws.ToFloat64(datapoint.Sum) > 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if !errors.Is(err, wait.ErrTimeout) {
+		return err
+	}
+	return fmt.Errorf("no notification published to %s within %d minutes", topicName, minutes)
+}
@@ -0,0 +1,78 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/buildinfo"
+	"github.com/lambda-java-template/tests/internal/expectations"
+)
+
+// validateBuildMetadata checks every function against the build metadata the pipeline recorded for it:
+// it must have been built for the architecture of the environment profile, be deployed as that
+// architecture, and, when TEST_EXPECTED_COMMIT is set, have been built from that commit
+func validateBuildMetadata(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+	client := lambda.NewFromConfig(cfg)
+	profile := expectations.For(environment)
+	expectedCommit := suiteConfig.ExpectedCommit
+	if expectedCommit == "" {
+		t.Log("TEST_EXPECTED_COMMIT is not set, so the commit functions were built from is not checked")
+	}
+
+	// The SSM parameter covers every function without tags, so it is read at most once
+	parameterName := buildinfo.ParameterName(resolver.Settings().BaseName())
+	var parameter *buildinfo.Metadata
+	read := false
+	fromParameter := func(t *testing.T) *buildinfo.Metadata {
+		if read {
+			return parameter
+		}
+		read = true
+		output, err := ssm.NewFromConfig(cfg).GetParameter(context.TODO(), &ssm.GetParameterInput{Name: aws.String(parameterName)})
+		var notFound *ssmtypes.ParameterNotFound
+		if errors.As(err, &notFound) {
+			return nil
+		}
+		require.NoError(t, err, "Failed to get build metadata parameter %s", parameterName)
+		metadata, err := buildinfo.Parse(aws.ToString(output.Parameter.Value))
+		require.NoError(t, err, "Build metadata parameter %s", parameterName)
+		parameter = &metadata
+		return parameter
+	}
+
+	for _, function := range deploymentFunctions(t, client, resolver) {
+		functionName := aws.ToString(function.FunctionName)
+		t.Run(functionName, func(t *testing.T) {
+			tags, err := client.ListTags(context.TODO(), &lambda.ListTagsInput{Resource: function.FunctionArn})
+			require.NoError(t, err, "Failed to list tags of %s", functionName)
+
+			metadata, ok, err := buildinfo.FromTags(tags.Tags)
+			require.NoError(t, err, "Build metadata tags of %s", functionName)
+			if !ok {
+				recorded := fromParameter(t)
+				if recorded == nil {
+					t.Skipf("The pipeline recorded no build metadata for %s: pass build_sha to Terraform or write %s", functionName, parameterName)
+				}
+				metadata = *recorded
+			}
+			t.Logf("%s was built from %s for %s at %s", functionName, metadata.GitSHA, metadata.Architecture, metadata.BuildTime)
+
+			deployed := ""
+			if len(function.Architectures) > 0 {
+				deployed = string(function.Architectures[0])
+			}
+			for _, gap := range metadata.Gaps(deployed, profile.Architecture, expectedCommit) {
+				assert.Fail(t, "Function does not match its build", "%s: %s", functionName, gap)
+			}
+		})
+	}
+}
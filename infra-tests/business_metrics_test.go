@@ -0,0 +1,50 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/businessmetrics"
+	"github.com/lambda-java-template/tests/internal/testconfig"
+	"github.com/lambda-java-template/tests/internal/wait"
+)
+
+// businessMetricsDelay bounds how long CloudWatch may take to extract the handlers' EMF records into metrics
+const businessMetricsDelay = 5 * time.Minute
+
+// assertBusinessMetrics fails when a business counter in internal/businessmetrics rose by less than the workflow
+// executions since start require, given how many ended in each terminal state. Counters are polled until they
+// catch up or businessMetricsDelay passes.
+func assertBusinessMetrics(t *testing.T, cfg aws.Config, environment string, start time.Time, reached map[string]int) {
+	requireFeature(t, testconfig.FeatureEmbeddedMetrics)
+	expected := businessmetrics.Expected(reached)
+	if len(expected) == 0 {
+		t.Skip("No execution ended in a state a business metric counts")
+	}
+	client := cloudwatch.NewFromConfig(cfg)
+
+	var shortfalls []string
+	err := wait.Until(context.TODO(), businessMetricsDelay, metricsBackoff, func(ctx context.Context) (bool, error) {
+		// Datapoints are stamped with the start of their minute, so the range opens a minute early
+		observed, err := businessmetrics.Sum(ctx, client, environment, start.Add(-time.Minute), time.Now().Add(time.Minute))
+		if err != nil {
+			return false, err
+		}
+		shortfalls = businessmetrics.Shortfalls(expected, observed)
+		return len(shortfalls) == 0, nil
+	})
+	if !errors.Is(err, wait.ErrTimeout) {
+		require.NoError(t, err)
+		return
+	}
+	for _, shortfall := range shortfalls {
+		t.Errorf("%s within %s; check the handlers still publish it with the %s dimension",
+			shortfall, businessMetricsDelay, businessmetrics.EnvironmentDimension)
+	}
+}
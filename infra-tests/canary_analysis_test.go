@@ -0,0 +1,92 @@
+package test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/canary"
+	"github.com/lambda-java-template/tests/internal/testconfig"
+	"github.com/lambda-java-template/tests/internal/tfspec"
+)
+
+const (
+	// defaultCanaryWindow is compared on each side of the deployment marker
+	defaultCanaryWindow = 30 * time.Minute
+
+	// minCanaryWindow is the shortest post-deploy window worth comparing
+	minCanaryWindow = 5 * time.Minute
+)
+
+// validateCanaryAnalysis fails when a function's error rate, p95 duration, or cold starts degraded after deployment.
+// The marker defaults to each function's last update and can be pinned with TEST_CANARY_DEPLOYED_AT (RFC 3339);
+// TEST_CANARY_WINDOW overrides the window length.
+func validateCanaryAnalysis(t *testing.T, cfg aws.Config, projectName, environment string) {
+	requireFeature(t, testconfig.FeatureServiceMetrics)
+	resolver := discover(cfg, projectName, environment)
+
+	lambdaClient := lambda.NewFromConfig(cfg)
+	collector := canary.NewCollector(cloudwatch.NewFromConfig(cfg), cloudwatchlogs.NewFromConfig(cfg))
+
+	windowLength := defaultCanaryWindow
+	if value := os.Getenv("TEST_CANARY_WINDOW"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		require.NoError(t, err, "TEST_CANARY_WINDOW must be a duration such as 30m")
+		windowLength = parsed
+	}
+
+	var pinnedMarker time.Time
+	if value := os.Getenv("TEST_CANARY_DEPLOYED_AT"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		require.NoError(t, err, "TEST_CANARY_DEPLOYED_AT must be an RFC 3339 timestamp")
+		pinnedMarker = parsed
+	}
+
+	for _, function := range tfspec.Functions {
+		functionName := resolver.FunctionName(function.NameSuffix)
+
+		t.Run(functionName, func(t *testing.T) {
+			marker := pinnedMarker
+			if marker.IsZero() {
+				configuration, err := lambdaClient.GetFunctionConfiguration(context.TODO(), &lambda.GetFunctionConfigurationInput{
+					FunctionName: aws.String(functionName),
+				})
+				require.NoError(t, err, "Failed to get configuration for %s", functionName)
+
+				// Lambda reports LastModified as 2006-01-02T15:04:05.000+0000
+				marker, err = time.Parse("2006-01-02T15:04:05.000-0700", aws.ToString(configuration.LastModified))
+				require.NoError(t, err, "Failed to parse LastModified of %s", functionName)
+			}
+
+			before, after := canary.Windows(marker, windowLength, time.Now())
+			if after.End.Sub(after.Start) < minCanaryWindow {
+				t.Skipf("Deployment at %s is too recent for canary analysis", marker.Format(time.RFC3339))
+			}
+
+			before, err := collector.Collect(context.TODO(), functionName, before)
+			require.NoError(t, err)
+			after, err = collector.Collect(context.TODO(), functionName, after)
+			require.NoError(t, err)
+
+			analysis := canary.Compare(before, after, canary.DefaultThresholds)
+			t.Logf("Before %s: %.0f invocations, %.2f%% errors, p95 %.0fms, %.0f cold starts",
+				marker.Format(time.RFC3339), before.Invocations, before.ErrorRate()*100, before.P95Duration, before.ColdStarts)
+			t.Logf("After  %s: %.0f invocations, %.2f%% errors, p95 %.0fms, %.0f cold starts",
+				marker.Format(time.RFC3339), after.Invocations, after.ErrorRate()*100, after.P95Duration, after.ColdStarts)
+
+			if analysis.Inconclusive != "" {
+				t.Skipf("Canary analysis inconclusive: %s", analysis.Inconclusive)
+			}
+			for _, degradation := range analysis.Degradations {
+				t.Errorf("Post-deploy %s", degradation)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+
+	"github.com/lambda-java-template/tests/internal/cassette"
+	"github.com/lambda-java-template/tests/internal/testconfig"
+	"github.com/lambda-java-template/tests/internal/tier"
+)
+
+// suiteCassette records or replays the suite's AWS and API traffic; nil unless -cassette-mode is record or replay
+var suiteCassette *cassette.Recorder
+
+// openCassette opens the cassette of the environment under test for mode. Cassettes hold the traffic of the
+// smoke suite, whose validators only read, since requests that create resources under unique names would never
+// match a recording. A process handing environments to child processes leaves the cassettes to them.
+func openCassette(mode cassette.Mode) error {
+	if mode == cassette.Off || orchestratingEnvironments() {
+		return nil
+	}
+	if selectedSuite != tier.Smoke {
+		return fmt.Errorf("-cassette-mode %s needs -suite smoke, whose validators only read", mode)
+	}
+	var err error
+	suiteCassette, err = cassette.Open(testconfig.EnvironmentFile(*cassettePath, suiteConfig.Environment), mode, http.DefaultTransport)
+	return err
+}
+
+// withCassette sends the calls of clients created from cfg through suiteCassette. Replayed calls are signed with
+// static credentials, since CI replaying a cassette may have none.
+func withCassette(cfg aws.Config) aws.Config {
+	if suiteCassette == nil {
+		return cfg
+	}
+	cfg.HTTPClient = &http.Client{Transport: suiteCassette}
+	if replaying() {
+		cfg.Credentials = credentials.NewStaticCredentialsProvider("replay", "replay", "")
+	}
+	return cfg
+}
+
+// cassetteTransport returns the transport the suite's API client sends through: suiteCassette, or nil for the
+// default one
+func cassetteTransport() http.RoundTripper {
+	if suiteCassette == nil {
+		return nil
+	}
+	return suiteCassette
+}
+
+// replaying reports whether the suite answers its requests from a cassette instead of sending them
+func replaying() bool {
+	return suiteCassette != nil && suiteCassette.Mode() == cassette.Replay
+}
+
+// skipWhenReplaying skips the rest of a test that reaches the deployment other than over HTTP, e.g. with a
+// TLS handshake, which a cassette cannot replay
+func skipWhenReplaying(t *testing.T, what string) {
+	if replaying() {
+		t.Skipf("%s cannot be replayed from a cassette", what)
+	}
+}
@@ -0,0 +1,228 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/asl"
+	"github.com/lambda-java-template/tests/internal/chaos"
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/discovery"
+	"github.com/lambda-java-template/tests/internal/history"
+	"github.com/lambda-java-template/tests/internal/payloads"
+)
+
+// paymentState is the workflow state whose function the chaos faults break
+const paymentState = "ProcessPayment"
+
+// chaosPropagationDelay gives IAM and Lambda time to apply a fault before an order is run against it
+const chaosPropagationDelay = 15 * time.Second
+
+// chaosNotificationWindow is how long notifications for a degraded order are collected once it has ended
+const chaosNotificationWindow = time.Minute
+
+// chaosFault breaks the payment function in one way and returns how to undo it
+type chaosFault struct {
+	name   string
+	inject func(t *testing.T) chaos.Restore
+	// verifyRestored checks the function is configured as it was before the fault
+	verifyRestored func(t *testing.T)
+}
+
+// validateChaos breaks the payment function, first by denying its role every AWS action and then by reserving
+// it no concurrency, and checks an order run against each fault ends in PaymentDeclined or ProcessingFailed
+// without being confirmed to the customer. Each fault is undone, and the undo checked, before the next.
+// Faults affect every order in the environment, so this only runs with -chaos.
+func validateChaos(t *testing.T, cfg aws.Config, projectName, environment string) {
+	if !*chaosFaults {
+		t.Skip("Skipping chaos faults; set -chaos to run them")
+	}
+	resolver := discover(cfg, projectName, environment)
+	lambdaClient := lambda.NewFromConfig(cfg)
+	iamClient := iam.NewFromConfig(cfg)
+	sfnClient := sfn.NewFromConfig(cfg)
+
+	definition := deployedDefinition(t, cfg, resolver, "order-processing")
+	stateMachineArn, err := resolver.StateMachineARN(context.TODO(), "order-processing")
+	require.NoError(t, err)
+	parsed, err := asl.Parse([]byte(definition))
+	require.NoError(t, err)
+	paymentFunction := chaos.FunctionForState(asl.LambdaReferences(parsed), paymentState)
+	if paymentFunction == "" {
+		t.Skipf("State %s of %s invokes no Lambda function", paymentState, stateMachineArn)
+	}
+
+	function, err := lambdaClient.GetFunctionConfiguration(context.TODO(), &lambda.GetFunctionConfigurationInput{
+		FunctionName: aws.String(paymentFunction),
+	})
+	require.NoError(t, err)
+	roleName, err := chaos.RoleName(aws.ToString(function.Role))
+	require.NoError(t, err)
+	originalConcurrency, err := lambdaClient.GetFunctionConcurrency(context.TODO(), &lambda.GetFunctionConcurrencyInput{
+		FunctionName: aws.String(paymentFunction),
+	})
+	require.NoError(t, err)
+
+	notifications := subscribeCaptureQueue(t, cfg, resolver)
+
+	faults := []chaosFault{
+		{
+			name: "Deny_Role_Policy",
+			inject: func(t *testing.T) chaos.Restore {
+				_, err := iamClient.PutRolePolicy(context.TODO(), &iam.PutRolePolicyInput{
+					RoleName:       aws.String(roleName),
+					PolicyName:     aws.String(chaos.DenyPolicyName),
+					PolicyDocument: aws.String(chaos.DenyAllPolicy),
+				})
+				require.NoError(t, err, "Failed to attach %s to role %s", chaos.DenyPolicyName, roleName)
+				return func(ctx context.Context) error {
+					_, err := iamClient.DeleteRolePolicy(ctx, &iam.DeleteRolePolicyInput{
+						RoleName:   aws.String(roleName),
+						PolicyName: aws.String(chaos.DenyPolicyName),
+					})
+					var notFound *iamtypes.NoSuchEntityException
+					if errors.As(err, &notFound) {
+						return nil
+					}
+					return err
+				}
+			},
+			verifyRestored: func(t *testing.T) {
+				_, err := iamClient.GetRolePolicy(context.TODO(), &iam.GetRolePolicyInput{
+					RoleName:   aws.String(roleName),
+					PolicyName: aws.String(chaos.DenyPolicyName),
+				})
+				var notFound *iamtypes.NoSuchEntityException
+				assert.True(t, errors.As(err, &notFound), "Role %s still has policy %s: %v", roleName, chaos.DenyPolicyName, err)
+			},
+		},
+		{
+			name: "Zero_Reserved_Concurrency",
+			inject: func(t *testing.T) chaos.Restore {
+				restore, err := chaos.ReserveConcurrency(context.TODO(), lambdaClient, paymentFunction, 0)
+				require.NoError(t, err)
+				return restore
+			},
+			verifyRestored: func(t *testing.T) {
+				current, err := lambdaClient.GetFunctionConcurrency(context.TODO(), &lambda.GetFunctionConcurrencyInput{
+					FunctionName: aws.String(paymentFunction),
+				})
+				require.NoError(t, err)
+				assert.Equal(t, aws.ToInt32(originalConcurrency.ReservedConcurrentExecutions), aws.ToInt32(current.ReservedConcurrentExecutions),
+					"Reserved concurrency of %s after the fault was undone", paymentFunction)
+				assert.Equal(t, originalConcurrency.ReservedConcurrentExecutions == nil, current.ReservedConcurrentExecutions == nil,
+					"Reservation of %s after the fault was undone", paymentFunction)
+			},
+		},
+	}
+
+	// Faults share the payment function, so they run one at a time
+	for _, fault := range faults {
+		t.Run(fault.name, func(t *testing.T) {
+			restore := fault.inject(t)
+			restored := false
+			t.Cleanup(func() {
+				if !restored {
+					assert.NoError(t, restore(context.TODO()), "Failed to undo %s on %s", fault.name, paymentFunction)
+				}
+			})
+			time.Sleep(chaosPropagationDelay)
+
+			orderId := fmt.Sprintf("%schaos-%d", testNamespace(), time.Now().UnixNano())
+			execution := executeWorkflow(t, sfnClient, stateMachineArn, payloads.SampleOrder(testNamespace(), orderId))
+			waitForExecution(t, sfnClient, execution, workflowExecutionTimeout)
+
+			err := restore(context.TODO())
+			restored = err == nil
+			require.NoError(t, err, "Failed to undo %s on %s", fault.name, paymentFunction)
+			fault.verifyRestored(t)
+
+			events := executionHistory(t, sfnClient, execution)
+			recordExecutionDiagram(t, "Chaos_Dependency_Failures/"+fault.name, execution, events)
+			var notificationTypes []string
+			if notifications != nil {
+				for _, payload := range notifications.collect(t, orderId, chaosNotificationWindow) {
+					assert.Empty(t, checks.MissingNotificationFields(payload), "Notification for order %s is missing required fields", orderId)
+					notificationType, _ := payload["notificationType"].(string)
+					notificationTypes = append(notificationTypes, notificationType)
+				}
+			}
+			for _, gap := range chaos.DegradationGaps(history.TerminalState(events), notificationTypes, checks.NotificationOrderConfirmation) {
+				assert.Fail(t, "Workflow did not degrade gracefully", "%s under %s: %s; visited %v",
+					execution.arn, fault.name, gap, history.EnteredStates(events))
+			}
+		})
+	}
+}
+
+// notificationCapture is a temporary queue subscribed to the notification topic
+type notificationCapture struct {
+	client   *sqs.Client
+	queueUrl string
+}
+
+// subscribeCaptureQueue subscribes a temporary queue to the notification topic and removes both when the test
+// ends. It returns nil when the topic is not deployed.
+func subscribeCaptureQueue(t *testing.T, cfg aws.Config, resolver *discovery.Resolver) *notificationCapture {
+	topicArn := notificationTopicArn(t, sns.NewFromConfig(cfg), resolver)
+	if topicArn == "" {
+		t.Logf("Notification topic %s is not deployed; notifications are not checked", resolver.ResourceName("notifications"))
+		return nil
+	}
+	return &notificationCapture{client: sqs.NewFromConfig(cfg), queueUrl: subscribeTopicQueue(t, cfg, resolver, topicArn)}
+}
+
+// collect returns the notifications published for orderId within window, deleting every message it reads
+func (c *notificationCapture) collect(t *testing.T, orderId string, window time.Duration) []map[string]any {
+	var found []map[string]any
+	// Other orders may be in flight on a shared environment
+	for _, payload := range c.receive(t, window) {
+		if payload["orderId"] == orderId {
+			found = append(found, payload)
+		}
+	}
+	return found
+}
+
+// receive returns every notification published within window, deleting every message it reads
+func (c *notificationCapture) receive(t *testing.T, window time.Duration) []map[string]any {
+	var received []map[string]any
+	deadline := time.Now().Add(window)
+	for time.Now().Before(deadline) {
+		messages, err := c.client.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(c.queueUrl),
+			MaxNumberOfMessages: 10,
+			WaitTimeSeconds:     int32(min(20, max(1, time.Until(deadline)/time.Second))),
+		})
+		require.NoError(t, err)
+
+		for _, message := range messages.Messages {
+			_, err := c.client.DeleteMessage(context.TODO(), &sqs.DeleteMessageInput{
+				QueueUrl:      aws.String(c.queueUrl),
+				ReceiptHandle: message.ReceiptHandle,
+			})
+			assert.NoError(t, err)
+
+			payload, err := checks.ParseNotification(aws.ToString(message.Body))
+			if err != nil {
+				t.Logf("Ignoring unparseable notification: %v", err)
+				continue
+			}
+			received = append(received, payload)
+		}
+	}
+	return received
+}
@@ -0,0 +1,43 @@
+// Command cleanup removes test data written under a namespace prefix from a deployed environment.
+//
+// Usage:
+//
+//	go run ./cmd/cleanup -env dev -namespace itest-
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+
+	"github.com/lambda-java-template/tests/internal/cleanup"
+	"github.com/lambda-java-template/tests/internal/seed"
+)
+
+func main() {
+	region := flag.String("region", "us-east-1", "AWS region of the target environment")
+	projectName := flag.String("project", "lambda-java-template", "Project name used in resource names")
+	environment := flag.String("env", "dev", "Environment (or namespace) used in resource names")
+	namespace := flag.String("namespace", seed.DefaultNamespace, "Prefix identifying test data to delete")
+	flag.Parse()
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(*region))
+	if err != nil {
+		log.Fatalf("Failed to load AWS configuration: %v", err)
+	}
+
+	target := cleanup.NewTarget(*projectName, *environment)
+	result, err := cleanup.Namespace(context.TODO(), dynamodb.NewFromConfig(cfg), sfn.NewFromConfig(cfg), cloudwatchlogs.NewFromConfig(cfg), target, *namespace)
+	if err != nil {
+		log.Fatalf("Cleanup stopped after removing %+v: %v", result, err)
+	}
+
+	fmt.Printf("Removed %d products, %d audit records, and %d log streams and stopped %d executions with namespace %q\n",
+		result.Products, result.AuditLogs, result.LogStreams, result.Executions, *namespace)
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lambda-java-template/tests/internal/tfspec"
+)
+
+// showDocument is the subset of `terraform show -json` output the generator reads
+type showDocument struct {
+	Values        *showValues `json:"values"`
+	PlannedValues *showValues `json:"planned_values"`
+}
+
+type showValues struct {
+	RootModule showModule `json:"root_module"`
+}
+
+type showModule struct {
+	Address      string         `json:"address"`
+	Resources    []showResource `json:"resources"`
+	ChildModules []showModule   `json:"child_modules"`
+}
+
+type showResource struct {
+	Address string          `json:"address"`
+	Mode    string          `json:"mode"`
+	Type    string          `json:"type"`
+	Values  json.RawMessage `json:"values"`
+}
+
+type lambdaValues struct {
+	FunctionName  string   `json:"function_name"`
+	Runtime       string   `json:"runtime"`
+	Handler       string   `json:"handler"`
+	Architectures []string `json:"architectures"`
+	Layers        []string `json:"layers"`
+	MemorySize    int32    `json:"memory_size"`
+	Timeout       int32    `json:"timeout"`
+	Environment   []struct {
+		Variables map[string]string `json:"variables"`
+	} `json:"environment"`
+	TracingConfig []struct {
+		Mode string `json:"mode"`
+	} `json:"tracing_config"`
+	// ReservedConcurrentExecutions is -1 when unreserved; older snapshots may leave it out
+	ReservedConcurrentExecutions *int32 `json:"reserved_concurrent_executions"`
+}
+
+type tableValues struct {
+	Name                 string `json:"name"`
+	BillingMode          string `json:"billing_mode"`
+	HashKey              string `json:"hash_key"`
+	RangeKey             string `json:"range_key"`
+	GlobalSecondaryIndex []struct {
+		Name           string `json:"name"`
+		HashKey        string `json:"hash_key"`
+		RangeKey       string `json:"range_key"`
+		ProjectionType string `json:"projection_type"`
+	} `json:"global_secondary_index"`
+	PointInTimeRecovery []struct {
+		Enabled bool `json:"enabled"`
+	} `json:"point_in_time_recovery"`
+	ServerSideEncryption []struct {
+		Enabled bool `json:"enabled"`
+	} `json:"server_side_encryption"`
+	TTL []struct {
+		Enabled       bool   `json:"enabled"`
+		AttributeName string `json:"attribute_name"`
+	} `json:"ttl"`
+	StreamEnabled bool `json:"stream_enabled"`
+}
+
+type routeValues struct {
+	RouteKey          string `json:"route_key"`
+	AuthorizationType string `json:"authorization_type"`
+}
+
+// spec is everything the generator emits
+type spec struct {
+	Functions []tfspec.FunctionSpec
+	Tables    []tfspec.TableSpec
+	Routes    []tfspec.RouteSpec
+}
+
+// extract walks every module of a show document and collects function, table, and route expectations
+func extract(document []byte, baseName string) (spec, error) {
+	var show showDocument
+	if err := json.Unmarshal(document, &show); err != nil {
+		return spec{}, fmt.Errorf("parsing Terraform JSON: %w", err)
+	}
+
+	values := show.Values
+	if values == nil {
+		values = show.PlannedValues
+	}
+	if values == nil {
+		return spec{}, errors.New("document has neither values nor planned_values; is the state empty?")
+	}
+
+	prefix := baseName + "-"
+	var result spec
+	var walk func(module showModule) error
+	walk = func(module showModule) error {
+		for _, resource := range module.Resources {
+			if resource.Mode != "managed" {
+				continue
+			}
+			if err := result.add(resource, prefix); err != nil {
+				return fmt.Errorf("%s: %w", resource.Address, err)
+			}
+		}
+		for _, child := range module.ChildModules {
+			if err := walk(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(values.RootModule); err != nil {
+		return spec{}, err
+	}
+
+	sort.Slice(result.Functions, func(i, j int) bool { return result.Functions[i].NameSuffix < result.Functions[j].NameSuffix })
+	sort.Slice(result.Tables, func(i, j int) bool { return result.Tables[i].NameSuffix < result.Tables[j].NameSuffix })
+	sort.Slice(result.Routes, func(i, j int) bool { return result.Routes[i].RouteKey < result.Routes[j].RouteKey })
+	return result, nil
+}
+
+// add converts one resource into an expectation when it is of a tracked type
+func (s *spec) add(resource showResource, prefix string) error {
+	switch resource.Type {
+	case "aws_lambda_function":
+		var values lambdaValues
+		if err := json.Unmarshal(resource.Values, &values); err != nil {
+			return err
+		}
+		// Functions outside the project naming scheme (e.g. ephemeral cleanup) are not expectations
+		if !strings.HasPrefix(values.FunctionName, prefix) {
+			return nil
+		}
+
+		function := tfspec.FunctionSpec{
+			NameSuffix:          strings.TrimPrefix(values.FunctionName, prefix),
+			Runtime:             values.Runtime,
+			Handler:             values.Handler,
+			Architectures:       values.Architectures,
+			Layers:              append([]string{}, values.Layers...),
+			MemorySize:          values.MemorySize,
+			Timeout:             values.Timeout,
+			ReservedConcurrency: -1,
+		}
+		if values.ReservedConcurrentExecutions != nil {
+			function.ReservedConcurrency = *values.ReservedConcurrentExecutions
+		}
+		if len(values.TracingConfig) > 0 {
+			function.TracingMode = values.TracingConfig[0].Mode
+		}
+		if len(values.Environment) > 0 {
+			for key := range values.Environment[0].Variables {
+				function.EnvironmentKeys = append(function.EnvironmentKeys, key)
+			}
+			sort.Strings(function.EnvironmentKeys)
+		}
+		s.Functions = append(s.Functions, function)
+
+	case "aws_dynamodb_table":
+		var values tableValues
+		if err := json.Unmarshal(resource.Values, &values); err != nil {
+			return err
+		}
+		if !strings.HasPrefix(values.Name, prefix) {
+			return nil
+		}
+
+		table := tfspec.TableSpec{
+			NameSuffix:    strings.TrimPrefix(values.Name, prefix),
+			HashKey:       values.HashKey,
+			RangeKey:      values.RangeKey,
+			BillingMode:   values.BillingMode,
+			StreamEnabled: values.StreamEnabled,
+		}
+		for _, gsi := range values.GlobalSecondaryIndex {
+			table.GlobalSecondaryIndexes = append(table.GlobalSecondaryIndexes, tfspec.IndexSpec{
+				Name:           gsi.Name,
+				HashKey:        gsi.HashKey,
+				RangeKey:       gsi.RangeKey,
+				ProjectionType: gsi.ProjectionType,
+			})
+		}
+		if len(values.PointInTimeRecovery) > 0 {
+			table.PointInTimeRecovery = values.PointInTimeRecovery[0].Enabled
+		}
+		if len(values.ServerSideEncryption) > 0 {
+			table.Encrypted = values.ServerSideEncryption[0].Enabled
+		}
+		if len(values.TTL) > 0 && values.TTL[0].Enabled {
+			table.TTLAttribute = values.TTL[0].AttributeName
+		}
+		s.Tables = append(s.Tables, table)
+
+	case "aws_apigatewayv2_route":
+		var values routeValues
+		if err := json.Unmarshal(resource.Values, &values); err != nil {
+			return err
+		}
+		s.Routes = append(s.Routes, tfspec.RouteSpec{
+			RouteKey:          values.RouteKey,
+			AuthorizationType: values.AuthorizationType,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractFromShowFixture(t *testing.T) {
+	document, err := os.ReadFile("../../testdata/terraform-show-dev.json")
+	require.NoError(t, err)
+
+	result, err := extract(document, "lambda-java-template-dev")
+	require.NoError(t, err)
+
+	require.Len(t, result.Functions, 2)
+	assert.Equal(t, "authorizer-service", result.Functions[0].NameSuffix)
+	assert.Equal(t, "product-service", result.Functions[1].NameSuffix)
+	assert.Equal(t, int32(512), result.Functions[1].MemorySize)
+	assert.Contains(t, result.Functions[1].EnvironmentKeys, "PRODUCTS_TABLE_NAME")
+	assert.Equal(t, int32(-1), result.Functions[1].ReservedConcurrency, "Functions without reserved concurrency are -1")
+
+	require.Len(t, result.Tables, 2)
+	assert.Equal(t, "audit-logs", result.Tables[0].NameSuffix)
+	assert.Equal(t, "ttl", result.Tables[0].TTLAttribute)
+	assert.Equal(t, "products", result.Tables[1].NameSuffix)
+	assert.True(t, result.Tables[1].PointInTimeRecovery)
+	require.Len(t, result.Tables[1].GlobalSecondaryIndexes, 1)
+	assert.Equal(t, "name-index", result.Tables[1].GlobalSecondaryIndexes[0].Name)
+
+	require.Len(t, result.Routes, 6)
+	assert.Equal(t, "GET /health", result.Routes[1].RouteKey)
+	assert.Equal(t, "NONE", result.Routes[1].AuthorizationType)
+}
+
+func TestExtractRequiresValues(t *testing.T) {
+	_, err := extract([]byte(`{"format_version":"1.0"}`), "lambda-java-template-dev")
+	assert.Error(t, err)
+}
+
+func TestGeneratedFileIsUpToDate(t *testing.T) {
+	document, err := os.ReadFile("../../testdata/terraform-show-dev.json")
+	require.NoError(t, err)
+
+	result, err := extract(document, "lambda-java-template-dev")
+	require.NoError(t, err)
+
+	source, err := render(result)
+	require.NoError(t, err)
+
+	generated, err := os.ReadFile("../../internal/tfspec/tfspec_gen.go")
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(source, generated), "internal/tfspec/tfspec_gen.go is stale; regenerate it with cmd/genexpectations")
+}
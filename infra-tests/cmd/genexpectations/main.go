@@ -0,0 +1,61 @@
+// Command genexpectations generates the tfspec package from Terraform state or plan JSON.
+//
+// Usage:
+//
+//	go run ./cmd/genexpectations -terraform-dir ../terraform -out internal/tfspec/tfspec_gen.go
+//	go run ./cmd/genexpectations -input show.json -base-name lambda-java-template-dev -out internal/tfspec/tfspec_gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+)
+
+func main() {
+	input := flag.String("input", "", "Path to `terraform show -json` output (state or plan); runs terraform when empty")
+	terraformDir := flag.String("terraform-dir", "../terraform", "Terraform working directory used when -input is empty")
+	baseName := flag.String("base-name", "lambda-java-template-dev", "Resource name prefix (<project>-<environment>) stripped from generated names")
+	out := flag.String("out", "internal/tfspec/tfspec_gen.go", "Path of the generated Go file")
+	flag.Parse()
+
+	document, err := readDocument(*input, *terraformDir)
+	if err != nil {
+		log.Fatalf("Failed to read Terraform JSON: %v", err)
+	}
+
+	spec, err := extract(document, *baseName)
+	if err != nil {
+		log.Fatalf("Failed to extract expectations: %v", err)
+	}
+
+	source, err := render(spec)
+	if err != nil {
+		log.Fatalf("Failed to render expectations: %v", err)
+	}
+
+	if err := os.WriteFile(*out, source, 0o644); err != nil {
+		log.Fatalf("Failed to write %s: %v", *out, err)
+	}
+	fmt.Printf("Wrote %d functions, %d tables, and %d routes to %s\n", len(spec.Functions), len(spec.Tables), len(spec.Routes), *out)
+}
+
+// readDocument loads JSON from path, or from `terraform show -json` in terraformDir when path is empty
+func readDocument(path, terraformDir string) ([]byte, error) {
+	if path != "" {
+		return os.ReadFile(path)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("terraform", "show", "-json")
+	cmd.Dir = terraformDir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("terraform show -json in %s: %w: %s", terraformDir, err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
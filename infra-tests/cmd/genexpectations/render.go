@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"text/template"
+)
+
+var generatedTemplate = template.Must(template.New("tfspec").Funcs(template.FuncMap{
+	"quote": func(value interface{}) string { return fmt.Sprintf("%#v", value) },
+}).Parse(`// Code generated by cmd/genexpectations; DO NOT EDIT.
+
+package tfspec
+
+// Functions are the Lambda functions declared in Terraform
+var Functions = []FunctionSpec{
+{{- range .Functions}}
+	{
+		NameSuffix:          {{quote .NameSuffix}},
+		Runtime:             {{quote .Runtime}},
+		Handler:             {{quote .Handler}},
+		Architectures:       {{quote .Architectures}},
+		Layers:              {{quote .Layers}},
+		MemorySize:          {{.MemorySize}},
+		Timeout:             {{.Timeout}},
+		TracingMode:         {{quote .TracingMode}},
+		EnvironmentKeys:     {{quote .EnvironmentKeys}},
+		ReservedConcurrency: {{.ReservedConcurrency}},
+	},
+{{- end}}
+}
+
+// Tables are the DynamoDB tables declared in Terraform
+var Tables = []TableSpec{
+{{- range .Tables}}
+	{
+		NameSuffix:  {{quote .NameSuffix}},
+		HashKey:     {{quote .HashKey}},
+		RangeKey:    {{quote .RangeKey}},
+		BillingMode: {{quote .BillingMode}},
+		GlobalSecondaryIndexes: []IndexSpec{
+		{{- range .GlobalSecondaryIndexes}}
+			{Name: {{quote .Name}}, HashKey: {{quote .HashKey}}, RangeKey: {{quote .RangeKey}}, ProjectionType: {{quote .ProjectionType}}},
+		{{- end}}
+		},
+		PointInTimeRecovery: {{.PointInTimeRecovery}},
+		Encrypted:           {{.Encrypted}},
+		TTLAttribute:        {{quote .TTLAttribute}},
+		StreamEnabled:       {{.StreamEnabled}},
+	},
+{{- end}}
+}
+
+// Routes are the API Gateway routes declared in Terraform
+var Routes = []RouteSpec{
+{{- range .Routes}}
+	{RouteKey: {{quote .RouteKey}}, AuthorizationType: {{quote .AuthorizationType}}},
+{{- end}}
+}
+`))
+
+// render produces the gofmt-ed source of the generated tfspec file
+func render(s spec) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := generatedTemplate.Execute(&buf, s); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/tfspec"
+)
+
+var (
+	// terraformFunction matches an entry of local.lambda_functions up to its handler
+	terraformFunction = regexp.MustCompile(`name\s*=\s*"\$\{local\.function_base_name\}-([\w-]+)"[^}]*?runtime\s*=\s*"([^"]+)"[^}]*?handler\s*=\s*"([^"]+)"`)
+	// terraformRoute matches one route of a function in local.lambda_functions
+	terraformRoute = regexp.MustCompile(`\{\s*path\s*=\s*"([^"]+)",\s*method\s*=\s*"([A-Z]+)",\s*auth\s*=\s*(true|false)\s*\}`)
+	// terraformTable* match the top-level arguments of a dynamodb-table module block
+	terraformTableName     = regexp.MustCompile(`(?m)^  name\s*=\s*"\$\{local\.function_base_name\}-([\w-]+)"`)
+	terraformTableHashKey  = regexp.MustCompile(`(?m)^  hash_key\s*=\s*"(\w+)"`)
+	terraformTableRangeKey = regexp.MustCompile(`(?m)^  range_key\s*=\s*"(\w+)"`)
+)
+
+// TestFixtureMatchesTerraform catches a terraform-show-dev.json snapshot left behind by a change to terraform/:
+// the functions, routes, and table keys declared there must be the ones generated from the snapshot.
+func TestFixtureMatchesTerraform(t *testing.T) {
+	document, err := os.ReadFile("../../testdata/terraform-show-dev.json")
+	require.NoError(t, err)
+	fixture, err := extract(document, "lambda-java-template-dev")
+	require.NoError(t, err)
+
+	locals, err := os.ReadFile("../../../terraform/locals.tf")
+	require.NoError(t, err)
+	dynamodb, err := os.ReadFile("../../../terraform/dynamodb.tf")
+	require.NoError(t, err)
+
+	var functions, fixtureFunctions []string
+	for _, match := range terraformFunction.FindAllStringSubmatch(string(locals), -1) {
+		functions = append(functions, strings.Join(match[1:], " "))
+	}
+	for _, function := range fixture.Functions {
+		fixtureFunctions = append(fixtureFunctions, strings.Join([]string{function.NameSuffix, function.Runtime, function.Handler}, " "))
+	}
+	require.NotEmpty(t, functions, "no functions found in terraform/locals.tf; update the patterns in this test")
+	sort.Strings(functions)
+	assert.Equal(t, functions, fixtureFunctions, "functions differ from terraform/locals.tf; refresh testdata/terraform-show-dev.json")
+
+	var routes []tfspec.RouteSpec
+	for _, match := range terraformRoute.FindAllStringSubmatch(string(locals), -1) {
+		authorization := "NONE"
+		if match[3] == "true" {
+			authorization = "CUSTOM"
+		}
+		routes = append(routes, tfspec.RouteSpec{RouteKey: match[2] + " " + match[1], AuthorizationType: authorization})
+	}
+	require.NotEmpty(t, routes, "no routes found in terraform/locals.tf; update the patterns in this test")
+	sort.Slice(routes, func(i, j int) bool { return routes[i].RouteKey < routes[j].RouteKey })
+	assert.Equal(t, routes, fixture.Routes, "routes differ from terraform/locals.tf; refresh testdata/terraform-show-dev.json")
+
+	var tables, fixtureTables []string
+	for _, block := range strings.Split(string(dynamodb), "\nmodule ")[1:] {
+		name := terraformTableName.FindStringSubmatch(block)
+		hashKey := terraformTableHashKey.FindStringSubmatch(block)
+		require.NotNil(t, name, "table module without a name in terraform/dynamodb.tf")
+		require.NotNil(t, hashKey, "table module without a hash_key in terraform/dynamodb.tf")
+		table := name[1] + " " + hashKey[1]
+		if rangeKey := terraformTableRangeKey.FindStringSubmatch(block); rangeKey != nil {
+			table += " " + rangeKey[1]
+		}
+		tables = append(tables, table)
+	}
+	for _, table := range fixture.Tables {
+		fixtureTables = append(fixtureTables, strings.TrimSpace(strings.Join([]string{table.NameSuffix, table.HashKey, table.RangeKey}, " ")))
+	}
+	sort.Strings(tables)
+	assert.Equal(t, tables, fixtureTables, "tables differ from terraform/dynamodb.tf; refresh testdata/terraform-show-dev.json")
+}
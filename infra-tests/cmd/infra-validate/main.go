@@ -0,0 +1,160 @@
+// Command infra-validate checks a deployed environment with the validators of the integration suite and
+// prints what passed and failed, so an operator or a release job can validate an environment without
+// driving go test.
+//
+// It builds the suite's test binary, or uses one built with `go test -c`, runs TestLambdaIntegration with
+// the selected suite tier and groups, and reads the JSON results the suite writes. It exits 1 when a
+// validator failed and 2 when the suite could not run. Run it from the infra-tests directory, which holds
+// the suite and the testdata it reads.
+//
+// Usage:
+//
+//	go run ./cmd/infra-validate -env prod -group security,monitoring -output json
+//	go test -c -o infra-tests.test && go run ./cmd/infra-validate -binary ./infra-tests.test -env staging -suite full
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/lambda-java-template/tests/internal/group"
+	"github.com/lambda-java-template/tests/internal/results"
+	"github.com/lambda-java-template/tests/internal/tier"
+)
+
+func main() {
+	environment := flag.String("env", "dev", "Environment to validate")
+	projectName := flag.String("project", "", "Project name of the deployment; the suite's default when empty")
+	region := flag.String("region", "", "AWS region of the deployment; the suite's default when empty")
+	groups := flag.String("group", "", "Comma-separated groups of validators to run, e.g. security,monitoring; empty runs every group")
+	suite := flag.String("suite", tier.Smoke.String(), "Suite tier: smoke (read-only checks), full (adds CRUD and workflow runs that write data), or soak (adds load and chaos)")
+	output := flag.String("output", "text", "Output format: text, or json for the suite's results document")
+	binary := flag.String("binary", "", "Test binary built with `go test -c`; built from the current directory when empty")
+	maxParallel := flag.Int("max-parallel", 4, "Most groups of validators run at once; 1 runs every validator one after another")
+	timeout := flag.Duration("timeout", 30*time.Minute, "Longest the suite may run")
+	verbose := flag.Bool("v", false, "Stream the suite's log to stderr")
+	flag.Parse()
+
+	if _, err := group.Parse(*groups); err != nil {
+		usage(err)
+	}
+	if _, err := tier.Parse(*suite); err != nil {
+		usage(err)
+	}
+	if *output != "text" && *output != "json" {
+		usage(fmt.Errorf("output must be text or json, got %q", *output))
+	}
+
+	workDir, err := os.MkdirTemp("", "infra-validate")
+	if err != nil {
+		log.Fatalf("Failed to create a working directory: %v", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	if *binary == "" {
+		*binary = filepath.Join(workDir, "infra-tests.test")
+		build := exec.Command("go", "test", "-c", "-o", *binary, ".")
+		build.Stdout, build.Stderr = os.Stderr, os.Stderr
+		if err := build.Run(); err != nil {
+			exit(2, "Failed to build the suite: %v", err)
+		}
+	}
+
+	resultsFile := filepath.Join(workDir, "results.json")
+	args := []string{"-test.run", "^TestLambdaIntegration$", "-test.timeout", timeout.String(), "-suite", *suite, "-group", *groups, "-max-parallel", strconv.Itoa(*maxParallel)}
+	if *verbose {
+		args = append(args, "-test.v")
+	}
+	run := exec.Command(*binary, args...)
+	run.Env = append(os.Environ(), "TEST_ENVIRONMENT="+*environment, "TEST_RESULTS_FILE="+resultsFile)
+	if *projectName != "" {
+		run.Env = append(run.Env, "TEST_PROJECT_NAME="+*projectName)
+	}
+	if *region != "" {
+		run.Env = append(run.Env, "TEST_AWS_REGION="+*region)
+	}
+
+	// The suite's log is kept back unless it is streamed, and shown only when the results cannot explain a failure
+	var suiteLog bytes.Buffer
+	if *verbose {
+		run.Stdout, run.Stderr = os.Stderr, os.Stderr
+	} else {
+		run.Stdout, run.Stderr = &suiteLog, &suiteLog
+	}
+	runErr := run.Run()
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		exit(2, "Failed to run %s: %v", *binary, runErr)
+	}
+
+	report, err := readReport(resultsFile)
+	if err != nil {
+		os.Stderr.Write(suiteLog.Bytes())
+		exit(2, "The suite wrote no results: %v", err)
+	}
+
+	switch *output {
+	case "json":
+		err = writeJSON(os.Stdout, report)
+	default:
+		err = writeText(os.Stdout, report)
+	}
+	if err != nil {
+		exit(2, "Failed to write the results: %v", err)
+	}
+
+	switch {
+	case report.Summary.Failed > 0:
+		os.Exit(1)
+	case runErr != nil:
+		// The suite failed outside any validator, e.g. in setup or by timing out
+		os.Stderr.Write(suiteLog.Bytes())
+		exit(1, "The suite failed: %v", runErr)
+	}
+}
+
+// readReport reads the results document the suite wrote
+func readReport(path string) (results.Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return results.Report{}, err
+	}
+	var report results.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return results.Report{}, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return report, nil
+}
+
+// writeJSON writes the results document as the suite wrote it
+func writeJSON(w io.Writer, report results.Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// usage reports an invalid flag and exits with status 2, as flag parsing does
+func usage(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	flag.Usage()
+	os.Exit(2)
+}
+
+// exit prints a message to stderr and exits with code
+func exit(code int, format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(code)
+}
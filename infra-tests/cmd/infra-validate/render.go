@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/lambda-java-template/tests/internal/results"
+)
+
+// statusLabels are how each outcome is printed
+var statusLabels = map[results.Status]string{
+	results.StatusPassed:  "PASS",
+	results.StatusFailed:  "FAIL",
+	results.StatusSkipped: "SKIP",
+}
+
+// writeText writes one line per validator, the components the deployment lacks, and the totals
+func writeText(w io.Writer, report results.Report) error {
+	fmt.Fprintf(w, "%s %s in %s (%s)\n\n", report.Project, report.Environment, report.Region, report.Target)
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, check := range report.Checks {
+		duration := (time.Duration(check.DurationMs) * time.Millisecond).Round(100 * time.Millisecond)
+		fmt.Fprintf(table, "%s\t%s\t%s\n", statusLabels[check.Status], check.Name, duration)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	if len(report.Missing) > 0 {
+		fmt.Fprintln(w, "\nNot deployed:")
+		for _, missing := range report.Missing {
+			fmt.Fprintf(w, "  %s %s, skipping %s\n", missing.Component, missing.Resource, strings.Join(missing.Skipped, ", "))
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d passed, %d failed, %d skipped\n", report.Summary.Passed, report.Summary.Failed, report.Summary.Skipped)
+	return err
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/results"
+)
+
+func TestWriteText(t *testing.T) {
+	report := results.Report{
+		Project:     "lambda-java-template",
+		Environment: "prod",
+		Region:      "us-east-1",
+		Target:      "aws",
+		Summary:     results.Summary{Passed: 1, Failed: 1, Skipped: 1},
+		Checks: []results.Check{
+			{Name: "Public_Exposure", Status: results.StatusFailed, DurationMs: 1240},
+			{Name: "Security_Configuration", Status: results.StatusPassed, DurationMs: 3010},
+			{Name: "WAF_Protection", Status: results.StatusSkipped},
+		},
+		Missing: []results.MissingComponent{
+			{Component: "HTTP API", Resource: "lambda-java-template-prod-api", Skipped: []string{"WAF_Protection"}},
+		},
+	}
+
+	var out strings.Builder
+	require.NoError(t, writeText(&out, report))
+	assert.Equal(t, `lambda-java-template prod in us-east-1 (aws)
+
+FAIL  Public_Exposure         1.2s
+PASS  Security_Configuration  3s
+SKIP  WAF_Protection          0s
+
+Not deployed:
+  HTTP API lambda-java-template-prod-api, skipping WAF_Protection
+
+1 passed, 1 failed, 1 skipped
+`, out.String())
+}
@@ -0,0 +1,55 @@
+// Command inventory writes a JSON and Markdown report of every resource tagged with a project environment.
+//
+// Usage:
+//
+//	go run ./cmd/inventory -env dev -json inventory.json -markdown inventory.md
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+
+	"github.com/lambda-java-template/tests/internal/inventory"
+)
+
+func main() {
+	region := flag.String("region", "us-east-1", "AWS region of the target environment")
+	projectName := flag.String("project", "lambda-java-template", "Value of the Project tag")
+	environment := flag.String("env", "dev", "Value of the Environment tag")
+	jsonPath := flag.String("json", "inventory.json", "Path of the JSON report; empty to skip")
+	markdownPath := flag.String("markdown", "inventory.md", "Path of the Markdown report; empty to skip")
+	flag.Parse()
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(*region))
+	if err != nil {
+		log.Fatalf("Failed to load AWS configuration: %v", err)
+	}
+
+	result, err := inventory.Collect(context.TODO(), inventory.NewClients(cfg), *projectName, *environment)
+	if err != nil {
+		log.Fatalf("Failed to build inventory: %v", err)
+	}
+
+	if *jsonPath != "" {
+		data, err := inventory.JSON(result)
+		if err != nil {
+			log.Fatalf("Failed to render JSON: %v", err)
+		}
+		if err := os.WriteFile(*jsonPath, append(data, '\n'), 0o644); err != nil {
+			log.Fatalf("Failed to write %s: %v", *jsonPath, err)
+		}
+	}
+
+	if *markdownPath != "" {
+		if err := os.WriteFile(*markdownPath, []byte(inventory.Markdown(result)), 0o644); err != nil {
+			log.Fatalf("Failed to write %s: %v", *markdownPath, err)
+		}
+	}
+
+	fmt.Printf("Inventoried %d resources tagged Project=%s Environment=%s\n", len(result.Resources), *projectName, *environment)
+}
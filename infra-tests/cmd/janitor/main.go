@@ -0,0 +1,91 @@
+// Command janitor deletes ephemeral test namespaces older than a time to live.
+//
+// Test runs deploy into namespaces starting with -prefix with is_ephemeral and created_at set, so Terraform
+// tags every resource with Ephemeral=true and CreatedAt. A run whose terraform destroy fails leaves its
+// namespace behind; the janitor deletes the functions, tables, state machines, APIs, log groups, and alarms
+// of every namespace older than -ttl and lists any other tagged resource it cannot delete. A namespace with
+// a resource not tagged Ephemeral=true is kept whatever its age.
+//
+// Usage:
+//
+//	go run ./cmd/janitor -ttl 6h -dry-run
+//	go run ./cmd/janitor -ttl 6h
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+
+	"github.com/lambda-java-template/tests/internal/inventory"
+	"github.com/lambda-java-template/tests/internal/janitor"
+	"github.com/lambda-java-template/tests/internal/orphans"
+)
+
+func main() {
+	region := flag.String("region", "us-east-1", "AWS region of the test namespaces")
+	projectName := flag.String("project", "lambda-java-template", "Value of the Project tag")
+	prefix := flag.String("prefix", janitor.DefaultPrefix, "Prefix of the Namespace tag of test namespaces")
+	ttl := flag.Duration("ttl", 6*time.Hour, "Age beyond which a namespace is deleted")
+	dryRun := flag.Bool("dry-run", false, "List expired namespaces without deleting them")
+	flag.Parse()
+
+	if *prefix == "" {
+		log.Fatal("An empty -prefix would match every namespace")
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(*region))
+	if err != nil {
+		log.Fatalf("Failed to load AWS configuration: %v", err)
+	}
+	result, err := inventory.Collect(context.TODO(), inventory.NewClients(cfg), *projectName, "")
+	if err != nil {
+		log.Fatalf("Failed to list tagged resources: %v", err)
+	}
+	namespaces, err := janitor.Group(result.Resources, *prefix)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	clients := orphans.NewClients(cfg)
+	now := time.Now()
+	failed := false
+	for _, namespace := range namespaces {
+		if !namespace.Ephemeral() {
+			fmt.Printf("Keeping %s: not every resource is tagged %s=true\n", namespace.Name, janitor.EphemeralTag)
+			continue
+		}
+		if namespace.CreatedAt.IsZero() {
+			fmt.Printf("Keeping %s: no resource is tagged %s\n", namespace.Name, janitor.CreatedAtTag)
+			continue
+		}
+		age := now.Sub(namespace.CreatedAt).Round(time.Minute)
+		if !namespace.Expired(now, *ttl) {
+			fmt.Printf("Keeping %s: %s old\n", namespace.Name, age)
+			continue
+		}
+
+		fmt.Printf("Deleting %s: %s old, %d resources\n", namespace.Name, age, len(namespace.Resources))
+		for _, resource := range janitor.TeardownOrder(namespace.Resources) {
+			if *dryRun {
+				fmt.Printf("  would delete %s\n", resource.ARN)
+				continue
+			}
+			if err := orphans.DeleteResource(context.TODO(), clients, resource); err != nil {
+				log.Printf("  %v", err)
+				failed = true
+				continue
+			}
+			fmt.Printf("  deleted %s\n", resource.ARN)
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
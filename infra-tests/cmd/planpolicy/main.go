@@ -0,0 +1,56 @@
+// Command planpolicy checks a saved Terraform plan against the deployment policies: no deletions in prod,
+// no security group ingress from 0.0.0.0/0 or ::/0, and every provider pinned below its next major release.
+//
+// Usage:
+//
+//	terraform -chdir=../terraform plan -out=tfplan
+//	go run ./cmd/planpolicy -terraform-dir ../terraform -plan-file tfplan -env prod
+//	terraform show -json tfplan > plan.json && go run ./cmd/planpolicy -plan plan.json -env dev
+//
+// It exits with status 1 when the plan violates a policy.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/lambda-java-template/tests/internal/planpolicy"
+)
+
+func main() {
+	environment := flag.String("env", "dev", "Environment the plan deploys; deletions are only refused in prod")
+	terraformDir := flag.String("terraform-dir", "", "Terraform working directory holding the saved plan")
+	planFile := flag.String("plan-file", "tfplan", "Saved plan file, relative to -terraform-dir")
+	planPath := flag.String("plan", "", "File holding terraform show -json output of a plan, used instead of -terraform-dir")
+	flag.Parse()
+
+	var document []byte
+	var err error
+	switch {
+	case *planPath != "":
+		document, err = os.ReadFile(*planPath)
+	case *terraformDir != "":
+		document, err = planpolicy.ShowPlan(context.TODO(), *terraformDir, *planFile)
+	default:
+		log.Fatal("Set -terraform-dir or -plan")
+	}
+	if err != nil {
+		log.Fatalf("Failed to read Terraform plan: %v", err)
+	}
+	plan, err := planpolicy.Parse(document)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	gaps := planpolicy.Evaluate(plan, *environment)
+	for _, gap := range gaps {
+		fmt.Printf("Policy violation: %s\n", gap)
+	}
+	fmt.Printf("Checked %d resource changes for %s; %d policy violations\n", len(plan.ResourceChanges), *environment, len(gaps))
+	if len(gaps) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,49 @@
+// Command seed idempotently upserts a product dataset into a deployed environment.
+//
+// Usage:
+//
+//	go run ./cmd/seed -env dev -dataset testdata/products.json -namespace demo-
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"github.com/lambda-java-template/tests/internal/seed"
+)
+
+func main() {
+	region := flag.String("region", "us-east-1", "AWS region of the target environment")
+	projectName := flag.String("project", "lambda-java-template", "Project name used in resource names")
+	environment := flag.String("env", "dev", "Environment (or namespace) used in resource names")
+	tableName := flag.String("table", "", "Products table name (defaults to <project>-<env>-products)")
+	datasetPath := flag.String("dataset", "testdata/products.json", "Path to a JSON array of products")
+	namespace := flag.String("namespace", seed.DefaultNamespace, "Prefix applied to every seeded product id")
+	flag.Parse()
+
+	if *tableName == "" {
+		*tableName = fmt.Sprintf("%s-%s-products", *projectName, *environment)
+	}
+
+	products, err := seed.LoadDataset(*datasetPath)
+	if err != nil {
+		log.Fatalf("Failed to load dataset: %v", err)
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(*region))
+	if err != nil {
+		log.Fatalf("Failed to load AWS configuration: %v", err)
+	}
+
+	written, err := seed.Upsert(context.TODO(), dynamodb.NewFromConfig(cfg), *tableName, *namespace, products)
+	if err != nil {
+		log.Fatalf("Seeded %d of %d products before failing: %v", written, len(products), err)
+	}
+
+	fmt.Printf("Seeded %d products into %s with namespace %q\n", written, *tableName, *namespace)
+}
@@ -0,0 +1,82 @@
+// Command sweep lists resources tagged with a project that are missing from its Terraform state, and can
+// delete those left behind by ephemeral namespaces whose test runs failed before destroying them.
+//
+// Usage:
+//
+//	go run ./cmd/sweep -terraform-dir ../terraform
+//	go run ./cmd/sweep -state state.json -env dev
+//	go run ./cmd/sweep -terraform-dir ../terraform -delete-orphans
+//
+// It exits with status 1 while orphans remain.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+
+	"github.com/lambda-java-template/tests/internal/inventory"
+	"github.com/lambda-java-template/tests/internal/orphans"
+)
+
+func main() {
+	region := flag.String("region", "us-east-1", "AWS region of the target environment")
+	projectName := flag.String("project", "lambda-java-template", "Value of the Project tag")
+	environment := flag.String("env", "", "Value of the Environment tag; empty sweeps every environment and namespace")
+	terraformDir := flag.String("terraform-dir", "", "Terraform working directory whose state is read with terraform show -json")
+	statePath := flag.String("state", "", "File holding terraform show -json output, used instead of -terraform-dir")
+	deleteOrphans := flag.Bool("delete-orphans", false, "Delete orphans tagged Ephemeral=true; other orphans are only listed")
+	flag.Parse()
+
+	var state []byte
+	var err error
+	switch {
+	case *statePath != "":
+		state, err = os.ReadFile(*statePath)
+	case *terraformDir != "":
+		state, err = orphans.ShowState(context.TODO(), *terraformDir)
+	default:
+		log.Fatal("Set -terraform-dir or -state")
+	}
+	if err != nil {
+		log.Fatalf("Failed to read Terraform state: %v", err)
+	}
+	managed, err := orphans.ManagedIdentifiers(state)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(*region))
+	if err != nil {
+		log.Fatalf("Failed to load AWS configuration: %v", err)
+	}
+	result, err := inventory.Collect(context.TODO(), inventory.NewClients(cfg), *projectName, *environment)
+	if err != nil {
+		log.Fatalf("Failed to list tagged resources: %v", err)
+	}
+
+	clients := orphans.NewClients(cfg)
+	remaining := 0
+	for _, orphan := range orphans.Find(result.Resources, managed) {
+		if *deleteOrphans && orphans.Deletable(orphan) {
+			if err := orphans.Delete(context.TODO(), clients, orphan); err != nil {
+				log.Printf("Failed to delete %s: %v", orphan.ARN, err)
+				remaining++
+				continue
+			}
+			fmt.Printf("Deleted %s (Environment=%s)\n", orphan.ARN, orphan.Tags["Environment"])
+			continue
+		}
+		fmt.Printf("Orphan %s (Environment=%s, Ephemeral=%s)\n", orphan.ARN, orphan.Tags["Environment"], orphan.Tags["Ephemeral"])
+		remaining++
+	}
+
+	fmt.Printf("Swept %d resources tagged Project=%s; %d orphans remain\n", len(result.Resources), *projectName, remaining)
+	if remaining > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,131 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/codedeploy"
+	codedeploytypes "github.com/aws/aws-sdk-go-v2/service/codedeploy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+)
+
+// deploymentTimeout bounds how long a gradual deployment may take to finish shifting traffic
+const deploymentTimeout = 30 * time.Minute
+
+// validateGradualDeployments checks the CodeDeploy deployment groups of the environment's Lambda functions:
+// traffic shifts in a canary or linear steps, failures and alarms roll back automatically, and the latest
+// deployment finished without rolling back. The template deploys all at once by default, so this is
+// skipped until a CodeDeploy application for the environment exists.
+func validateGradualDeployments(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+	client := codedeploy.NewFromConfig(cfg)
+
+	var applications []string
+	paginator := codedeploy.NewListApplicationsPaginator(client, &codedeploy.ListApplicationsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		require.NoError(t, err)
+		for _, name := range page.Applications {
+			if strings.HasPrefix(name, resolver.Settings().BaseName()) {
+				applications = append(applications, name)
+			}
+		}
+	}
+	if len(applications) == 0 {
+		t.Skipf("No CodeDeploy application named %s*; functions are deployed all at once", resolver.Settings().BaseName())
+	}
+
+	for _, application := range applications {
+		t.Run(application, func(t *testing.T) {
+			groups, err := client.ListDeploymentGroups(context.TODO(), &codedeploy.ListDeploymentGroupsInput{
+				ApplicationName: aws.String(application),
+			})
+			require.NoError(t, err)
+			require.NotEmpty(t, groups.DeploymentGroups, "CodeDeploy application %s has no deployment groups", application)
+
+			for _, groupName := range groups.DeploymentGroups {
+				t.Run(groupName, func(t *testing.T) {
+					group, err := client.GetDeploymentGroup(context.TODO(), &codedeploy.GetDeploymentGroupInput{
+						ApplicationName:     aws.String(application),
+						DeploymentGroupName: aws.String(groupName),
+					})
+					require.NoError(t, err)
+					validateDeploymentGroup(t, cfg, client, *group.DeploymentGroupInfo)
+				})
+			}
+		})
+	}
+}
+
+// validateDeploymentGroup checks one Lambda deployment group and waits for its latest deployment
+func validateDeploymentGroup(t *testing.T, cfg aws.Config, client *codedeploy.Client, group codedeploytypes.DeploymentGroupInfo) {
+	if group.ComputePlatform != codedeploytypes.ComputePlatformLambda {
+		t.Skipf("Deployment group %s deploys to %s, not Lambda", aws.ToString(group.DeploymentGroupName), group.ComputePlatform)
+	}
+
+	t.Run("Configuration", func(t *testing.T) {
+		for _, gap := range checks.DeploymentGroupGaps(group) {
+			assert.Fail(t, "Unsafe deployment group", "%s: %s", aws.ToString(group.DeploymentGroupName), gap)
+		}
+	})
+
+	t.Run("Rollback_Alarms", func(t *testing.T) {
+		names := checks.AlarmNames(group)
+		if len(names) == 0 {
+			t.Skip("Deployment group has no rollback alarms")
+		}
+
+		alarms, err := cloudwatch.NewFromConfig(cfg).DescribeAlarms(context.TODO(), &cloudwatch.DescribeAlarmsInput{
+			AlarmNames: names,
+		})
+		require.NoError(t, err)
+
+		existing := map[string]bool{}
+		for _, alarm := range alarms.MetricAlarms {
+			existing[aws.ToString(alarm.AlarmName)] = true
+		}
+		for _, alarm := range alarms.CompositeAlarms {
+			existing[aws.ToString(alarm.AlarmName)] = true
+		}
+		for _, name := range names {
+			assert.True(t, existing[name], "Rollback alarm %s does not exist, so it can never stop a deployment", name)
+		}
+	})
+
+	t.Run("Latest_Deployment", func(t *testing.T) {
+		if group.LastAttemptedDeployment == nil {
+			t.Skip("Deployment group has never deployed")
+		}
+		deploymentId := aws.ToString(group.LastAttemptedDeployment.DeploymentId)
+
+		var deployment *codedeploytypes.DeploymentInfo
+		waitFor(t, deploymentTimeout, "deployment "+deploymentId+" to finish", func(ctx context.Context) (bool, error) {
+			output, err := client.GetDeployment(ctx, &codedeploy.GetDeploymentInput{DeploymentId: aws.String(deploymentId)})
+			if err != nil {
+				return false, err
+			}
+			deployment = output.DeploymentInfo
+			switch deployment.Status {
+			case codedeploytypes.DeploymentStatusSucceeded, codedeploytypes.DeploymentStatusFailed, codedeploytypes.DeploymentStatusStopped:
+				return true, nil
+			}
+			return false, nil
+		})
+
+		message := ""
+		if deployment.ErrorInformation != nil {
+			message = aws.ToString(deployment.ErrorInformation.Message)
+		}
+		assert.Equal(t, codedeploytypes.DeploymentStatusSucceeded, deployment.Status, "Deployment %s: %s", deploymentId, message)
+		if deployment.RollbackInfo != nil {
+			assert.Fail(t, "Deployment rolled back", "Deployment %s: %s", deploymentId, aws.ToString(deployment.RollbackInfo.RollbackMessage))
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/coldstart"
+	"github.com/lambda-java-template/tests/internal/payloads"
+)
+
+// coldStartMarker is the environment variable changed before each invocation, so Lambda
+// discards the function's execution environments and the next invocation starts cold
+const coldStartMarker = "COLD_START_BENCHMARK"
+
+// functionUpdateTimeout bounds how long a configuration update or a SnapStart version may take to become ready
+const functionUpdateTimeout = 5 * time.Minute
+
+// validateColdStartBenchmark forces -cold-starts cold starts of every function, reports the p50 and p95
+// start-up time next to the previous run, and appends the results to -cold-start-history.
+// It changes function configuration, so it is skipped unless the flag is set.
+func validateColdStartBenchmark(t *testing.T, cfg aws.Config, projectName, environment string) {
+	if *coldStarts <= 0 {
+		t.Skip("Skipping cold start benchmark; set -cold-starts to run it")
+	}
+	resolver := discover(cfg, projectName, environment)
+	lambdaClient := lambda.NewFromConfig(cfg)
+
+	history, err := coldstart.LoadHistory(*coldStartHistory)
+	require.NoError(t, err)
+
+	events := coldStartEvents()
+
+	var mu sync.Mutex
+	run := coldstart.Run{Timestamp: time.Now().UTC(), Environment: environment, Functions: map[string]coldstart.Result{}}
+
+	// Functions are benchmarked in parallel; each one's cold starts are forced one after another
+	t.Run("Functions", func(t *testing.T) {
+		for suffix, event := range events {
+			functionName := resolver.FunctionName(suffix)
+			t.Run(suffix, func(t *testing.T) {
+				t.Parallel()
+				requireFunction(t, lambdaClient, functionName)
+
+				startUps := forceColdStarts(t, lambdaClient, functionName, event, *coldStarts)
+				result := coldstart.Summarize(startUps)
+				suiteResults.RecordLatency(suffix+" cold start p95", time.Duration(result.P95Ms)*time.Millisecond)
+
+				if previous, ok := coldstart.Previous(history, environment, suffix); ok {
+					t.Logf("%s: cold start p50 %dms, p95 %dms over %d samples (previous run: p50 %dms, p95 %dms, change %+dms)",
+						functionName, result.P50Ms, result.P95Ms, result.Samples, previous.P50Ms, previous.P95Ms, result.P95Ms-previous.P95Ms)
+				} else {
+					t.Logf("%s: cold start p50 %dms, p95 %dms over %d samples (no previous run)",
+						functionName, result.P50Ms, result.P95Ms, result.Samples)
+				}
+
+				mu.Lock()
+				run.Functions[suffix] = result
+				mu.Unlock()
+			})
+		}
+	})
+
+	if len(run.Functions) > 0 {
+		require.NoError(t, coldstart.AppendHistory(*coldStartHistory, run))
+		t.Logf("Appended results to %s", *coldStartHistory)
+	}
+}
+
+// coldStartEvents returns, by function suffix, an event each function handles without side effects outside the test namespace
+func coldStartEvents() map[string]any {
+	events := map[string]any{
+		"product-service":    payloads.NewHTTPRequest("GET /health", "/health", nil, ""),
+		"authorizer-service": payloads.NewAuthorizerRequest("GET /health", "/health", map[string]string{"x-api-key": "infra-tests-key"}),
+	}
+	for _, suffix := range workflowFunctions {
+		events[suffix] = payloads.SampleOrder(testNamespace(), fmt.Sprintf("%scold-start-%d", testNamespace(), time.Now().UnixNano()))
+	}
+	return events
+}
+
+// forceColdStarts changes the function's configuration before each of count invocations and returns the
+// start-up time each one reported. Functions with SnapStart get a new version per sample, so the restore
+// of a fresh snapshot is measured. The original configuration is restored when the test ends.
+func forceColdStarts(t *testing.T, client *lambda.Client, functionName string, event any, count int) []time.Duration {
+	config, err := client.GetFunctionConfiguration(context.TODO(), &lambda.GetFunctionConfigurationInput{
+		FunctionName: aws.String(functionName),
+	})
+	require.NoError(t, err)
+	snapStart := config.SnapStart != nil && config.SnapStart.ApplyOn == lambdatypes.SnapStartApplyOnPublishedVersions
+
+	original := map[string]string{}
+	if config.Environment != nil {
+		original = config.Environment.Variables
+	}
+	t.Cleanup(func() { updateEnvironment(t, client, functionName, original) })
+
+	var startUps []time.Duration
+	for i := 0; i < count; i++ {
+		variables := map[string]string{coldStartMarker: fmt.Sprintf("%d", time.Now().UnixNano())}
+		for name, value := range original {
+			variables[name] = value
+		}
+		updateEnvironment(t, client, functionName, variables)
+
+		target := functionName
+		if snapStart {
+			target = publishSnapStartVersion(t, client, functionName)
+		}
+
+		output := invokeFunction(t, client, target, event)
+		logTail, err := base64.StdEncoding.DecodeString(aws.ToString(output.LogResult))
+		require.NoError(t, err)
+
+		report, ok := coldstart.ParseReport(string(logTail))
+		require.True(t, ok, "No REPORT line in the log tail of %s:\n%s", target, logTail)
+		if !assert.True(t, report.Cold(), "Invocation %d of %s was not a cold start", i+1, target) {
+			continue
+		}
+		startUps = append(startUps, report.StartUp())
+	}
+	return startUps
+}
+
+// updateEnvironment sets a function's environment variables with setEnvironment and stops t when that fails
+func updateEnvironment(t *testing.T, client *lambda.Client, functionName string, variables map[string]string) {
+	require.NoError(t, setEnvironment(client, functionName, variables))
+}
+
+// setEnvironment replaces a function's environment variables and waits for the update to finish
+func setEnvironment(client *lambda.Client, functionName string, variables map[string]string) error {
+	_, err := client.UpdateFunctionConfiguration(context.TODO(), &lambda.UpdateFunctionConfigurationInput{
+		FunctionName: aws.String(functionName),
+		Environment:  &lambdatypes.Environment{Variables: variables},
+	})
+	if err != nil {
+		return fmt.Errorf("updating the environment of %s: %w", functionName, err)
+	}
+
+	err = lambda.NewFunctionUpdatedV2Waiter(client).Wait(context.TODO(), &lambda.GetFunctionInput{
+		FunctionName: aws.String(functionName),
+	}, functionUpdateTimeout)
+	if err != nil {
+		return fmt.Errorf("waiting for the update of %s: %w", functionName, err)
+	}
+	return nil
+}
+
+// publishSnapStartVersion publishes a version, waits for its snapshot, and returns its qualified name.
+// The version is deleted when the test ends.
+func publishSnapStartVersion(t *testing.T, client *lambda.Client, functionName string) string {
+	published, err := client.PublishVersion(context.TODO(), &lambda.PublishVersionInput{
+		FunctionName: aws.String(functionName),
+		Description:  aws.String("Cold start benchmark"),
+	})
+	require.NoError(t, err, "Failed to publish a version of %s", functionName)
+	version := aws.ToString(published.Version)
+
+	t.Cleanup(func() {
+		_, err := client.DeleteFunction(context.TODO(), &lambda.DeleteFunctionInput{
+			FunctionName: aws.String(functionName),
+			Qualifier:    aws.String(version),
+		})
+		assert.NoError(t, err, "Failed to delete version %s of %s", version, functionName)
+	})
+
+	err = lambda.NewFunctionActiveV2Waiter(client).Wait(context.TODO(), &lambda.GetFunctionInput{
+		FunctionName: aws.String(functionName),
+		Qualifier:    aws.String(version),
+	}, functionUpdateTimeout)
+	require.NoError(t, err, "Snapshot of %s version %s did not become active", functionName, version)
+
+	return fmt.Sprintf("%s:%s", functionName, version)
+}
@@ -0,0 +1,54 @@
+package test
+
+import (
+	"github.com/lambda-java-template/tests/internal/compliance"
+	"github.com/lambda-java-template/tests/internal/testconfig"
+)
+
+// validatorControls annotates validators with the compliance controls a pass evidences.
+// Validators missing here evidence no control; keep it in step with integrationValidators.
+var validatorControls = map[string][]string{
+	"Lambda_Functions_Validation":   {compliance.SecureConfiguration},
+	"Runtime_Management_Validation": {compliance.SecureConfiguration},
+	"Runtime_Deprecation":           {compliance.SecureConfiguration},
+	"Deployment_Packages":           {compliance.ChangeManagement},
+	"Build_Metadata":                {compliance.ChangeManagement},
+	"Version_Skew":                  {compliance.ChangeManagement},
+	"Health_Check":                  {compliance.Monitoring},
+	"DynamoDB_Tables_Validation":    {compliance.EncryptionAtRest, compliance.BackupRecovery},
+	"DynamoDB_PITR_Restore":         {compliance.BackupRecovery},
+	"Authorizer_Contract":           {compliance.LeastPrivilege},
+	"IAM_Route_Authorization":       {compliance.LeastPrivilege},
+	"Route_Authorization_Matrix":    {compliance.LeastPrivilege},
+	"Access_Logs":                   {compliance.Logging},
+	"SQS_DLQ_Validation":            {compliance.EncryptionAtRest},
+	"Security_Configuration":        {compliance.EncryptionAtRest, compliance.EncryptionInTransit, compliance.LeastPrivilege},
+	"Public_Exposure":               {compliance.LeastPrivilege, compliance.NetworkProtection},
+	"Security_Hub_Findings":         {compliance.Monitoring, compliance.SecureConfiguration},
+	"VPC_Networking":                {compliance.NetworkProtection},
+	"WAF_Protection":                {compliance.NetworkProtection},
+	"Custom_Domain_TLS":             {compliance.EncryptionInTransit},
+	"API_Throttling":                {compliance.NetworkProtection},
+	"API_Stage_Deployment":          {compliance.ChangeManagement},
+	"CloudWatch_Monitoring":         {compliance.Monitoring},
+	"Workflow_Execution_SLO":        {compliance.Monitoring},
+	"Log_Group_Configuration":       {compliance.Logging, compliance.EncryptionAtRest},
+	"Terraform_Modules_Validation":  {compliance.ChangeManagement},
+	"Resource_Inventory":            {compliance.AssetInventory},
+	"Inventory_Snapshot":            {compliance.AssetInventory, compliance.ChangeManagement},
+	"Orphaned_Resources":            {compliance.AssetInventory, compliance.ChangeManagement},
+	"Tag_Policy":                    {compliance.AssetInventory},
+	"Trusted_Advisor_Checks":        {compliance.SecureConfiguration},
+}
+
+// writeComplianceReport maps this run's validator outcomes to controls and writes the coverage report to path.
+// Each environment of a multi-environment run writes its own report, with the environment appended to the name.
+func writeComplianceReport(path string) error {
+	if err := compliance.Validate(validatorControls); err != nil {
+		return err
+	}
+	if inEnvironmentChild() {
+		path = testconfig.EnvironmentFile(path, suiteConfig.Environment)
+	}
+	return compliance.WriteFile(path, compliance.Build(suiteResults.Report(), validatorControls))
+}
@@ -0,0 +1,81 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/tfspec"
+)
+
+// minUnreservedConcurrency is the headroom Lambda itself keeps for functions without reserved
+// concurrency; an account at the minimum cannot reserve more and throttles under any real load
+const minUnreservedConcurrency = 100
+
+// throttleWindow is how far back the smoke suite's own traffic is checked for throttling
+const throttleWindow = 15 * time.Minute
+
+// validateConcurrency checks each function's reserved concurrency against Terraform, the account's
+// unreserved concurrency headroom, and that no function was throttled while the suite ran
+func validateConcurrency(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+	lambdaClient := lambda.NewFromConfig(cfg)
+
+	t.Run("Reserved_Concurrency", func(t *testing.T) {
+		for _, function := range tfspec.Functions {
+			functionName := resolver.FunctionName(function.NameSuffix)
+			t.Run(function.NameSuffix, func(t *testing.T) {
+				requireFunction(t, lambdaClient, functionName)
+
+				concurrency, err := lambdaClient.GetFunctionConcurrency(context.TODO(), &lambda.GetFunctionConcurrencyInput{
+					FunctionName: aws.String(functionName),
+				})
+				require.NoError(t, err)
+
+				reserved := int32(-1)
+				if concurrency.ReservedConcurrentExecutions != nil {
+					reserved = *concurrency.ReservedConcurrentExecutions
+				}
+				assert.Equal(t, function.ReservedConcurrency, reserved, "Reserved concurrency of %s (-1 is unreserved)", functionName)
+				assert.NotZero(t, reserved, "%s has reserved concurrency 0, so every invocation is throttled", functionName)
+			})
+		}
+	})
+
+	t.Run("Account_Headroom", func(t *testing.T) {
+		settings, err := lambdaClient.GetAccountSettings(context.TODO(), &lambda.GetAccountSettingsInput{})
+		require.NoError(t, err)
+
+		limit := settings.AccountLimit
+		unreserved := aws.ToInt32(limit.UnreservedConcurrentExecutions)
+		t.Logf("Account concurrency limit %d, unreserved %d", limit.ConcurrentExecutions, unreserved)
+		assert.Greater(t, unreserved, int32(minUnreservedConcurrency),
+			"Only %d of %d concurrent executions are unreserved; request a Lambda concurrency quota increase before launch",
+			unreserved, limit.ConcurrentExecutions)
+	})
+
+	t.Run("No_Throttles", func(t *testing.T) {
+		metricsClient := cloudwatch.NewFromConfig(cfg)
+		end := time.Now()
+		start := end.Add(-throttleWindow)
+
+		// Metrics lag by a minute or two, so the latest requests of the suite may not be counted yet
+		for _, function := range tfspec.Functions {
+			functionName := resolver.FunctionName(function.NameSuffix)
+			t.Run(function.NameSuffix, func(t *testing.T) {
+				requireFunction(t, lambdaClient, functionName)
+
+				invocations, throttles, err := lambdaUsage(context.TODO(), metricsClient, functionName, start, end)
+				require.NoError(t, err)
+				t.Logf("%s: %.0f invocations, %.0f throttles in the last %s", functionName, invocations, throttles, throttleWindow)
+				assert.Zero(t, throttles, "%s was throttled %.0f times while the suite ran", functionName, throttles)
+			})
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	cetypes "github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/cost"
+	"github.com/lambda-java-template/tests/internal/expectations"
+)
+
+// costExplorerRegion serves the Cost Explorer API for every commercial region
+const costExplorerRegion = "us-east-1"
+
+// validateCostBudget projects this month's cost of the deployment's Lambda functions, tables, state machines,
+// and API from Cost Explorer and fails when it exceeds the environment profile's budget.
+// Costs are attributed through the Project and Environment cost allocation tags, which must be activated
+// in the billing console. Each Cost Explorer request is billed, so this is skipped with -short.
+func validateCostBudget(t *testing.T, cfg aws.Config, projectName, environment string) {
+	if testing.Short() {
+		t.Skip("Skipping cost estimation in short mode")
+	}
+	budget := expectations.For(environment).MonthlyBudget
+
+	now := time.Now().UTC()
+	start := cost.MonthStart(now)
+	end := now.Truncate(24 * time.Hour)
+	if !end.After(start) {
+		t.Skip("Cost Explorer has no complete day of this month yet")
+	}
+
+	client := costexplorer.NewFromConfig(cfg, func(o *costexplorer.Options) { o.Region = costExplorerRegion })
+	monthToDate := map[string]float64{}
+	var nextToken *string
+	for {
+		page, err := client.GetCostAndUsage(context.TODO(), &costexplorer.GetCostAndUsageInput{
+			TimePeriod: &cetypes.DateInterval{
+				Start: aws.String(start.Format(time.DateOnly)),
+				End:   aws.String(end.Format(time.DateOnly)),
+			},
+			Granularity: cetypes.GranularityMonthly,
+			Metrics:     []string{"UnblendedCost"},
+			Filter: &cetypes.Expression{And: []cetypes.Expression{
+				{Tags: &cetypes.TagValues{Key: aws.String("Project"), Values: []string{projectName}}},
+				{Tags: &cetypes.TagValues{Key: aws.String("Environment"), Values: []string{environment}}},
+				{Dimensions: &cetypes.DimensionValues{Key: cetypes.DimensionService, Values: cost.Services}},
+			}},
+			GroupBy:       []cetypes.GroupDefinition{{Type: cetypes.GroupDefinitionTypeDimension, Key: aws.String(string(cetypes.DimensionService))}},
+			NextPageToken: nextToken,
+		})
+		require.NoError(t, err, "Cost Explorer must be enabled for the account")
+
+		for _, result := range page.ResultsByTime {
+			for _, group := range result.Groups {
+				amount, err := strconv.ParseFloat(aws.ToString(group.Metrics["UnblendedCost"].Amount), 64)
+				require.NoError(t, err)
+				monthToDate[group.Keys[0]] += amount
+			}
+		}
+		if page.NextPageToken == nil {
+			break
+		}
+		nextToken = page.NextPageToken
+	}
+
+	// Spend covers only completed days, so the projection runs to the end of the last one
+	estimate := cost.Project(monthToDate, end)
+	if len(monthToDate) == 0 {
+		t.Logf("No cost tagged Project=%s Environment=%s this month; check the tags are activated for cost allocation", projectName, environment)
+	}
+	t.Logf("Projected monthly cost $%.2f of a $%.2f budget: %s", estimate.Total(), budget, estimate)
+	for _, gap := range cost.BudgetGaps(estimate, budget) {
+		assert.Fail(t, "Over budget", "%s %s: %s", projectName, environment, gap)
+	}
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+
+	"github.com/lambda-java-template/tests/internal/discovery"
+	"github.com/lambda-java-template/tests/internal/permissions"
+)
+
+// checkCredentials stops the run before any validator when the credentials cannot reach the deployment: STS
+// cannot say who they belong to, the region is in another partition, or policy simulation denies a read API
+// validators call. When the credentials may not simulate their own policies the check is logged and skipped,
+// and validators report any denied call themselves.
+func checkCredentials(t *testing.T, cfg aws.Config, resolver *discovery.Resolver) {
+	ctx := context.TODO()
+
+	caller, err := sts.NewFromConfig(cfg).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		t.Fatalf("Credentials are not usable, check the AWS profile or TEST_ROLE_ARN: %v", err)
+	}
+	identity, err := permissions.ParseIdentity(aws.ToString(caller.Arn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("Running as %s in %s", identity.ARN, cfg.Region)
+
+	if err := identity.CheckRegion(cfg.Region); err != nil {
+		t.Fatal(err)
+	}
+
+	// LocalStack allows every call, and the root user cannot be denied one
+	if suiteConfig.IsLocalStack() || identity.Root {
+		return
+	}
+
+	iamClient := iam.NewFromConfig(cfg)
+	source := identity.PolicySourceARN()
+	if source == "" {
+		role, err := iamClient.GetRole(ctx, &iam.GetRoleInput{RoleName: aws.String(identity.RoleName)})
+		if err != nil {
+			handleSimulationError(t, identity, err)
+			return
+		}
+		source = aws.ToString(role.Role.Arn)
+	}
+
+	var denials []permissions.Denial
+	for _, requirement := range permissions.Required(identity, cfg.Region, resolver) {
+		paginator := iam.NewSimulatePrincipalPolicyPaginator(iamClient, &iam.SimulatePrincipalPolicyInput{
+			PolicySourceArn: aws.String(source),
+			ActionNames:     requirement.Actions,
+			ResourceArns:    []string{requirement.Resource},
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				handleSimulationError(t, identity, err)
+				return
+			}
+			for _, result := range page.EvaluationResults {
+				if result.EvalDecision != types.PolicyEvaluationDecisionTypeAllowed {
+					denials = append(denials, permissions.Denial{
+						Action:   aws.ToString(result.EvalActionName),
+						Resource: requirement.Resource,
+						Decision: string(result.EvalDecision),
+					})
+				}
+			}
+		}
+	}
+	if err := permissions.Error(identity, denials); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// handleSimulationError skips the permission check when the credentials may not read their own IAM policies,
+// which read-only CI roles commonly cannot, and fails the run on any other error
+func handleSimulationError(t *testing.T, identity permissions.Identity, err error) {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && (apiErr.ErrorCode() == "AccessDenied" || apiErr.ErrorCode() == "AccessDeniedException") {
+		t.Logf("Skipping the permission check: %s may not simulate its IAM policies: %v", identity.ARN, err)
+		return
+	}
+	t.Fatalf("Failed to check the permissions of %s: %v", identity.ARN, err)
+}
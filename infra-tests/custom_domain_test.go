@@ -0,0 +1,177 @@
+package test
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+)
+
+// certificateRenewalWindow fails certificates this close to expiry; ACM renews 60 days ahead,
+// so a certificate inside the window is not being renewed
+const certificateRenewalWindow = 30 * 24 * time.Hour
+
+// tlsHandshakeTimeout bounds each TLS handshake with the custom domain
+const tlsHandshakeTimeout = 10 * time.Second
+
+// customDomain is a custom domain name mapped to the API under test
+type customDomain struct {
+	name       string
+	mappingKey string
+	config     types.DomainNameConfiguration
+}
+
+// validateCustomDomains checks each custom domain mapped to the API: a TLS 1.2 security policy, an issued
+// ACM certificate outside the renewal window, a Route 53 alias to the API Gateway domain, and a live
+// HTTPS handshake. The template does not create a custom domain, so this is skipped until one is mapped.
+func validateCustomDomains(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+	api := requireAPI(t, resolver)
+
+	domains := mappedDomains(t, apigatewayv2.NewFromConfig(cfg), api.ID)
+	if len(domains) == 0 {
+		t.Skipf("No custom domain name is mapped to API %s", api.ID)
+	}
+	acmClient := acm.NewFromConfig(cfg)
+	route53Client := route53.NewFromConfig(cfg)
+
+	for _, domain := range domains {
+		t.Run(domain.name, func(t *testing.T) {
+			t.Run("TLS_Policy", func(t *testing.T) {
+				assert.Equal(t, types.SecurityPolicyTls12, domain.config.SecurityPolicy, "Minimum TLS policy of %s", domain.name)
+			})
+
+			t.Run("Certificate", func(t *testing.T) {
+				certificate, err := acmClient.DescribeCertificate(context.TODO(), &acm.DescribeCertificateInput{
+					CertificateArn: domain.config.CertificateArn,
+				})
+				require.NoError(t, err)
+
+				details := certificate.Certificate
+				for _, gap := range checks.CertificateGaps(string(details.Status), aws.ToTime(details.NotAfter), time.Now(), certificateRenewalWindow) {
+					assert.Fail(t, "Certificate cannot serve the domain", "%s (%s): %s", domain.name, aws.ToString(domain.config.CertificateArn), gap)
+				}
+			})
+
+			t.Run("Alias_Record", func(t *testing.T) {
+				record := aliasRecord(t, route53Client, domain.name)
+				require.NotNil(t, record, "No Route 53 A record for %s in a public hosted zone", domain.name)
+				require.NotNil(t, record.AliasTarget, "Route 53 record for %s is not an alias", domain.name)
+				assert.True(t, checks.SameDNSName(aws.ToString(domain.config.ApiGatewayDomainName), aws.ToString(record.AliasTarget.DNSName)),
+					"%s aliases %s, not the API Gateway domain %s", domain.name, aws.ToString(record.AliasTarget.DNSName), aws.ToString(domain.config.ApiGatewayDomainName))
+
+				skipWhenReplaying(t, "DNS lookups")
+				addresses, err := net.LookupHost(domain.name)
+				require.NoError(t, err, "%s does not resolve", domain.name)
+				assert.NotEmpty(t, addresses)
+			})
+
+			t.Run("HTTPS_Handshake", func(t *testing.T) {
+				skipWhenReplaying(t, "TLS handshakes")
+				dialer := &net.Dialer{Timeout: tlsHandshakeTimeout}
+				conn, err := tls.DialWithDialer(dialer, "tcp", domain.name+":443", &tls.Config{ServerName: domain.name, MinVersion: tls.VersionTLS12})
+				require.NoError(t, err, "TLS 1.2+ handshake with %s", domain.name)
+				t.Logf("%s negotiated %s", domain.name, tls.VersionName(conn.ConnectionState().Version))
+				conn.Close()
+
+				legacy, err := tls.DialWithDialer(dialer, "tcp", domain.name+":443", &tls.Config{ServerName: domain.name, MinVersion: tls.VersionTLS10, MaxVersion: tls.VersionTLS11})
+				if err == nil {
+					legacy.Close()
+				}
+				assert.Error(t, err, "%s accepted a TLS 1.1 handshake", domain.name)
+
+				healthURL := "https://" + domain.name + "/" + strings.Trim(domain.mappingKey+"/health", "/")
+				resp, err := suiteHTTP.Get(healthURL)
+				require.NoError(t, err)
+				defer resp.Body.Close()
+				assert.Equal(t, http.StatusOK, resp.StatusCode, "GET %s", healthURL)
+			})
+		})
+	}
+}
+
+// mappedDomains returns the custom domain names with an API mapping to apiId
+func mappedDomains(t *testing.T, client *apigatewayv2.Client, apiId string) []customDomain {
+	var domains []customDomain
+	var nextToken *string
+	for {
+		page, err := client.GetDomainNames(context.TODO(), &apigatewayv2.GetDomainNamesInput{NextToken: nextToken})
+		require.NoError(t, err)
+
+		for _, domain := range page.Items {
+			if len(domain.DomainNameConfigurations) == 0 {
+				continue
+			}
+			mappings, err := client.GetApiMappings(context.TODO(), &apigatewayv2.GetApiMappingsInput{DomainName: domain.DomainName})
+			require.NoError(t, err)
+			for _, mapping := range mappings.Items {
+				if aws.ToString(mapping.ApiId) == apiId {
+					domains = append(domains, customDomain{
+						name:       aws.ToString(domain.DomainName),
+						mappingKey: aws.ToString(mapping.ApiMappingKey),
+						config:     domain.DomainNameConfigurations[0],
+					})
+					break
+				}
+			}
+		}
+
+		if page.NextToken == nil {
+			break
+		}
+		nextToken = page.NextToken
+	}
+	return domains
+}
+
+// aliasRecord returns the A record for name in the most specific public hosted zone containing it,
+// or nil when there is none
+func aliasRecord(t *testing.T, client *route53.Client, name string) *route53types.ResourceRecordSet {
+	var zone *route53types.HostedZone
+	var zoneName string
+	paginator := route53.NewListHostedZonesPaginator(client, &route53.ListHostedZonesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		require.NoError(t, err)
+		for i, candidate := range page.HostedZones {
+			if candidate.Config != nil && candidate.Config.PrivateZone {
+				continue
+			}
+			candidateName := strings.TrimSuffix(aws.ToString(candidate.Name), ".")
+			if (name == candidateName || strings.HasSuffix(name, "."+candidateName)) && len(candidateName) > len(zoneName) {
+				zone, zoneName = &page.HostedZones[i], candidateName
+			}
+		}
+	}
+	if zone == nil {
+		return nil
+	}
+
+	records, err := client.ListResourceRecordSets(context.TODO(), &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    zone.Id,
+		StartRecordName: aws.String(name),
+		StartRecordType: route53types.RRTypeA,
+		MaxItems:        aws.Int32(1),
+	})
+	require.NoError(t, err)
+	for i, record := range records.ResourceRecordSets {
+		if checks.SameDNSName(aws.ToString(record.Name), name) && record.Type == route53types.RRTypeA {
+			return &records.ResourceRecordSets[i]
+		}
+	}
+	return nil
+}
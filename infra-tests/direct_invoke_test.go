@@ -0,0 +1,142 @@
+package test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/payloads"
+	"github.com/lambda-java-template/tests/internal/responses"
+)
+
+// validateDirectInvocation invokes each function with the event its trigger sends and checks the raw result.
+// Workflow functions are not behind the API, so this is the only smoke test they get outside a workflow run.
+func validateDirectInvocation(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+
+	lambdaClient := lambda.NewFromConfig(cfg)
+
+	t.Run("Product_Service", func(t *testing.T) {
+		functionName := resolver.FunctionName("product-service")
+
+		t.Run("Health", func(t *testing.T) {
+			response := invokeProxy(t, lambdaClient, functionName, payloads.NewHTTPRequest("GET /health", "/health", nil, ""))
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+			assert.NotEmpty(t, response.Headers["x-correlation-id"], "Responses carry a correlation id")
+
+			var health responses.HealthResponse
+			decodeResponse(t, []byte(response.Body), &health)
+			assert.Equal(t, "healthy", health.Status)
+			assert.Equal(t, "product-service", health.Service)
+		})
+
+		t.Run("List_Products", func(t *testing.T) {
+			response := invokeProxy(t, lambdaClient, functionName, payloads.NewHTTPRequest("GET /products", "/products", nil, ""))
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+			decodeResponse(t, []byte(response.Body), &responses.ProductList{})
+		})
+
+		t.Run("Missing_Product", func(t *testing.T) {
+			path := fmt.Sprintf("/products/%smissing-%d", testNamespace(), time.Now().UnixNano())
+			response := invokeProxy(t, lambdaClient, functionName, payloads.NewHTTPRequest("GET /products/{id}", path, nil, ""))
+			assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+			var body responses.ErrorResponse
+			decodeResponse(t, []byte(response.Body), &body)
+			assert.Equal(t, http.StatusNotFound, body.StatusCode, "Error body status code")
+		})
+	})
+
+	t.Run("Authorizer_Service", func(t *testing.T) {
+		functionName := resolver.FunctionName("authorizer-service")
+
+		tests := []struct {
+			name       string
+			headers    map[string]string
+			authorized bool
+		}{
+			{"With_API_Key", map[string]string{"x-api-key": "infra-tests-key"}, true},
+			{"Without_API_Key", nil, false},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				output := invokeFunction(t, lambdaClient, functionName, payloads.NewAuthorizerRequest("GET /products", "/products", tt.headers))
+
+				response, err := payloads.ParseAuthorizerResponse(output.Payload)
+				require.NoError(t, err)
+				assert.Equal(t, tt.authorized, *response.IsAuthorized)
+				assert.NotNil(t, response.Context, "Authorizer responses carry a context for the integration")
+			})
+		}
+	})
+
+	t.Run("Workflow_Functions", func(t *testing.T) {
+		for _, suffix := range workflowFunctions {
+			functionName := resolver.FunctionName(suffix)
+			t.Run(suffix, func(t *testing.T) {
+				requireFunction(t, lambdaClient, functionName)
+
+				orderId := fmt.Sprintf("%sinvoke-%d", testNamespace(), time.Now().UnixNano())
+				output := invokeFunction(t, lambdaClient, functionName, payloads.SampleOrder(testNamespace(), orderId))
+
+				// Task results become the next state's input, so they must be JSON objects
+				var result map[string]any
+				assert.NoError(t, json.Unmarshal(output.Payload, &result), "Result of %s is not a JSON object: %s", functionName, output.Payload)
+			})
+		}
+	})
+}
+
+// requireFunction skips the test when a function is not deployed in the environment
+func requireFunction(t *testing.T, client *lambda.Client, functionName string) {
+	_, err := client.GetFunctionConfiguration(context.TODO(), &lambda.GetFunctionConfigurationInput{
+		FunctionName: aws.String(functionName),
+	})
+	var notFound *lambdatypes.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		t.Skipf("Function %s is not deployed", functionName)
+	}
+	require.NoError(t, err)
+}
+
+// invokeFunction invokes a function synchronously and fails the test on an invocation or function error.
+// The tail of the function's log is included in the failure message.
+func invokeFunction(t *testing.T, client *lambda.Client, functionName string, event any) *lambda.InvokeOutput {
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	output, err := client.Invoke(context.TODO(), &lambda.InvokeInput{
+		FunctionName:   aws.String(functionName),
+		InvocationType: lambdatypes.InvocationTypeRequestResponse,
+		LogType:        lambdatypes.LogTypeTail,
+		Payload:        payload,
+	})
+	require.NoError(t, err, "Failed to invoke %s", functionName)
+	require.Equal(t, int32(http.StatusOK), output.StatusCode, "Invocation of %s", functionName)
+
+	if output.FunctionError != nil {
+		logTail, _ := base64.StdEncoding.DecodeString(aws.ToString(output.LogResult))
+		require.FailNow(t, "Function error", "%s returned %s: %s\n%s", functionName, *output.FunctionError, output.Payload, logTail)
+	}
+	return output
+}
+
+// invokeProxy invokes a function with an HTTP API event and decodes its proxy integration result
+func invokeProxy(t *testing.T, client *lambda.Client, functionName string, request payloads.HTTPRequest) payloads.ProxyResponse {
+	output := invokeFunction(t, client, functionName, request)
+
+	response, err := payloads.ParseProxyResponse(output.Payload)
+	require.NoError(t, err, "Result of %s %s", functionName, request.RouteKey)
+	return response
+}
@@ -0,0 +1,119 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/applicationautoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/expectations"
+	"github.com/lambda-java-template/tests/internal/testconfig"
+	"github.com/lambda-java-template/tests/internal/tfspec"
+)
+
+// capacityMetricWindow is how far back table consumption and throttling are read, covering the suite's own traffic
+const capacityMetricWindow = 15 * time.Minute
+
+// validateDynamoDBCapacity checks each table's capacity mode against the environment's profile, and that the
+// last minutes of traffic, the suite's included, were neither throttled nor close to the provisioned capacity
+func validateDynamoDBCapacity(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+	profile := expectations.For(environment)
+
+	dynamoClient := dynamodb.NewFromConfig(cfg)
+	autoscalingClient := applicationautoscaling.NewFromConfig(cfg)
+	cwClient := cloudwatch.NewFromConfig(cfg)
+
+	for _, table := range tfspec.Tables {
+		tableName := resolver.TableName(table.NameSuffix)
+		t.Run(table.NameSuffix, func(t *testing.T) {
+			t.Run("Capacity_Mode", func(t *testing.T) {
+				described, err := dynamoClient.DescribeTable(context.TODO(), &dynamodb.DescribeTableInput{TableName: aws.String(tableName)})
+				require.NoError(t, err)
+
+				targets, err := autoscalingClient.DescribeScalableTargets(context.TODO(), &applicationautoscaling.DescribeScalableTargetsInput{
+					ServiceNamespace: autoscalingtypes.ServiceNamespaceDynamodb,
+					ResourceIds:      []string{"table/" + tableName},
+				})
+				require.NoError(t, err)
+
+				gaps := checks.CapacityModeGaps(*described.Table, profile.ReadCapacity, profile.WriteCapacity, profile.AutoScaling, len(targets.ScalableTargets) > 0)
+				for _, gap := range gaps {
+					assert.Fail(t, "Capacity differs from the environment profile", "Table %s in %s: %s", tableName, profile.Name, gap)
+				}
+			})
+
+			t.Run("Recent_Usage", func(t *testing.T) {
+				requireFeature(t, testconfig.FeatureServiceMetrics)
+
+				usage, err := tableUsage(context.TODO(), cwClient, tableName, time.Now().Add(-capacityMetricWindow), time.Now())
+				require.NoError(t, err)
+				t.Logf("%s over the last %s: peak %.2f RCU/s, %.2f WCU/s, %.0f read and %.0f write throttles",
+					tableName, capacityMetricWindow, usage.PeakRead, usage.PeakWrite, usage.ReadThrottles, usage.WriteThrottles)
+
+				for _, gap := range checks.UsageGaps(usage, profile.ReadCapacity, profile.WriteCapacity) {
+					assert.Fail(t, "Table is throttling or close to it", "Table %s: %s", tableName, gap)
+				}
+			})
+		})
+	}
+}
+
+// tableUsage reads a table's consumed capacity and throttle events between start and end in one-minute periods
+func tableUsage(ctx context.Context, client *cloudwatch.Client, tableName string, start, end time.Time) (checks.TableUsage, error) {
+	query := func(id, metric string) cwtypes.MetricDataQuery {
+		return cwtypes.MetricDataQuery{
+			Id: aws.String(id),
+			MetricStat: &cwtypes.MetricStat{
+				Metric: &cwtypes.Metric{
+					Namespace:  aws.String("AWS/DynamoDB"),
+					MetricName: aws.String(metric),
+					Dimensions: []cwtypes.Dimension{{Name: aws.String("TableName"), Value: aws.String(tableName)}},
+				},
+				Period: aws.Int32(60),
+				Stat:   aws.String("Sum"),
+			},
+		}
+	}
+
+	output, err := client.GetMetricData(ctx, &cloudwatch.GetMetricDataInput{
+		StartTime: aws.Time(start),
+		EndTime:   aws.Time(end),
+		MetricDataQueries: []cwtypes.MetricDataQuery{
+			query("read", "ConsumedReadCapacityUnits"),
+			query("write", "ConsumedWriteCapacityUnits"),
+			query("readThrottles", "ReadThrottleEvents"),
+			query("writeThrottles", "WriteThrottleEvents"),
+		},
+	})
+	if err != nil {
+		return checks.TableUsage{}, fmt.Errorf("reading capacity metrics of %s: %w", tableName, err)
+	}
+
+	var usage checks.TableUsage
+	for _, result := range output.MetricDataResults {
+		for _, value := range result.Values {
+			switch aws.ToString(result.Id) {
+			case "read":
+				usage.PeakRead = max(usage.PeakRead, value/60)
+			case "write":
+				usage.PeakWrite = max(usage.PeakWrite, value/60)
+			case "readThrottles":
+				usage.ReadThrottles += value
+			case "writeThrottles":
+				usage.WriteThrottles += value
+			}
+		}
+	}
+	return usage, nil
+}
@@ -0,0 +1,37 @@
+package test
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+)
+
+// environmentChildEnv marks a process started to run the suite against one of several environments
+const environmentChildEnv = "INFRA_TESTS_ENVIRONMENT_CHILD"
+
+// inEnvironmentChild reports whether this process is running the suite against one environment on behalf of a parent
+func inEnvironmentChild() bool {
+	return os.Getenv(environmentChildEnv) == "1"
+}
+
+// orchestratingEnvironments reports whether this process hands each of several environments to a child process
+func orchestratingEnvironments() bool {
+	return len(suiteConfig.EnvironmentNames()) > 1 && !inEnvironmentChild()
+}
+
+// runEnvironment runs TestLambdaIntegration against one environment in a child test process with the same flags.
+// Suite-wide state such as the config, the discovery cache, and the results summary then belongs to a single
+// environment, and each child writes its summary to the environment's own results file.
+func runEnvironment(t *testing.T, environment string) {
+	args := append(append([]string{}, os.Args[1:]...), "-test.run", "^TestLambdaIntegration$")
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), environmentChildEnv+"=1", "TEST_ENVIRONMENT="+environment)
+	if resultsFile := suiteConfig.EnvironmentResultsFile(environment); resultsFile != "" {
+		cmd.Env = append(cmd.Env, "TEST_RESULTS_FILE="+resultsFile)
+	}
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		t.Errorf("Suite failed against %s: %v", environment, err)
+	}
+}
@@ -0,0 +1,164 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	eventbridgetypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+)
+
+// auditDeliveryTimeout bounds how long a published event may take to reach the audit-log table
+const auditDeliveryTimeout = 2 * time.Minute
+
+// validateEventBridge validates the event bus the product service publishes to and its audit-log routing
+func validateEventBridge(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+
+	lambdaClient := lambda.NewFromConfig(cfg)
+	eventsClient := eventbridge.NewFromConfig(cfg)
+
+	functionName := resolver.FunctionName("product-service")
+	function, err := lambdaClient.GetFunctionConfiguration(context.TODO(), &lambda.GetFunctionConfigurationInput{
+		FunctionName: aws.String(functionName),
+	})
+	require.NoError(t, err)
+
+	var busName string
+	if function.Environment != nil {
+		busName = function.Environment.Variables["EVENT_BUS_NAME"]
+	}
+	if busName == "" {
+		t.Skipf("Function %s does not set EVENT_BUS_NAME", functionName)
+	}
+	recordResource(t, "eventbridge", busName)
+
+	t.Run("Event_Bus_Exists", func(t *testing.T) {
+		bus, err := eventsClient.DescribeEventBus(context.TODO(), &eventbridge.DescribeEventBusInput{
+			Name: aws.String(busName),
+		})
+		require.NoError(t, err, "Event bus %s not found", busName)
+		assert.Equal(t, busName, aws.ToString(bus.Name))
+	})
+
+	var rules []eventbridgetypes.Rule
+	var nextToken *string
+	for {
+		page, err := eventsClient.ListRules(context.TODO(), &eventbridge.ListRulesInput{
+			EventBusName: aws.String(busName),
+			NextToken:    nextToken,
+		})
+		require.NoError(t, err)
+		rules = append(rules, page.Rules...)
+
+		if page.NextToken == nil {
+			break
+		}
+		nextToken = page.NextToken
+	}
+
+	t.Run("Rules_Configuration", func(t *testing.T) {
+		require.NotEmpty(t, rules, "Event bus %s has no rules, so published events go nowhere", busName)
+
+		for _, rule := range rules {
+			assert.Equal(t, eventbridgetypes.RuleStateEnabled, rule.State, "Rule %s is not enabled", aws.ToString(rule.Name))
+			assert.NotEmpty(t, aws.ToString(rule.EventPattern), "Rule %s on a custom bus should match an event pattern", aws.ToString(rule.Name))
+		}
+	})
+
+	t.Run("Rule_Targets", func(t *testing.T) {
+		for _, rule := range rules {
+			ruleName := aws.ToString(rule.Name)
+			targets, err := eventsClient.ListTargetsByRule(context.TODO(), &eventbridge.ListTargetsByRuleInput{
+				Rule:         rule.Name,
+				EventBusName: aws.String(busName),
+			})
+			require.NoError(t, err, "Failed to list targets of rule %s", ruleName)
+
+			assert.NotEmpty(t, targets.Targets, "Rule %s has no targets", ruleName)
+			assert.Empty(t, checks.TargetsWithoutDeadLetterQueue(targets.Targets),
+				"Targets of rule %s have no dead-letter queue, so failed deliveries are dropped", ruleName)
+
+			for _, target := range targets.Targets {
+				if target.DeadLetterConfig != nil && target.DeadLetterConfig.Arn != nil {
+					assert.Contains(t, *target.DeadLetterConfig.Arn, ":sqs:", "Dead-letter queue of target %s is not an SQS queue", aws.ToString(target.Id))
+				}
+			}
+		}
+	})
+
+	t.Run("Event_Reaches_Audit_Log", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("Skipping EventBridge delivery check in short mode")
+		}
+
+		// Publish an event shaped like the first rule that names a literal source and detail type
+		var source, detailType string
+		matched := false
+		for _, rule := range rules {
+			pattern, err := checks.ParseEventPattern(aws.ToString(rule.EventPattern))
+			if err != nil {
+				continue
+			}
+			if source, detailType, matched = pattern.MatchingEvent(); matched {
+				break
+			}
+		}
+		if !matched {
+			t.Skipf("No rule on %s matches a literal source and detail type", busName)
+		}
+
+		// Namespaced ids are removed by the suite's cleanup along with other test data
+		eventId := fmt.Sprintf("%seventbridge-%d", testNamespace(), time.Now().UnixNano())
+		detail, err := json.Marshal(map[string]string{
+			"event_id": eventId,
+			"source":   "infra-tests",
+		})
+		require.NoError(t, err)
+
+		published, err := eventsClient.PutEvents(context.TODO(), &eventbridge.PutEventsInput{
+			Entries: []eventbridgetypes.PutEventsRequestEntry{{
+				EventBusName: aws.String(busName),
+				Source:       aws.String(source),
+				DetailType:   aws.String(detailType),
+				Detail:       aws.String(string(detail)),
+			}},
+		})
+		require.NoError(t, err)
+		require.Zero(t, published.FailedEntryCount, "Event was rejected: %s", aws.ToString(published.Entries[0].ErrorMessage))
+
+		// The audit writer may key the record by the id in the detail or by the EventBridge event id
+		candidates := []string{eventId, aws.ToString(published.Entries[0].EventId)}
+		dynamoClient := dynamodb.NewFromConfig(cfg)
+		auditTable := resolver.TableName("audit-logs")
+
+		assertEventually(t, auditDeliveryTimeout, func() bool {
+			for _, candidate := range candidates {
+				items, err := dynamoClient.Query(context.TODO(), &dynamodb.QueryInput{
+					TableName:              aws.String(auditTable),
+					KeyConditionExpression: aws.String("event_id = :id"),
+					ExpressionAttributeValues: map[string]dynamodbtypes.AttributeValue{
+						":id": &dynamodbtypes.AttributeValueMemberS{Value: candidate},
+					},
+				})
+				require.NoError(t, err)
+				if items.Count > 0 {
+					return true
+				}
+			}
+			return false
+		}, "No audit record for event %s (%s/%s) in %s", strings.Join(candidates, " or "), source, detailType, auditTable)
+	})
+}
@@ -0,0 +1,40 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/wait"
+)
+
+// waitFor polls condition with the suite backoff until it holds, failing the test when it does not within timeout.
+// An error from condition fails the test at once; eventually consistent reads should report false instead.
+func waitFor(t *testing.T, timeout time.Duration, description string, condition wait.Condition) {
+	t.Helper()
+
+	err := wait.Until(context.TODO(), timeout, suiteConfig.Backoff(), condition)
+	if errors.Is(err, wait.ErrTimeout) {
+		require.FailNow(t, "Timed out", "%s did not happen within %s", description, timeout)
+	}
+	require.NoError(t, err, "Waiting for %s", description)
+}
+
+// assertEventually polls condition with the suite backoff and records a failure, without stopping the test,
+// when it does not hold within timeout
+func assertEventually(t *testing.T, timeout time.Duration, condition func() bool, msgAndArgs ...any) bool {
+	t.Helper()
+
+	err := wait.Until(context.TODO(), timeout, suiteConfig.Backoff(), func(context.Context) (bool, error) {
+		return condition(), nil
+	})
+	if err != nil {
+		return assert.Fail(t, fmt.Sprintf("Condition not met within %s", timeout), msgAndArgs...)
+	}
+	return true
+}
@@ -0,0 +1,139 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	sfntypes "github.com/aws/aws-sdk-go-v2/service/sfn/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/asl"
+	"github.com/lambda-java-template/tests/internal/chaos"
+	"github.com/lambda-java-template/tests/internal/expectations"
+	"github.com/lambda-java-template/tests/internal/history"
+	"github.com/lambda-java-template/tests/internal/payloads"
+)
+
+// failureModeScenarios map each failure mode to the terminal state a valid order must end in under it, and
+// whether the failed state must have been cut off by its TimeoutSeconds
+var failureModeScenarios = []struct {
+	mode     string
+	terminal string
+	timesOut bool
+}{
+	{chaos.FailureModeInventoryUnavailable, "InventoryUnavailable", false},
+	{chaos.FailureModePaymentDeclined, "PaymentDeclined", false},
+	{chaos.FailureModePaymentError, "ProcessingFailed", false},
+	{chaos.FailureModePaymentSlow, "ProcessingFailed", true},
+}
+
+// taskTimeoutSlack is how long after its TimeoutSeconds a task may still be reported as timed out
+const taskTimeoutSlack = 5 * time.Second
+
+// validateFailureModes sets FAILURE_MODE on every function the workflow invokes and checks a valid order
+// ends in the branch the mode forces, so those branches are exercised without crafted data.
+// Production functions must not declare the variable at all. Everywhere else the modes change shared
+// functions, so they only run with -failure-modes and only against functions that declare the variable.
+func validateFailureModes(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+	lambdaClient := lambda.NewFromConfig(cfg)
+	sfnClient := sfn.NewFromConfig(cfg)
+
+	definition := deployedDefinition(t, cfg, resolver, "order-processing")
+	stateMachineArn, err := resolver.StateMachineARN(context.TODO(), "order-processing")
+	require.NoError(t, err)
+	parsed, err := asl.Parse([]byte(definition))
+	require.NoError(t, err)
+
+	originals := map[string]map[string]string{}
+	var supporting []string
+	for _, function := range chaos.Functions(asl.LambdaReferences(parsed)) {
+		config, err := lambdaClient.GetFunctionConfiguration(context.TODO(), &lambda.GetFunctionConfigurationInput{
+			FunctionName: aws.String(function),
+		})
+		require.NoError(t, err)
+		var variables map[string]string
+		if config.Environment != nil {
+			variables = config.Environment.Variables
+		}
+		if _, ok := variables[chaos.FailureModeVariable]; ok {
+			originals[function] = variables
+			supporting = append(supporting, function)
+		}
+	}
+
+	if expectations.For(environment).Name == "prod" {
+		assert.Empty(t, supporting, "Production functions must not read %s", chaos.FailureModeVariable)
+		return
+	}
+	if testing.Short() || !*failureModes {
+		t.Skip("Skipping failure modes; set -failure-modes to run them")
+	}
+	if len(supporting) == 0 {
+		t.Skipf("No function invoked by %s declares %s", stateMachineArn, chaos.FailureModeVariable)
+	}
+
+	// Put back exactly what was found, even when a scenario fails halfway through. Every function is
+	// restored before any failure is reported, so one failed update cannot leave the others injected.
+	t.Cleanup(func() {
+		var errs []error
+		for _, function := range supporting {
+			errs = append(errs, setEnvironment(lambdaClient, function, originals[function]))
+		}
+		assert.NoError(t, errors.Join(errs...), "Failed to restore the environment of every failure mode function")
+	})
+
+	// Scenarios share the workflow's functions, so they run one at a time
+	for _, scenario := range failureModeScenarios {
+		t.Run(scenario.mode, func(t *testing.T) {
+			for _, function := range supporting {
+				updateEnvironment(t, lambdaClient, function, chaos.WithFailureMode(originals[function], scenario.mode))
+			}
+
+			orderId := fmt.Sprintf("%sfailure-mode-%d", testNamespace(), time.Now().UnixNano())
+			execution := executeWorkflow(t, sfnClient, stateMachineArn, payloads.SampleOrder(testNamespace(), orderId))
+			waitForExecution(t, sfnClient, execution, workflowExecutionTimeout)
+
+			events := executionHistory(t, sfnClient, execution)
+			recordExecutionDiagram(t, "Failure_Mode_Injection/"+scenario.mode, execution, events)
+			assert.Equal(t, scenario.terminal, history.TerminalState(events), "Execution %s with %s=%s visited %v",
+				execution.arn, chaos.FailureModeVariable, scenario.mode, history.EnteredStates(events))
+			if scenario.timesOut {
+				assertTaskTimedOut(t, lambdaClient, parsed, events)
+			}
+		})
+	}
+}
+
+// assertTaskTimedOut checks the state an execution failed in was cut off by its own TimeoutSeconds, failing with
+// States.Timeout and taking the Catch path, instead of waiting for its function's Lambda timeout
+func assertTaskTimedOut(t *testing.T, lambdaClient *lambda.Client, definition asl.Definition, events []sfntypes.HistoryEvent) {
+	step, ok := history.FailedStep(history.Steps(events))
+	require.True(t, ok, "No state failed, visited %v", history.EnteredStates(events))
+	assert.Equal(t, "States.Timeout", step.Error, "%s failed instead of timing out: %s", step.State, step.Cause)
+
+	state, ok := asl.Lookup(definition, step.State)
+	if !ok || state.TimeoutSeconds <= 0 {
+		t.Logf("%s has no TimeoutSeconds at the top level of the definition; not checking how long it ran", step.State)
+		return
+	}
+	timeout := time.Duration(state.TimeoutSeconds) * time.Second
+	assert.LessOrEqual(t, step.LongestAttempt, timeout+taskTimeoutSlack,
+		"An attempt of %s ran for %s, past its TimeoutSeconds of %s", step.State, step.LongestAttempt, timeout)
+
+	function := chaos.FunctionForState(asl.LambdaReferences(definition), step.State)
+	if function == "" {
+		return
+	}
+	config, err := lambdaClient.GetFunctionConfiguration(context.TODO(), &lambda.GetFunctionConfigurationInput{FunctionName: aws.String(function)})
+	require.NoError(t, err)
+	functionTimeout := time.Duration(aws.ToInt32(config.Timeout)) * time.Second
+	assert.Less(t, timeout, functionTimeout, "%s only gives up on %s when the invocation itself times out", step.State, function)
+}
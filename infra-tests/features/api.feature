@@ -0,0 +1,21 @@
+Feature: Product API
+  As a consumer of the template's product service
+  I want the deployed API to answer health checks and protect product data
+  So that new services built from the template start secure and observable
+
+  Background:
+    Given the configured environment is deployed
+
+  Scenario: Health check is public
+    When I send a GET request to "/health"
+    Then the response status should be 200
+    And the response JSON field "status" should be "healthy"
+
+  Scenario: Products require an API key
+    When I send a GET request to "/products"
+    Then the response status should be 401
+
+  Scenario: Products are listed with an API key
+    When I send a GET request to "/products" with an API key
+    Then the response status should be 200
+
@@ -0,0 +1,27 @@
+Feature: Deployed infrastructure
+  As an operator of an environment built from the template
+  I want its resources to match the template's standards
+  So that every environment is configured the same way
+
+  Background:
+    Given the configured environment is deployed
+
+  Scenario Outline: Resources pass the <validator> validator
+    Then the "<validator>" validator passes
+
+    Examples:
+      | validator                   |
+      | Lambda_Functions_Validation |
+      | DynamoDB_Tables_Validation  |
+      | API_Gateway_Integration     |
+      | Security_Configuration      |
+      | CloudWatch_Monitoring       |
+
+  @workflow
+  Scenario: A workflow run notifies operators
+    When I start the "order-processing" workflow with input:
+      """
+      {"source": "bdd"}
+      """
+    Then the workflow execution succeeds within 5 minutes
+    And a notification is published to the "alerts" topic within 5 minutes
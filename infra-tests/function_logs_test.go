@@ -0,0 +1,91 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/discovery"
+	"github.com/lambda-java-template/tests/internal/testconfig"
+)
+
+const (
+	// functionLogDeliveryTimeout bounds how long smoke-test invocations may take to show up in Logs Insights
+	functionLogDeliveryTimeout = 3 * time.Minute
+
+	// initDurationBudget is the longest init phase a cold start may take before it is reported
+	initDurationBudget = 10 * time.Second
+)
+
+// validateFunctionLogs sends smoke-test requests with a known correlation id and checks, with Logs Insights,
+// that each function logged them as structured JSON without errors
+func validateFunctionLogs(t *testing.T, cfg aws.Config, resolver *discovery.Resolver) {
+	requireFeature(t, testconfig.FeatureLogsInsights)
+	apiEndpoint := requireAPI(t, resolver).Endpoint
+
+	logsClient := cloudwatchlogs.NewFromConfig(cfg)
+	correlationId := fmt.Sprintf("%slogs-%d", testNamespace(), time.Now().UnixNano())
+	start := time.Now().Add(-time.Minute)
+
+	for _, path := range []string{"/health", "/products"} {
+		req, err := http.NewRequest(http.MethodGet, apiEndpoint+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("x-api-key", fmt.Sprintf("infra-tests-%s", suiteConfig.Environment))
+		req.Header.Set("x-correlation-id", correlationId)
+
+		resp, err := suiteHTTP.Do(req)
+		require.NoError(t, err, "GET %s", path)
+		resp.Body.Close()
+	}
+
+	for _, suffix := range []string{"product-service", "authorizer-service"} {
+		functionName := resolver.FunctionName(suffix)
+		logGroup := fmt.Sprintf("/aws/lambda/%s", functionName)
+
+		t.Run(suffix, func(t *testing.T) {
+			// count runs a stats query over the smoke-test window and returns one of its fields
+			count := func(query, field string) int {
+				rows := runLogsInsightsQuery(t, logsClient, logGroup, query, start, time.Now().Add(time.Minute))
+				value, err := checks.InsightsCount(rows, field)
+				require.NoError(t, err)
+				return value
+			}
+
+			// Later queries only mean something once the smoke-test invocations have been ingested
+			delivered := assertEventually(t, functionLogDeliveryTimeout, func() bool {
+				return count(checks.ColdStartQuery, "invocations") > 0
+			}, "No invocations of %s logged in %s", functionName, logGroup)
+			if !delivered {
+				return
+			}
+
+			if suffix == "product-service" {
+				assertEventually(t, functionLogDeliveryTimeout, func() bool {
+					return count(checks.MessageCountQuery(correlationId), "count") > 0
+				}, "Correlation id %s not logged by %s", correlationId, functionName)
+			}
+
+			assert.Zero(t, count(checks.UnstructuredLogQuery, "count"), "%s wrote log lines that are not JSON", functionName)
+			assert.Zero(t, count(checks.ErrorLogQuery, "count"), "%s logged errors during the smoke test", functionName)
+
+			rows := runLogsInsightsQuery(t, logsClient, logGroup, checks.ColdStartQuery, start, time.Now().Add(time.Minute))
+			coldStarts, err := checks.InsightsCount(rows, "coldStarts")
+			require.NoError(t, err)
+			maxInit, err := checks.InsightsMilliseconds(rows, "maxInitDuration")
+			require.NoError(t, err)
+
+			t.Logf("%s: %d cold starts, longest init %s", functionName, coldStarts, maxInit)
+			if coldStarts > 0 {
+				suiteResults.RecordLatency(functionName+" init", maxInit)
+			}
+			assert.Less(t, maxInit, initDurationBudget, "%s init phase", functionName)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/assertaws"
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/responses"
+	"github.com/lambda-java-template/tests/internal/seed"
+)
+
+// nameIndexConsistencyTimeout bounds how long seeded products may take to reach the name-index
+const nameIndexConsistencyTimeout = 30 * time.Second
+
+// nameIndexProducts are seeded under a per-run namespace and suffix. Two share a name, so the index key
+// collides, and the café pair differs only in Unicode normalization, so they must stay distinct keys.
+func nameIndexProducts(suffix string) []seed.Product {
+	return []seed.Product{
+		{ID: "collide-1", Name: "Collision " + suffix, Price: 10},
+		{ID: "collide-2", Name: "Collision " + suffix, Price: 20},
+		{ID: "composed", Name: "Caf\u00e9 \u2615 " + suffix, Price: 3.5},
+		{ID: "decomposed", Name: "Cafe\u0301 \u2615 " + suffix, Price: 4.5},
+		{ID: "cjk", Name: "東京 テスト " + suffix, Price: 7},
+		{ID: "emoji", Name: "\U0001F9EA \U0001F680 " + suffix, Price: 1.25},
+	}
+}
+
+// validateNameIndex seeds products with colliding and Unicode names, then queries the name-index directly
+// and reads the products through the API. Each query must return exactly the items a consistent scan finds
+// for the name, with every attribute projected, so a drifted index definition fails here.
+func validateNameIndex(t *testing.T, cfg aws.Config, projectName, environment string) {
+	if testing.Short() {
+		t.Skip("Skipping name-index queries in short mode")
+	}
+	resolver := discover(cfg, projectName, environment)
+	apiEndpoint := requireAPI(t, resolver).Endpoint
+	dynamoClient := dynamodb.NewFromConfig(cfg)
+	productsTable := resolver.TableName("products")
+
+	table := assertaws.Table(t, assertaws.NewClients(cfg), productsTable).HasGSI("name-index", "ALL")
+	projectsAll := false
+	for _, gsi := range table.Description().GlobalSecondaryIndexes {
+		if aws.ToString(gsi.IndexName) == "name-index" && gsi.Projection != nil {
+			projectsAll = gsi.Projection.ProjectionType == dynamodbtypes.ProjectionTypeAll
+		}
+	}
+
+	namespace := fmt.Sprintf("%sgsi-%d-", testNamespace(), time.Now().UnixNano())
+	products := nameIndexProducts(namespace)
+	_, err := seed.Upsert(context.TODO(), dynamoClient, productsTable, namespace, products)
+	t.Cleanup(func() {
+		for _, product := range products {
+			_, err := dynamoClient.DeleteItem(context.TODO(), &dynamodb.DeleteItemInput{
+				TableName: aws.String(productsTable),
+				Key:       map[string]dynamodbtypes.AttributeValue{"id": &dynamodbtypes.AttributeValueMemberS{Value: namespace + product.ID}},
+			})
+			assert.NoError(t, err, "Failed to remove product %s", namespace+product.ID)
+		}
+	})
+	require.NoError(t, err)
+
+	seededByName := map[string][]string{}
+	var names []string
+	for _, product := range products {
+		if seededByName[product.Name] == nil {
+			names = append(names, product.Name)
+		}
+		seededByName[product.Name] = append(seededByName[product.Name], namespace+product.ID)
+	}
+
+	t.Run("Direct_Query", func(t *testing.T) {
+		for _, name := range names {
+			// Global secondary indexes are eventually consistent with the table
+			var indexed []map[string]dynamodbtypes.AttributeValue
+			waitFor(t, nameIndexConsistencyTimeout, fmt.Sprintf("name-index entries for %q", name), func(ctx context.Context) (bool, error) {
+				items, err := queryNameIndex(ctx, dynamoClient, productsTable, name)
+				indexed = items
+				return err == nil && len(items) >= len(seededByName[name]), err
+			})
+
+			oracle := scanByName(t, dynamoClient, productsTable, name)
+			for _, gap := range checks.IndexQueryGaps(indexed, oracle, "id", projectsAll) {
+				assert.Fail(t, "name-index disagrees with the table", "%s, name %+q: %s", productsTable, name, gap)
+			}
+		}
+	})
+
+	t.Run("Through_API", func(t *testing.T) {
+		status, body := callAPI(t, http.MethodGet, apiEndpoint+"/products", nil)
+		require.Equal(t, http.StatusOK, status, "GET /products: %s", body)
+		var list responses.ProductList
+		decodeResponse(t, body, &list)
+		listed := map[string]responses.Product{}
+		for _, product := range list.Products {
+			listed[product.ID] = product
+		}
+
+		for _, product := range products {
+			id := namespace + product.ID
+			if assert.Contains(t, listed, id, "GET /products is missing seeded product %s", id) {
+				assert.Equal(t, product.Name, listed[id].Name, "Name of %s in GET /products", id)
+			}
+
+			productURL := fmt.Sprintf("%s/products/%s", apiEndpoint, id)
+			status, body := callAPI(t, http.MethodGet, productURL, nil)
+			require.Equal(t, http.StatusOK, status, "GET %s: %s", productURL, body)
+			var stored responses.Product
+			decodeResponse(t, body, &stored)
+			assert.Equal(t, product.Name, stored.Name, "GET %s must return the name byte for byte", productURL)
+			assert.Equal(t, product.Price, stored.Price, "GET %s", productURL)
+		}
+	})
+}
+
+// queryNameIndex returns every name-index item with the given name
+func queryNameIndex(ctx context.Context, client *dynamodb.Client, tableName, name string) ([]map[string]dynamodbtypes.AttributeValue, error) {
+	var items []map[string]dynamodbtypes.AttributeValue
+	paginator := dynamodb.NewQueryPaginator(client, &dynamodb.QueryInput{
+		TableName:                aws.String(tableName),
+		IndexName:                aws.String("name-index"),
+		KeyConditionExpression:   aws.String("#name = :name"),
+		ExpressionAttributeNames: map[string]string{"#name": "name"},
+		ExpressionAttributeValues: map[string]dynamodbtypes.AttributeValue{
+			":name": &dynamodbtypes.AttributeValueMemberS{Value: name},
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+	}
+	return items, nil
+}
+
+// scanByName is the oracle for name-index queries: a strongly consistent scan of the base table
+func scanByName(t *testing.T, client *dynamodb.Client, tableName, name string) []map[string]dynamodbtypes.AttributeValue {
+	var items []map[string]dynamodbtypes.AttributeValue
+	paginator := dynamodb.NewScanPaginator(client, &dynamodb.ScanInput{
+		TableName:                aws.String(tableName),
+		ConsistentRead:           aws.Bool(true),
+		FilterExpression:         aws.String("#name = :name"),
+		ExpressionAttributeNames: map[string]string{"#name": "name"},
+		ExpressionAttributeValues: map[string]dynamodbtypes.AttributeValue{
+			":name": &dynamodbtypes.AttributeValueMemberS{Value: name},
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		require.NoError(t, err)
+		items = append(items, page.Items...)
+	}
+	return items
+}
@@ -0,0 +1,45 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/healthcheck"
+	"github.com/lambda-java-template/tests/internal/openapi"
+)
+
+// validateHealthCheck calls the product service's deep health check, validates the response against the
+// HealthResponse schema of the OpenAPI spec, and fails when a dependency is degraded or not probed. DynamoDB
+// is always probed; EventBridge only when the function publishes to a bus through EVENT_BUS_NAME.
+func validateHealthCheck(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+
+	spec, err := openapi.Load(openAPISpecPath)
+	require.NoError(t, err)
+
+	functionName := resolver.FunctionName("product-service")
+	function, err := lambda.NewFromConfig(cfg).GetFunctionConfiguration(context.TODO(), &lambda.GetFunctionConfigurationInput{
+		FunctionName: aws.String(functionName),
+	})
+	require.NoError(t, err, "Failed to get configuration of %s", functionName)
+	required := []string{healthcheck.DynamoDB}
+	if function.Environment != nil && function.Environment.Variables["EVENT_BUS_NAME"] != "" {
+		required = append(required, healthcheck.EventBridge)
+	}
+
+	api := requireAPI(t, resolver)
+	status, body := callAPI(t, http.MethodGet, api.Endpoint+"/health", nil)
+	report, err := healthcheck.Parse(spec, status, body)
+	require.NoError(t, err)
+	t.Logf("%s runs %s, up %ds, dependencies %v", functionName, report.Version, report.UptimeSeconds, report.Dependencies)
+
+	for _, gap := range report.Gaps(required) {
+		assert.Fail(t, "Product service is not healthy", "GET /health: %s", gap)
+	}
+}
@@ -0,0 +1,128 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/sigv4http"
+)
+
+// iamProbePathValue replaces path parameters when probing IAM-protected routes
+const iamProbePathValue = "itest-iam-probe"
+
+// routePathParameter matches {id} and greedy {proxy+} path parameters
+var routePathParameter = regexp.MustCompile(`\{[^}]+\}`)
+
+// iamCaller is one way of calling an IAM-protected route and whether API Gateway should let it through
+type iamCaller struct {
+	name    string
+	client  *http.Client
+	allowed bool
+}
+
+// validateIAMRouteAuthorization validates that AWS_IAM routes accept permitted SigV4 callers and reject everyone else.
+// TEST_IAM_ALLOWED_ROLE_ARN and TEST_IAM_DENIED_ROLE_ARN name roles to assume for the positive and negative cases;
+// without an allowed role the suite's own credentials are expected to be permitted.
+func validateIAMRouteAuthorization(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+
+	apiClient := apigatewayv2.NewFromConfig(cfg)
+
+	deployedAPI := requireAPI(t, resolver)
+	apiId := deployedAPI.ID
+	apiEndpoint := deployedAPI.Endpoint
+
+	var iamRoutes []string
+	var nextToken *string
+	for {
+		routes, err := apiClient.GetRoutes(context.TODO(), &apigatewayv2.GetRoutesInput{
+			ApiId:     aws.String(apiId),
+			NextToken: nextToken,
+		})
+		require.NoError(t, err)
+
+		for _, route := range routes.Items {
+			if route.AuthorizationType == types.AuthorizationTypeAwsIam && !strings.HasPrefix(*route.RouteKey, "$") {
+				iamRoutes = append(iamRoutes, *route.RouteKey)
+			}
+		}
+
+		if routes.NextToken == nil {
+			break
+		}
+		nextToken = routes.NextToken
+	}
+	if len(iamRoutes) == 0 {
+		t.Skipf("API %s has no AWS_IAM routes", resolver.APIName())
+	}
+
+	stsClient := sts.NewFromConfig(cfg)
+	permitted := cfg.Credentials
+	if roleArn := os.Getenv("TEST_IAM_ALLOWED_ROLE_ARN"); roleArn != "" {
+		permitted = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(stsClient, roleArn))
+	}
+	var denied aws.CredentialsProvider
+	if roleArn := os.Getenv("TEST_IAM_DENIED_ROLE_ARN"); roleArn != "" {
+		denied = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(stsClient, roleArn))
+	}
+
+	// A valid signature scoped to another region must never be accepted
+	wrongRegion := "us-west-2"
+	if cfg.Region == wrongRegion {
+		wrongRegion = "us-east-1"
+	}
+
+	callers := []iamCaller{
+		{"Unsigned", http.DefaultClient, false},
+		{"Wrong_Region_Scope", sigv4http.NewClient(permitted, wrongRegion), false},
+		{"Permitted_Principal", sigv4http.NewClient(permitted, cfg.Region), true},
+	}
+	if denied != nil {
+		callers = append(callers, iamCaller{"Denied_Principal", sigv4http.NewClient(denied, cfg.Region), false})
+	}
+
+	for _, routeKey := range iamRoutes {
+		method, path := routeRequest(routeKey, iamProbePathValue)
+
+		t.Run(routeKey, func(t *testing.T) {
+			for _, caller := range callers {
+				t.Run(caller.name, func(t *testing.T) {
+					req, err := http.NewRequest(method, apiEndpoint+path, nil)
+					require.NoError(t, err)
+
+					resp, err := caller.client.Do(req)
+					require.NoError(t, err)
+					resp.Body.Close()
+
+					// The probe path does not exist, so permitted callers may still get 4xx from the integration
+					if caller.allowed {
+						assert.NotEqual(t, http.StatusForbidden, resp.StatusCode, "%s %s rejected a permitted caller", method, path)
+					} else {
+						assert.Equal(t, http.StatusForbidden, resp.StatusCode, "%s %s accepted a %s caller", method, path, caller.name)
+					}
+				})
+			}
+		})
+	}
+}
+
+// routeRequest turns a route key into a concrete method and path, filling path parameters with probeValue
+func routeRequest(routeKey, probeValue string) (string, string) {
+	method, path, _ := strings.Cut(routeKey, " ")
+	if method == "ANY" {
+		method = http.MethodGet
+	}
+	return method, routePathParameter.ReplaceAllString(path, probeValue)
+}
@@ -0,0 +1,68 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	sfntypes "github.com/aws/aws-sdk-go-v2/service/sfn/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/discovery"
+	"github.com/lambda-java-template/tests/internal/payloads"
+)
+
+// sideEffectActions mark the audit actions that charge a payment or change stock.
+// Each must be recorded once per order however often the order is submitted.
+var sideEffectActions = []string{"payment", "inventory"}
+
+// validateOrderIdempotency submits the same order twice and fails when the audit log shows the
+// second run charging the payment or decrementing inventory again
+func validateOrderIdempotency(t *testing.T, cfg aws.Config, projectName, environment string) {
+	if testing.Short() {
+		t.Skip("Skipping order idempotency in short mode")
+	}
+	resolver := discover(cfg, projectName, environment)
+
+	stateMachineArn, err := resolver.StateMachineARN(context.TODO(), "order-processing")
+	if errors.Is(err, discovery.ErrNotFound) {
+		t.Skipf("%v", err)
+	}
+	require.NoError(t, err)
+	client := sfn.NewFromConfig(cfg)
+	dynamoClient := dynamodb.NewFromConfig(cfg)
+	auditTable := resolver.TableName("audit-logs")
+
+	started := time.Now().UTC()
+	orderId := fmt.Sprintf("%sidempotent-%d", testNamespace(), started.UnixNano())
+	order := payloads.SampleOrder(testNamespace(), orderId)
+
+	first := waitForExecution(t, client, executeWorkflow(t, client, stateMachineArn, order), workflowExecutionTimeout)
+	require.Equal(t, sfntypes.ExecutionStatusSucceeded, first.status, "First run %s failed: %s %s", first.arn, first.error, first.cause)
+
+	// A duplicate may succeed with the original result or fail as a duplicate; either is idempotent
+	// as long as no side effect repeats
+	second := waitForExecution(t, client, executeWorkflow(t, client, stateMachineArn, order), workflowExecutionTimeout)
+	t.Logf("Second run %s ended %s %s", second.arn, second.status, second.error)
+	assert.NotEqual(t, sfntypes.ExecutionStatusTimedOut, second.status, "Second run %s timed out", second.arn)
+
+	var counts map[string]int
+	recorded := assertEventually(t, auditWriteTimeout, func() bool {
+		counts = checks.CountActions(auditRecords(t, dynamoClient, auditTable, orderId, started.Add(-time.Minute)))
+		return checks.MatchingActions(counts, sideEffectActions...) > 0
+	}, "No payment or inventory audit record in %s references order %s", auditTable, orderId)
+	if !recorded {
+		return
+	}
+
+	t.Logf("Audit actions for order %s: %v", orderId, counts)
+	assert.Empty(t, checks.RepeatedActions(counts, sideEffectActions...),
+		"Submitting order %s twice repeated side effects; the services must skip work already recorded for an order ID", orderId)
+}
@@ -0,0 +1,141 @@
+// Package artifacts downloads the deployment package of a Lambda function and checks what is inside it:
+// the bootstrap of a custom runtime and the handler classes of a Java function.
+//
+// GetFunction returns a presigned URL to the package that is valid for ten minutes, so a package is
+// downloaded right after the function is described and inspected in memory.
+package artifacts
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Download fetches a deployment package from the presigned URL GetFunction returns
+func Download(ctx context.Context, client *http.Client, url string) (*zip.Reader, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("downloading deployment package: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading deployment package: HTTP %d", response.StatusCode)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading deployment package: %w", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("opening deployment package: %w", err)
+	}
+	return archive, nil
+}
+
+// BootstrapGaps reports a package for a custom runtime without an executable bootstrap file at its root
+func BootstrapGaps(archive *zip.Reader) []string {
+	for _, file := range archive.File {
+		if file.Name != "bootstrap" {
+			continue
+		}
+		mode := file.Mode()
+		switch {
+		case mode.IsDir():
+			return []string{"bootstrap is a directory"}
+		case mode&0o111 == 0:
+			// Archives built on Windows carry no Unix permissions, so they lose the executable bit too
+			return []string{fmt.Sprintf("bootstrap is not executable (mode %s)", mode)}
+		}
+		return nil
+	}
+	return []string{"package has no bootstrap file at its root"}
+}
+
+// nestedJarDirs are where Lambda and Spring Boot packages keep dependency JARs
+var nestedJarDirs = []string{"lib/", "BOOT-INF/lib/"}
+
+// classDirs are where a package keeps its own classes: the root for Lambda packages, BOOT-INF/classes for Spring Boot JARs
+var classDirs = []string{"", "BOOT-INF/classes/"}
+
+// ClassFile returns the archive path of a Java class, e.g. com/example/Handler.class for com.example.Handler.
+// A handler such as com.example.Handler::handleRequest names its class before the ::.
+func ClassFile(handler string) string {
+	class, _, _ := strings.Cut(handler, "::")
+	return strings.ReplaceAll(class, ".", "/") + ".class"
+}
+
+// ClassGaps reports each class that is neither in the package nor in one of its dependency JARs
+func ClassGaps(archive *zip.Reader, classes ...string) ([]string, error) {
+	found := map[string]bool{}
+	wanted := map[string]string{}
+	for _, class := range classes {
+		name, _, _ := strings.Cut(class, "::")
+		wanted[ClassFile(class)] = name
+	}
+
+	search := func(files []*zip.File, dirs []string) {
+		for _, file := range files {
+			for _, dir := range dirs {
+				if path, ok := strings.CutPrefix(file.Name, dir); ok && wanted[path] != "" {
+					found[path] = true
+				}
+			}
+		}
+	}
+	search(archive.File, classDirs)
+
+	for _, file := range archive.File {
+		if len(found) == len(wanted) {
+			break
+		}
+		if !strings.HasSuffix(file.Name, ".jar") || !hasAnyPrefix(file.Name, nestedJarDirs) {
+			continue
+		}
+		nested, err := openNested(file)
+		if err != nil {
+			return nil, fmt.Errorf("opening %s: %w", file.Name, err)
+		}
+		search(nested.File, []string{""})
+	}
+
+	var gaps []string
+	for _, class := range classes {
+		if path := ClassFile(class); !found[path] {
+			gaps = append(gaps, fmt.Sprintf("class %s (%s) is not in the package or its dependency JARs", wanted[path], path))
+		}
+	}
+	return gaps, nil
+}
+
+// openNested reads a JAR stored inside the package
+func openNested(file *zip.File) (*zip.Reader, error) {
+	content, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	return zip.NewReader(bytes.NewReader(data), int64(len(data)))
+}
+
+// hasAnyPrefix reports whether name starts with one of prefixes
+func hasAnyPrefix(name string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
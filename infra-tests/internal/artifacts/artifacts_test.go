@@ -0,0 +1,106 @@
+package artifacts
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// packageOf builds a zip archive holding files with the given modes
+func packageOf(t *testing.T, files map[string]fs.FileMode) []byte {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, mode := range files {
+		header := &zip.FileHeader{Name: name, Method: zip.Deflate}
+		header.SetMode(mode)
+		entry, err := writer.CreateHeader(header)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte("#!/bin/sh\n"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func reader(t *testing.T, data []byte) *zip.Reader {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	return archive
+}
+
+func TestBootstrapGaps(t *testing.T) {
+	assert.Empty(t, BootstrapGaps(reader(t, packageOf(t, map[string]fs.FileMode{"bootstrap": 0o755}))))
+	assert.Equal(t, []string{"bootstrap is not executable (mode -rw-r--r--)"},
+		BootstrapGaps(reader(t, packageOf(t, map[string]fs.FileMode{"bootstrap": 0o644}))))
+	assert.Equal(t, []string{"package has no bootstrap file at its root"},
+		BootstrapGaps(reader(t, packageOf(t, map[string]fs.FileMode{"bin/bootstrap": 0o755}))))
+}
+
+// jarOf builds an archive holding the named entries, each with the given content
+func jarOf(t *testing.T, entries map[string][]byte) []byte {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range entries {
+		entry, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func TestClassFile(t *testing.T) {
+	assert.Equal(t, "software/amazonaws/example/payment/PaymentHandler.class", ClassFile("software.amazonaws.example.payment.PaymentHandler::handleRequest"))
+	assert.Equal(t, "org/springframework/boot/loader/launch/JarLauncher.class", ClassFile("org.springframework.boot.loader.launch.JarLauncher"))
+}
+
+func TestClassGaps(t *testing.T) {
+	dependency := jarOf(t, map[string][]byte{"com/amazonaws/services/lambda/runtime/RequestHandler.class": nil})
+	springBoot := reader(t, jarOf(t, map[string][]byte{
+		"org/springframework/boot/loader/launch/JarLauncher.class":                     nil,
+		"BOOT-INF/classes/software/amazonaws/example/product/ProductApplication.class": nil,
+		"BOOT-INF/lib/aws-lambda-java-core.jar":                                        dependency,
+	}))
+
+	gaps, err := ClassGaps(springBoot,
+		"org.springframework.boot.loader.launch.JarLauncher",
+		"software.amazonaws.example.product.ProductApplication",
+		"com.amazonaws.services.lambda.runtime.RequestHandler")
+	require.NoError(t, err)
+	assert.Empty(t, gaps, "Classes are found at the root, under BOOT-INF/classes, and in dependency JARs")
+
+	lambdaZip := reader(t, jarOf(t, map[string][]byte{
+		"software/amazonaws/example/payment/PaymentHandler.class": nil,
+		"lib/aws-lambda-java-core.jar":                            dependency,
+	}))
+	gaps, err = ClassGaps(lambdaZip, "software.amazonaws.example.payment.PaymentHandler::handleRequest", "software.amazonaws.example.payment.PaymentHandlr::handleRequest")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"class software.amazonaws.example.payment.PaymentHandlr (software/amazonaws/example/payment/PaymentHandlr.class) is not in the package or its dependency JARs"}, gaps)
+}
+
+func TestDownload(t *testing.T) {
+	data := packageOf(t, map[string]fs.FileMode{"bootstrap": 0o755})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/package.zip" {
+			http.Error(w, "expired", http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	archive, err := Download(context.TODO(), server.Client(), server.URL+"/package.zip")
+	require.NoError(t, err)
+	assert.Len(t, archive.File, 1)
+
+	_, err = Download(context.TODO(), server.Client(), server.URL+"/expired")
+	assert.EqualError(t, err, "downloading deployment package: HTTP 403")
+}
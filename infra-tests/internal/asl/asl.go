@@ -0,0 +1,74 @@
+// Package asl parses Amazon States Language definitions so state machines can be compared and linted offline
+package asl
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Placeholders Normalize puts in place of deployment-specific parts of ARNs
+const (
+	PartitionPlaceholder = "${Partition}"
+	RegionPlaceholder    = "${Region}"
+	AccountPlaceholder   = "${AccountId}"
+	BaseNamePlaceholder  = "${BaseName}"
+)
+
+// arnPattern matches an ARN and captures its partition, service, region, account, and resource
+var arnPattern = regexp.MustCompile(`^arn:(aws[a-z-]*):([a-z0-9-]+):([a-z0-9-]*):(\d{12})?:(.+)$`)
+
+// Normalize rewrites a definition so it no longer depends on the deployment it was read from.
+// Partition, region, and account in every ARN become placeholders, as does the "<project>-<environment>"
+// base name in resource names, and the JSON is re-indented with sorted keys.
+func Normalize(definition []byte, baseName string) ([]byte, error) {
+	var document any
+	if err := json.Unmarshal(definition, &document); err != nil {
+		return nil, fmt.Errorf("parsing state machine definition: %w", err)
+	}
+
+	normalized := normalizeValue(document, baseName)
+	return json.MarshalIndent(normalized, "", "  ")
+}
+
+// normalizeValue rewrites every ARN string within a decoded JSON value
+func normalizeValue(value any, baseName string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			v[key] = normalizeValue(child, baseName)
+		}
+		return v
+	case []any:
+		for i, child := range v {
+			v[i] = normalizeValue(child, baseName)
+		}
+		return v
+	case string:
+		return normalizeARN(v, baseName)
+	default:
+		return v
+	}
+}
+
+// normalizeARN replaces the deployment-specific parts of an ARN; other strings are returned unchanged
+func normalizeARN(value, baseName string) string {
+	match := arnPattern.FindStringSubmatch(value)
+	if match == nil {
+		return value
+	}
+
+	region, account := match[3], match[4]
+	if region != "" {
+		region = RegionPlaceholder
+	}
+	if account != "" {
+		account = AccountPlaceholder
+	}
+	resource := match[5]
+	if baseName != "" {
+		resource = strings.ReplaceAll(resource, baseName, BaseNamePlaceholder)
+	}
+	return fmt.Sprintf("arn:%s:%s:%s:%s:%s", PartitionPlaceholder, match[2], region, account, resource)
+}
@@ -0,0 +1,63 @@
+package asl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeReplacesDeploymentSpecificARNs(t *testing.T) {
+	definition := `{
+		"StartAt": "ValidateOrder",
+		"States": {
+			"ValidateOrder": {
+				"Type": "Task",
+				"Resource": "arn:aws:states:::lambda:invoke",
+				"Parameters": {"FunctionName": "arn:aws:lambda:us-east-1:123456789012:function:lambda-java-template-dev-order-validation:$LATEST"},
+				"Next": "Notify"
+			},
+			"Notify": {
+				"Type": "Task",
+				"Resource": "arn:aws:states:::sns:publish",
+				"Parameters": {"TopicArn": "arn:aws-us-gov:sns:us-gov-west-1:123456789012:lambda-java-template-dev-notifications"},
+				"End": true
+			}
+		}
+	}`
+
+	normalized, err := Normalize([]byte(definition), "lambda-java-template-dev")
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"StartAt": "ValidateOrder",
+		"States": {
+			"ValidateOrder": {
+				"Type": "Task",
+				"Resource": "arn:${Partition}:states:::lambda:invoke",
+				"Parameters": {"FunctionName": "arn:${Partition}:lambda:${Region}:${AccountId}:function:${BaseName}-order-validation:$LATEST"},
+				"Next": "Notify"
+			},
+			"Notify": {
+				"Type": "Task",
+				"Resource": "arn:${Partition}:states:::sns:publish",
+				"Parameters": {"TopicArn": "arn:${Partition}:sns:${Region}:${AccountId}:${BaseName}-notifications"},
+				"End": true
+			}
+		}
+	}`, string(normalized))
+}
+
+func TestNormalizeIsStableAcrossDeployments(t *testing.T) {
+	dev, err := Normalize([]byte(`{"Resource":"arn:aws:lambda:us-east-1:111111111111:function:orders-dev-payment","Comment":"x"}`), "orders-dev")
+	require.NoError(t, err)
+	prod, err := Normalize([]byte(`{"Comment":"x","Resource":"arn:aws:lambda:eu-west-1:222222222222:function:orders-prod-payment"}`), "orders-prod")
+	require.NoError(t, err)
+
+	assert.Equal(t, string(dev), string(prod), "Key order, region, account, and environment do not affect the result")
+}
+
+func TestNormalizeRejectsInvalidJSON(t *testing.T) {
+	_, err := Normalize([]byte(`{"StartAt":`), "orders-dev")
+	assert.Error(t, err)
+}
@@ -0,0 +1,310 @@
+package asl
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Definition is the part of an ASL state machine the linter inspects
+type Definition struct {
+	StartAt        string           `json:"StartAt"`
+	TimeoutSeconds int              `json:"TimeoutSeconds"`
+	States         map[string]State `json:"States"`
+}
+
+// State is one ASL state. Fields that do not apply to a state's type are left empty.
+type State struct {
+	Type                 string         `json:"Type"`
+	Resource             string         `json:"Resource"`
+	Parameters           map[string]any `json:"Parameters"`
+	Next                 string         `json:"Next"`
+	End                  bool           `json:"End"`
+	Default              string         `json:"Default"`
+	Choices              []Choice       `json:"Choices"`
+	Retry                []Retrier      `json:"Retry"`
+	Catch                []Catcher      `json:"Catch"`
+	TimeoutSeconds       int            `json:"TimeoutSeconds"`
+	TimeoutSecondsPath   string         `json:"TimeoutSecondsPath"`
+	HeartbeatSeconds     int            `json:"HeartbeatSeconds"`
+	HeartbeatSecondsPath string         `json:"HeartbeatSecondsPath"`
+	Branches             []Definition   `json:"Branches"`
+	Iterator             *Definition    `json:"Iterator"`
+	ItemProcessor        *Definition    `json:"ItemProcessor"`
+}
+
+// Choice is a rule of a Choice state; only its transition matters to the linter
+type Choice struct {
+	Next string `json:"Next"`
+}
+
+// Retrier is an entry of a state's Retry list
+type Retrier struct {
+	ErrorEquals     []string `json:"ErrorEquals"`
+	IntervalSeconds int      `json:"IntervalSeconds"`
+	MaxAttempts     *int     `json:"MaxAttempts"`
+	BackoffRate     float64  `json:"BackoffRate"`
+}
+
+// Catcher is an entry of a state's Catch list
+type Catcher struct {
+	ErrorEquals []string `json:"ErrorEquals"`
+	Next        string   `json:"Next"`
+}
+
+// LongTaskSeconds is the TimeoutSeconds above which a Task counts as long-running and must send heartbeats
+const LongTaskSeconds = 300
+
+// Finding is a lint rule a state breaks
+type Finding struct {
+	State   string
+	Message string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s", f.State, f.Message)
+}
+
+// Parse decodes an ASL definition
+func Parse(definition []byte) (Definition, error) {
+	var parsed Definition
+	if err := json.Unmarshal(definition, &parsed); err != nil {
+		return Definition{}, fmt.Errorf("parsing state machine definition: %w", err)
+	}
+	return parsed, nil
+}
+
+// TerminalStates returns the Succeed and Fail states at the top level of a definition, sorted by name
+func TerminalStates(definition Definition) []string {
+	var names []string
+	for _, name := range sortedStateNames(definition.States) {
+		if stateType := definition.States[name].Type; stateType == "Succeed" || stateType == "Fail" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// StateNames returns the name of every state, those of Parallel branches and Map iterators included, sorted.
+// Names are not prefixed with the state they are nested in, as they are in execution histories.
+func StateNames(definition Definition) []string {
+	var names []string
+	for _, name := range sortedStateNames(definition.States) {
+		names = append(names, name)
+		state := definition.States[name]
+		for _, branch := range state.Branches {
+			names = append(names, StateNames(branch)...)
+		}
+		for _, nested := range []*Definition{state.Iterator, state.ItemProcessor} {
+			if nested != nil {
+				names = append(names, StateNames(*nested)...)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the state a path of Lint or LambdaReferences names, such as "ProcessItems/ReserveItem" for a
+// state of a Map iterator or "Notify/branch[0]/SendEmail" for one of a Parallel branch
+func Lookup(definition Definition, path string) (State, bool) {
+	name, rest, nested := strings.Cut(path, "/")
+	state, ok := definition.States[name]
+	if !ok || !nested {
+		return state, ok
+	}
+
+	if strings.HasPrefix(rest, "branch[") {
+		branch, rest, _ := strings.Cut(rest, "/")
+		var i int
+		if _, err := fmt.Sscanf(branch, "branch[%d]", &i); err != nil || i < 0 || i >= len(state.Branches) {
+			return State{}, false
+		}
+		return Lookup(state.Branches[i], rest)
+	}
+	for _, iterator := range []*Definition{state.Iterator, state.ItemProcessor} {
+		if iterator != nil {
+			return Lookup(*iterator, rest)
+		}
+	}
+	return State{}, false
+}
+
+// Transitions returns the states a state can move to, including through its Catch list
+func (s State) Transitions() []string {
+	var next []string
+	for _, target := range append([]string{s.Next, s.Default}, choiceTargets(s.Choices)...) {
+		if target != "" {
+			next = append(next, target)
+		}
+	}
+	for _, catcher := range s.Catch {
+		next = append(next, catcher.Next)
+	}
+	return next
+}
+
+// choiceTargets returns the Next of every choice rule
+func choiceTargets(choices []Choice) []string {
+	targets := make([]string, 0, len(choices))
+	for _, choice := range choices {
+		targets = append(targets, choice.Next)
+	}
+	return targets
+}
+
+// Lint checks that every Task state retries with backoff, catches errors into a path that ends in a Fail state,
+// and has a timeout, that long-running tasks also have a heartbeat shorter than that timeout, that the execution
+// itself has a timeout, and that every transition names a defined state.
+// Parallel branches and Map iterators are checked as nested definitions with their own state names.
+func Lint(definition Definition) []Finding {
+	var findings []Finding
+	if definition.TimeoutSeconds <= 0 {
+		findings = append(findings, Finding{"(state machine)", "no TimeoutSeconds, so a stuck execution runs for a year"})
+	}
+	return append(findings, lintStates(definition, "")...)
+}
+
+// lintStates lints the states of a definition, prefixing state names with the path of enclosing states
+func lintStates(definition Definition, prefix string) []Finding {
+	var findings []Finding
+	add := func(name, format string, args ...any) {
+		findings = append(findings, Finding{prefix + name, fmt.Sprintf(format, args...)})
+	}
+
+	if _, ok := definition.States[definition.StartAt]; !ok {
+		add("(StartAt)", "starts at undefined state %q", definition.StartAt)
+	}
+
+	for _, name := range sortedStateNames(definition.States) {
+		state := definition.States[name]
+
+		for _, target := range state.Transitions() {
+			if _, ok := definition.States[target]; !ok {
+				add(name, "transitions to undefined state %q", target)
+			}
+		}
+
+		for i, branch := range state.Branches {
+			findings = append(findings, lintStates(branch, fmt.Sprintf("%s%s/branch[%d]/", prefix, name, i))...)
+		}
+		for _, nested := range []*Definition{state.Iterator, state.ItemProcessor} {
+			if nested != nil {
+				findings = append(findings, lintStates(*nested, prefix+name+"/")...)
+			}
+		}
+
+		if state.Type != "Task" {
+			continue
+		}
+		if !hasBackoff(state.Retry) {
+			add(name, "Task has no Retry with a BackoffRate above 1")
+		}
+		if len(state.Catch) == 0 {
+			add(name, "Task has no Catch")
+		}
+		for _, catcher := range state.Catch {
+			if _, ok := definition.States[catcher.Next]; ok && !reachesFail(definition.States, catcher.Next) {
+				add(name, "Catch for %v routes to %q, which never reaches a Fail state", catcher.ErrorEquals, catcher.Next)
+			}
+		}
+		if state.TimeoutSeconds <= 0 && state.TimeoutSecondsPath == "" {
+			add(name, "Task has no TimeoutSeconds")
+		}
+		if state.longRunning() && state.HeartbeatSeconds <= 0 && state.HeartbeatSecondsPath == "" {
+			add(name, "long-running Task has no HeartbeatSeconds, so a worker that dies is only noticed when the Task times out")
+		}
+		if state.HeartbeatSeconds > 0 && state.TimeoutSeconds > 0 && state.HeartbeatSeconds >= state.TimeoutSeconds {
+			add(name, "HeartbeatSeconds %d is not below TimeoutSeconds %d", state.HeartbeatSeconds, state.TimeoutSeconds)
+		}
+	}
+	return findings
+}
+
+// longRunning reports whether a Task can run for long: it waits for a task token or an activity worker, both of
+// which can die without reporting back, or its timeout is above LongTaskSeconds
+func (s State) longRunning() bool {
+	return strings.HasSuffix(s.Resource, ".waitForTaskToken") || strings.Contains(s.Resource, ":activity:") ||
+		s.TimeoutSeconds > LongTaskSeconds
+}
+
+// hasBackoff reports whether any retrier waits longer between successive attempts
+func hasBackoff(retriers []Retrier) bool {
+	for _, retrier := range retriers {
+		if retrier.BackoffRate > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// reachesFail reports whether a Fail state can be reached from the named state,
+// so a caught error is reported as a failed execution rather than swallowed
+func reachesFail(states map[string]State, start string) bool {
+	seen := map[string]bool{}
+	queue := []string{start}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		state, ok := states[name]
+		if !ok {
+			continue
+		}
+		if state.Type == "Fail" {
+			return true
+		}
+		queue = append(queue, state.Transitions()...)
+	}
+	return false
+}
+
+// LambdaReferences maps every Lambda function ARN the definition invokes to the states invoking it.
+// Both the optimized lambda:invoke integration and direct function ARNs in Resource are recognised;
+// function names chosen at run time through "FunctionName.$" cannot be checked and are left out.
+func LambdaReferences(definition Definition) map[string][]string {
+	references := make(map[string][]string)
+	collectLambdaReferences(definition, "", references)
+	return references
+}
+
+// collectLambdaReferences adds the Lambda references of a definition and its nested definitions
+func collectLambdaReferences(definition Definition, prefix string, references map[string][]string) {
+	for _, name := range sortedStateNames(definition.States) {
+		state := definition.States[name]
+
+		function := ""
+		if strings.HasPrefix(state.Resource, "arn:") && strings.Contains(state.Resource, ":lambda:") && strings.Contains(state.Resource, ":function:") {
+			function = state.Resource
+		} else if strings.Contains(state.Resource, ":states:::lambda:invoke") {
+			function, _ = state.Parameters["FunctionName"].(string)
+		}
+		if function != "" {
+			references[function] = append(references[function], prefix+name)
+		}
+
+		for i, branch := range state.Branches {
+			collectLambdaReferences(branch, fmt.Sprintf("%s%s/branch[%d]/", prefix, name, i), references)
+		}
+		for _, nested := range []*Definition{state.Iterator, state.ItemProcessor} {
+			if nested != nil {
+				collectLambdaReferences(*nested, prefix+name+"/", references)
+			}
+		}
+	}
+}
+
+// sortedStateNames returns state names in a stable order so findings are reproducible
+func sortedStateNames(states map[string]State) []string {
+	names := make([]string, 0, len(states))
+	for name := range states {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
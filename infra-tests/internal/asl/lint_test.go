@@ -0,0 +1,208 @@
+package asl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// compliantDefinition follows every lint rule: a validated task that notifies and fails on error
+const compliantDefinition = `{
+	"StartAt": "ValidateOrder",
+	"TimeoutSeconds": 300,
+	"States": {
+		"ValidateOrder": {
+			"Type": "Task",
+			"Resource": "arn:aws:states:::lambda:invoke",
+			"Parameters": {"FunctionName": "arn:aws:lambda:us-east-1:123456789012:function:orders-dev-order-validation", "Payload.$": "$"},
+			"TimeoutSeconds": 30,
+			"Retry": [{"ErrorEquals": ["Lambda.ServiceException"], "IntervalSeconds": 2, "MaxAttempts": 3, "BackoffRate": 2}],
+			"Catch": [{"ErrorEquals": ["States.ALL"], "Next": "NotifyFailure"}],
+			"Next": "OrderSuccess"
+		},
+		"NotifyFailure": {
+			"Type": "Task",
+			"Resource": "arn:aws:lambda:us-east-1:123456789012:function:orders-dev-notification",
+			"TimeoutSeconds": 30,
+			"Retry": [{"ErrorEquals": ["States.ALL"], "BackoffRate": 1.5}],
+			"Catch": [{"ErrorEquals": ["States.ALL"], "Next": "ProcessingFailed"}],
+			"Next": "ProcessingFailed"
+		},
+		"OrderSuccess": {"Type": "Succeed"},
+		"ProcessingFailed": {"Type": "Fail", "Error": "ProcessingFailed"}
+	}
+}`
+
+func TestLintAcceptsCompliantDefinition(t *testing.T) {
+	definition, err := Parse([]byte(compliantDefinition))
+	require.NoError(t, err)
+	assert.Empty(t, Lint(definition))
+}
+
+func TestLintReportsTaskGaps(t *testing.T) {
+	definition, err := Parse([]byte(`{
+		"StartAt": "Charge",
+		"States": {
+			"Charge": {
+				"Type": "Task",
+				"Resource": "arn:aws:lambda:us-east-1:123456789012:function:orders-dev-payment",
+				"Retry": [{"ErrorEquals": ["States.ALL"], "BackoffRate": 1}],
+				"Catch": [{"ErrorEquals": ["States.ALL"], "Next": "Ignore"}],
+				"Next": "Ship"
+			},
+			"Ignore": {"Type": "Succeed"}
+		}
+	}`))
+	require.NoError(t, err)
+
+	var messages []string
+	for _, finding := range Lint(definition) {
+		messages = append(messages, finding.String())
+	}
+	assert.ElementsMatch(t, []string{
+		"(state machine): no TimeoutSeconds, so a stuck execution runs for a year",
+		`Charge: transitions to undefined state "Ship"`,
+		"Charge: Task has no Retry with a BackoffRate above 1",
+		`Charge: Catch for [States.ALL] routes to "Ignore", which never reaches a Fail state`,
+		"Charge: Task has no TimeoutSeconds",
+	}, messages)
+}
+
+func TestLintRequiresHeartbeatsOfLongTasks(t *testing.T) {
+	definition, err := Parse([]byte(`{
+		"StartAt": "AwaitApproval",
+		"TimeoutSeconds": 3600,
+		"States": {
+			"AwaitApproval": {
+				"Type": "Task",
+				"Resource": "arn:aws:states:::sqs:sendMessage.waitForTaskToken",
+				"TimeoutSeconds": 600,
+				"Retry": [{"ErrorEquals": ["States.ALL"], "BackoffRate": 2}],
+				"Catch": [{"ErrorEquals": ["States.ALL"], "Next": "Failed"}],
+				"Next": "Reconcile"
+			},
+			"Reconcile": {
+				"Type": "Task",
+				"Resource": "arn:aws:states:::lambda:invoke",
+				"TimeoutSeconds": 60,
+				"HeartbeatSeconds": 60,
+				"Retry": [{"ErrorEquals": ["States.ALL"], "BackoffRate": 2}],
+				"Catch": [{"ErrorEquals": ["States.ALL"], "Next": "Failed"}],
+				"End": true
+			},
+			"Failed": {"Type": "Fail"}
+		}
+	}`))
+	require.NoError(t, err)
+
+	var messages []string
+	for _, finding := range Lint(definition) {
+		messages = append(messages, finding.String())
+	}
+	assert.Equal(t, []string{
+		"AwaitApproval: long-running Task has no HeartbeatSeconds, so a worker that dies is only noticed when the Task times out",
+		"Reconcile: HeartbeatSeconds 60 is not below TimeoutSeconds 60",
+	}, messages)
+
+	awaiting := definition.States["AwaitApproval"]
+	awaiting.HeartbeatSecondsPath = "$.heartbeatSeconds"
+	definition.States["AwaitApproval"] = awaiting
+	assert.Len(t, Lint(definition), 1, "A heartbeat read from the input counts")
+}
+
+func TestLookup(t *testing.T) {
+	definition, err := Parse([]byte(`{
+		"StartAt": "ProcessItems",
+		"States": {
+			"ProcessItems": {
+				"Type": "Map",
+				"ItemProcessor": {"StartAt": "ReserveItem", "States": {"ReserveItem": {"Type": "Task", "TimeoutSeconds": 20, "End": true}}},
+				"Next": "Notify"
+			},
+			"Notify": {
+				"Type": "Parallel",
+				"Branches": [{"StartAt": "SendEmail", "States": {"SendEmail": {"Type": "Task", "TimeoutSeconds": 10, "End": true}}}],
+				"End": true
+			}
+		}
+	}`))
+	require.NoError(t, err)
+
+	state, ok := Lookup(definition, "ProcessItems/ReserveItem")
+	assert.True(t, ok)
+	assert.Equal(t, 20, state.TimeoutSeconds)
+	state, ok = Lookup(definition, "Notify/branch[0]/SendEmail")
+	assert.True(t, ok)
+	assert.Equal(t, 10, state.TimeoutSeconds)
+	state, ok = Lookup(definition, "Notify")
+	assert.True(t, ok)
+	assert.Equal(t, "Parallel", state.Type)
+
+	for _, missing := range []string{"Ship", "Notify/branch[1]/SendEmail", "Notify/SendEmail", "ProcessItems/Ship"} {
+		_, ok := Lookup(definition, missing)
+		assert.False(t, ok, missing)
+	}
+}
+
+func TestLintChecksNestedDefinitions(t *testing.T) {
+	definition, err := Parse([]byte(`{
+		"StartAt": "ProcessItems",
+		"TimeoutSeconds": 300,
+		"States": {
+			"ProcessItems": {
+				"Type": "Map",
+				"ItemProcessor": {
+					"StartAt": "ReserveItem",
+					"States": {
+						"ReserveItem": {"Type": "Task", "Resource": "arn:aws:lambda:us-east-1:123456789012:function:orders-dev-inventory", "End": true}
+					}
+				},
+				"End": true
+			}
+		}
+	}`))
+	require.NoError(t, err)
+
+	findings := Lint(definition)
+	require.NotEmpty(t, findings)
+	for _, finding := range findings {
+		assert.Equal(t, "ProcessItems/ReserveItem", finding.State)
+	}
+}
+
+func TestLambdaReferences(t *testing.T) {
+	definition, err := Parse([]byte(compliantDefinition))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]string{
+		"arn:aws:lambda:us-east-1:123456789012:function:orders-dev-order-validation": {"ValidateOrder"},
+		"arn:aws:lambda:us-east-1:123456789012:function:orders-dev-notification":     {"NotifyFailure"},
+	}, LambdaReferences(definition))
+}
+
+func TestStateNames(t *testing.T) {
+	definition, err := Parse([]byte(`{
+		"StartAt": "ProcessItems",
+		"States": {
+			"ProcessItems": {
+				"Type": "Map",
+				"ItemProcessor": {"StartAt": "ReserveItem", "States": {"ReserveItem": {"Type": "Pass", "End": true}}},
+				"Next": "Notify"
+			},
+			"Notify": {
+				"Type": "Parallel",
+				"Branches": [{"StartAt": "SendEmail", "States": {"SendEmail": {"Type": "Pass", "End": true}}}],
+				"End": true
+			}
+		}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Notify", "ProcessItems", "ReserveItem", "SendEmail"}, StateNames(definition))
+}
+
+func TestTerminalStates(t *testing.T) {
+	definition, err := Parse([]byte(compliantDefinition))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"OrderSuccess", "ProcessingFailed"}, TerminalStates(definition))
+}
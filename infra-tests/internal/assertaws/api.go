@@ -0,0 +1,239 @@
+package assertaws
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// APIAssertion asserts on the configuration of one API Gateway HTTP API
+type APIAssertion struct {
+	t           testing.TB
+	clients     *Clients
+	id          string
+	subject     string
+	api         *apigatewayv2.GetApiOutput
+	routes      []types.Route
+	authorizers []types.Authorizer
+}
+
+// API fetches the API with the given ID and returns assertions on it
+func API(t testing.TB, clients *Clients, id string) *APIAssertion {
+	t.Helper()
+
+	output, err := clients.APIGateway.GetApi(context.TODO(), &apigatewayv2.GetApiInput{
+		ApiId: aws.String(id),
+	})
+	require.NoError(t, err, "Failed to get API %s", id)
+
+	// HTTP APIs carry no ARN of their own; this is the form IAM policies and tags use
+	arn := fmt.Sprintf("arn:aws:apigateway:%s::/apis/%s", clients.region, id)
+	return &APIAssertion{t: t, clients: clients, id: id, subject: subject(aws.ToString(output.Name), &arn), api: output}
+}
+
+// HasName asserts the API name
+func (a *APIAssertion) HasName(name string) *APIAssertion {
+	a.t.Helper()
+	assert.Equal(a.t, name, aws.ToString(a.api.Name), "API %s name", a.subject)
+	return a
+}
+
+// HasProtocolType asserts the API protocol, HTTP or WEBSOCKET
+func (a *APIAssertion) HasProtocolType(protocol string) *APIAssertion {
+	a.t.Helper()
+	assert.Equal(a.t, protocol, string(a.api.ProtocolType), "API %s protocol type", a.subject)
+	return a
+}
+
+// HasDescriptionContaining asserts the API description contains fragment
+func (a *APIAssertion) HasDescriptionContaining(fragment string) *APIAssertion {
+	a.t.Helper()
+	assert.Contains(a.t, aws.ToString(a.api.Description), fragment, "API %s description", a.subject)
+	return a
+}
+
+// HasEndpoint asserts the API has an invoke endpoint
+func (a *APIAssertion) HasEndpoint() *APIAssertion {
+	a.t.Helper()
+	assert.NotEmpty(a.t, aws.ToString(a.api.ApiEndpoint), "API %s endpoint", a.subject)
+	return a
+}
+
+// AllowsCORSMethods asserts CORS, when configured, allows each method
+func (a *APIAssertion) AllowsCORSMethods(methods ...string) *APIAssertion {
+	a.t.Helper()
+	if a.api.CorsConfiguration == nil {
+		return a
+	}
+	for _, method := range methods {
+		assert.Contains(a.t, a.api.CorsConfiguration.AllowMethods, method, "API %s CORS methods", a.subject)
+	}
+	return a
+}
+
+// HasCORS asserts CORS is configured, allows each method, and lets browsers cache preflight responses for maxAge seconds
+func (a *APIAssertion) HasCORS(maxAge int32, methods ...string) *APIAssertion {
+	a.t.Helper()
+	if !assert.NotNil(a.t, a.api.CorsConfiguration, "API %s has no CORS configuration", a.subject) {
+		return a
+	}
+	a.AllowsCORSMethods(methods...)
+	assert.Equal(a.t, maxAge, aws.ToInt32(a.api.CorsConfiguration.MaxAge), "API %s CORS max age", a.subject)
+	return a
+}
+
+// HasLambdaProxyIntegrations asserts the API has integrations and each one proxies to a Lambda function with
+// the given payload format version, giving up before the function's own timeout
+func (a *APIAssertion) HasLambdaProxyIntegrations(payloadFormatVersion string) *APIAssertion {
+	a.t.Helper()
+	var integrations []types.Integration
+	var nextToken *string
+	for {
+		output, err := a.clients.APIGateway.GetIntegrations(context.TODO(), &apigatewayv2.GetIntegrationsInput{
+			ApiId:     aws.String(a.id),
+			NextToken: nextToken,
+		})
+		require.NoError(a.t, err, "Failed to get integrations of API %s", a.subject)
+		integrations = append(integrations, output.Items...)
+		if nextToken = output.NextToken; nextToken == nil {
+			break
+		}
+	}
+
+	assert.NotEmpty(a.t, integrations, "API %s has no integrations", a.subject)
+	for _, integration := range integrations {
+		id := aws.ToString(integration.IntegrationId)
+		assert.Equal(a.t, types.IntegrationTypeAwsProxy, integration.IntegrationType, "API %s integration %s type", a.subject, id)
+		assert.Equal(a.t, payloadFormatVersion, aws.ToString(integration.PayloadFormatVersion), "API %s integration %s payload format", a.subject, id)
+
+		function := integrationFunction(aws.ToString(integration.IntegrationUri))
+		if !assert.NotEmpty(a.t, function, "API %s integration %s target %s is not a Lambda function", a.subject, id, aws.ToString(integration.IntegrationUri)) {
+			continue
+		}
+		configuration, err := a.clients.Lambda.GetFunctionConfiguration(context.TODO(), &lambda.GetFunctionConfigurationInput{
+			FunctionName: aws.String(function),
+		})
+		if !assert.NoError(a.t, err, "API %s integration %s invokes %s", a.subject, id, function) {
+			continue
+		}
+		// HTTP APIs default to the 30 second maximum when no timeout is set
+		timeout := aws.ToInt32(integration.TimeoutInMillis)
+		if timeout == 0 {
+			timeout = 30000
+		}
+		assert.Less(a.t, timeout, aws.ToInt32(configuration.Timeout)*1000,
+			"API %s integration %s timeout in ms must be below the %d second timeout of %s", a.subject, id, aws.ToInt32(configuration.Timeout), function)
+	}
+	return a
+}
+
+// integrationFunction returns the Lambda function ARN an integration URI invokes, whether the URI is the function
+// ARN itself or its API Gateway invoke ARN
+// (arn:aws:apigateway:<region>:lambda:path/2015-03-31/functions/<function ARN>/invocations), or "" for other targets
+func integrationFunction(uri string) string {
+	if _, path, ok := strings.Cut(uri, ":lambda:path/"); ok {
+		_, function, _ := strings.Cut(path, "/functions/")
+		return strings.TrimSuffix(function, "/invocations")
+	}
+	if strings.HasPrefix(uri, "arn:") && strings.Contains(uri, ":lambda:") && strings.Contains(uri, ":function:") {
+		return uri
+	}
+	return ""
+}
+
+// HasRoute asserts a route exists with the given authorization type
+func (a *APIAssertion) HasRoute(routeKey, authorizationType string) *APIAssertion {
+	a.t.Helper()
+	for _, route := range a.loadRoutes() {
+		if aws.ToString(route.RouteKey) == routeKey {
+			assert.Equal(a.t, authorizationType, string(route.AuthorizationType), "API %s route %s authorization type", a.subject, routeKey)
+			return a
+		}
+	}
+	assert.Fail(a.t, "Route not found", "API %s has no route %s", a.subject, routeKey)
+	return a
+}
+
+// HasAuthorizedRoutes asserts every route, including ones Terraform does not declare, uses the named authorizer,
+// apart from the route keys in public
+func (a *APIAssertion) HasAuthorizedRoutes(authorizerName string, public ...string) *APIAssertion {
+	a.t.Helper()
+	var authorizerID string
+	for _, authorizer := range a.loadAuthorizers() {
+		if aws.ToString(authorizer.Name) == authorizerName {
+			authorizerID = aws.ToString(authorizer.AuthorizerId)
+		}
+	}
+	if authorizerID == "" {
+		assert.Fail(a.t, "Authorizer not found", "API %s has no authorizer %s", a.subject, authorizerName)
+		return a
+	}
+
+	for _, route := range a.loadRoutes() {
+		routeKey := aws.ToString(route.RouteKey)
+		if slices.Contains(public, routeKey) {
+			continue
+		}
+		assert.Equal(a.t, types.AuthorizationTypeCustom, route.AuthorizationType, "API %s route %s is not on the public allowlist but is not authorized", a.subject, routeKey)
+		assert.Equal(a.t, authorizerID, aws.ToString(route.AuthorizerId), "API %s route %s authorizer", a.subject, routeKey)
+	}
+	return a
+}
+
+// HasRequestAuthorizer asserts a REQUEST authorizer with the given payload format version and result cache TTL
+func (a *APIAssertion) HasRequestAuthorizer(name, payloadFormatVersion string, ttlSeconds int32) *APIAssertion {
+	a.t.Helper()
+	for _, authorizer := range a.loadAuthorizers() {
+		if aws.ToString(authorizer.Name) == name {
+			assert.Equal(a.t, types.AuthorizerTypeRequest, authorizer.AuthorizerType, "API %s authorizer %s type", a.subject, name)
+			assert.Equal(a.t, payloadFormatVersion, aws.ToString(authorizer.AuthorizerPayloadFormatVersion), "API %s authorizer %s payload format", a.subject, name)
+			assert.Equal(a.t, ttlSeconds, aws.ToInt32(authorizer.AuthorizerResultTtlInSeconds), "API %s authorizer %s result TTL", a.subject, name)
+			return a
+		}
+	}
+	assert.Fail(a.t, "Authorizer not found", "API %s has no authorizer %s", a.subject, name)
+	return a
+}
+
+// loadRoutes fetches the API routes on first use
+func (a *APIAssertion) loadRoutes() []types.Route {
+	a.t.Helper()
+	if a.routes == nil {
+		var nextToken *string
+		a.routes = []types.Route{}
+		for {
+			output, err := a.clients.APIGateway.GetRoutes(context.TODO(), &apigatewayv2.GetRoutesInput{
+				ApiId:     aws.String(a.id),
+				NextToken: nextToken,
+			})
+			require.NoError(a.t, err, "Failed to get routes of API %s", a.subject)
+			a.routes = append(a.routes, output.Items...)
+			if nextToken = output.NextToken; nextToken == nil {
+				break
+			}
+		}
+	}
+	return a.routes
+}
+
+// loadAuthorizers fetches the API authorizers on first use
+func (a *APIAssertion) loadAuthorizers() []types.Authorizer {
+	a.t.Helper()
+	if a.authorizers == nil {
+		output, err := a.clients.APIGateway.GetAuthorizers(context.TODO(), &apigatewayv2.GetAuthorizersInput{
+			ApiId: aws.String(a.id),
+		})
+		require.NoError(a.t, err, "Failed to get authorizers of API %s", a.subject)
+		a.authorizers = append([]types.Authorizer{}, output.Items...)
+	}
+	return a.authorizers
+}
@@ -0,0 +1,45 @@
+// Package assertaws provides fluent assertions on deployed AWS resources.
+//
+// Each constructor fetches the resource once and fails the test if it cannot be found;
+// the returned value's methods assert individual properties and can be chained:
+//
+//	assertaws.Lambda(t, clients, name).HasRuntime("java21").HasMemoryBetween(256, 1024).HasTag("ManagedBy", "terraform")
+//
+// Failure messages name the resource with its ARN, so a failure in a run against several accounts or
+// regions says which one is wrong.
+package assertaws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+)
+
+// Clients holds the service clients shared by every assertion
+type Clients struct {
+	Lambda     *lambda.Client
+	DynamoDB   *dynamodb.Client
+	APIGateway *apigatewayv2.Client
+	region     string
+}
+
+// NewClients builds the service clients from an AWS config
+func NewClients(cfg aws.Config) *Clients {
+	return &Clients{
+		Lambda:     lambda.NewFromConfig(cfg),
+		DynamoDB:   dynamodb.NewFromConfig(cfg),
+		APIGateway: apigatewayv2.NewFromConfig(cfg),
+		region:     cfg.Region,
+	}
+}
+
+// subject names a resource in failure messages, with its ARN when known
+func subject(name string, arn *string) string {
+	if aws.ToString(arn) == "" {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, aws.ToString(arn))
+}
@@ -0,0 +1,204 @@
+package assertaws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TableAssertion asserts on the configuration of one DynamoDB table
+type TableAssertion struct {
+	t       testing.TB
+	clients *Clients
+	name    string
+	subject string
+	table   *types.TableDescription
+	tags    map[string]string
+}
+
+// Table describes the named table and returns assertions on it
+func Table(t testing.TB, clients *Clients, name string) *TableAssertion {
+	t.Helper()
+
+	output, err := clients.DynamoDB.DescribeTable(context.TODO(), &dynamodb.DescribeTableInput{
+		TableName: aws.String(name),
+	})
+	require.NoError(t, err, "Failed to describe DynamoDB table %s", name)
+
+	return &TableAssertion{t: t, clients: clients, name: name, subject: subject(name, output.Table.TableArn), table: output.Table}
+}
+
+// Description returns the fetched table description for checks not covered here
+func (a *TableAssertion) Description() *types.TableDescription {
+	return a.table
+}
+
+// IsActive asserts the table status is ACTIVE
+func (a *TableAssertion) IsActive() *TableAssertion {
+	a.t.Helper()
+	assert.Equal(a.t, "ACTIVE", string(a.table.TableStatus), "Table %s status", a.subject)
+	return a
+}
+
+// HasBillingMode asserts the table billing mode.
+// Tables created with provisioned capacity that never switched mode have no billing mode summary.
+func (a *TableAssertion) HasBillingMode(mode string) *TableAssertion {
+	a.t.Helper()
+	actual := types.BillingModeProvisioned
+	if a.table.BillingModeSummary != nil {
+		actual = a.table.BillingModeSummary.BillingMode
+	}
+	assert.Equal(a.t, mode, string(actual), "Table %s billing mode", a.subject)
+	return a
+}
+
+// HasHashKey asserts the partition key attribute
+func (a *TableAssertion) HasHashKey(attribute string) *TableAssertion {
+	a.t.Helper()
+	a.assertKey(0, attribute, types.KeyTypeHash)
+	return a
+}
+
+// HasRangeKey asserts the sort key attribute
+func (a *TableAssertion) HasRangeKey(attribute string) *TableAssertion {
+	a.t.Helper()
+	a.assertKey(1, attribute, types.KeyTypeRange)
+	return a
+}
+
+// IsEncrypted asserts server-side encryption is enabled
+func (a *TableAssertion) IsEncrypted() *TableAssertion {
+	a.t.Helper()
+	if assert.NotNil(a.t, a.table.SSEDescription, "Table %s has no encryption settings", a.subject) {
+		assert.Equal(a.t, "ENABLED", string(a.table.SSEDescription.Status), "Table %s encryption", a.subject)
+	}
+	return a
+}
+
+// HasGSI asserts an active global secondary index with the given projection exists
+func (a *TableAssertion) HasGSI(indexName, projection string) *TableAssertion {
+	a.t.Helper()
+	for _, gsi := range a.table.GlobalSecondaryIndexes {
+		if aws.ToString(gsi.IndexName) == indexName {
+			assert.Equal(a.t, "ACTIVE", string(gsi.IndexStatus), "Table %s index %s status", a.subject, indexName)
+			if projection != "" {
+				assert.Equal(a.t, projection, string(gsi.Projection.ProjectionType), "Table %s index %s projection", a.subject, indexName)
+			}
+			return a
+		}
+	}
+	assert.Fail(a.t, "Index not found", "Table %s has no global secondary index %s", a.subject, indexName)
+	return a
+}
+
+// HasPointInTimeRecovery asserts whether point-in-time recovery is enabled
+func (a *TableAssertion) HasPointInTimeRecovery(enabled bool) *TableAssertion {
+	a.t.Helper()
+	output, err := a.clients.DynamoDB.DescribeContinuousBackups(context.TODO(), &dynamodb.DescribeContinuousBackupsInput{
+		TableName: aws.String(a.name),
+	})
+	require.NoError(a.t, err, "Failed to describe continuous backups of table %s", a.subject)
+
+	expected := types.PointInTimeRecoveryStatusDisabled
+	if enabled {
+		expected = types.PointInTimeRecoveryStatusEnabled
+	}
+	actual := output.ContinuousBackupsDescription.PointInTimeRecoveryDescription.PointInTimeRecoveryStatus
+	assert.Equal(a.t, expected, actual, "Table %s point-in-time recovery", a.subject)
+	return a
+}
+
+// HasTTL asserts time to live deletes items by attribute, or is disabled when attribute is empty
+func (a *TableAssertion) HasTTL(attribute string) *TableAssertion {
+	a.t.Helper()
+	output, err := a.clients.DynamoDB.DescribeTimeToLive(context.TODO(), &dynamodb.DescribeTimeToLiveInput{
+		TableName: aws.String(a.name),
+	})
+	require.NoError(a.t, err, "Failed to describe time to live of table %s", a.subject)
+
+	description := output.TimeToLiveDescription
+	if attribute == "" {
+		if description != nil {
+			assert.Contains(a.t, []types.TimeToLiveStatus{types.TimeToLiveStatusDisabled, ""}, description.TimeToLiveStatus, "Table %s time to live", a.subject)
+		}
+		return a
+	}
+	if assert.NotNil(a.t, description, "Table %s has no time to live settings", a.subject) {
+		assert.Equal(a.t, types.TimeToLiveStatusEnabled, description.TimeToLiveStatus, "Table %s time to live", a.subject)
+		assert.Equal(a.t, attribute, aws.ToString(description.AttributeName), "Table %s time to live attribute", a.subject)
+	}
+	return a
+}
+
+// HasStream asserts a stream with the given view type is enabled and consumed by a Lambda event source mapping,
+// or that streams are disabled when viewType is empty
+func (a *TableAssertion) HasStream(viewType string) *TableAssertion {
+	a.t.Helper()
+	stream := a.table.StreamSpecification
+	if viewType == "" {
+		assert.False(a.t, stream != nil && aws.ToBool(stream.StreamEnabled), "Table %s has a stream nothing is expected to consume", a.subject)
+		return a
+	}
+	if !assert.True(a.t, stream != nil && aws.ToBool(stream.StreamEnabled), "Table %s has no stream", a.subject) {
+		return a
+	}
+	assert.Equal(a.t, viewType, string(stream.StreamViewType), "Table %s stream view type", a.subject)
+
+	mappings, err := a.clients.Lambda.ListEventSourceMappings(context.TODO(), &lambda.ListEventSourceMappingsInput{
+		EventSourceArn: a.table.LatestStreamArn,
+	})
+	require.NoError(a.t, err, "Failed to list event source mappings of table %s", a.subject)
+	assert.NotEmpty(a.t, mappings.EventSourceMappings, "Nothing consumes the stream of table %s", a.subject)
+	return a
+}
+
+// HasTagKey asserts a tag is present
+func (a *TableAssertion) HasTagKey(key string) *TableAssertion {
+	a.t.Helper()
+	assert.Contains(a.t, a.loadTags(), key, "Table %s tag %s", a.subject, key)
+	return a
+}
+
+// HasTag asserts a tag has the given value
+func (a *TableAssertion) HasTag(key, value string) *TableAssertion {
+	a.t.Helper()
+	tags := a.loadTags()
+	if assert.Contains(a.t, tags, key, "Table %s tag %s", a.subject, key) {
+		assert.Equal(a.t, value, tags[key], "Table %s tag %s", a.subject, key)
+	}
+	return a
+}
+
+// assertKey checks the key schema element at position
+func (a *TableAssertion) assertKey(position int, attribute string, keyType types.KeyType) {
+	a.t.Helper()
+	if !assert.Greater(a.t, len(a.table.KeySchema), position, "Table %s has no %s key", a.subject, keyType) {
+		return
+	}
+	key := a.table.KeySchema[position]
+	assert.Equal(a.t, attribute, aws.ToString(key.AttributeName), "Table %s %s key", a.subject, keyType)
+	assert.Equal(a.t, keyType, key.KeyType, "Table %s key type", a.subject)
+}
+
+// loadTags fetches the table tags on first use
+func (a *TableAssertion) loadTags() map[string]string {
+	a.t.Helper()
+	if a.tags == nil {
+		output, err := a.clients.DynamoDB.ListTagsOfResource(context.TODO(), &dynamodb.ListTagsOfResourceInput{
+			ResourceArn: a.table.TableArn,
+		})
+		require.NoError(a.t, err, "Failed to list tags of table %s", a.subject)
+
+		a.tags = make(map[string]string, len(output.Tags))
+		for _, tag := range output.Tags {
+			a.tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+	}
+	return a.tags
+}
@@ -0,0 +1,177 @@
+package assertaws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// LambdaAssertion asserts on the configuration of one Lambda function
+type LambdaAssertion struct {
+	t       testing.TB
+	clients *Clients
+	name    string
+	subject string
+	output  *lambda.GetFunctionOutput
+	tags    map[string]string
+}
+
+// Lambda fetches the named function and returns assertions on it
+func Lambda(t testing.TB, clients *Clients, name string) *LambdaAssertion {
+	t.Helper()
+
+	output, err := clients.Lambda.GetFunction(context.TODO(), &lambda.GetFunctionInput{
+		FunctionName: aws.String(name),
+	})
+	require.NoError(t, err, "Failed to get Lambda function %s", name)
+
+	return &LambdaAssertion{t: t, clients: clients, name: name, subject: subject(name, output.Configuration.FunctionArn), output: output}
+}
+
+// Configuration returns the fetched function configuration for checks not covered here
+func (a *LambdaAssertion) Configuration() *types.FunctionConfiguration {
+	return a.output.Configuration
+}
+
+// Code returns the fetched deployment package location
+func (a *LambdaAssertion) Code() *types.FunctionCodeLocation {
+	return a.output.Code
+}
+
+// HasRuntime asserts the function runtime
+func (a *LambdaAssertion) HasRuntime(runtime string) *LambdaAssertion {
+	a.t.Helper()
+	assert.Equal(a.t, runtime, string(a.Configuration().Runtime), "Lambda %s runtime", a.subject)
+	return a
+}
+
+// HasArchitecture asserts the function's instruction set architecture
+func (a *LambdaAssertion) HasArchitecture(architecture string) *LambdaAssertion {
+	a.t.Helper()
+	if assert.NotEmpty(a.t, a.Configuration().Architectures, "Lambda %s has no architectures", a.subject) {
+		assert.Equal(a.t, architecture, string(a.Configuration().Architectures[0]), "Lambda %s architecture", a.subject)
+	}
+	return a
+}
+
+// HasMemory asserts the function memory size in MB
+func (a *LambdaAssertion) HasMemory(memory int32) *LambdaAssertion {
+	a.t.Helper()
+	assert.Equal(a.t, memory, aws.ToInt32(a.Configuration().MemorySize), "Lambda %s memory size", a.subject)
+	return a
+}
+
+// HasMemoryBetween asserts the function memory size in MB lies within [min, max]
+func (a *LambdaAssertion) HasMemoryBetween(min, max int32) *LambdaAssertion {
+	a.t.Helper()
+	memory := aws.ToInt32(a.Configuration().MemorySize)
+	assert.GreaterOrEqual(a.t, memory, min, "Lambda %s memory size", a.subject)
+	assert.LessOrEqual(a.t, memory, max, "Lambda %s memory size", a.subject)
+	return a
+}
+
+// HasTimeout asserts the function timeout in seconds
+func (a *LambdaAssertion) HasTimeout(timeout int32) *LambdaAssertion {
+	a.t.Helper()
+	assert.Equal(a.t, timeout, aws.ToInt32(a.Configuration().Timeout), "Lambda %s timeout", a.subject)
+	return a
+}
+
+// HasHandler asserts the function handler
+func (a *LambdaAssertion) HasHandler(handler string) *LambdaAssertion {
+	a.t.Helper()
+	assert.Equal(a.t, handler, aws.ToString(a.Configuration().Handler), "Lambda %s handler", a.subject)
+	return a
+}
+
+// HasTracingMode asserts the X-Ray tracing mode
+func (a *LambdaAssertion) HasTracingMode(mode string) *LambdaAssertion {
+	a.t.Helper()
+	if assert.NotNil(a.t, a.Configuration().TracingConfig, "Lambda %s has no tracing config", a.subject) {
+		assert.Equal(a.t, mode, string(a.Configuration().TracingConfig.Mode), "Lambda %s tracing mode", a.subject)
+	}
+	return a
+}
+
+// HasEnvKey asserts an environment variable is set
+func (a *LambdaAssertion) HasEnvKey(key string) *LambdaAssertion {
+	a.t.Helper()
+	assert.Contains(a.t, a.envVars(), key, "Lambda %s environment variable %s", a.subject, key)
+	return a
+}
+
+// HasEnv asserts an environment variable has the given value
+func (a *LambdaAssertion) HasEnv(key, value string) *LambdaAssertion {
+	a.t.Helper()
+	envVars := a.envVars()
+	if assert.Contains(a.t, envVars, key, "Lambda %s environment variable %s", a.subject, key) {
+		assert.Equal(a.t, value, envVars[key], "Lambda %s environment variable %s", a.subject, key)
+	}
+	return a
+}
+
+// HasRoleContaining asserts the function's execution role ARN contains fragment, e.g. the function name
+// for a role of its own
+func (a *LambdaAssertion) HasRoleContaining(fragment string) *LambdaAssertion {
+	a.t.Helper()
+	assert.Contains(a.t, aws.ToString(a.Configuration().Role), fragment, "Lambda %s execution role", a.subject)
+	return a
+}
+
+// IsActive asserts the function state is Active
+func (a *LambdaAssertion) IsActive() *LambdaAssertion {
+	a.t.Helper()
+	assert.Equal(a.t, "Active", string(a.Configuration().State), "Lambda %s state", a.subject)
+	return a
+}
+
+// HasCodeSizeBetween asserts the deployment package size lies strictly between min and max bytes
+func (a *LambdaAssertion) HasCodeSizeBetween(min, max int64) *LambdaAssertion {
+	a.t.Helper()
+	assert.Greater(a.t, a.Configuration().CodeSize, min, "Lambda %s code size", a.subject)
+	assert.Less(a.t, a.Configuration().CodeSize, max, "Lambda %s code size", a.subject)
+	return a
+}
+
+// HasTagKey asserts a tag is present
+func (a *LambdaAssertion) HasTagKey(key string) *LambdaAssertion {
+	a.t.Helper()
+	assert.Contains(a.t, a.loadTags(), key, "Lambda %s tag %s", a.subject, key)
+	return a
+}
+
+// HasTag asserts a tag has the given value
+func (a *LambdaAssertion) HasTag(key, value string) *LambdaAssertion {
+	a.t.Helper()
+	tags := a.loadTags()
+	if assert.Contains(a.t, tags, key, "Lambda %s tag %s", a.subject, key) {
+		assert.Equal(a.t, value, tags[key], "Lambda %s tag %s", a.subject, key)
+	}
+	return a
+}
+
+// envVars returns the function's environment variables, empty when none are set
+func (a *LambdaAssertion) envVars() map[string]string {
+	if a.Configuration().Environment == nil {
+		return map[string]string{}
+	}
+	return a.Configuration().Environment.Variables
+}
+
+// loadTags fetches the function tags on first use
+func (a *LambdaAssertion) loadTags() map[string]string {
+	a.t.Helper()
+	if a.tags == nil {
+		output, err := a.clients.Lambda.ListTags(context.TODO(), &lambda.ListTagsInput{
+			Resource: a.Configuration().FunctionArn,
+		})
+		require.NoError(a.t, err, "Failed to list tags of Lambda %s", a.subject)
+		a.tags = output.Tags
+	}
+	return a.tags
+}
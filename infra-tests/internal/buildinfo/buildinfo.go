@@ -0,0 +1,100 @@
+// Package buildinfo reads the build metadata the pipeline records for a deployment and compares it with
+// what is deployed, so the suite can tell which commit it is validating and catch a function built for
+// one architecture but deployed as another.
+//
+// The pipeline records metadata either as function tags, set by Terraform from its build_sha, build_time,
+// and lambda_architecture variables, or as a JSON SSM parameter named by ParameterName:
+//
+//	{"gitSha": "d1e7014…", "architecture": "x86_64", "buildTime": "2024-05-01T12:00:00Z"}
+//
+// Tags win over the parameter, since they travel with the function they describe.
+package buildinfo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Tags the pipeline records build metadata under
+const (
+	TagGitSHA       = "GitSha"
+	TagArchitecture = "BuildArchitecture"
+	TagBuildTime    = "BuildTime"
+)
+
+// minCommitLength is the shortest abbreviated commit hash compared with a full one
+const minCommitLength = 7
+
+// Metadata describes the build a function was deployed from
+type Metadata struct {
+	GitSHA       string    `json:"gitSha"`
+	Architecture string    `json:"architecture"`
+	BuildTime    time.Time `json:"buildTime"`
+}
+
+// ParameterName is the SSM parameter a pipeline writes a deployment's build metadata to
+func ParameterName(baseName string) string {
+	return "/" + baseName + "/build-metadata"
+}
+
+// FromTags reads build metadata from function tags. It returns false when the function has no GitSha tag.
+func FromTags(tags map[string]string) (Metadata, bool, error) {
+	sha := tags[TagGitSHA]
+	if sha == "" {
+		return Metadata{}, false, nil
+	}
+	metadata := Metadata{GitSHA: sha, Architecture: tags[TagArchitecture]}
+	if value := tags[TagBuildTime]; value != "" {
+		built, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return Metadata{}, false, fmt.Errorf("parsing %s tag %q: %w", TagBuildTime, value, err)
+		}
+		metadata.BuildTime = built
+	}
+	return metadata, true, nil
+}
+
+// Parse reads build metadata from the value of the SSM parameter
+func Parse(value string) (Metadata, error) {
+	var metadata Metadata
+	if err := json.Unmarshal([]byte(value), &metadata); err != nil {
+		return Metadata{}, fmt.Errorf("parsing build metadata: %w", err)
+	}
+	if metadata.GitSHA == "" {
+		return Metadata{}, errors.New("build metadata has no gitSha")
+	}
+	return metadata, nil
+}
+
+// Gaps reports where a function differs from its build metadata and from what the suite expects: the
+// profile's architecture, the architecture the function is deployed as, and the expected commit, which
+// is only compared when set. Abbreviated commit hashes match the full hash they start.
+func (m Metadata) Gaps(deployedArchitecture, expectedArchitecture, expectedCommit string) []string {
+	var gaps []string
+	if m.Architecture == "" {
+		gaps = append(gaps, fmt.Sprintf("build metadata of commit %s records no architecture", m.GitSHA))
+	} else {
+		if m.Architecture != expectedArchitecture {
+			gaps = append(gaps, fmt.Sprintf("built for %s but the environment profile expects %s", m.Architecture, expectedArchitecture))
+		}
+		if m.Architecture != deployedArchitecture {
+			gaps = append(gaps, fmt.Sprintf("built for %s but deployed as %s", m.Architecture, deployedArchitecture))
+		}
+	}
+	if expectedCommit != "" && !SameCommit(m.GitSHA, expectedCommit) {
+		gaps = append(gaps, fmt.Sprintf("built from commit %s, expected %s", m.GitSHA, expectedCommit))
+	}
+	return gaps
+}
+
+// SameCommit reports whether two commit hashes, either of which may be abbreviated, name the same commit
+func SameCommit(a, b string) bool {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	if len(a) < minCommitLength || len(b) < minCommitLength {
+		return a == b
+	}
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
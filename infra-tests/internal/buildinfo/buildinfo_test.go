@@ -0,0 +1,66 @@
+package buildinfo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const commit = "d1e7014c5a0b9f3e2d4c6b8a0f1e3d5c7b9a1f2e"
+
+func TestFromTags(t *testing.T) {
+	metadata, ok, err := FromTags(map[string]string{
+		TagGitSHA:       commit,
+		TagArchitecture: "x86_64",
+		TagBuildTime:    "2024-05-01T12:00:00Z",
+	})
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, commit, metadata.GitSHA)
+	assert.Equal(t, "x86_64", metadata.Architecture)
+	assert.Equal(t, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), metadata.BuildTime)
+
+	_, ok, err = FromTags(map[string]string{"Project": "lambda-java-template"})
+	require.NoError(t, err)
+	assert.False(t, ok, "Untagged functions have no metadata")
+
+	_, _, err = FromTags(map[string]string{TagGitSHA: commit, TagBuildTime: "yesterday"})
+	assert.Error(t, err)
+}
+
+func TestParse(t *testing.T) {
+	metadata, err := Parse(`{"gitSha": "d1e7014", "architecture": "arm64", "buildTime": "2024-05-01T12:00:00Z"}`)
+	require.NoError(t, err)
+	assert.Equal(t, Metadata{GitSHA: "d1e7014", Architecture: "arm64", BuildTime: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}, metadata)
+
+	_, err = Parse(`{"architecture": "arm64"}`)
+	assert.Error(t, err, "Metadata must name a commit")
+	_, err = Parse(`d1e7014`)
+	assert.Error(t, err)
+}
+
+func TestParameterName(t *testing.T) {
+	assert.Equal(t, "/lambda-java-template-dev/build-metadata", ParameterName("lambda-java-template-dev"))
+}
+
+func TestGaps(t *testing.T) {
+	metadata := Metadata{GitSHA: commit, Architecture: "x86_64"}
+	assert.Empty(t, metadata.Gaps("x86_64", "x86_64", ""))
+	assert.Empty(t, metadata.Gaps("x86_64", "x86_64", "d1e7014"))
+
+	assert.Equal(t, []string{"built for x86_64 but the environment profile expects arm64", "built for x86_64 but deployed as arm64"},
+		metadata.Gaps("arm64", "arm64", ""))
+	assert.Equal(t, []string{"built from commit " + commit + ", expected 11664ba"}, metadata.Gaps("x86_64", "x86_64", "11664ba"))
+
+	assert.Equal(t, []string{"build metadata of commit d1e7014 records no architecture"},
+		Metadata{GitSHA: "d1e7014"}.Gaps("x86_64", "x86_64", ""))
+}
+
+func TestSameCommit(t *testing.T) {
+	assert.True(t, SameCommit(commit, "d1e7014"))
+	assert.True(t, SameCommit("D1E7014", commit))
+	assert.False(t, SameCommit(commit, "d1e"), "Too short to compare as a prefix")
+	assert.False(t, SameCommit(commit, "11664ba"))
+}
@@ -0,0 +1,81 @@
+package buildinfo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EnvVersion is the environment variable a function's application version is set in
+const EnvVersion = "APP_VERSION"
+
+// Sources a reported version is read from
+const (
+	SourceEnvironment = "env " + EnvVersion
+	SourceTag         = "tag " + TagGitSHA
+	SourceHealth      = "GET /health"
+)
+
+// Reported is the application version one function, or one endpoint serving it, reports
+type Reported struct {
+	Function string
+	Version  string
+	Source   string
+}
+
+func (r Reported) String() string {
+	return fmt.Sprintf("%s (%s)", r.Function, r.Source)
+}
+
+// FunctionVersion returns the version a function reports through its environment or, failing that, its
+// GitSha tag. It returns false when the function reports neither.
+func FunctionVersion(function string, environment, tags map[string]string) (Reported, bool) {
+	if version := environment[EnvVersion]; version != "" {
+		return Reported{Function: function, Version: version, Source: SourceEnvironment}, true
+	}
+	if version := tags[TagGitSHA]; version != "" {
+		return Reported{Function: function, Version: version, Source: SourceTag}, true
+	}
+	return Reported{}, false
+}
+
+// Skew groups reported versions that name different builds. It returns nil when every report names the
+// same build; abbreviated commit hashes match the full hash they start, so a tag and a /health body
+// agree whether or not either shortens the commit.
+func Skew(reported []Reported) map[string][]Reported {
+	groups := map[string][]Reported{}
+	for _, report := range reported {
+		version := report.Version
+		for existing := range groups {
+			if SameCommit(existing, version) {
+				version = existing
+				break
+			}
+		}
+		groups[version] = append(groups[version], report)
+	}
+	if len(groups) < 2 {
+		return nil
+	}
+	return groups
+}
+
+// DescribeSkew renders skewed versions one per line, each with the functions reporting it, in version order
+func DescribeSkew(groups map[string][]Reported) string {
+	versions := make([]string, 0, len(groups))
+	for version := range groups {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	lines := make([]string, 0, len(versions))
+	for _, version := range versions {
+		var reporters []string
+		for _, report := range groups[version] {
+			reporters = append(reporters, report.String())
+		}
+		sort.Strings(reporters)
+		lines = append(lines, fmt.Sprintf("%s: %s", version, strings.Join(reporters, ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,48 @@
+package buildinfo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunctionVersion(t *testing.T) {
+	reported, ok := FunctionVersion("product-service", map[string]string{EnvVersion: "11664ba"}, map[string]string{TagGitSHA: commit})
+	require.True(t, ok)
+	assert.Equal(t, Reported{Function: "product-service", Version: "11664ba", Source: SourceEnvironment}, reported,
+		"The environment is what the running code sees, so it wins over the tag")
+
+	reported, ok = FunctionVersion("authorizer-service", nil, map[string]string{TagGitSHA: commit})
+	require.True(t, ok)
+	assert.Equal(t, SourceTag, reported.Source)
+
+	_, ok = FunctionVersion("payment", map[string]string{"LOG_LEVEL": "INFO"}, nil)
+	assert.False(t, ok)
+}
+
+func TestSkew(t *testing.T) {
+	consistent := []Reported{
+		{Function: "product-service", Version: commit, Source: SourceEnvironment},
+		{Function: "authorizer-service", Version: commit, Source: SourceTag},
+		{Function: "product-service", Version: "d1e7014", Source: SourceHealth},
+	}
+	assert.Nil(t, Skew(consistent))
+	assert.Nil(t, Skew(nil))
+
+	mixed := append(consistent, Reported{Function: "authorizer-service", Version: "11664ba", Source: SourceEnvironment})
+	groups := Skew(mixed)
+	require.Len(t, groups, 2)
+	assert.Len(t, groups["11664ba"], 1)
+	assert.Equal(t, "11664ba: authorizer-service (env APP_VERSION)\n"+
+		commit+": authorizer-service (tag GitSha), product-service (GET /health), product-service (env APP_VERSION)",
+		DescribeSkew(groups))
+}
+
+func TestSkewComparesReleaseVersionsExactly(t *testing.T) {
+	groups := Skew([]Reported{
+		{Function: "product-service", Version: "1.4.0", Source: SourceEnvironment},
+		{Function: "authorizer-service", Version: "1.4.1", Source: SourceEnvironment},
+	})
+	assert.Len(t, groups, 2)
+}
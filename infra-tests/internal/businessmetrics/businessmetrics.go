@@ -0,0 +1,114 @@
+// Package businessmetrics reads the business metrics the workflow handlers publish in CloudWatch embedded metric
+// format (EMF) and works out how much each must have risen after a set of workflow executions, so a handler that
+// stops counting orders or declines fails the run instead of leaving a dashboard flat.
+//
+// The handlers write EMF records to their logs with Powertools for AWS Lambda (Java), into Namespace with the
+// single dimension EnvironmentDimension set to the deployment's environment; CloudWatch extracts the metrics
+// from the log records, usually within a few minutes.
+package businessmetrics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// Namespace the workflow handlers publish business metrics to
+const Namespace = "OrderProcessing"
+
+// EnvironmentDimension separates the metrics of each environment sharing an account
+const EnvironmentDimension = "Environment"
+
+// Counter is a business metric that rises by one for each execution ending in one of its terminal states
+type Counter struct {
+	Metric    string
+	Terminals []string
+}
+
+// Counters are the business metrics checked against workflow executions
+var Counters = []Counter{
+	{Metric: "OrdersProcessed", Terminals: []string{"OrderSuccess"}},
+	{Metric: "PaymentDeclines", Terminals: []string{"PaymentDeclined"}},
+}
+
+// MetricDataAPI is the CloudWatch operation used to read the counters
+type MetricDataAPI interface {
+	GetMetricData(ctx context.Context, params *cloudwatch.GetMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricDataOutput, error)
+}
+
+// Expected returns how much each counter must have risen after executions ending in terminal states, counted by
+// state. Counters no execution moved are left out, since nothing can be said about them.
+func Expected(reached map[string]int) map[string]float64 {
+	expected := map[string]float64{}
+	for _, counter := range Counters {
+		for _, terminal := range counter.Terminals {
+			if reached[terminal] > 0 {
+				expected[counter.Metric] += float64(reached[terminal])
+			}
+		}
+	}
+	return expected
+}
+
+// Sum returns the sum of each of Counters in an environment between start and end
+func Sum(ctx context.Context, api MetricDataAPI, environment string, start, end time.Time) (map[string]float64, error) {
+	queries := make([]cwtypes.MetricDataQuery, len(Counters))
+	for i, counter := range Counters {
+		queries[i] = cwtypes.MetricDataQuery{
+			Id: aws.String(strings.ToLower(counter.Metric)),
+			MetricStat: &cwtypes.MetricStat{
+				Metric: &cwtypes.Metric{
+					Namespace:  aws.String(Namespace),
+					MetricName: aws.String(counter.Metric),
+					Dimensions: []cwtypes.Dimension{{Name: aws.String(EnvironmentDimension), Value: aws.String(environment)}},
+				},
+				Period: aws.Int32(60),
+				Stat:   aws.String("Sum"),
+			},
+		}
+	}
+
+	sums := map[string]float64{}
+	paginator := cloudwatch.NewGetMetricDataPaginator(api, &cloudwatch.GetMetricDataInput{
+		StartTime:         aws.Time(start),
+		EndTime:           aws.Time(end),
+		MetricDataQueries: queries,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s metrics: %w", Namespace, err)
+		}
+		for _, result := range page.MetricDataResults {
+			for _, counter := range Counters {
+				if aws.ToString(result.Id) != strings.ToLower(counter.Metric) {
+					continue
+				}
+				for _, value := range result.Values {
+					sums[counter.Metric] += value
+				}
+			}
+		}
+	}
+	return sums, nil
+}
+
+// Shortfalls describes each counter that rose by less than expected. Other traffic may raise a counter further,
+// so only a shortfall is reported.
+func Shortfalls(expected, observed map[string]float64) []string {
+	var shortfalls []string
+	for _, counter := range Counters {
+		want, ok := expected[counter.Metric]
+		if !ok || observed[counter.Metric] >= want {
+			continue
+		}
+		shortfalls = append(shortfalls, fmt.Sprintf("%s/%s rose by %.0f, expected at least %.0f from executions ending in %s",
+			Namespace, counter.Metric, observed[counter.Metric], want, strings.Join(counter.Terminals, " or ")))
+	}
+	return shortfalls
+}
@@ -0,0 +1,59 @@
+package businessmetrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMetrics answers every query with values by query id and keeps the request
+type fakeMetrics struct {
+	values  map[string][]float64
+	request *cloudwatch.GetMetricDataInput
+}
+
+func (f *fakeMetrics) GetMetricData(ctx context.Context, params *cloudwatch.GetMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricDataOutput, error) {
+	f.request = params
+	output := &cloudwatch.GetMetricDataOutput{}
+	for _, query := range params.MetricDataQueries {
+		output.MetricDataResults = append(output.MetricDataResults, cwtypes.MetricDataResult{Id: query.Id, Values: f.values[aws.ToString(query.Id)]})
+	}
+	return output, nil
+}
+
+func TestExpected(t *testing.T) {
+	assert.Equal(t, map[string]float64{"OrdersProcessed": 2, "PaymentDeclines": 1},
+		Expected(map[string]int{"OrderSuccess": 2, "PaymentDeclined": 1, "ValidationFailed": 3}))
+	assert.Equal(t, map[string]float64{"OrdersProcessed": 1}, Expected(map[string]int{"OrderSuccess": 1}),
+		"a counter no execution moved is not expected to rise")
+}
+
+func TestSum(t *testing.T) {
+	api := &fakeMetrics{values: map[string][]float64{"ordersprocessed": {1, 2}, "paymentdeclines": {}}}
+	end := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	sums, err := Sum(context.Background(), api, "staging", end.Add(-time.Hour), end)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"OrdersProcessed": 3}, sums)
+
+	require.Len(t, api.request.MetricDataQueries, len(Counters))
+	metric := api.request.MetricDataQueries[0].MetricStat.Metric
+	assert.Equal(t, Namespace, aws.ToString(metric.Namespace))
+	assert.Equal(t, "OrdersProcessed", aws.ToString(metric.MetricName))
+	assert.Equal(t, []cwtypes.Dimension{{Name: aws.String(EnvironmentDimension), Value: aws.String("staging")}}, metric.Dimensions)
+}
+
+func TestShortfalls(t *testing.T) {
+	expected := map[string]float64{"OrdersProcessed": 2, "PaymentDeclines": 1}
+
+	assert.Empty(t, Shortfalls(expected, map[string]float64{"OrdersProcessed": 5, "PaymentDeclines": 1}), "other traffic may add to a counter")
+	assert.Equal(t, []string{"OrderProcessing/PaymentDeclines rose by 0, expected at least 1 from executions ending in PaymentDeclined"},
+		Shortfalls(expected, map[string]float64{"OrdersProcessed": 2}))
+	assert.Empty(t, Shortfalls(map[string]float64{}, map[string]float64{}))
+}
@@ -0,0 +1,124 @@
+// Package canary compares a function's health before and after a deployment.
+//
+// A window of equal length on each side of the deployment marker is collected from CloudWatch
+// (invocations, errors, p95 duration) and Lambda REPORT logs (cold starts), and the post-deploy
+// window fails when it degrades beyond the configured thresholds.
+package canary
+
+import (
+	"fmt"
+	"time"
+)
+
+// Window holds the metrics of one function over a time range
+type Window struct {
+	Start       time.Time
+	End         time.Time
+	Invocations float64
+	Errors      float64
+	// P95Duration is the 95th percentile duration in milliseconds
+	P95Duration float64
+	ColdStarts  float64
+}
+
+// ErrorRate returns errors per invocation
+func (w Window) ErrorRate() float64 {
+	if w.Invocations == 0 {
+		return 0
+	}
+	return w.Errors / w.Invocations
+}
+
+// ColdStartRate returns cold starts per invocation
+func (w Window) ColdStartRate() float64 {
+	if w.Invocations == 0 {
+		return 0
+	}
+	return w.ColdStarts / w.Invocations
+}
+
+// Thresholds bound how much the post-deploy window may degrade
+type Thresholds struct {
+	// MinInvocations is the traffic each window needs before it is compared
+	MinInvocations float64
+	// MaxErrorRateIncrease is the allowed rise in error rate, in absolute terms (0.01 is one percentage point)
+	MaxErrorRateIncrease float64
+	// MaxP95DurationRatio is the allowed ratio of post-deploy to pre-deploy p95 duration
+	MaxP95DurationRatio float64
+	// MaxColdStartRateIncrease is the allowed rise in cold starts per invocation, in absolute terms
+	MaxColdStartRateIncrease float64
+}
+
+// DefaultThresholds suit the template's Java functions, whose cold starts dominate tail latency
+var DefaultThresholds = Thresholds{
+	MinInvocations:           20,
+	MaxErrorRateIncrease:     0.01,
+	MaxP95DurationRatio:      1.25,
+	MaxColdStartRateIncrease: 0.05,
+}
+
+// Degradation is one metric that moved beyond its threshold
+type Degradation struct {
+	Metric string
+	Before float64
+	After  float64
+	Limit  float64
+}
+
+func (d Degradation) String() string {
+	return fmt.Sprintf("%s degraded from %.4g to %.4g (limit %.4g)", d.Metric, d.Before, d.After, d.Limit)
+}
+
+// Analysis is the outcome of comparing two windows
+type Analysis struct {
+	Before       Window
+	After        Window
+	Degradations []Degradation
+	// Inconclusive explains why the windows could not be compared; it is empty when they were
+	Inconclusive string
+}
+
+// Passed reports whether the windows were compared and nothing degraded
+func (a Analysis) Passed() bool {
+	return a.Inconclusive == "" && len(a.Degradations) == 0
+}
+
+// Compare checks the post-deploy window against the pre-deploy window
+func Compare(before, after Window, thresholds Thresholds) Analysis {
+	analysis := Analysis{Before: before, After: after}
+
+	if before.Invocations < thresholds.MinInvocations || after.Invocations < thresholds.MinInvocations {
+		analysis.Inconclusive = fmt.Sprintf("need %.0f invocations per window, got %.0f before and %.0f after",
+			thresholds.MinInvocations, before.Invocations, after.Invocations)
+		return analysis
+	}
+
+	if limit := before.ErrorRate() + thresholds.MaxErrorRateIncrease; after.ErrorRate() > limit {
+		analysis.Degradations = append(analysis.Degradations, Degradation{"error rate", before.ErrorRate(), after.ErrorRate(), limit})
+	}
+	// Without a pre-deploy duration there is no baseline to scale
+	if before.P95Duration > 0 {
+		if limit := before.P95Duration * thresholds.MaxP95DurationRatio; after.P95Duration > limit {
+			analysis.Degradations = append(analysis.Degradations, Degradation{"p95 duration (ms)", before.P95Duration, after.P95Duration, limit})
+		}
+	}
+	if limit := before.ColdStartRate() + thresholds.MaxColdStartRateIncrease; after.ColdStartRate() > limit {
+		analysis.Degradations = append(analysis.Degradations, Degradation{"cold start rate", before.ColdStartRate(), after.ColdStartRate(), limit})
+	}
+	return analysis
+}
+
+// Windows splits time around a deployment marker into a pre-deploy and post-deploy window of length.
+// The post-deploy window ends at now when the full length has not elapsed yet, and the pre-deploy
+// window is shortened to match so both cover the same amount of time.
+func Windows(marker time.Time, length time.Duration, now time.Time) (before, after Window) {
+	end := marker.Add(length)
+	if end.After(now) {
+		end = now
+	}
+	elapsed := end.Sub(marker)
+
+	before = Window{Start: marker.Add(-elapsed), End: marker}
+	after = Window{Start: marker, End: end}
+	return before, after
+}
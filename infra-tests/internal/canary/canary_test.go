@@ -0,0 +1,60 @@
+package canary
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	baseline := Window{Invocations: 1000, Errors: 5, P95Duration: 200, ColdStarts: 10}
+
+	tests := []struct {
+		name     string
+		after    Window
+		degraded []string
+	}{
+		{"Unchanged", Window{Invocations: 900, Errors: 4, P95Duration: 210, ColdStarts: 12}, nil},
+		{"Error spike", Window{Invocations: 1000, Errors: 40, P95Duration: 200, ColdStarts: 10}, []string{"error rate"}},
+		{"Slower tail", Window{Invocations: 1000, Errors: 5, P95Duration: 300, ColdStarts: 10}, []string{"p95 duration (ms)"}},
+		{"Cold start regression", Window{Invocations: 1000, Errors: 5, P95Duration: 200, ColdStarts: 100}, []string{"cold start rate"}},
+		{"Everything worse", Window{Invocations: 1000, Errors: 50, P95Duration: 500, ColdStarts: 100}, []string{"error rate", "p95 duration (ms)", "cold start rate"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := Compare(baseline, tt.after, DefaultThresholds)
+
+			var degraded []string
+			for _, degradation := range analysis.Degradations {
+				degraded = append(degraded, degradation.Metric)
+			}
+			assert.Equal(t, tt.degraded, degraded)
+			assert.Equal(t, tt.degraded == nil, analysis.Passed())
+		})
+	}
+}
+
+func TestCompareIsInconclusiveWithoutTraffic(t *testing.T) {
+	analysis := Compare(Window{Invocations: 500}, Window{Invocations: 3, Errors: 3}, DefaultThresholds)
+
+	assert.NotEmpty(t, analysis.Inconclusive)
+	assert.Empty(t, analysis.Degradations)
+	assert.False(t, analysis.Passed())
+}
+
+func TestWindows(t *testing.T) {
+	marker := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	before, after := Windows(marker, 30*time.Minute, marker.Add(2*time.Hour))
+	assert.Equal(t, marker.Add(-30*time.Minute), before.Start)
+	assert.Equal(t, marker, before.End)
+	assert.Equal(t, marker, after.Start)
+	assert.Equal(t, marker.Add(30*time.Minute), after.End)
+
+	// A deployment ten minutes ago only has ten minutes of post-deploy data to compare
+	before, after = Windows(marker, 30*time.Minute, marker.Add(10*time.Minute))
+	assert.Equal(t, marker.Add(-10*time.Minute), before.Start)
+	assert.Equal(t, marker.Add(10*time.Minute), after.End)
+}
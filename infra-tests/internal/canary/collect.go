@@ -0,0 +1,151 @@
+package canary
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.7 --name "^(MetricDataAPI|QueryAPI)$" --output mocks --outpkg mocks --with-expecter
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	logstypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+)
+
+// coldStartQuery counts invocations whose REPORT line carries an init duration
+const coldStartQuery = `filter @type = "REPORT" | stats count(@initDuration) as coldStarts`
+
+// MetricDataAPI is the CloudWatch operation used to read Lambda metrics
+type MetricDataAPI interface {
+	GetMetricData(ctx context.Context, params *cloudwatch.GetMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricDataOutput, error)
+}
+
+// QueryAPI is the CloudWatch Logs operations used to run Logs Insights queries
+type QueryAPI interface {
+	StartQuery(ctx context.Context, params *cloudwatchlogs.StartQueryInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.StartQueryOutput, error)
+	GetQueryResults(ctx context.Context, params *cloudwatchlogs.GetQueryResultsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.GetQueryResultsOutput, error)
+}
+
+// Collector fills windows with metrics for a function
+type Collector struct {
+	Metrics MetricDataAPI
+	Logs    QueryAPI
+	// PollInterval is how often Logs Insights results are checked
+	PollInterval time.Duration
+}
+
+// NewCollector returns a collector using real CloudWatch clients
+func NewCollector(metrics *cloudwatch.Client, logs *cloudwatchlogs.Client) Collector {
+	return Collector{Metrics: metrics, Logs: logs, PollInterval: time.Second}
+}
+
+// Collect reads the metrics of functionName over the window's time range
+func (c Collector) Collect(ctx context.Context, functionName string, window Window) (Window, error) {
+	// One datapoint per metric covers the whole window; periods must be whole minutes
+	period := int32(math.Ceil(window.End.Sub(window.Start).Minutes())) * 60
+	if period < 60 {
+		period = 60
+	}
+
+	dimensions := []cwtypes.Dimension{{Name: aws.String("FunctionName"), Value: aws.String(functionName)}}
+	query := func(id, metric, stat string) cwtypes.MetricDataQuery {
+		return cwtypes.MetricDataQuery{
+			Id: aws.String(id),
+			MetricStat: &cwtypes.MetricStat{
+				Metric: &cwtypes.Metric{Namespace: aws.String("AWS/Lambda"), MetricName: aws.String(metric), Dimensions: dimensions},
+				Period: aws.Int32(period),
+				Stat:   aws.String(stat),
+			},
+		}
+	}
+
+	output, err := c.Metrics.GetMetricData(ctx, &cloudwatch.GetMetricDataInput{
+		StartTime: aws.Time(window.Start),
+		EndTime:   aws.Time(window.End),
+		MetricDataQueries: []cwtypes.MetricDataQuery{
+			query("invocations", "Invocations", "Sum"),
+			query("errors", "Errors", "Sum"),
+			query("p95", "Duration", "p95"),
+		},
+	})
+	if err != nil {
+		return window, fmt.Errorf("reading metrics for %s: %w", functionName, err)
+	}
+
+	for _, result := range output.MetricDataResults {
+		switch aws.ToString(result.Id) {
+		case "invocations":
+			window.Invocations = sum(result.Values)
+		case "errors":
+			window.Errors = sum(result.Values)
+		case "p95":
+			window.P95Duration = largest(result.Values)
+		}
+	}
+
+	window.ColdStarts, err = c.coldStarts(ctx, functionName, window)
+	return window, err
+}
+
+// coldStarts counts cold starts in the function's log group with Logs Insights
+func (c Collector) coldStarts(ctx context.Context, functionName string, window Window) (float64, error) {
+	logGroup := fmt.Sprintf("/aws/lambda/%s", functionName)
+	started, err := c.Logs.StartQuery(ctx, &cloudwatchlogs.StartQueryInput{
+		LogGroupName: aws.String(logGroup),
+		QueryString:  aws.String(coldStartQuery),
+		StartTime:    aws.Int64(window.Start.Unix()),
+		EndTime:      aws.Int64(window.End.Unix()),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("starting cold start query on %s: %w", logGroup, err)
+	}
+
+	for {
+		results, err := c.Logs.GetQueryResults(ctx, &cloudwatchlogs.GetQueryResultsInput{QueryId: started.QueryId})
+		if err != nil {
+			return 0, err
+		}
+
+		switch results.Status {
+		case logstypes.QueryStatusComplete:
+			for _, row := range results.Results {
+				for _, field := range row {
+					if aws.ToString(field.Field) == "coldStarts" {
+						return strconv.ParseFloat(aws.ToString(field.Value), 64)
+					}
+				}
+			}
+			return 0, nil
+		case logstypes.QueryStatusFailed, logstypes.QueryStatusCancelled, logstypes.QueryStatusTimeout:
+			return 0, fmt.Errorf("cold start query on %s ended with status %s", logGroup, results.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(c.PollInterval):
+		}
+	}
+}
+
+// sum adds every datapoint
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+	return total
+}
+
+// largest returns the largest datapoint, or zero when there are none
+func largest(values []float64) float64 {
+	result := 0.0
+	for _, value := range values {
+		result = math.Max(result, value)
+	}
+	return result
+}
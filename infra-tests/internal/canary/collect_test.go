@@ -0,0 +1,56 @@
+package canary
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	logstypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/canary/mocks"
+)
+
+func TestCollect(t *testing.T) {
+	metrics := mocks.NewMetricDataAPI(t)
+	logs := mocks.NewQueryAPI(t)
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	metrics.EXPECT().GetMetricData(mock.Anything, mock.MatchedBy(func(in *cloudwatch.GetMetricDataInput) bool {
+		// A 30 minute window is read as a single 1800 second period
+		return len(in.MetricDataQueries) == 3 && *in.MetricDataQueries[0].MetricStat.Period == 1800
+	})).Return(&cloudwatch.GetMetricDataOutput{
+		MetricDataResults: []cwtypes.MetricDataResult{
+			{Id: aws.String("invocations"), Values: []float64{600, 400}},
+			{Id: aws.String("errors"), Values: []float64{7}},
+			{Id: aws.String("p95"), Values: []float64{180, 240}},
+		},
+	}, nil)
+
+	logs.EXPECT().StartQuery(mock.Anything, mock.MatchedBy(func(in *cloudwatchlogs.StartQueryInput) bool {
+		return *in.LogGroupName == "/aws/lambda/fn"
+	})).Return(&cloudwatchlogs.StartQueryOutput{QueryId: aws.String("q")}, nil)
+	logs.EXPECT().GetQueryResults(mock.Anything, mock.Anything).Return(&cloudwatchlogs.GetQueryResultsOutput{
+		Status: logstypes.QueryStatusRunning,
+	}, nil).Once()
+	logs.EXPECT().GetQueryResults(mock.Anything, mock.Anything).Return(&cloudwatchlogs.GetQueryResultsOutput{
+		Status:  logstypes.QueryStatusComplete,
+		Results: [][]logstypes.ResultField{{{Field: aws.String("coldStarts"), Value: aws.String("12")}}},
+	}, nil).Once()
+
+	collector := Collector{Metrics: metrics, Logs: logs, PollInterval: time.Millisecond}
+	window, err := collector.Collect(context.TODO(), "fn", Window{Start: start, End: start.Add(30 * time.Minute)})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1000.0, window.Invocations)
+	assert.Equal(t, 7.0, window.Errors)
+	assert.Equal(t, 240.0, window.P95Duration)
+	assert.Equal(t, 12.0, window.ColdStarts)
+	assert.Equal(t, start, window.Start)
+}
@@ -0,0 +1,295 @@
+// Package cassette records the suite's HTTP traffic to AWS and the deployed API and replays it later, so
+// read-only validators can run in CI without credentials or a deployment. In record mode every exchange is
+// sent and kept; in replay mode nothing is sent and each request is answered with the response recorded for it.
+//
+// Requests are matched by method, URL, X-Amz-Target header, and body; signatures, dates, and other headers
+// are ignored. A request with no exact match is matched again with every digit masked, since time ranges and
+// other numbers in request bodies change from run to run. Responses to the same request are replayed in the
+// order they were recorded, and the last one is repeated once they run out, so polling loops end as they did.
+// Secrets and account IDs in responses are masked before a cassette is saved, so it can be committed.
+package cassette
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode selects whether traffic is sent, recorded, or replayed
+type Mode string
+
+// Modes
+const (
+	Off    Mode = "off"
+	Record Mode = "record"
+	Replay Mode = "replay"
+)
+
+// ParseMode returns the mode a -cassette-mode flag value names
+func ParseMode(name string) (Mode, error) {
+	switch mode := Mode(name); mode {
+	case Off, Record, Replay:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("cassette mode must be %s, %s, or %s, got %q", Off, Record, Replay, name)
+	}
+}
+
+// ErrNotRecorded is returned in replay mode for a request the cassette has no response for
+var ErrNotRecorded = errors.New("request not recorded in cassette")
+
+// droppedHeaders are response headers not worth keeping: they change on every call and nothing reads them
+var droppedHeaders = []string{"Date", "Set-Cookie", "Content-Length"}
+
+// digits masks numbers for the fallback match
+var digits = regexp.MustCompile(`[0-9]+`)
+
+// Interaction is one recorded request and its response
+type Interaction struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Target string `json:"target,omitempty"`
+	// BodyHash is the SHA-256 of the request body; bodies are not kept, since they can be long
+	BodyHash string `json:"bodyHash"`
+	// MaskedBodyHash is the SHA-256 of the request body with its digits masked
+	MaskedBodyHash string `json:"maskedBodyHash"`
+
+	Status  int                 `json:"status"`
+	Headers map[string][]string `json:"headers,omitempty"`
+	Body    string              `json:"body"`
+	// Base64 marks a Body that is not UTF-8 and was encoded
+	Base64 bool `json:"base64,omitempty"`
+}
+
+// key identifies the request of an interaction exactly
+func (i Interaction) key() string {
+	return strings.Join([]string{i.Method, i.URL, i.Target, i.BodyHash}, " ")
+}
+
+// maskedKey identifies the request of an interaction with digits masked
+func (i Interaction) maskedKey() string {
+	return digits.ReplaceAllString(strings.Join([]string{i.Method, i.URL, i.Target}, " "), "0") + " " + i.MaskedBodyHash
+}
+
+// file is the layout of a cassette
+type file struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that records or replays a cassette. It is safe for concurrent use.
+type Recorder struct {
+	mode      Mode
+	path      string
+	transport http.RoundTripper
+
+	mu            sync.Mutex
+	recorded      []Interaction
+	exact, masked responses
+}
+
+// responses are the recorded responses by request key: those not yet replayed, and the last one replayed
+type responses struct {
+	pending map[string][]Interaction
+	last    map[string]Interaction
+}
+
+// add queues a recorded response to key
+func (q responses) add(key string, interaction Interaction) {
+	q.pending[key] = append(q.pending[key], interaction)
+}
+
+// take returns the next response to key, or the last one again once every response has been replayed
+func (q responses) take(key string) (Interaction, bool) {
+	if pending := q.pending[key]; len(pending) > 0 {
+		q.pending[key] = pending[1:]
+		q.last[key] = pending[0]
+		return pending[0], true
+	}
+	last, ok := q.last[key]
+	return last, ok
+}
+
+// newResponses returns an empty set of responses
+func newResponses() responses {
+	return responses{pending: map[string][]Interaction{}, last: map[string]Interaction{}}
+}
+
+// Open returns a recorder for the cassette at path. In record mode transport sends each request and the cassette
+// is written by Save; in replay mode the cassette must exist and transport is never used.
+func Open(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{mode: mode, path: path, transport: transport, exact: newResponses(), masked: newResponses()}
+	if mode != Replay {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette: %w; record it with -cassette-mode record", err)
+	}
+	var parsed file
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("parsing cassette %s: %w", path, err)
+	}
+	for _, interaction := range parsed.Interactions {
+		r.exact.add(interaction.key(), interaction)
+		r.masked.add(interaction.maskedKey(), interaction)
+	}
+	return r, nil
+}
+
+// Mode returns the recorder's mode
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// RoundTrip sends and records req, or answers it from the cassette
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	interaction := Interaction{
+		Method:         req.Method,
+		URL:            normalizeURL(req),
+		Target:         req.Header.Get("X-Amz-Target"),
+		BodyHash:       hash(body),
+		MaskedBodyHash: hash(digits.ReplaceAll(body, []byte("0"))),
+	}
+
+	if r.mode == Replay {
+		recorded, ok := r.next(interaction)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s %s %s", ErrNotRecorded, interaction.Method, interaction.URL, interaction.Target)
+		}
+		return recorded.response(req)
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil || r.mode != Record {
+		return resp, err
+	}
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	interaction.Status = resp.StatusCode
+	interaction.Headers = map[string][]string{}
+	for name, values := range resp.Header {
+		interaction.Headers[name] = values
+	}
+	for _, name := range droppedHeaders {
+		delete(interaction.Headers, name)
+	}
+	if utf8.Valid(responseBody) {
+		interaction.Body = string(responseBody)
+	} else {
+		interaction.Body = base64.StdEncoding.EncodeToString(responseBody)
+		interaction.Base64 = true
+	}
+
+	r.mu.Lock()
+	r.recorded = append(r.recorded, interaction)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// next takes the response recorded for request, trying an exact match before a masked one
+func (r *Recorder) next(request Interaction) (Interaction, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if interaction, ok := r.exact.take(request.key()); ok {
+		return interaction, true
+	}
+	return r.masked.take(request.maskedKey())
+}
+
+// response rebuilds the recorded response to req
+func (i Interaction) response(req *http.Request) (*http.Response, error) {
+	body := []byte(i.Body)
+	if i.Base64 {
+		var err error
+		if body, err = base64.StdEncoding.DecodeString(i.Body); err != nil {
+			return nil, fmt.Errorf("decoding recorded response to %s %s: %w", i.Method, i.URL, err)
+		}
+	}
+	header := http.Header{}
+	for name, values := range i.Headers {
+		header[name] = values
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.Status, http.StatusText(i.Status)),
+		StatusCode:    i.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// Save writes the recorded interactions to the cassette, redacted and sorted so a re-recording diffs cleanly;
+// it does nothing unless recording
+func (r *Recorder) Save() error {
+	if r.mode != Record {
+		return nil
+	}
+	r.mu.Lock()
+	interactions := make([]Interaction, len(r.recorded))
+	for n, interaction := range r.recorded {
+		interactions[n] = redact(interaction)
+	}
+	r.mu.Unlock()
+	// Stable, so responses to the same request keep the order they were received in
+	sort.SliceStable(interactions, func(a, b int) bool { return interactions[a].key() < interactions[b].key() })
+
+	data, err := json.MarshalIndent(file{Interactions: interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// normalizeURL returns the request URL with its query parameters sorted
+func normalizeURL(req *http.Request) string {
+	u := *req.URL
+	u.RawQuery = u.Query().Encode()
+	if u.Host == "" {
+		u.Host = req.Host
+	}
+	return u.String()
+}
+
+// hash returns the hex SHA-256 of data
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,116 @@
+package cassette
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// send posts body to url through recorder with an AWS JSON target and returns the response status and body
+func send(t *testing.T, recorder http.RoundTripper, url, target, body string) (int, string, error) {
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("X-Amz-Target", target)
+	req.Header.Set("X-Amz-Date", "20260101T000000Z")
+
+	resp, err := (&http.Client{Transport: recorder}).Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(data), nil
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		if r.Header.Get("X-Amz-Target") == "DynamoDB_20120810.DescribeTable" {
+			fmt.Fprintf(w, `{"call":%d,"request":%s}`, n, body)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte{0xff, 0xfe})
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "smoke-dev.json")
+	recorder, err := Open(path, Record, http.DefaultTransport)
+	require.NoError(t, err)
+
+	status, body, err := send(t, recorder, server.URL, "DynamoDB_20120810.DescribeTable", `{"TableName":"products"}`)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, `{"call":1,"request":{"TableName":"products"}}`, body, "the request body is still sent")
+	_, _, err = send(t, recorder, server.URL, "DynamoDB_20120810.DescribeTable", `{"TableName":"products"}`)
+	require.NoError(t, err)
+	_, _, err = send(t, recorder, server.URL, "DynamoDB_20120810.DescribeTable", `{"TableName":"audit-1700000000"}`)
+	require.NoError(t, err)
+	_, _, err = send(t, recorder, server.URL, "Other.Operation", `{}`)
+	require.NoError(t, err)
+	require.NoError(t, recorder.Save())
+
+	replay, err := Open(path, Replay, nil)
+	require.NoError(t, err)
+	before := calls.Load()
+
+	_, body, err = send(t, replay, server.URL, "DynamoDB_20120810.DescribeTable", `{"TableName":"products"}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"call":1,"request":{"TableName":"products"}}`, body)
+	_, body, err = send(t, replay, server.URL, "DynamoDB_20120810.DescribeTable", `{"TableName":"products"}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"call":2,"request":{"TableName":"products"}}`, body, "responses are replayed in the order they were recorded")
+	_, body, err = send(t, replay, server.URL, "DynamoDB_20120810.DescribeTable", `{"TableName":"products"}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"call":2,"request":{"TableName":"products"}}`, body, "the last response is repeated once they run out")
+
+	_, body, err = send(t, replay, server.URL, "DynamoDB_20120810.DescribeTable", `{"TableName":"audit-1800000000"}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"call":3,"request":{"TableName":"audit-1700000000"}}`, body, "numbers may differ from the recording")
+
+	status, body, err = send(t, replay, server.URL, "Other.Operation", `{}`)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, string([]byte{0xff, 0xfe}), body, "bodies that are not UTF-8 survive the round trip")
+
+	_, _, err = send(t, replay, server.URL, "DynamoDB_20120810.DescribeTable", `{"TableName":"orders"}`)
+	assert.ErrorIs(t, err, ErrNotRecorded)
+	assert.ErrorContains(t, err, "DynamoDB_20120810.DescribeTable")
+
+	assert.Equal(t, before, calls.Load(), "nothing is sent while replaying")
+}
+
+func TestOpenReplayNeedsACassette(t *testing.T) {
+	_, err := Open(filepath.Join(t.TempDir(), "missing.json"), Replay, nil)
+	assert.ErrorContains(t, err, "record it with -cassette-mode record")
+}
+
+func TestSaveOnlyWritesRecordings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "smoke-dev.json")
+	recorder, err := Open(path, Off, http.DefaultTransport)
+	require.NoError(t, err)
+	require.NoError(t, recorder.Save())
+	assert.NoFileExists(t, path)
+}
+
+func TestParseMode(t *testing.T) {
+	for _, name := range []string{"off", "record", "replay"} {
+		mode, err := ParseMode(name)
+		require.NoError(t, err)
+		assert.Equal(t, Mode(name), mode)
+	}
+	_, err := ParseMode("playback")
+	assert.EqualError(t, err, `cassette mode must be off, record, or replay, got "playback"`)
+}
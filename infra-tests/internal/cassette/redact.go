@@ -0,0 +1,91 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces secret values in a saved cassette
+const Redacted = "REDACTED"
+
+// RedactedAccountID replaces every AWS account ID in a saved cassette. Requests built from a redacted response
+// still match their recording, since the masked match ignores digits.
+const RedactedAccountID = "000000000000"
+
+// numbers finds the runs of digits an account ID may be; dots are included so decimals are left alone
+var numbers = regexp.MustCompile(`[0-9.]+`)
+
+// redact returns an interaction with the secrets of its response masked: presigned code locations and
+// environment variable values of functions, SSM parameter values, Secrets Manager secrets, and account IDs.
+// Bodies that are not JSON only have their account IDs masked.
+func redact(i Interaction) Interaction {
+	i.URL = redactAccountIDs(i.URL)
+	if i.Headers != nil {
+		headers := make(map[string][]string, len(i.Headers))
+		for name, values := range i.Headers {
+			masked := make([]string, len(values))
+			for n, value := range values {
+				masked[n] = redactAccountIDs(value)
+			}
+			headers[name] = masked
+		}
+		i.Headers = headers
+	}
+	if i.Base64 {
+		return i
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(i.Body))
+	decoder.UseNumber()
+	var document any
+	if err := decoder.Decode(&document); err == nil && !decoder.More() {
+		var out bytes.Buffer
+		encoder := json.NewEncoder(&out)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(redactValue(document, "")); err == nil {
+			i.Body = strings.TrimSuffix(out.String(), "\n")
+		}
+	}
+	i.Body = redactAccountIDs(i.Body)
+	return i
+}
+
+// redactValue masks the secrets in a decoded JSON value found under the key parent
+func redactValue(value any, parent string) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, child := range value {
+			switch {
+			case parent == "Code" && key == "Location",
+				(parent == "Parameter" || parent == "Parameters") && key == "Value",
+				key == "SecretString" || key == "SecretBinary":
+				value[key] = Redacted
+			case parent == "Environment" && key == "Variables":
+				if variables, ok := child.(map[string]any); ok {
+					for name := range variables {
+						variables[name] = Redacted
+					}
+				}
+			default:
+				value[key] = redactValue(child, key)
+			}
+		}
+	case []any:
+		for n, child := range value {
+			value[n] = redactValue(child, parent)
+		}
+	}
+	return value
+}
+
+// redactAccountIDs replaces every standalone 12-digit number in s with RedactedAccountID
+func redactAccountIDs(s string) string {
+	return numbers.ReplaceAllStringFunc(s, func(number string) string {
+		if len(number) == 12 && !strings.Contains(number, ".") {
+			return RedactedAccountID
+		}
+		return number
+	})
+}
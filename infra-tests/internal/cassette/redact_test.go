@@ -0,0 +1,89 @@
+package cassette
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	redacted := redact(Interaction{
+		URL:     "https://sqs.us-east-1.amazonaws.com/481516234200/orders",
+		Headers: map[string][]string{"X-Amz-Function-Arn": {"arn:aws:lambda:us-east-1:481516234200:function:f"}},
+		Body: `{"Parameters":[{"Name":"/app/db-password","Value":"hunter2"}],"Parameter":{"Value":"s3cret"},` +
+			`"SecretString":"{\"key\":\"value\"}","Configuration":{"Environment":{"Variables":{"API_KEY":"abc"}}},` +
+			`"Code":{"Location":"https://bucket.s3.amazonaws.com/code?X-Amz-Signature=1"},"Sum":123456789012.5,"Count":1700000000000}`,
+	})
+	assert.Equal(t, "https://sqs.us-east-1.amazonaws.com/000000000000/orders", redacted.URL)
+	assert.Equal(t, []string{"arn:aws:lambda:us-east-1:000000000000:function:f"}, redacted.Headers["X-Amz-Function-Arn"])
+	assert.JSONEq(t, `{"Parameters":[{"Name":"/app/db-password","Value":"REDACTED"}],"Parameter":{"Value":"REDACTED"},`+
+		`"SecretString":"REDACTED","Configuration":{"Environment":{"Variables":{"API_KEY":"REDACTED"}}},`+
+		`"Code":{"Location":"REDACTED"},"Sum":123456789012.5,"Count":1700000000000}`, redacted.Body,
+		"secrets are masked; names, decimals, and longer numbers are kept")
+
+	xml := redact(Interaction{Body: "<Arn>arn:aws:sns:us-east-1:481516234200:alerts</Arn>"})
+	assert.Equal(t, "<Arn>arn:aws:sns:us-east-1:000000000000:alerts</Arn>", xml.Body, "bodies that are not JSON have their account IDs masked")
+
+	binary := Interaction{Body: "NDgxNTE2MjM0MjAw", Base64: true}
+	assert.Equal(t, binary, redact(binary), "encoded bodies are left alone")
+}
+
+func TestSaveRedacts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "smoke-dev.json")
+	recorder, err := Open(path, Record, nil)
+	require.NoError(t, err)
+	recorder.recorded = []Interaction{{Method: http.MethodPost, Body: `{"Parameter":{"Value":"s3cret"}}`}}
+	require.NoError(t, recorder.Save())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "s3cret")
+}
+
+// TestReplayFixture replays a redacted GetFunction recording through the Lambda client, as the suite does in CI
+func TestReplayFixture(t *testing.T) {
+	recorder, err := Open("testdata/lambda-get-function.json", Replay, nil)
+	require.NoError(t, err)
+	client := lambda.NewFromConfig(aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("replay", "replay", ""),
+		HTTPClient:  &http.Client{Transport: recorder},
+	})
+
+	function, err := client.GetFunction(context.TODO(), &lambda.GetFunctionInput{
+		FunctionName: aws.String("lambda-java-template-dev-product-service"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Redacted, aws.ToString(function.Code.Location))
+	assert.Equal(t, "arn:aws:lambda:us-east-1:000000000000:function:lambda-java-template-dev-product-service", aws.ToString(function.Configuration.FunctionArn))
+	assert.Equal(t, Redacted, function.Configuration.Environment.Variables["PRODUCTS_TABLE_NAME"], "variable names are kept, values are not")
+	assert.Equal(t, int32(512), aws.ToInt32(function.Configuration.MemorySize))
+}
+
+// TestCommittedCassettesAreRedacted fails when a cassette holding secrets or account IDs is committed
+func TestCommittedCassettesAreRedacted(t *testing.T) {
+	fixtures, err := filepath.Glob("testdata/*.json")
+	require.NoError(t, err)
+	recordings, err := filepath.Glob("../../testdata/cassettes/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, fixtures)
+
+	for _, path := range append(fixtures, recordings...) {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var parsed file
+		require.NoError(t, json.Unmarshal(data, &parsed), path)
+		for _, interaction := range parsed.Interactions {
+			assert.Equal(t, redact(interaction), interaction, "%s: %s %s is not redacted; record it again", path, interaction.Method, interaction.URL)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Package chaos injects failures into the dependencies of the order workflow and undoes them.
+//
+// Each fault changes one deployed resource and returns a Restore that puts back exactly what it
+// found, so a failed test leaves the environment as it was once its cleanups have run.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// DenyPolicyName is the inline policy attached to a role to cut it off from every AWS API
+const DenyPolicyName = "infra-tests-chaos-deny-all"
+
+// DenyAllPolicy denies every action on every resource; an explicit deny wins over any allow
+const DenyAllPolicy = `{"Version":"2012-10-17","Statement":[{"Sid":"InfraTestsChaos","Effect":"Deny","Action":"*","Resource":"*"}]}`
+
+// DegradedStates are the terminal states an order may end in while a dependency of the payment step is broken:
+// the payment function reporting a decline, or the workflow catching the failure
+var DegradedStates = []string{"PaymentDeclined", "ProcessingFailed"}
+
+// FailureModeVariable is the environment variable the workflow handlers read to fail on purpose.
+// A handler declares it, empty, to show it supports failure modes, acts on the modes that concern it,
+// and ignores the rest, so one mode can be set on every function of the workflow at once.
+const FailureModeVariable = "FAILURE_MODE"
+
+// Failure modes of FailureModeVariable
+const (
+	// FailureModeInventoryUnavailable makes the inventory handler report every item out of stock
+	FailureModeInventoryUnavailable = "inventory-unavailable"
+	// FailureModePaymentDeclined makes the payment handler decline every payment
+	FailureModePaymentDeclined = "payment-declined"
+	// FailureModePaymentError makes the payment handler throw, as if its payment provider were down
+	FailureModePaymentError = "payment-error"
+	// FailureModePaymentSlow makes the payment handler sleep until its own Lambda timeout, as if its payment
+	// provider accepted the connection and never answered
+	FailureModePaymentSlow = "payment-slow"
+)
+
+// WithFailureMode returns a copy of variables with FailureModeVariable set to mode
+func WithFailureMode(variables map[string]string, mode string) map[string]string {
+	updated := make(map[string]string, len(variables)+1)
+	for name, value := range variables {
+		updated[name] = value
+	}
+	updated[FailureModeVariable] = mode
+	return updated
+}
+
+// Restore undoes a fault
+type Restore func(ctx context.Context) error
+
+// ConcurrencyAPI is the part of the Lambda API used to change a function's reserved concurrency
+type ConcurrencyAPI interface {
+	GetFunctionConcurrency(ctx context.Context, params *lambda.GetFunctionConcurrencyInput, optFns ...func(*lambda.Options)) (*lambda.GetFunctionConcurrencyOutput, error)
+	PutFunctionConcurrency(ctx context.Context, params *lambda.PutFunctionConcurrencyInput, optFns ...func(*lambda.Options)) (*lambda.PutFunctionConcurrencyOutput, error)
+	DeleteFunctionConcurrency(ctx context.Context, params *lambda.DeleteFunctionConcurrencyInput, optFns ...func(*lambda.Options)) (*lambda.DeleteFunctionConcurrencyOutput, error)
+}
+
+// ReserveConcurrency sets a function's reserved concurrency; 0 throttles every invocation.
+// The Restore puts back the previous reservation, or removes it when the function had none.
+func ReserveConcurrency(ctx context.Context, client ConcurrencyAPI, functionName string, reserved int32) (Restore, error) {
+	current, err := client.GetFunctionConcurrency(ctx, &lambda.GetFunctionConcurrencyInput{FunctionName: aws.String(functionName)})
+	if err != nil {
+		return nil, fmt.Errorf("reading reserved concurrency of %s: %w", functionName, err)
+	}
+	previous := current.ReservedConcurrentExecutions
+
+	_, err = client.PutFunctionConcurrency(ctx, &lambda.PutFunctionConcurrencyInput{
+		FunctionName:                 aws.String(functionName),
+		ReservedConcurrentExecutions: aws.Int32(reserved),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reserving %d concurrent executions for %s: %w", reserved, functionName, err)
+	}
+
+	return func(ctx context.Context) error {
+		if previous == nil {
+			_, err := client.DeleteFunctionConcurrency(ctx, &lambda.DeleteFunctionConcurrencyInput{FunctionName: aws.String(functionName)})
+			var notFound *lambdatypes.ResourceNotFoundException
+			if err != nil && !errors.As(err, &notFound) {
+				return fmt.Errorf("removing reserved concurrency of %s: %w", functionName, err)
+			}
+			return nil
+		}
+		_, err := client.PutFunctionConcurrency(ctx, &lambda.PutFunctionConcurrencyInput{
+			FunctionName:                 aws.String(functionName),
+			ReservedConcurrentExecutions: previous,
+		})
+		if err != nil {
+			return fmt.Errorf("restoring %d reserved concurrent executions for %s: %w", aws.ToInt32(previous), functionName, err)
+		}
+		return nil
+	}, nil
+}
+
+// FunctionForState returns the function a state invokes, given the references of asl.LambdaReferences,
+// with any version or alias qualifier removed. It returns "" when no function is invoked by the state.
+func FunctionForState(references map[string][]string, state string) string {
+	functions := make([]string, 0, len(references))
+	for function := range references {
+		functions = append(functions, function)
+	}
+	sort.Strings(functions)
+
+	for _, function := range functions {
+		for _, name := range references[function] {
+			if name == state || strings.HasSuffix(name, "/"+state) {
+				return unqualified(function)
+			}
+		}
+	}
+	return ""
+}
+
+// Functions returns, sorted and without qualifiers, every function in the references of asl.LambdaReferences
+func Functions(references map[string][]string) []string {
+	seen := map[string]bool{}
+	var functions []string
+	for function := range references {
+		if name := unqualified(function); !seen[name] {
+			seen[name] = true
+			functions = append(functions, name)
+		}
+	}
+	sort.Strings(functions)
+	return functions
+}
+
+// unqualified strips the version or alias from a function ARN; names and other values are returned unchanged
+func unqualified(function string) string {
+	parsed, err := arn.Parse(function)
+	if err != nil || parsed.Service != "lambda" {
+		return function
+	}
+	parts := strings.Split(parsed.Resource, ":")
+	if len(parts) > 2 {
+		parsed.Resource = strings.Join(parts[:2], ":")
+	}
+	return parsed.String()
+}
+
+// RoleName returns the name of a role from its ARN, dropping any path
+func RoleName(roleArn string) (string, error) {
+	parsed, err := arn.Parse(roleArn)
+	if err != nil || !strings.HasPrefix(parsed.Resource, "role/") {
+		return "", fmt.Errorf("%q is not an IAM role ARN", roleArn)
+	}
+	return parsed.Resource[strings.LastIndex(parsed.Resource, "/")+1:], nil
+}
+
+// DegradationGaps describes how an order run under a fault failed to degrade gracefully: ending anywhere but
+// a degraded state, or being confirmed to the customer. notificationTypes are the types of the notifications
+// published for the order.
+func DegradationGaps(terminal string, notificationTypes []string, confirmationType string) []string {
+	var gaps []string
+	degraded := false
+	for _, state := range DegradedStates {
+		degraded = degraded || terminal == state
+	}
+	if !degraded {
+		gaps = append(gaps, fmt.Sprintf("order ended in %q, expected one of %v", terminal, DegradedStates))
+	}
+	for _, notificationType := range notificationTypes {
+		if notificationType == confirmationType {
+			gaps = append(gaps, fmt.Sprintf("order was sent %s although it did not complete", confirmationType))
+			break
+		}
+	}
+	return gaps
+}
@@ -0,0 +1,94 @@
+package chaos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConcurrency keeps one function's reserved concurrency in memory
+type fakeConcurrency struct {
+	reserved *int32
+}
+
+func (f *fakeConcurrency) GetFunctionConcurrency(ctx context.Context, params *lambda.GetFunctionConcurrencyInput, optFns ...func(*lambda.Options)) (*lambda.GetFunctionConcurrencyOutput, error) {
+	return &lambda.GetFunctionConcurrencyOutput{ReservedConcurrentExecutions: f.reserved}, nil
+}
+
+func (f *fakeConcurrency) PutFunctionConcurrency(ctx context.Context, params *lambda.PutFunctionConcurrencyInput, optFns ...func(*lambda.Options)) (*lambda.PutFunctionConcurrencyOutput, error) {
+	f.reserved = aws.Int32(aws.ToInt32(params.ReservedConcurrentExecutions))
+	return &lambda.PutFunctionConcurrencyOutput{ReservedConcurrentExecutions: f.reserved}, nil
+}
+
+func (f *fakeConcurrency) DeleteFunctionConcurrency(ctx context.Context, params *lambda.DeleteFunctionConcurrencyInput, optFns ...func(*lambda.Options)) (*lambda.DeleteFunctionConcurrencyOutput, error) {
+	f.reserved = nil
+	return &lambda.DeleteFunctionConcurrencyOutput{}, nil
+}
+
+func TestReserveConcurrency(t *testing.T) {
+	unreserved := &fakeConcurrency{}
+	restore, err := ReserveConcurrency(context.Background(), unreserved, "payment", 0)
+	require.NoError(t, err)
+	assert.Equal(t, aws.Int32(0), unreserved.reserved)
+	require.NoError(t, restore(context.Background()))
+	assert.Nil(t, unreserved.reserved, "A function without a reservation gets none back")
+
+	reserved := &fakeConcurrency{reserved: aws.Int32(25)}
+	restore, err = ReserveConcurrency(context.Background(), reserved, "payment", 0)
+	require.NoError(t, err)
+	assert.Equal(t, aws.Int32(0), reserved.reserved)
+	require.NoError(t, restore(context.Background()))
+	assert.Equal(t, aws.Int32(25), reserved.reserved)
+}
+
+func TestWithFailureMode(t *testing.T) {
+	original := map[string]string{"TABLE_NAME": "orders", FailureModeVariable: ""}
+	updated := WithFailureMode(original, FailureModePaymentDeclined)
+
+	assert.Equal(t, map[string]string{"TABLE_NAME": "orders", FailureModeVariable: "payment-declined"}, updated)
+	assert.Empty(t, original[FailureModeVariable], "The original variables are left untouched")
+	assert.Equal(t, map[string]string{FailureModeVariable: "inventory-unavailable"}, WithFailureMode(nil, FailureModeInventoryUnavailable))
+}
+
+func TestFunctionReferences(t *testing.T) {
+	references := map[string][]string{
+		"arn:aws:lambda:us-east-1:123456789012:function:app-dev-validate":          {"ValidateOrder"},
+		"arn:aws:lambda:us-east-1:123456789012:function:app-dev-payment:live":      {"ProcessPayment"},
+		"arn:aws:lambda:us-east-1:123456789012:function:app-dev-inventory:$LATEST": {"ReserveItems/ReserveItem"},
+	}
+
+	assert.Equal(t, "arn:aws:lambda:us-east-1:123456789012:function:app-dev-payment", FunctionForState(references, "ProcessPayment"))
+	assert.Equal(t, "arn:aws:lambda:us-east-1:123456789012:function:app-dev-inventory", FunctionForState(references, "ReserveItem"))
+	assert.Equal(t, "arn:aws:lambda:us-east-1:123456789012:function:app-dev-validate", FunctionForState(references, "ValidateOrder"))
+	assert.Empty(t, FunctionForState(references, "SendNotification"))
+
+	references["arn:aws:lambda:us-east-1:123456789012:function:app-dev-payment"] = []string{"RefundPayment"}
+	assert.Equal(t, []string{
+		"arn:aws:lambda:us-east-1:123456789012:function:app-dev-inventory",
+		"arn:aws:lambda:us-east-1:123456789012:function:app-dev-payment",
+		"arn:aws:lambda:us-east-1:123456789012:function:app-dev-validate",
+	}, Functions(references))
+}
+
+func TestRoleName(t *testing.T) {
+	name, err := RoleName("arn:aws:iam::123456789012:role/service-role/app-dev-payment-role")
+	require.NoError(t, err)
+	assert.Equal(t, "app-dev-payment-role", name)
+
+	_, err = RoleName("arn:aws:iam::123456789012:user/alice")
+	assert.Error(t, err)
+}
+
+func TestDegradationGaps(t *testing.T) {
+	assert.Empty(t, DegradationGaps("PaymentDeclined", nil, "ORDER_CONFIRMATION"))
+	assert.Empty(t, DegradationGaps("ProcessingFailed", []string{"ORDER_FAILED"}, "ORDER_CONFIRMATION"))
+
+	assert.Equal(t, []string{
+		`order ended in "OrderSuccess", expected one of [PaymentDeclined ProcessingFailed]`,
+		"order was sent ORDER_CONFIRMATION although it did not complete",
+	}, DegradationGaps("OrderSuccess", []string{"ORDER_CONFIRMATION"}, "ORDER_CONFIRMATION"))
+}
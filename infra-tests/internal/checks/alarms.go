@@ -0,0 +1,299 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+
+	"github.com/lambda-java-template/tests/internal/expectations"
+)
+
+// ListAlarms returns every metric alarm across all pages of DescribeAlarms
+func ListAlarms(ctx context.Context, api AlarmAPI) ([]cwtypes.MetricAlarm, error) {
+	var alarms []cwtypes.MetricAlarm
+	var nextToken *string
+	for {
+		page, err := api.DescribeAlarms(ctx, &cloudwatch.DescribeAlarmsInput{NextToken: nextToken})
+		if err != nil {
+			return nil, err
+		}
+		alarms = append(alarms, page.MetricAlarms...)
+
+		if page.NextToken == nil {
+			return alarms, nil
+		}
+		nextToken = page.NextToken
+	}
+}
+
+// ListCompositeAlarms returns every composite alarm across all pages of DescribeAlarms
+func ListCompositeAlarms(ctx context.Context, api AlarmAPI) ([]cwtypes.CompositeAlarm, error) {
+	var alarms []cwtypes.CompositeAlarm
+	var nextToken *string
+	for {
+		page, err := api.DescribeAlarms(ctx, &cloudwatch.DescribeAlarmsInput{
+			AlarmTypes: []cwtypes.AlarmType{cwtypes.AlarmTypeCompositeAlarm},
+			NextToken:  nextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		alarms = append(alarms, page.CompositeAlarms...)
+
+		if page.NextToken == nil {
+			return alarms, nil
+		}
+		nextToken = page.NextToken
+	}
+}
+
+// anomalyBand matches the metric math expression of an anomaly detection band, capturing the id of the metric
+// it is drawn around and its optional width in standard deviations
+var anomalyBand = regexp.MustCompile(`^\s*ANOMALY_DETECTION_BAND\(\s*(\w+)\s*(?:,\s*([0-9.]+)\s*)?\)\s*$`)
+
+// defaultAnomalyBand is the width CloudWatch gives a band whose expression sets none
+const defaultAnomalyBand = 2
+
+// watchedMetric is the metric an alarm watches
+type watchedMetric struct {
+	namespace  string
+	metric     string
+	statistic  string
+	period     int32
+	dimensions map[string]string
+	// band is the width of the anomaly detection band in standard deviations; 0 for a static threshold
+	band float64
+}
+
+// watched returns the metric an alarm watches: its own for a static threshold, or the one its anomaly detection
+// band is drawn around. It fails for an alarm comparing against a threshold metric that is not such a band.
+func watched(alarm cwtypes.MetricAlarm) (watchedMetric, error) {
+	if alarm.ThresholdMetricId == nil {
+		return watchedMetric{
+			namespace:  aws.ToString(alarm.Namespace),
+			metric:     aws.ToString(alarm.MetricName),
+			statistic:  string(alarm.Statistic),
+			period:     aws.ToInt32(alarm.Period),
+			dimensions: dimensionMap(alarm.Dimensions),
+		}, nil
+	}
+
+	queries := make(map[string]cwtypes.MetricDataQuery, len(alarm.Metrics))
+	for _, query := range alarm.Metrics {
+		queries[aws.ToString(query.Id)] = query
+	}
+	threshold := aws.ToString(alarm.ThresholdMetricId)
+	match := anomalyBand.FindStringSubmatch(aws.ToString(queries[threshold].Expression))
+	if match == nil {
+		return watchedMetric{}, fmt.Errorf("compares against threshold metric %s, which is not an anomaly detection band", threshold)
+	}
+	band := float64(defaultAnomalyBand)
+	if match[2] != "" {
+		band, _ = strconv.ParseFloat(match[2], 64)
+	}
+	query, ok := queries[match[1]]
+	if !ok || query.MetricStat == nil || query.MetricStat.Metric == nil {
+		return watchedMetric{}, fmt.Errorf("draws its anomaly detection band around %s, which is not a metric of the alarm", match[1])
+	}
+	return watchedMetric{
+		namespace:  aws.ToString(query.MetricStat.Metric.Namespace),
+		metric:     aws.ToString(query.MetricStat.Metric.MetricName),
+		statistic:  aws.ToString(query.MetricStat.Stat),
+		period:     aws.ToInt32(query.MetricStat.Period),
+		dimensions: dimensionMap(query.MetricStat.Metric.Dimensions),
+		band:       band,
+	}, nil
+}
+
+// dimensionMap returns dimensions by name
+func dimensionMap(dimensions []cwtypes.Dimension) map[string]string {
+	values := make(map[string]string, len(dimensions))
+	for _, dimension := range dimensions {
+		values[aws.ToString(dimension.Name)] = aws.ToString(dimension.Value)
+	}
+	return values
+}
+
+// AlarmGaps compares the metric alarms of the deployment named baseName, those named baseName-*, with the
+// alarms it must have: each one must exist, watch the declared metric and resource, fire at the declared
+// threshold or outside the declared anomaly detection band, and notify the deployment's alert topic. An alarm
+// of the deployment that is not declared is reported too, so the declaration stays complete.
+func AlarmGaps(alarms []cwtypes.MetricAlarm, want []expectations.Alarm, baseName string) []string {
+	deployed := make(map[string]cwtypes.MetricAlarm)
+	for _, alarm := range alarms {
+		if strings.HasPrefix(aws.ToString(alarm.AlarmName), baseName+"-") {
+			deployed[aws.ToString(alarm.AlarmName)] = alarm
+		}
+	}
+
+	var gaps []string
+	topic := ":" + baseName + "-" + expectations.AlarmTopic
+	for _, expected := range want {
+		name := expected.AlarmName(baseName)
+		alarm, ok := deployed[name]
+		if !ok {
+			gaps = append(gaps, fmt.Sprintf("alarm %s does not exist", name))
+			continue
+		}
+		delete(deployed, name)
+
+		mismatch := func(attribute string, actual, expected any) {
+			if actual != expected {
+				gaps = append(gaps, fmt.Sprintf("alarm %s %s is %v, expected %v", name, attribute, actual, expected))
+			}
+		}
+		metric, err := watched(alarm)
+		if err != nil {
+			gaps = append(gaps, fmt.Sprintf("alarm %s %v", name, err))
+			continue
+		}
+		mismatch("namespace", metric.namespace, expected.Namespace)
+		mismatch("metric", metric.metric, expected.Metric)
+		mismatch("statistic", metric.statistic, string(expected.Statistic))
+		mismatch("comparison", alarm.ComparisonOperator, expected.Comparison)
+		if expected.AnomalyBand > 0 {
+			mismatch("anomaly band", metric.band, expected.AnomalyBand)
+		} else {
+			mismatch("anomaly band", metric.band, 0.0)
+			mismatch("threshold", aws.ToFloat64(alarm.Threshold), expected.Threshold)
+		}
+		mismatch("period", metric.period, expected.Period)
+		mismatch("evaluation periods", aws.ToInt32(alarm.EvaluationPeriods), expected.EvaluationPeriods)
+
+		if !maps.Equal(metric.dimensions, expected.Dimensions(baseName)) {
+			gaps = append(gaps, fmt.Sprintf("alarm %s selects %v, expected %v", name, metric.dimensions, expected.Dimensions(baseName)))
+		}
+
+		if !notifies(alarm.AlarmActions, topic) {
+			gaps = append(gaps, fmt.Sprintf("alarm %s does not notify %s-%s when it fires", name, baseName, expectations.AlarmTopic))
+		}
+		if expected.NotifiesOK && !notifies(alarm.OKActions, topic) {
+			gaps = append(gaps, fmt.Sprintf("alarm %s does not notify %s-%s when it recovers", name, baseName, expectations.AlarmTopic))
+		}
+	}
+
+	unexpected := slices.Sorted(maps.Keys(deployed))
+	for _, name := range unexpected {
+		gaps = append(gaps, fmt.Sprintf("alarm %s is not declared in the environment's expectations", name))
+	}
+	return gaps
+}
+
+// ruleAlarm matches an ALARM() function in a composite alarm rule, capturing the alarm's name or ARN
+var ruleAlarm = regexp.MustCompile(`\bALARM\(\s*"?([^"()]+?)"?\s*\)`)
+
+// RuleAlarms returns the names of the alarms a composite alarm rule tests for the ALARM state, in the order the
+// rule names them; alarms named by ARN are returned by name
+func RuleAlarms(rule string) []string {
+	var names []string
+	for _, match := range ruleAlarm.FindAllStringSubmatch(rule, -1) {
+		name := match[1]
+		if _, after, ok := strings.Cut(name, ":alarm:"); ok && strings.HasPrefix(name, "arn:") {
+			name = after
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// CompositeAlarmGaps compares the composite alarms of the deployment named baseName with those it must have:
+// each one must exist, its rule must fire when any one of the declared children is in ALARM and test no other
+// alarm, and it must notify the deployment's alert topic when it fires and when it recovers. A composite alarm
+// of the deployment that is not declared is reported too.
+func CompositeAlarmGaps(alarms []cwtypes.CompositeAlarm, want []expectations.CompositeAlarm, baseName string) []string {
+	deployed := make(map[string]cwtypes.CompositeAlarm)
+	for _, alarm := range alarms {
+		if strings.HasPrefix(aws.ToString(alarm.AlarmName), baseName+"-") {
+			deployed[aws.ToString(alarm.AlarmName)] = alarm
+		}
+	}
+
+	var gaps []string
+	topic := ":" + baseName + "-" + expectations.AlarmTopic
+	for _, expected := range want {
+		name := expected.AlarmName(baseName)
+		alarm, ok := deployed[name]
+		if !ok {
+			gaps = append(gaps, fmt.Sprintf("composite alarm %s does not exist", name))
+			continue
+		}
+		delete(deployed, name)
+
+		rule := aws.ToString(alarm.AlarmRule)
+		// Any child alone must be enough, so children may only be joined with OR
+		if strings.Contains(rule, " AND ") || strings.Contains(rule, "NOT ") {
+			gaps = append(gaps, fmt.Sprintf("composite alarm %s does not fire on any one child alarm: %s", name, rule))
+		}
+		referenced := RuleAlarms(rule)
+		children := expected.ChildNames(baseName)
+		for _, child := range children {
+			if !slices.Contains(referenced, child) {
+				gaps = append(gaps, fmt.Sprintf("composite alarm %s rule does not test %s", name, child))
+			}
+		}
+		for _, other := range referenced {
+			if !slices.Contains(children, other) {
+				gaps = append(gaps, fmt.Sprintf("composite alarm %s rule tests %s, which is not a declared child", name, other))
+			}
+		}
+
+		if !notifies(alarm.AlarmActions, topic) {
+			gaps = append(gaps, fmt.Sprintf("composite alarm %s does not notify %s-%s when it fires", name, baseName, expectations.AlarmTopic))
+		}
+		if !notifies(alarm.OKActions, topic) {
+			gaps = append(gaps, fmt.Sprintf("composite alarm %s does not notify %s-%s when it recovers", name, baseName, expectations.AlarmTopic))
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(deployed)) {
+		gaps = append(gaps, fmt.Sprintf("composite alarm %s is not declared in the environment's expectations", name))
+	}
+	return gaps
+}
+
+// notifies reports whether actions include the SNS topic whose ARN ends in topic
+func notifies(actions []string, topic string) bool {
+	return slices.ContainsFunc(actions, func(action string) bool {
+		return strings.HasPrefix(action, "arn:aws:sns:") && strings.HasSuffix(action, topic)
+	})
+}
+
+// AlarmTopics maps each SNS topic that alarms notify to the alarms notifying it.
+// Alarms without an SNS action are listed under the empty string, since nobody hears them go off.
+func AlarmTopics(alarms []cwtypes.MetricAlarm) map[string][]string {
+	topics := make(map[string][]string)
+	for _, alarm := range alarms {
+		notified := false
+		for _, action := range alarm.AlarmActions {
+			if strings.HasPrefix(action, "arn:aws:sns:") {
+				topics[action] = append(topics[action], *alarm.AlarmName)
+				notified = true
+			}
+		}
+		if !notified {
+			topics[""] = append(topics[""], *alarm.AlarmName)
+		}
+	}
+	return topics
+}
+
+// ConfirmedSubscriptions counts subscriptions that will receive messages.
+// SNS reports unconfirmed subscriptions with the placeholder ARN "PendingConfirmation".
+func ConfirmedSubscriptions(subscriptions []snstypes.Subscription) int {
+	confirmed := 0
+	for _, subscription := range subscriptions {
+		if strings.HasPrefix(aws.ToString(subscription.SubscriptionArn), "arn:") {
+			confirmed++
+		}
+	}
+	return confirmed
+}
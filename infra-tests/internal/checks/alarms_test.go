@@ -0,0 +1,246 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks/mocks"
+	"github.com/lambda-java-template/tests/internal/expectations"
+)
+
+func TestListAlarmsFollowsPages(t *testing.T) {
+	api := mocks.NewAlarmAPI(t)
+	api.EXPECT().DescribeAlarms(mock.Anything, mock.MatchedBy(func(in *cloudwatch.DescribeAlarmsInput) bool {
+		return in.NextToken == nil
+	})).Return(&cloudwatch.DescribeAlarmsOutput{
+		MetricAlarms: []cwtypes.MetricAlarm{
+			{AlarmName: aws.String("lambda-java-template-dev-product-service-error-rate")},
+			{AlarmName: aws.String("lambda-java-template-dev-product-service-duration")},
+		},
+		NextToken: aws.String("next"),
+	}, nil).Once()
+	api.EXPECT().DescribeAlarms(mock.Anything, mock.MatchedBy(func(in *cloudwatch.DescribeAlarmsInput) bool {
+		return aws.ToString(in.NextToken) == "next"
+	})).Return(&cloudwatch.DescribeAlarmsOutput{
+		MetricAlarms: []cwtypes.MetricAlarm{{AlarmName: aws.String("lambda-java-template-dev-api-5xx-errors")}},
+	}, nil).Once()
+
+	alarms, err := ListAlarms(context.TODO(), api)
+	require.NoError(t, err)
+	assert.Len(t, alarms, 3)
+}
+
+// deployedAlarm builds the alarm Terraform deploys for expected in the dev deployment
+func deployedAlarm(expected expectations.Alarm) cwtypes.MetricAlarm {
+	const baseName = "lambda-java-template-dev"
+	alerts := "arn:aws:sns:us-east-1:123456789012:lambda-java-template-dev-alerts"
+	var dimensions []cwtypes.Dimension
+	for name, value := range expected.Dimensions(baseName) {
+		dimensions = append(dimensions, cwtypes.Dimension{Name: aws.String(name), Value: aws.String(value)})
+	}
+	alarm := cwtypes.MetricAlarm{
+		AlarmName:          aws.String(expected.AlarmName(baseName)),
+		ComparisonOperator: expected.Comparison,
+		EvaluationPeriods:  aws.Int32(expected.EvaluationPeriods),
+		AlarmActions:       []string{alerts},
+	}
+	if expected.AnomalyBand > 0 {
+		alarm.ThresholdMetricId = aws.String("band")
+		alarm.Metrics = []cwtypes.MetricDataQuery{
+			{Id: aws.String("band"), Expression: aws.String(fmt.Sprintf("ANOMALY_DETECTION_BAND(watched, %g)", expected.AnomalyBand)), ReturnData: aws.Bool(true)},
+			{Id: aws.String("watched"), ReturnData: aws.Bool(true), MetricStat: &cwtypes.MetricStat{
+				Metric: &cwtypes.Metric{Namespace: aws.String(expected.Namespace), MetricName: aws.String(expected.Metric), Dimensions: dimensions},
+				Period: aws.Int32(expected.Period),
+				Stat:   aws.String(string(expected.Statistic)),
+			}},
+		}
+	} else {
+		alarm.Namespace = aws.String(expected.Namespace)
+		alarm.MetricName = aws.String(expected.Metric)
+		alarm.Statistic = expected.Statistic
+		alarm.Threshold = aws.Float64(expected.Threshold)
+		alarm.Period = aws.Int32(expected.Period)
+		alarm.Dimensions = dimensions
+	}
+	if expected.NotifiesOK {
+		alarm.OKActions = []string{alerts}
+	}
+	return alarm
+}
+
+func TestAlarmGapsOfMatchingDeployment(t *testing.T) {
+	want := expectations.For("prod").Alarms
+	var alarms []cwtypes.MetricAlarm
+	for _, expected := range want {
+		alarms = append(alarms, deployedAlarm(expected))
+	}
+	// Another deployment's alarms are not this one's to judge
+	alarms = append(alarms, cwtypes.MetricAlarm{AlarmName: aws.String("lambda-java-template-staging-api-latency")})
+
+	assert.Empty(t, AlarmGaps(alarms, want, "lambda-java-template-dev"))
+}
+
+func TestAlarmGaps(t *testing.T) {
+	want := []expectations.Alarm{
+		{Resource: "product-service", Name: "error-rate", Namespace: "AWS/Lambda", Metric: "Errors", Dimension: "FunctionName",
+			Statistic: cwtypes.StatisticSum, Comparison: cwtypes.ComparisonOperatorGreaterThanThreshold, Threshold: 5, Period: 300, EvaluationPeriods: 2, NotifiesOK: true},
+		{Resource: "products", Name: "read-throttles", Namespace: "AWS/DynamoDB", Metric: "ReadThrottles", Dimension: "TableName",
+			Statistic: cwtypes.StatisticSum, Comparison: cwtypes.ComparisonOperatorGreaterThanThreshold, Threshold: 0, Period: 300, EvaluationPeriods: 1},
+	}
+
+	// The error rate alarm fires later than declared, watches the wrong function, and is silent on recovery
+	errorRate := deployedAlarm(want[0])
+	errorRate.Threshold = aws.Float64(50)
+	errorRate.Dimensions = []cwtypes.Dimension{{Name: aws.String("FunctionName"), Value: aws.String("lambda-java-template-dev-authorizer-service")}}
+	errorRate.OKActions = nil
+	undeclared := cwtypes.MetricAlarm{AlarmName: aws.String("lambda-java-template-dev-products-consumed-capacity")}
+
+	assert.Equal(t, []string{
+		"alarm lambda-java-template-dev-product-service-error-rate threshold is 50, expected 5",
+		"alarm lambda-java-template-dev-product-service-error-rate selects map[FunctionName:lambda-java-template-dev-authorizer-service], expected map[FunctionName:lambda-java-template-dev-product-service]",
+		"alarm lambda-java-template-dev-product-service-error-rate does not notify lambda-java-template-dev-alerts when it recovers",
+		"alarm lambda-java-template-dev-products-read-throttles does not exist",
+		"alarm lambda-java-template-dev-products-consumed-capacity is not declared in the environment's expectations",
+	}, AlarmGaps([]cwtypes.MetricAlarm{errorRate, undeclared}, want, "lambda-java-template-dev"))
+}
+
+func TestAlarmGapsOfAnomalyDetectionAlarms(t *testing.T) {
+	var want []expectations.Alarm
+	for _, alarm := range expectations.For("dev").Alarms {
+		if alarm.AnomalyBand > 0 {
+			want = append(want, alarm)
+		}
+	}
+	require.NotEmpty(t, want)
+	latency := want[len(want)-1]
+	require.Equal(t, "latency-anomaly", latency.Name)
+
+	// A wider band, and a band drawn around the wrong metric
+	wider := deployedAlarm(latency)
+	wider.Metrics[0].Expression = aws.String("ANOMALY_DETECTION_BAND(watched,4)")
+	wider.Metrics[1].MetricStat.Metric.MetricName = aws.String("IntegrationLatency")
+	assert.Equal(t, []string{
+		"alarm lambda-java-template-dev-api-latency-anomaly metric is IntegrationLatency, expected Latency",
+		"alarm lambda-java-template-dev-api-latency-anomaly anomaly band is 4, expected 2",
+	}, AlarmGaps([]cwtypes.MetricAlarm{wider}, want[len(want)-1:], "lambda-java-template-dev"))
+
+	// A band without a width is CloudWatch's default of two standard deviations
+	defaultWidth := deployedAlarm(latency)
+	defaultWidth.Metrics[0].Expression = aws.String("ANOMALY_DETECTION_BAND(watched)")
+	assert.Empty(t, AlarmGaps([]cwtypes.MetricAlarm{defaultWidth}, want[len(want)-1:], "lambda-java-template-dev"))
+
+	notABand := deployedAlarm(latency)
+	notABand.Metrics[0].Expression = aws.String("watched * 2")
+	assert.Equal(t, []string{
+		"alarm lambda-java-template-dev-api-latency-anomaly compares against threshold metric band, which is not an anomaly detection band",
+	}, AlarmGaps([]cwtypes.MetricAlarm{notABand}, want[len(want)-1:], "lambda-java-template-dev"))
+
+	// A static threshold where a band is declared
+	static := deployedAlarm(expectations.Alarm{Resource: "api", Name: "latency-anomaly", Namespace: "AWS/ApiGateway", Metric: "Latency", Dimension: "ApiName",
+		Statistic: cwtypes.StatisticAverage, Comparison: cwtypes.ComparisonOperatorGreaterThanThreshold, Threshold: 5000, Period: 300, EvaluationPeriods: 3})
+	assert.Equal(t, []string{
+		"alarm lambda-java-template-dev-api-latency-anomaly comparison is GreaterThanThreshold, expected GreaterThanUpperThreshold",
+		"alarm lambda-java-template-dev-api-latency-anomaly anomaly band is 0, expected 2",
+	}, AlarmGaps([]cwtypes.MetricAlarm{static}, want[len(want)-1:], "lambda-java-template-dev"))
+}
+
+func TestRuleAlarms(t *testing.T) {
+	assert.Equal(t, []string{"api-5xx-errors", "product-service-error-rate", "authorizer-service-error-rate"},
+		RuleAlarms(`ALARM("api-5xx-errors") OR ALARM(product-service-error-rate) OR OK("api-latency") OR `+
+			`ALARM("arn:aws:cloudwatch:us-east-1:123456789012:alarm:authorizer-service-error-rate")`))
+	assert.Empty(t, RuleAlarms("TRUE"))
+}
+
+// deployedCompositeAlarm builds the composite alarm Terraform deploys for expected in the dev deployment
+func deployedCompositeAlarm(expected expectations.CompositeAlarm) cwtypes.CompositeAlarm {
+	alerts := "arn:aws:sns:us-east-1:123456789012:lambda-java-template-dev-alerts"
+	var rule []string
+	for _, child := range expected.ChildNames("lambda-java-template-dev") {
+		rule = append(rule, fmt.Sprintf("ALARM(%q)", child))
+	}
+	return cwtypes.CompositeAlarm{
+		AlarmName:    aws.String(expected.AlarmName("lambda-java-template-dev")),
+		AlarmRule:    aws.String(strings.Join(rule, " OR ")),
+		AlarmActions: []string{alerts},
+		OKActions:    []string{alerts},
+	}
+}
+
+func TestCompositeAlarmGapsOfMatchingDeployment(t *testing.T) {
+	want := expectations.For("prod").CompositeAlarms
+	var alarms []cwtypes.CompositeAlarm
+	for _, expected := range want {
+		alarms = append(alarms, deployedCompositeAlarm(expected))
+	}
+	alarms = append(alarms, cwtypes.CompositeAlarm{AlarmName: aws.String("lambda-java-template-staging-service-health")})
+
+	assert.Empty(t, CompositeAlarmGaps(alarms, want, "lambda-java-template-dev"))
+}
+
+func TestCompositeAlarmGaps(t *testing.T) {
+	want := []expectations.CompositeAlarm{{Name: "service-health", Children: []expectations.Alarm{
+		{Resource: "api", Name: "5xx-errors"},
+		{Resource: "product-service", Name: "error-rate"},
+	}}}
+
+	// The rule needs both children at once, tests an alarm that is not a child, and the alarm is silent on recovery
+	health := deployedCompositeAlarm(want[0])
+	health.AlarmRule = aws.String(`ALARM("lambda-java-template-dev-api-5xx-errors") AND ALARM("lambda-java-template-dev-api-4xx-errors")`)
+	health.OKActions = nil
+	undeclared := cwtypes.CompositeAlarm{AlarmName: aws.String("lambda-java-template-dev-data-health")}
+
+	assert.Equal(t, []string{
+		`composite alarm lambda-java-template-dev-service-health does not fire on any one child alarm: ALARM("lambda-java-template-dev-api-5xx-errors") AND ALARM("lambda-java-template-dev-api-4xx-errors")`,
+		"composite alarm lambda-java-template-dev-service-health rule does not test lambda-java-template-dev-product-service-error-rate",
+		"composite alarm lambda-java-template-dev-service-health rule tests lambda-java-template-dev-api-4xx-errors, which is not a declared child",
+		"composite alarm lambda-java-template-dev-service-health does not notify lambda-java-template-dev-alerts when it recovers",
+		"composite alarm lambda-java-template-dev-data-health is not declared in the environment's expectations",
+	}, CompositeAlarmGaps([]cwtypes.CompositeAlarm{health, undeclared}, want, "lambda-java-template-dev"))
+
+	assert.Equal(t, []string{"composite alarm lambda-java-template-dev-service-health does not exist"},
+		CompositeAlarmGaps(nil, want, "lambda-java-template-dev"))
+}
+
+func TestAlarmGapsRequireTheAlertTopic(t *testing.T) {
+	want := expectations.For("dev").Alarms[:1]
+	alarm := deployedAlarm(want[0])
+	alarm.AlarmActions = []string{"arn:aws:sns:us-east-1:123456789012:lambda-java-template-staging-alerts"}
+
+	assert.Equal(t, []string{
+		"alarm lambda-java-template-dev-product-service-error-rate does not notify lambda-java-template-dev-alerts when it fires",
+	}, AlarmGaps([]cwtypes.MetricAlarm{alarm}, want, "lambda-java-template-dev"))
+}
+
+func TestAlarmTopics(t *testing.T) {
+	alerts := "arn:aws:sns:us-east-1:123456789012:lambda-java-template-dev-alerts"
+	alarms := []cwtypes.MetricAlarm{
+		{AlarmName: aws.String("product-service-errors"), AlarmActions: []string{alerts}},
+		{AlarmName: aws.String("api-5xx"), AlarmActions: []string{alerts, "arn:aws:autoscaling:us-east-1:123456789012:scalingPolicy:x"}},
+		{AlarmName: aws.String("products-throttles")},
+		{AlarmName: aws.String("scale-out"), AlarmActions: []string{"arn:aws:autoscaling:us-east-1:123456789012:scalingPolicy:x"}},
+	}
+
+	assert.Equal(t, map[string][]string{
+		alerts: {"product-service-errors", "api-5xx"},
+		"":     {"products-throttles", "scale-out"},
+	}, AlarmTopics(alarms))
+}
+
+func TestConfirmedSubscriptions(t *testing.T) {
+	subscriptions := []snstypes.Subscription{
+		{SubscriptionArn: aws.String("arn:aws:sns:us-east-1:123456789012:alerts:0b1c")},
+		{SubscriptionArn: aws.String("PendingConfirmation")},
+		{SubscriptionArn: aws.String("Deleted")},
+	}
+	assert.Equal(t, 1, ConfirmedSubscriptions(subscriptions))
+}
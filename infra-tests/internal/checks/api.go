@@ -0,0 +1,38 @@
+// Package checks holds validation logic that runs against narrow AWS client interfaces.
+//
+// Validators pass real SDK clients; unit tests pass the generated mocks in checks/mocks,
+// so the logic (route diffing, policy parsing, alarm classification) is tested without AWS.
+package checks
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.7 --name "^(RouteAPI|EventSourceAPI|AlarmAPI|TrustedAdvisorAPI)$" --output mocks --outpkg mocks --with-expecter
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/support"
+)
+
+// RouteAPI is the API Gateway operation used to list routes
+type RouteAPI interface {
+	GetRoutes(ctx context.Context, params *apigatewayv2.GetRoutesInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetRoutesOutput, error)
+}
+
+// EventSourceAPI is the Lambda operations used to find what invokes a function
+type EventSourceAPI interface {
+	ListEventSourceMappings(ctx context.Context, params *lambda.ListEventSourceMappingsInput, optFns ...func(*lambda.Options)) (*lambda.ListEventSourceMappingsOutput, error)
+	GetPolicy(ctx context.Context, params *lambda.GetPolicyInput, optFns ...func(*lambda.Options)) (*lambda.GetPolicyOutput, error)
+}
+
+// AlarmAPI is the CloudWatch operation used to list alarms
+type AlarmAPI interface {
+	DescribeAlarms(ctx context.Context, params *cloudwatch.DescribeAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DescribeAlarmsOutput, error)
+}
+
+// TrustedAdvisorAPI is the Support operations used to read Trusted Advisor checks and their results
+type TrustedAdvisorAPI interface {
+	DescribeTrustedAdvisorChecks(ctx context.Context, params *support.DescribeTrustedAdvisorChecksInput, optFns ...func(*support.Options)) (*support.DescribeTrustedAdvisorChecksOutput, error)
+	DescribeTrustedAdvisorCheckResult(ctx context.Context, params *support.DescribeTrustedAdvisorCheckResultInput, optFns ...func(*support.Options)) (*support.DescribeTrustedAdvisorCheckResultOutput, error)
+}
@@ -0,0 +1,173 @@
+package checks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// ItemReferences reports whether any string attribute of an item, including strings nested in
+// maps and lists, equals or embeds value. Audit records name the entity they describe in
+// different attributes (an entity id, a JSON detail document), so every string is considered.
+func ItemReferences(item map[string]dynamodbtypes.AttributeValue, value string) bool {
+	for _, attribute := range item {
+		if attributeReferences(attribute, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// attributeReferences checks one attribute value for ItemReferences
+func attributeReferences(attribute dynamodbtypes.AttributeValue, value string) bool {
+	switch typed := attribute.(type) {
+	case *dynamodbtypes.AttributeValueMemberS:
+		return strings.Contains(typed.Value, value)
+	case *dynamodbtypes.AttributeValueMemberSS:
+		for _, member := range typed.Value {
+			if strings.Contains(member, value) {
+				return true
+			}
+		}
+	case *dynamodbtypes.AttributeValueMemberM:
+		return ItemReferences(typed.Value, value)
+	case *dynamodbtypes.AttributeValueMemberL:
+		for _, member := range typed.Value {
+			if attributeReferences(member, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CountActions counts audit records by their action attribute; records without one are counted under ""
+func CountActions(items []map[string]dynamodbtypes.AttributeValue) map[string]int {
+	counts := make(map[string]int)
+	for _, item := range items {
+		action := ""
+		if value, ok := item["action"].(*dynamodbtypes.AttributeValueMemberS); ok {
+			action = value.Value
+		}
+		counts[action]++
+	}
+	return counts
+}
+
+// RepeatedActions returns, sorted, the actions recorded more than once whose names contain any of markers, ignoring case
+func RepeatedActions(counts map[string]int, markers ...string) []string {
+	var repeated []string
+	for action, count := range counts {
+		if count > 1 && matchesAny(action, markers) {
+			repeated = append(repeated, action)
+		}
+	}
+	sort.Strings(repeated)
+	return repeated
+}
+
+// MatchingActions counts the records whose action contains any of markers, ignoring case
+func MatchingActions(counts map[string]int, markers ...string) int {
+	total := 0
+	for action, count := range counts {
+		if matchesAny(action, markers) {
+			total += count
+		}
+	}
+	return total
+}
+
+// matchesAny reports whether action contains any of markers, ignoring case
+func matchesAny(action string, markers []string) bool {
+	for _, marker := range markers {
+		if strings.Contains(strings.ToLower(action), strings.ToLower(marker)) {
+			return true
+		}
+	}
+	return false
+}
+
+// AuditRequiredFields are the attributes every audit record must carry
+var AuditRequiredFields = []string{"event_id", "timestamp", "actor"}
+
+// AuditedAction is a mutation that must leave exactly one audit record
+type AuditedAction struct {
+	// Entity is the id of the product or order the action changed
+	Entity string
+	// Action is matched, ignoring case, against the record's action attribute, e.g. "create" matches "ProductCreated"
+	Action string
+	// Before and After require the record to carry the entity's state before and after the action
+	Before bool
+	After  bool
+}
+
+// String names the action in gaps
+func (a AuditedAction) String() string {
+	return a.Action + " of " + a.Entity
+}
+
+// AuditGaps describes how the audit log falls short of the actions: actions with no record or with
+// several, and records missing a required field or the before and after images their action calls for
+func AuditGaps(actions []AuditedAction, records []map[string]dynamodbtypes.AttributeValue) []string {
+	var gaps []string
+	for _, action := range actions {
+		var matching []map[string]dynamodbtypes.AttributeValue
+		for _, record := range records {
+			if matchesAny(recordAction(record), []string{action.Action}) && ItemReferences(record, action.Entity) {
+				matching = append(matching, record)
+			}
+		}
+
+		switch len(matching) {
+		case 0:
+			gaps = append(gaps, fmt.Sprintf("%s has no audit record", action))
+			continue
+		case 1:
+		default:
+			gaps = append(gaps, fmt.Sprintf("%s has %d audit records", action, len(matching)))
+			continue
+		}
+
+		required := append([]string{}, AuditRequiredFields...)
+		if action.Before {
+			required = append(required, "before")
+		}
+		if action.After {
+			required = append(required, "after")
+		}
+		var missing []string
+		for _, field := range required {
+			if !hasValue(matching[0][field]) {
+				missing = append(missing, field)
+			}
+		}
+		if len(missing) > 0 {
+			gaps = append(gaps, fmt.Sprintf("audit record of %s is missing %s", action, strings.Join(missing, ", ")))
+		}
+	}
+	return gaps
+}
+
+// recordAction returns the action attribute of an audit record, or "" when it has none
+func recordAction(record map[string]dynamodbtypes.AttributeValue) string {
+	if value, ok := record["action"].(*dynamodbtypes.AttributeValueMemberS); ok {
+		return value.Value
+	}
+	return ""
+}
+
+// hasValue reports whether an attribute is present and not null or an empty string
+func hasValue(attribute dynamodbtypes.AttributeValue) bool {
+	switch typed := attribute.(type) {
+	case nil:
+		return false
+	case *dynamodbtypes.AttributeValueMemberNULL:
+		return false
+	case *dynamodbtypes.AttributeValueMemberS:
+		return typed.Value != ""
+	default:
+		return true
+	}
+}
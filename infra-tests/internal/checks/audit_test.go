@@ -0,0 +1,118 @@
+package checks
+
+import (
+	"testing"
+
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItemReferences(t *testing.T) {
+	productId := "0b6f7d2e-4c1a-4f7e-9d7b-2f1e9a6c8d10"
+
+	tests := []struct {
+		name     string
+		item     map[string]dynamodbtypes.AttributeValue
+		expected bool
+	}{
+		{"entity id attribute", map[string]dynamodbtypes.AttributeValue{
+			"event_id":  &dynamodbtypes.AttributeValueMemberS{Value: "evt-1"},
+			"entity_id": &dynamodbtypes.AttributeValueMemberS{Value: productId},
+		}, true},
+		{"JSON detail", map[string]dynamodbtypes.AttributeValue{
+			"detail": &dynamodbtypes.AttributeValueMemberS{Value: `{"productId":"` + productId + `"}`},
+		}, true},
+		{"nested map", map[string]dynamodbtypes.AttributeValue{
+			"payload": &dynamodbtypes.AttributeValueMemberM{Value: map[string]dynamodbtypes.AttributeValue{
+				"ids": &dynamodbtypes.AttributeValueMemberL{Value: []dynamodbtypes.AttributeValue{
+					&dynamodbtypes.AttributeValueMemberS{Value: productId},
+				}},
+			}},
+		}, true},
+		{"other entity", map[string]dynamodbtypes.AttributeValue{
+			"entity_id": &dynamodbtypes.AttributeValueMemberS{Value: "another-product"},
+			"count":     &dynamodbtypes.AttributeValueMemberN{Value: "1"},
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ItemReferences(tt.item, productId))
+		})
+	}
+}
+
+func TestCountActions(t *testing.T) {
+	record := func(action string) map[string]dynamodbtypes.AttributeValue {
+		return map[string]dynamodbtypes.AttributeValue{"action": &dynamodbtypes.AttributeValueMemberS{Value: action}}
+	}
+	items := []map[string]dynamodbtypes.AttributeValue{
+		record("PAYMENT_CHARGED"),
+		record("INVENTORY_RESERVED"),
+		record("PAYMENT_CHARGED"),
+		{"event_id": &dynamodbtypes.AttributeValueMemberS{Value: "evt-1"}},
+	}
+
+	assert.Equal(t, map[string]int{"PAYMENT_CHARGED": 2, "INVENTORY_RESERVED": 1, "": 1}, CountActions(items))
+}
+
+func TestRepeatedActions(t *testing.T) {
+	counts := map[string]int{
+		"PAYMENT_CHARGED":       2,
+		"inventory_decremented": 3,
+		"ORDER_RECEIVED":        2,
+		"INVENTORY_RESERVED":    1,
+	}
+
+	assert.Equal(t, []string{"PAYMENT_CHARGED", "inventory_decremented"}, RepeatedActions(counts, "payment", "INVENTORY"))
+	assert.Empty(t, RepeatedActions(counts, "refund"))
+	assert.Equal(t, 6, MatchingActions(counts, "payment", "INVENTORY"))
+}
+
+func TestAuditGaps(t *testing.T) {
+	s := func(value string) dynamodbtypes.AttributeValue {
+		return &dynamodbtypes.AttributeValueMemberS{Value: value}
+	}
+	record := func(eventId, action, entity string, extra map[string]dynamodbtypes.AttributeValue) map[string]dynamodbtypes.AttributeValue {
+		item := map[string]dynamodbtypes.AttributeValue{
+			"event_id":  s(eventId),
+			"timestamp": s("2024-06-01T12:00:00Z"),
+			"actor":     s("infra-tests-dev"),
+			"action":    s(action),
+			"entity_id": s(entity),
+		}
+		for name, value := range extra {
+			item[name] = value
+		}
+		return item
+	}
+	image := &dynamodbtypes.AttributeValueMemberM{Value: map[string]dynamodbtypes.AttributeValue{"name": s("widget")}}
+
+	actions := []AuditedAction{
+		{Entity: "p-1", Action: "create", After: true},
+		{Entity: "p-1", Action: "update", Before: true, After: true},
+		{Entity: "p-1", Action: "delete", Before: true},
+		{Entity: "o-1", Action: "payment"},
+	}
+	complete := []map[string]dynamodbtypes.AttributeValue{
+		record("e1", "ProductCreated", "p-1", map[string]dynamodbtypes.AttributeValue{"after": image}),
+		record("e2", "ProductUpdated", "p-1", map[string]dynamodbtypes.AttributeValue{"before": image, "after": image}),
+		record("e3", "ProductDeleted", "p-1", map[string]dynamodbtypes.AttributeValue{"before": image}),
+		record("e4", "PAYMENT_CHARGED", "o-1", nil),
+		record("e5", "ProductCreated", "p-2", map[string]dynamodbtypes.AttributeValue{"after": image}),
+	}
+	assert.Empty(t, AuditGaps(actions, complete))
+
+	incomplete := []map[string]dynamodbtypes.AttributeValue{
+		record("e1", "ProductCreated", "p-1", map[string]dynamodbtypes.AttributeValue{"after": image}),
+		record("e1-retry", "ProductCreated", "p-1", map[string]dynamodbtypes.AttributeValue{"after": image}),
+		record("e2", "ProductUpdated", "p-1", map[string]dynamodbtypes.AttributeValue{"actor": s(""), "after": image}),
+		record("e4", "PAYMENT_CHARGED", "o-1", map[string]dynamodbtypes.AttributeValue{"timestamp": &dynamodbtypes.AttributeValueMemberNULL{Value: true}}),
+	}
+	assert.Equal(t, []string{
+		"create of p-1 has 2 audit records",
+		"audit record of update of p-1 is missing actor, before",
+		"delete of p-1 has no audit record",
+		"audit record of payment of o-1 is missing timestamp",
+	}, AuditGaps(actions, incomplete))
+}
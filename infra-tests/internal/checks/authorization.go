@@ -0,0 +1,51 @@
+package checks
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+)
+
+// RouteAccess is one row of an API's authorization matrix: how a route is authorized and whether it may be public
+type RouteAccess struct {
+	RouteKey          string
+	AuthorizationType types.AuthorizationType
+	Public            bool
+}
+
+// AuthorizationMatrix classifies every route of an API as public, when its key is on the public allowlist, or
+// protected otherwise, so a route added without being classified is protected by default. Keys on the protected
+// denylist are never public, even when also allowlisted. The returned gaps describe each route whose
+// authorization does not match its classification. Rows are sorted by route key.
+func AuthorizationMatrix(routes []types.Route, public, protected []string) ([]RouteAccess, []string) {
+	var matrix []RouteAccess
+	var gaps []string
+	for _, route := range routes {
+		key := aws.ToString(route.RouteKey)
+		access := RouteAccess{
+			RouteKey:          key,
+			AuthorizationType: route.AuthorizationType,
+			Public:            slices.Contains(public, key) && !slices.Contains(protected, key),
+		}
+		if access.AuthorizationType == "" {
+			access.AuthorizationType = types.AuthorizationTypeNone
+		}
+		matrix = append(matrix, access)
+
+		switch {
+		case slices.Contains(public, key) && slices.Contains(protected, key):
+			gaps = append(gaps, fmt.Sprintf("%s is on both the public allowlist and the protected denylist", key))
+		case access.Public && access.AuthorizationType != types.AuthorizationTypeNone:
+			gaps = append(gaps, fmt.Sprintf("%s is on the public allowlist but requires %s authorization", key, access.AuthorizationType))
+		}
+		if !access.Public && access.AuthorizationType == types.AuthorizationTypeNone {
+			gaps = append(gaps, fmt.Sprintf("%s has no authorization but is not on the public allowlist", key))
+		}
+	}
+	sort.Slice(matrix, func(i, j int) bool { return matrix[i].RouteKey < matrix[j].RouteKey })
+	sort.Strings(gaps)
+	return matrix, gaps
+}
@@ -0,0 +1,45 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizationMatrix(t *testing.T) {
+	route := func(key string, authorization types.AuthorizationType) types.Route {
+		return types.Route{RouteKey: aws.String(key), AuthorizationType: authorization}
+	}
+	public := []string{"GET /health"}
+	protected := []string{"GET /products", "POST /products"}
+
+	matrix, gaps := AuthorizationMatrix([]types.Route{
+		route("POST /products", types.AuthorizationTypeCustom),
+		route("GET /health", types.AuthorizationTypeNone),
+		route("GET /products", types.AuthorizationTypeCustom),
+		route("GET /reports", types.AuthorizationTypeAwsIam),
+	}, public, protected)
+	assert.Empty(t, gaps)
+	assert.Equal(t, []RouteAccess{
+		{RouteKey: "GET /health", AuthorizationType: types.AuthorizationTypeNone, Public: true},
+		{RouteKey: "GET /products", AuthorizationType: types.AuthorizationTypeCustom},
+		{RouteKey: "GET /reports", AuthorizationType: types.AuthorizationTypeAwsIam},
+		{RouteKey: "POST /products", AuthorizationType: types.AuthorizationTypeCustom},
+	}, matrix, "a route on neither list is protected")
+
+	matrix, gaps = AuthorizationMatrix([]types.Route{
+		route("GET /health", types.AuthorizationTypeCustom),
+		route("GET /orders", ""),
+		route("GET /products", types.AuthorizationTypeNone),
+	}, []string{"GET /health", "GET /products"}, protected)
+	assert.Equal(t, []string{
+		"GET /health is on the public allowlist but requires CUSTOM authorization",
+		"GET /orders has no authorization but is not on the public allowlist",
+		"GET /products has no authorization but is not on the public allowlist",
+		"GET /products is on both the public allowlist and the protected denylist",
+	}, gaps)
+	assert.Equal(t, types.AuthorizationTypeNone, matrix[1].AuthorizationType, "an unset authorization type is NONE")
+	assert.False(t, matrix[2].Public, "the denylist overrides the allowlist")
+}
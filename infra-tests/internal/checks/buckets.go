@@ -0,0 +1,38 @@
+package checks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// ArtifactKey returns the S3 key Terraform uploads a function's JAR to, e.g.
+// "product_service/product-service.jar" for the product-service function
+func ArtifactKey(functionSuffix string) string {
+	return fmt.Sprintf("%s/%s.jar", strings.ReplaceAll(functionSuffix, "-", "_"), functionSuffix)
+}
+
+// ExpiresNoncurrentVersions reports whether an enabled lifecycle rule removes superseded object versions,
+// so a versioned artifact bucket does not keep every JAR ever deployed
+func ExpiresNoncurrentVersions(rules []s3types.LifecycleRule) bool {
+	for _, rule := range rules {
+		if rule.Status != s3types.ExpirationStatusEnabled || rule.NoncurrentVersionExpiration == nil {
+			continue
+		}
+		if aws.ToInt32(rule.NoncurrentVersionExpiration.NoncurrentDays) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// BlocksPublicAccess reports whether all four public access block settings are on
+func BlocksPublicAccess(config *s3types.PublicAccessBlockConfiguration) bool {
+	return config != nil &&
+		aws.ToBool(config.BlockPublicAcls) &&
+		aws.ToBool(config.BlockPublicPolicy) &&
+		aws.ToBool(config.IgnorePublicAcls) &&
+		aws.ToBool(config.RestrictPublicBuckets)
+}
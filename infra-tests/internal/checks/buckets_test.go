@@ -0,0 +1,46 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArtifactKey(t *testing.T) {
+	assert.Equal(t, "product_service/product-service.jar", ArtifactKey("product-service"))
+	assert.Equal(t, "authorizer_service/authorizer-service.jar", ArtifactKey("authorizer-service"))
+}
+
+func TestExpiresNoncurrentVersions(t *testing.T) {
+	expiring := s3types.LifecycleRule{
+		Status:                      s3types.ExpirationStatusEnabled,
+		NoncurrentVersionExpiration: &s3types.NoncurrentVersionExpiration{NoncurrentDays: aws.Int32(30)},
+	}
+	disabled := expiring
+	disabled.Status = s3types.ExpirationStatusDisabled
+	currentOnly := s3types.LifecycleRule{
+		Status:     s3types.ExpirationStatusEnabled,
+		Expiration: &s3types.LifecycleExpiration{Days: aws.Int32(90)},
+	}
+
+	assert.True(t, ExpiresNoncurrentVersions([]s3types.LifecycleRule{currentOnly, expiring}))
+	assert.False(t, ExpiresNoncurrentVersions([]s3types.LifecycleRule{disabled, currentOnly}))
+	assert.False(t, ExpiresNoncurrentVersions(nil))
+}
+
+func TestBlocksPublicAccess(t *testing.T) {
+	all := &s3types.PublicAccessBlockConfiguration{
+		BlockPublicAcls:       aws.Bool(true),
+		BlockPublicPolicy:     aws.Bool(true),
+		IgnorePublicAcls:      aws.Bool(true),
+		RestrictPublicBuckets: aws.Bool(true),
+	}
+	partial := *all
+	partial.RestrictPublicBuckets = aws.Bool(false)
+
+	assert.True(t, BlocksPublicAccess(all))
+	assert.False(t, BlocksPublicAccess(&partial))
+	assert.False(t, BlocksPublicAccess(nil))
+}
@@ -0,0 +1,78 @@
+package checks
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// MaxCapacityUtilization is the share of a table's provisioned capacity its busiest second may consume
+// before the table is considered close to throttling
+const MaxCapacityUtilization = 0.8
+
+// TableUsage is a table's consumption and throttling over a window
+type TableUsage struct {
+	// PeakRead and PeakWrite are the consumed capacity units per second of the busiest minute
+	PeakRead  float64
+	PeakWrite float64
+
+	// ReadThrottles and WriteThrottles count requests rejected for exceeding capacity
+	ReadThrottles  float64
+	WriteThrottles float64
+}
+
+// UsageGaps describes throttled requests, and consumption close to the provisioned capacity of a table.
+// readCapacity and writeCapacity are 0 for on-demand tables, which only the throttle counts apply to.
+func UsageGaps(usage TableUsage, readCapacity, writeCapacity int64) []string {
+	var gaps []string
+	if usage.ReadThrottles > 0 {
+		gaps = append(gaps, fmt.Sprintf("%.0f read requests were throttled", usage.ReadThrottles))
+	}
+	if usage.WriteThrottles > 0 {
+		gaps = append(gaps, fmt.Sprintf("%.0f write requests were throttled", usage.WriteThrottles))
+	}
+	if readCapacity > 0 && usage.PeakRead > MaxCapacityUtilization*float64(readCapacity) {
+		gaps = append(gaps, fmt.Sprintf("peak read consumption of %.2f units per second is above %.0f%% of the %d provisioned",
+			usage.PeakRead, MaxCapacityUtilization*100, readCapacity))
+	}
+	if writeCapacity > 0 && usage.PeakWrite > MaxCapacityUtilization*float64(writeCapacity) {
+		gaps = append(gaps, fmt.Sprintf("peak write consumption of %.2f units per second is above %.0f%% of the %d provisioned",
+			usage.PeakWrite, MaxCapacityUtilization*100, writeCapacity))
+	}
+	return gaps
+}
+
+// CapacityModeGaps describes how a table's capacity settings differ from what its environment expects:
+// auto scaling registered or missing, and, for fixed provisioned capacity, units other than readCapacity
+// and writeCapacity on the table or any of its global secondary indexes
+func CapacityModeGaps(table dynamodbtypes.TableDescription, readCapacity, writeCapacity int64, wantAutoScaling, autoScaled bool) []string {
+	onDemand := billingMode(table) == dynamodbtypes.BillingModePayPerRequest
+	switch {
+	case autoScaled && (onDemand || !wantAutoScaling):
+		return []string{"capacity is managed by auto scaling, but the environment expects fixed capacity"}
+	case !autoScaled && wantAutoScaling:
+		return []string{"capacity is not managed by auto scaling"}
+	case onDemand || autoScaled:
+		return nil
+	}
+
+	var gaps []string
+	throughputGaps := func(scope string, throughput *dynamodbtypes.ProvisionedThroughputDescription) {
+		if throughput == nil {
+			gaps = append(gaps, fmt.Sprintf("%s has no provisioned throughput", scope))
+			return
+		}
+		if read := aws.ToInt64(throughput.ReadCapacityUnits); read != readCapacity {
+			gaps = append(gaps, fmt.Sprintf("%s provisions %d read units, expected %d", scope, read, readCapacity))
+		}
+		if write := aws.ToInt64(throughput.WriteCapacityUnits); write != writeCapacity {
+			gaps = append(gaps, fmt.Sprintf("%s provisions %d write units, expected %d", scope, write, writeCapacity))
+		}
+	}
+	throughputGaps("table", table.ProvisionedThroughput)
+	for _, index := range table.GlobalSecondaryIndexes {
+		throughputGaps("global index "+aws.ToString(index.IndexName), index.ProvisionedThroughput)
+	}
+	return gaps
+}
@@ -0,0 +1,42 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageGaps(t *testing.T) {
+	assert.Empty(t, UsageGaps(TableUsage{PeakRead: 3.9, PeakWrite: 4}, 5, 5))
+	assert.Empty(t, UsageGaps(TableUsage{PeakRead: 900, PeakWrite: 400}, 0, 0), "On-demand tables have no capacity to approach")
+
+	assert.Equal(t, []string{
+		"2 read requests were throttled",
+		"1 write requests were throttled",
+		"peak write consumption of 4.50 units per second is above 80% of the 5 provisioned",
+	}, UsageGaps(TableUsage{PeakRead: 1, PeakWrite: 4.5, ReadThrottles: 2, WriteThrottles: 1}, 5, 5))
+}
+
+func TestCapacityModeGaps(t *testing.T) {
+	provisioned := func(read, write int64) *dynamodbtypes.ProvisionedThroughputDescription {
+		return &dynamodbtypes.ProvisionedThroughputDescription{ReadCapacityUnits: aws.Int64(read), WriteCapacityUnits: aws.Int64(write)}
+	}
+	table := dynamodbtypes.TableDescription{
+		ProvisionedThroughput: provisioned(5, 5),
+		GlobalSecondaryIndexes: []dynamodbtypes.GlobalSecondaryIndexDescription{
+			{IndexName: aws.String("name-index"), ProvisionedThroughput: provisioned(5, 10)},
+		},
+	}
+	assert.Equal(t, []string{"global index name-index provisions 10 write units, expected 5"}, CapacityModeGaps(table, 5, 5, false, false))
+	assert.Empty(t, CapacityModeGaps(table, 5, 5, true, true), "Auto scaled capacity may drift from the baseline")
+	assert.Equal(t, []string{"capacity is not managed by auto scaling"}, CapacityModeGaps(table, 5, 5, true, false))
+
+	onDemand := dynamodbtypes.TableDescription{
+		BillingModeSummary:    &dynamodbtypes.BillingModeSummary{BillingMode: dynamodbtypes.BillingModePayPerRequest},
+		ProvisionedThroughput: provisioned(0, 0),
+	}
+	assert.Empty(t, CapacityModeGaps(onDemand, 0, 0, false, false))
+	assert.Equal(t, []string{"capacity is managed by auto scaling, but the environment expects fixed capacity"}, CapacityModeGaps(onDemand, 0, 0, false, true))
+}
@@ -0,0 +1,69 @@
+package checks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	codedeploytypes "github.com/aws/aws-sdk-go-v2/service/codedeploy/types"
+)
+
+// IsGradualDeploymentConfig reports whether a Lambda deployment configuration shifts traffic in steps,
+// like CodeDeployDefault.LambdaCanary10Percent5Minutes, rather than all at once
+func IsGradualDeploymentConfig(name string) bool {
+	return strings.Contains(name, "Canary") || strings.Contains(name, "Linear")
+}
+
+// DeploymentGroupGaps describes how a Lambda deployment group falls short of a safe gradual rollout:
+// traffic shifted all at once, or a failed or alarming deployment that is not rolled back automatically
+func DeploymentGroupGaps(group codedeploytypes.DeploymentGroupInfo) []string {
+	var gaps []string
+	if config := aws.ToString(group.DeploymentConfigName); !IsGradualDeploymentConfig(config) {
+		gaps = append(gaps, fmt.Sprintf("deployment config %q shifts all traffic at once", config))
+	}
+
+	rollback := group.AutoRollbackConfiguration
+	if rollback == nil || !rollback.Enabled {
+		gaps = append(gaps, "automatic rollback is disabled")
+	} else {
+		for _, event := range []codedeploytypes.AutoRollbackEvent{
+			codedeploytypes.AutoRollbackEventDeploymentFailure,
+			codedeploytypes.AutoRollbackEventDeploymentStopOnAlarm,
+		} {
+			if !containsRollbackEvent(rollback.Events, event) {
+				gaps = append(gaps, fmt.Sprintf("no automatic rollback on %s", event))
+			}
+		}
+	}
+
+	alarms := group.AlarmConfiguration
+	switch {
+	case alarms == nil || !alarms.Enabled || len(alarms.Alarms) == 0:
+		gaps = append(gaps, "no alarms stop the deployment")
+	case alarms.IgnorePollAlarmFailure:
+		gaps = append(gaps, "deployment continues when alarm state cannot be read")
+	}
+	return gaps
+}
+
+// containsRollbackEvent reports whether events includes event
+func containsRollbackEvent(events []codedeploytypes.AutoRollbackEvent, event codedeploytypes.AutoRollbackEvent) bool {
+	for _, candidate := range events {
+		if candidate == event {
+			return true
+		}
+	}
+	return false
+}
+
+// AlarmNames returns the names of the alarms that stop a deployment group's deployments
+func AlarmNames(group codedeploytypes.DeploymentGroupInfo) []string {
+	if group.AlarmConfiguration == nil {
+		return nil
+	}
+	names := make([]string, 0, len(group.AlarmConfiguration.Alarms))
+	for _, alarm := range group.AlarmConfiguration.Alarms {
+		names = append(names, aws.ToString(alarm.Name))
+	}
+	return names
+}
@@ -0,0 +1,55 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	codedeploytypes "github.com/aws/aws-sdk-go-v2/service/codedeploy/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// safeDeploymentGroup rolls out in a canary and rolls back on failure or alarm
+func safeDeploymentGroup() codedeploytypes.DeploymentGroupInfo {
+	return codedeploytypes.DeploymentGroupInfo{
+		DeploymentGroupName:  aws.String("product-service"),
+		DeploymentConfigName: aws.String("CodeDeployDefault.LambdaCanary10Percent5Minutes"),
+		AutoRollbackConfiguration: &codedeploytypes.AutoRollbackConfiguration{
+			Enabled: true,
+			Events: []codedeploytypes.AutoRollbackEvent{
+				codedeploytypes.AutoRollbackEventDeploymentFailure,
+				codedeploytypes.AutoRollbackEventDeploymentStopOnAlarm,
+			},
+		},
+		AlarmConfiguration: &codedeploytypes.AlarmConfiguration{
+			Enabled: true,
+			Alarms:  []codedeploytypes.Alarm{{Name: aws.String("product-service-errors")}},
+		},
+	}
+}
+
+func TestIsGradualDeploymentConfig(t *testing.T) {
+	assert.True(t, IsGradualDeploymentConfig("CodeDeployDefault.LambdaCanary10Percent5Minutes"))
+	assert.True(t, IsGradualDeploymentConfig("CodeDeployDefault.LambdaLinear10PercentEvery1Minute"))
+	assert.False(t, IsGradualDeploymentConfig("CodeDeployDefault.LambdaAllAtOnce"))
+}
+
+func TestDeploymentGroupGaps(t *testing.T) {
+	assert.Empty(t, DeploymentGroupGaps(safeDeploymentGroup()))
+	assert.Equal(t, []string{"product-service-errors"}, AlarmNames(safeDeploymentGroup()))
+
+	allAtOnce := safeDeploymentGroup()
+	allAtOnce.DeploymentConfigName = aws.String("CodeDeployDefault.LambdaAllAtOnce")
+	allAtOnce.AutoRollbackConfiguration.Events = allAtOnce.AutoRollbackConfiguration.Events[:1]
+	allAtOnce.AlarmConfiguration.IgnorePollAlarmFailure = true
+	assert.Equal(t, []string{
+		`deployment config "CodeDeployDefault.LambdaAllAtOnce" shifts all traffic at once`,
+		"no automatic rollback on DEPLOYMENT_STOP_ON_ALARM",
+		"deployment continues when alarm state cannot be read",
+	}, DeploymentGroupGaps(allAtOnce))
+
+	unguarded := safeDeploymentGroup()
+	unguarded.AutoRollbackConfiguration = nil
+	unguarded.AlarmConfiguration = nil
+	assert.Equal(t, []string{"automatic rollback is disabled", "no alarms stop the deployment"}, DeploymentGroupGaps(unguarded))
+	assert.Empty(t, AlarmNames(unguarded))
+}
@@ -0,0 +1,30 @@
+package checks
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CertificateGaps describes why an ACM certificate cannot safely serve a custom domain: it is not issued,
+// or it expires within window of now. ACM renews certificates 60 days before they expire, so one inside
+// a shorter window means renewal is failing.
+func CertificateGaps(status string, notAfter, now time.Time, window time.Duration) []string {
+	var gaps []string
+	if status != "ISSUED" {
+		gaps = append(gaps, fmt.Sprintf("certificate status is %s", status))
+	}
+	switch remaining := notAfter.Sub(now); {
+	case remaining <= 0:
+		gaps = append(gaps, fmt.Sprintf("certificate expired on %s", notAfter.Format(time.DateOnly)))
+	case remaining < window:
+		gaps = append(gaps, fmt.Sprintf("certificate expires on %s, within %d days", notAfter.Format(time.DateOnly), int(window.Hours()/24)))
+	}
+	return gaps
+}
+
+// SameDNSName reports whether two DNS names are equal, ignoring case and a trailing dot, so a Route 53
+// alias target like "d-abc.execute-api.us-east-1.amazonaws.com." matches the name API Gateway reports
+func SameDNSName(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
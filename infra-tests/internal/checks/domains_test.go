@@ -0,0 +1,26 @@
+package checks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertificateGaps(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	window := 30 * 24 * time.Hour
+
+	assert.Empty(t, CertificateGaps("ISSUED", now.AddDate(0, 6, 0), now, window))
+	assert.Equal(t, []string{"certificate expires on 2024-06-11, within 30 days"},
+		CertificateGaps("ISSUED", now.AddDate(0, 0, 10), now, window))
+	assert.Equal(t, []string{"certificate status is EXPIRED", "certificate expired on 2024-05-01"},
+		CertificateGaps("EXPIRED", now.AddDate(0, -1, 0), now, window))
+	assert.Equal(t, []string{"certificate status is PENDING_VALIDATION"},
+		CertificateGaps("PENDING_VALIDATION", now.AddDate(1, 0, 0), now, window))
+}
+
+func TestSameDNSName(t *testing.T) {
+	assert.True(t, SameDNSName("d-abc123.execute-api.us-east-1.amazonaws.com.", "D-ABC123.execute-api.us-east-1.amazonaws.com"))
+	assert.False(t, SameDNSName("d-abc123.execute-api.us-east-1.amazonaws.com", "d-def456.execute-api.us-east-1.amazonaws.com"))
+}
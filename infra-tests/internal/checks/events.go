@@ -0,0 +1,64 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	eventbridgetypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+)
+
+// EventPattern holds the source and detail-type filters of an EventBridge rule pattern.
+// Each filter is a list of literal strings or content filters such as {"prefix": "order."}.
+type EventPattern struct {
+	Source     []json.RawMessage `json:"source"`
+	DetailType []json.RawMessage `json:"detail-type"`
+}
+
+// ParseEventPattern decodes the source and detail-type filters of a rule's event pattern
+func ParseEventPattern(document string) (EventPattern, error) {
+	var pattern EventPattern
+	if err := json.Unmarshal([]byte(document), &pattern); err != nil {
+		return EventPattern{}, fmt.Errorf("parsing event pattern: %w", err)
+	}
+	return pattern, nil
+}
+
+// MatchingEvent returns a source and detail type that the pattern matches.
+// ok is false when a filter only has content filters, so no literal value is known to match.
+func (p EventPattern) MatchingEvent() (source, detailType string, ok bool) {
+	source, ok = firstLiteral(p.Source)
+	if !ok {
+		return "", "", false
+	}
+	detailType, ok = firstLiteral(p.DetailType)
+	if !ok {
+		return "", "", false
+	}
+	return source, detailType, true
+}
+
+// firstLiteral returns the first string in a filter list; an absent filter matches anything
+func firstLiteral(values []json.RawMessage) (string, bool) {
+	if len(values) == 0 {
+		return "infra-tests", true
+	}
+	for _, value := range values {
+		var literal string
+		if json.Unmarshal(value, &literal) == nil {
+			return literal, true
+		}
+	}
+	return "", false
+}
+
+// TargetsWithoutDeadLetterQueue returns the IDs of rule targets that drop events when delivery fails
+func TargetsWithoutDeadLetterQueue(targets []eventbridgetypes.Target) []string {
+	var missing []string
+	for _, target := range targets {
+		if target.DeadLetterConfig == nil || aws.ToString(target.DeadLetterConfig.Arn) == "" {
+			missing = append(missing, aws.ToString(target.Id))
+		}
+	}
+	return missing
+}
@@ -0,0 +1,52 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	eventbridgetypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventPatternMatchingEvent(t *testing.T) {
+	tests := []struct {
+		name       string
+		pattern    string
+		source     string
+		detailType string
+		ok         bool
+	}{
+		{"literals", `{"source":["product-service"],"detail-type":["ProductCreated","ProductDeleted"]}`, "product-service", "ProductCreated", true},
+		{"literal after content filter", `{"source":[{"prefix":"product"},"product-service"],"detail-type":["ProductCreated"]}`, "product-service", "ProductCreated", true},
+		{"absent detail-type", `{"source":["product-service"]}`, "product-service", "infra-tests", true},
+		{"content filters only", `{"source":[{"prefix":"product"}],"detail-type":["ProductCreated"]}`, "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := ParseEventPattern(tt.pattern)
+			require.NoError(t, err)
+
+			source, detailType, ok := pattern.MatchingEvent()
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.source, source)
+			assert.Equal(t, tt.detailType, detailType)
+		})
+	}
+}
+
+func TestParseEventPatternRejectsInvalidJSON(t *testing.T) {
+	_, err := ParseEventPattern("not json")
+	assert.Error(t, err)
+}
+
+func TestTargetsWithoutDeadLetterQueue(t *testing.T) {
+	targets := []eventbridgetypes.Target{
+		{Id: aws.String("audit"), DeadLetterConfig: &eventbridgetypes.DeadLetterConfig{Arn: aws.String("arn:aws:sqs:us-east-1:123456789012:audit-dlq")}},
+		{Id: aws.String("no-config")},
+		{Id: aws.String("empty-arn"), DeadLetterConfig: &eventbridgetypes.DeadLetterConfig{}},
+	}
+
+	assert.Equal(t, []string{"no-config", "empty-arn"}, TargetsWithoutDeadLetterQueue(targets))
+}
@@ -0,0 +1,151 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// sourceConditionKeys are the condition keys that tie a service principal to one caller
+var sourceConditionKeys = []string{"aws:sourcearn", "aws:sourceaccount"}
+
+// FunctionURLGaps reports a function URL anyone can call without signing the request
+func FunctionURLGaps(url, authType string) []string {
+	if authType == "NONE" {
+		return []string{fmt.Sprintf("function URL %s has AuthType NONE, so anyone on the internet can invoke it", url)}
+	}
+	return nil
+}
+
+// policyStatement is one statement of a Lambda resource policy
+type policyStatement struct {
+	Sid       string                                `json:"Sid"`
+	Effect    string                                `json:"Effect"`
+	Principal json.RawMessage                       `json:"Principal"`
+	Action    json.RawMessage                       `json:"Action"`
+	Condition map[string]map[string]json.RawMessage `json:"Condition"`
+}
+
+// PublicInvokeGaps reports statements of a Lambda resource policy that let callers outside the deployment
+// invoke the function: a "*" principal whose aws:SourceArn is not one of allowedSources, such as the
+// project's API (arn:aws:execute-api:<region>:<account>:<api id>/) or state machine ARNs, and a service
+// principal not tied to a caller by aws:SourceArn or aws:SourceAccount, which any account's resource of
+// that service could use
+func PublicInvokeGaps(document string, allowedSources []string) ([]string, error) {
+	var policy struct {
+		Statement []policyStatement `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(document), &policy); err != nil {
+		return nil, fmt.Errorf("parsing resource policy: %w", err)
+	}
+
+	var gaps []string
+	for i, statement := range policy.Statement {
+		if statement.Effect != "Allow" || !invokes(statement.Action) {
+			continue
+		}
+		name := statement.Sid
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		sources := conditionValues(statement.Condition, "aws:sourcearn")
+
+		switch {
+		case publicPrincipal(statement.Principal):
+			if len(sources) == 0 {
+				gaps = append(gaps, fmt.Sprintf("statement %s lets any principal invoke the function", name))
+			}
+			for _, source := range sources {
+				if !hasAnyPrefix(source, allowedSources) {
+					gaps = append(gaps, fmt.Sprintf("statement %s lets any principal invoke the function from %s, which is not the deployment's API or state machine", name, source))
+				}
+			}
+		case servicePrincipal(statement.Principal):
+			tied := false
+			for _, key := range sourceConditionKeys {
+				tied = tied || len(conditionValues(statement.Condition, key)) > 0
+			}
+			if !tied {
+				gaps = append(gaps, fmt.Sprintf("statement %s lets the service invoke the function on behalf of any account; add an aws:SourceArn or aws:SourceAccount condition", name))
+			}
+		}
+	}
+	sort.Strings(gaps)
+	return gaps, nil
+}
+
+// invokes reports whether a statement's actions include invoking the function or its URL
+func invokes(action json.RawMessage) bool {
+	for _, name := range stringOrList(action) {
+		switch strings.ToLower(name) {
+		case "*", "lambda:*", "lambda:invoke*", "lambda:invokefunction", "lambda:invokefunctionurl":
+			return true
+		}
+	}
+	return false
+}
+
+// publicPrincipal reports whether a principal is "*" or {"AWS": "*"}
+func publicPrincipal(principal json.RawMessage) bool {
+	var wildcard string
+	if json.Unmarshal(principal, &wildcard) == nil {
+		return wildcard == "*"
+	}
+	var object map[string]json.RawMessage
+	if json.Unmarshal(principal, &object) != nil {
+		return false
+	}
+	for _, value := range stringOrList(object["AWS"]) {
+		if value == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// servicePrincipal reports whether a principal names an AWS service
+func servicePrincipal(principal json.RawMessage) bool {
+	var object map[string]json.RawMessage
+	if json.Unmarshal(principal, &object) != nil {
+		return false
+	}
+	return len(stringOrList(object["Service"])) > 0
+}
+
+// conditionValues returns the values a condition block gives a key under any operator; keys are case-insensitive
+func conditionValues(condition map[string]map[string]json.RawMessage, key string) []string {
+	var values []string
+	for _, keys := range condition {
+		for name, value := range keys {
+			if strings.EqualFold(name, key) {
+				values = append(values, stringOrList(value)...)
+			}
+		}
+	}
+	return values
+}
+
+// stringOrList decodes a policy value that is either a string or a list of strings
+func stringOrList(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var single string
+	if json.Unmarshal(raw, &single) == nil {
+		return []string{single}
+	}
+	var list []string
+	_ = json.Unmarshal(raw, &list)
+	return list
+}
+
+// hasAnyPrefix reports whether value starts with one of prefixes
+func hasAnyPrefix(value string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
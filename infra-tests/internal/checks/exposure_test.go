@@ -0,0 +1,48 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunctionURLGaps(t *testing.T) {
+	assert.Empty(t, FunctionURLGaps("https://abc.lambda-url.us-east-1.on.aws/", "AWS_IAM"))
+	assert.Equal(t, []string{"function URL https://abc.lambda-url.us-east-1.on.aws/ has AuthType NONE, so anyone on the internet can invoke it"},
+		FunctionURLGaps("https://abc.lambda-url.us-east-1.on.aws/", "NONE"))
+}
+
+func TestPublicInvokeGaps(t *testing.T) {
+	allowed := []string{"arn:aws:execute-api:us-east-1:123456789012:abc123/"}
+	document := `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{"Sid": "api", "Effect": "Allow", "Principal": {"Service": "apigateway.amazonaws.com"}, "Action": "lambda:InvokeFunction",
+			 "Condition": {"ArnLike": {"AWS:SourceArn": "arn:aws:execute-api:us-east-1:123456789012:abc123/*/*"}}},
+			{"Sid": "scoped-wildcard", "Effect": "Allow", "Principal": "*", "Action": "lambda:InvokeFunction",
+			 "Condition": {"ArnLike": {"aws:SourceArn": "arn:aws:execute-api:us-east-1:123456789012:abc123/*"}}},
+			{"Sid": "url", "Effect": "Allow", "Principal": "*", "Action": "lambda:InvokeFunctionUrl",
+			 "Condition": {"StringEquals": {"lambda:FunctionUrlAuthType": "NONE"}}},
+			{"Sid": "other-api", "Effect": "Allow", "Principal": {"AWS": "*"}, "Action": ["lambda:InvokeFunction"],
+			 "Condition": {"ArnLike": {"AWS:SourceArn": "arn:aws:execute-api:us-east-1:999999999999:zzz/*"}}},
+			{"Sid": "any-topic", "Effect": "Allow", "Principal": {"Service": "sns.amazonaws.com"}, "Action": "lambda:InvokeFunction"},
+			{"Sid": "own-account", "Effect": "Allow", "Principal": {"Service": "events.amazonaws.com"}, "Action": "lambda:InvokeFunction",
+			 "Condition": {"StringEquals": {"AWS:SourceAccount": "123456789012"}}},
+			{"Sid": "read-only", "Effect": "Allow", "Principal": "*", "Action": "lambda:GetFunction"},
+			{"Sid": "denied", "Effect": "Deny", "Principal": "*", "Action": "lambda:InvokeFunction"},
+			{"Sid": "partner", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::210987654321:root"}, "Action": "lambda:InvokeFunction"}
+		]
+	}`
+
+	gaps, err := PublicInvokeGaps(document, allowed)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"statement any-topic lets the service invoke the function on behalf of any account; add an aws:SourceArn or aws:SourceAccount condition",
+		"statement other-api lets any principal invoke the function from arn:aws:execute-api:us-east-1:999999999999:zzz/*, which is not the deployment's API or state machine",
+		"statement url lets any principal invoke the function",
+	}, gaps)
+
+	_, err = PublicInvokeGaps("not json", allowed)
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+package checks
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// IndexQueryGaps compares the items a global secondary index query returned with the base table items
+// an oracle scan found for the same index key. Every oracle item must be returned exactly once and nothing
+// else may be; with an ALL projection each returned item must also equal its base table item attribute for attribute.
+// Items are identified by keyAttribute, the table's partition key.
+func IndexQueryGaps(returned, oracle []map[string]dynamodbtypes.AttributeValue, keyAttribute string, projectsAll bool) []string {
+	expected := map[string]map[string]dynamodbtypes.AttributeValue{}
+	for _, item := range oracle {
+		expected[itemKey(item, keyAttribute)] = item
+	}
+
+	var gaps []string
+	seen := map[string]int{}
+	for _, item := range returned {
+		key := itemKey(item, keyAttribute)
+		seen[key]++
+		base, ok := expected[key]
+		switch {
+		case !ok:
+			gaps = append(gaps, fmt.Sprintf("index returned %s, which the scan did not find", key))
+		case seen[key] == 2:
+			gaps = append(gaps, fmt.Sprintf("index returned %s more than once", key))
+		case seen[key] == 1 && projectsAll:
+			for _, attribute := range differingAttributes(base, item) {
+				gaps = append(gaps, fmt.Sprintf("index item %s differs from the table in %s", key, attribute))
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(expected))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if seen[key] == 0 {
+			gaps = append(gaps, fmt.Sprintf("index did not return %s", key))
+		}
+	}
+	return gaps
+}
+
+// itemKey renders an item's key attribute for gaps
+func itemKey(item map[string]dynamodbtypes.AttributeValue, keyAttribute string) string {
+	if value, ok := item[keyAttribute].(*dynamodbtypes.AttributeValueMemberS); ok {
+		return value.Value
+	}
+	return fmt.Sprintf("%v", item[keyAttribute])
+}
+
+// differingAttributes returns, sorted, the attributes missing from or different in projected
+func differingAttributes(base, projected map[string]dynamodbtypes.AttributeValue) []string {
+	var attributes []string
+	for name, value := range base {
+		if !reflect.DeepEqual(value, projected[name]) {
+			attributes = append(attributes, name)
+		}
+	}
+	for name := range projected {
+		if _, ok := base[name]; !ok {
+			attributes = append(attributes, name)
+		}
+	}
+	sort.Strings(attributes)
+	return attributes
+}
@@ -0,0 +1,32 @@
+package checks
+
+import (
+	"testing"
+
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexQueryGaps(t *testing.T) {
+	product := func(id, name, price string) map[string]dynamodbtypes.AttributeValue {
+		return map[string]dynamodbtypes.AttributeValue{
+			"id":    &dynamodbtypes.AttributeValueMemberS{Value: id},
+			"name":  &dynamodbtypes.AttributeValueMemberS{Value: name},
+			"price": &dynamodbtypes.AttributeValueMemberN{Value: price},
+		}
+	}
+	oracle := []map[string]dynamodbtypes.AttributeValue{product("a", "Café", "1"), product("b", "Café", "2")}
+
+	assert.Empty(t, IndexQueryGaps([]map[string]dynamodbtypes.AttributeValue{product("b", "Café", "2"), product("a", "Café", "1")}, oracle, "id", true))
+
+	keysOnly := map[string]dynamodbtypes.AttributeValue{"id": oracle[0]["id"], "name": oracle[0]["name"]}
+	assert.Empty(t, IndexQueryGaps([]map[string]dynamodbtypes.AttributeValue{keysOnly, oracle[1]}, oracle, "id", false))
+	assert.Equal(t, []string{"index item a differs from the table in price"},
+		IndexQueryGaps([]map[string]dynamodbtypes.AttributeValue{keysOnly, oracle[1]}, oracle, "id", true))
+
+	assert.Equal(t, []string{
+		"index returned a more than once",
+		"index returned c, which the scan did not find",
+		"index did not return b",
+	}, IndexQueryGaps([]map[string]dynamodbtypes.AttributeValue{oracle[0], oracle[0], product("c", "Café", "3")}, oracle, "id", true))
+}
@@ -0,0 +1,121 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	logstypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+)
+
+// Logs Insights queries run against a function's log group. Each one reduces to a single stats row.
+const (
+	// UnstructuredLogQuery counts application lines that are not JSON objects.
+	// Lambda's own START/END/REPORT/INIT lines are plain text in the Text log format and are ignored.
+	UnstructuredLogQuery = `fields @message
+| filter @message not like /^\s*\{/
+| filter @message not like /^(START|END|REPORT|INIT_START|INIT_REPORT|EXTENSION|TELEMETRY)\b/
+| stats count() as count`
+
+	// ErrorLogQuery counts entries logged at ERROR level by Powertools' JSON layout
+	ErrorLogQuery = `fields @message
+| filter level = "ERROR"
+| stats count() as count`
+
+	// ColdStartQuery counts invocations that paid an init phase and the longest init they paid
+	ColdStartQuery = `filter @type = "REPORT"
+| stats count() as invocations, count(@initDuration) as coldStarts, max(@initDuration) as maxInitDuration`
+)
+
+// MessageCountQuery counts log entries containing text, such as a correlation id
+func MessageCountQuery(text string) string {
+	return fmt.Sprintf("fields @message\n| filter @message like %q\n| stats count() as count", text)
+}
+
+// InsightsValue returns a field of the first result row, or "" when the query matched nothing
+func InsightsValue(rows [][]logstypes.ResultField, field string) string {
+	if len(rows) == 0 {
+		return ""
+	}
+	for _, column := range rows[0] {
+		if column.Field != nil && *column.Field == field && column.Value != nil {
+			return *column.Value
+		}
+	}
+	return ""
+}
+
+// InsightsCount returns a numeric stats field of the first result row, or 0 when the query matched nothing
+func InsightsCount(rows [][]logstypes.ResultField, field string) (int, error) {
+	value := InsightsValue(rows, field)
+	if value == "" {
+		return 0, nil
+	}
+	count, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing %s %q: %w", field, value, err)
+	}
+	return int(count), nil
+}
+
+// InsightsMilliseconds returns a stats field in milliseconds, such as max(@initDuration), as a duration
+func InsightsMilliseconds(rows [][]logstypes.ResultField, field string) (time.Duration, error) {
+	value := InsightsValue(rows, field)
+	if value == "" {
+		return 0, nil
+	}
+	milliseconds, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing %s %q: %w", field, value, err)
+	}
+	return time.Duration(milliseconds * float64(time.Millisecond)), nil
+}
+
+// ShipsTo reports whether a subscription filter forwards the log group to a destination ARN starting with destination
+func ShipsTo(filters []logstypes.SubscriptionFilter, destination string) bool {
+	for _, filter := range filters {
+		if strings.HasPrefix(aws.ToString(filter.DestinationArn), destination) {
+			return true
+		}
+	}
+	return false
+}
+
+// AccessLogFields are the fields every API access log entry must carry, with the $context variable each holds
+var AccessLogFields = map[string]string{
+	"requestId":          "$context.requestId",
+	"routeKey":           "$context.routeKey",
+	"status":             "$context.status",
+	"integrationLatency": "$context.integrationLatency",
+}
+
+// AccessLogFormatGaps describes how an API stage's access log format falls short of JSON entries with the
+// required fields: a format that is not a JSON object, and fields missing or holding a different variable
+func AccessLogFormatGaps(format string, required map[string]string) []string {
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(format), &fields); err != nil {
+		return []string{fmt.Sprintf("format is not a JSON object: %s", format)}
+	}
+
+	names := make([]string, 0, len(required))
+	for name := range required {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var gaps []string
+	for _, name := range names {
+		value, ok := fields[name]
+		switch {
+		case !ok:
+			gaps = append(gaps, fmt.Sprintf("no %s field", name))
+		case value != required[name]:
+			gaps = append(gaps, fmt.Sprintf("%s field is %v, not %s", name, value, required[name]))
+		}
+	}
+	return gaps
+}
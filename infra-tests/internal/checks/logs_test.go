@@ -0,0 +1,72 @@
+package checks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	logstypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statsRow builds a single Logs Insights result row from field/value pairs
+func statsRow(pairs ...string) [][]logstypes.ResultField {
+	var row []logstypes.ResultField
+	for i := 0; i < len(pairs); i += 2 {
+		row = append(row, logstypes.ResultField{Field: aws.String(pairs[i]), Value: aws.String(pairs[i+1])})
+	}
+	return [][]logstypes.ResultField{row}
+}
+
+func TestInsightsCount(t *testing.T) {
+	rows := statsRow("invocations", "12", "coldStarts", "2")
+
+	count, err := InsightsCount(rows, "coldStarts")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = InsightsCount(nil, "count")
+	require.NoError(t, err)
+	assert.Zero(t, count, "A query that matched nothing returns no rows")
+
+	_, err = InsightsCount(statsRow("count", "many"), "count")
+	assert.Error(t, err)
+}
+
+func TestInsightsMilliseconds(t *testing.T) {
+	duration, err := InsightsMilliseconds(statsRow("maxInitDuration", "2841.57"), "maxInitDuration")
+	require.NoError(t, err)
+	assert.Equal(t, 2841*time.Millisecond, duration.Truncate(time.Millisecond))
+
+	duration, err = InsightsMilliseconds(statsRow("coldStarts", "0"), "maxInitDuration")
+	require.NoError(t, err)
+	assert.Zero(t, duration)
+}
+
+func TestMessageCountQuery(t *testing.T) {
+	assert.Contains(t, MessageCountQuery("itest-logs-1"), `filter @message like "itest-logs-1"`)
+}
+
+func TestShipsTo(t *testing.T) {
+	filters := []logstypes.SubscriptionFilter{
+		{FilterName: aws.String("ship"), DestinationArn: aws.String("arn:aws:firehose:us-east-1:123456789012:deliverystream/central-logs")},
+	}
+	assert.True(t, ShipsTo(filters, "arn:aws:firehose:us-east-1:123456789012:deliverystream/central-logs"))
+	assert.True(t, ShipsTo(filters, "arn:aws:firehose:"), "Destinations match by prefix")
+	assert.False(t, ShipsTo(filters, "arn:aws:lambda:"))
+	assert.False(t, ShipsTo(nil, "arn:aws:firehose:"))
+}
+
+func TestAccessLogFormatGaps(t *testing.T) {
+	format := `{"requestId":"$context.requestId","routeKey":"$context.routeKey","status":"$context.status","integrationLatency":"$context.integrationLatency","ip":"$context.identity.sourceIp"}`
+	assert.Empty(t, AccessLogFormatGaps(format, AccessLogFields))
+
+	assert.Equal(t, []string{
+		"no integrationLatency field",
+		"status field is $context.integrationStatus, not $context.status",
+	}, AccessLogFormatGaps(`{"requestId":"$context.requestId","routeKey":"$context.routeKey","status":"$context.integrationStatus"}`, AccessLogFields))
+
+	assert.Equal(t, []string{"format is not a JSON object: $context.requestId $context.status"},
+		AccessLogFormatGaps("$context.requestId $context.status", AccessLogFields))
+}
@@ -0,0 +1,89 @@
+package checks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MinSubnetZones is the fewest Availability Zones a VPC-attached function's subnets may span;
+// with one zone, an outage of that zone takes the function down
+const MinSubnetZones = 2
+
+// PrivateServices are the AWS services the workflow functions call, which a VPC-attached function
+// reaches through a VPC endpoint or a NAT gateway
+var PrivateServices = []string{"dynamodb", "states"}
+
+// EgressRule is one outbound rule of a security group. Protocol "-1" means every protocol and port.
+type EgressRule struct {
+	Protocol     string
+	FromPort     int32
+	ToPort       int32
+	Destinations []string
+}
+
+// ZoneSpreadGaps describes a function whose subnets, mapped to their Availability Zones, span fewer than MinSubnetZones zones
+func ZoneSpreadGaps(subnetZones map[string]string) []string {
+	zones := map[string]bool{}
+	for _, zone := range subnetZones {
+		zones[zone] = true
+	}
+	if len(zones) >= MinSubnetZones {
+		return nil
+	}
+	return []string{fmt.Sprintf("subnets span %d Availability Zone(s) %v, expected at least %d", len(zones), sortedZones(zones), MinSubnetZones)}
+}
+
+// EgressGaps describes security group rules that keep a function from reaching AWS APIs, which are served over HTTPS
+func EgressGaps(rules []EgressRule) []string {
+	for _, rule := range rules {
+		if len(rule.Destinations) == 0 {
+			continue
+		}
+		if rule.Protocol == "-1" || (strings.EqualFold(rule.Protocol, "tcp") && rule.FromPort <= 443 && rule.ToPort >= 443) {
+			return nil
+		}
+	}
+	return []string{"no egress rule allows HTTPS (TCP 443), so AWS APIs are unreachable"}
+}
+
+// PrivateAccessGaps describes the PrivateServices a function in a VPC cannot reach: those without an interface
+// or gateway endpoint in endpointServices (service names such as com.amazonaws.us-east-1.dynamodb) when not
+// every subnet routes the internet through a NAT gateway
+func PrivateAccessGaps(region string, endpointServices []string, natRoute bool) []string {
+	if natRoute {
+		return nil
+	}
+	endpoints := map[string]bool{}
+	for _, service := range endpointServices {
+		endpoints[service] = true
+	}
+
+	var gaps []string
+	for _, service := range PrivateServices {
+		name := fmt.Sprintf("com.amazonaws.%s.%s", region, service)
+		if !endpoints[name] {
+			gaps = append(gaps, fmt.Sprintf("no VPC endpoint for %s and no NAT gateway route, so %s is unreachable", name, service))
+		}
+	}
+	return gaps
+}
+
+// ColdStartRegressionGaps describes a p95 cold start more than allowanceMs above the previous run's
+func ColdStartRegressionGaps(p95Ms, previousP95Ms, allowanceMs int64) []string {
+	if p95Ms <= previousP95Ms+allowanceMs {
+		return nil
+	}
+	return []string{fmt.Sprintf("p95 cold start %dms is %dms above the previous run's %dms, more than the %dms allowed",
+		p95Ms, p95Ms-previousP95Ms, previousP95Ms, allowanceMs)}
+}
+
+// sortedZones returns the keys of a set in order
+func sortedZones(zones map[string]bool) []string {
+	names := make([]string, 0, len(zones))
+	for zone := range zones {
+		names = append(names, zone)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,43 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZoneSpreadGaps(t *testing.T) {
+	assert.Empty(t, ZoneSpreadGaps(map[string]string{"subnet-a": "us-east-1a", "subnet-b": "us-east-1b"}))
+	assert.Equal(t, []string{"subnets span 1 Availability Zone(s) [us-east-1a], expected at least 2"},
+		ZoneSpreadGaps(map[string]string{"subnet-a": "us-east-1a", "subnet-b": "us-east-1a"}))
+}
+
+func TestEgressGaps(t *testing.T) {
+	anywhere := []string{"0.0.0.0/0"}
+	assert.Empty(t, EgressGaps([]EgressRule{{Protocol: "-1", Destinations: anywhere}}))
+	assert.Empty(t, EgressGaps([]EgressRule{{Protocol: "tcp", FromPort: 443, ToPort: 443, Destinations: []string{"pl-02cd2c6b"}}}))
+	assert.Empty(t, EgressGaps([]EgressRule{{Protocol: "tcp", FromPort: 0, ToPort: 65535, Destinations: anywhere}}))
+
+	blocked := []string{"no egress rule allows HTTPS (TCP 443), so AWS APIs are unreachable"}
+	assert.Equal(t, blocked, EgressGaps(nil))
+	assert.Equal(t, blocked, EgressGaps([]EgressRule{
+		{Protocol: "tcp", FromPort: 5432, ToPort: 5432, Destinations: anywhere},
+		{Protocol: "udp", FromPort: 443, ToPort: 443, Destinations: anywhere},
+		{Protocol: "tcp", FromPort: 443, ToPort: 443},
+	}))
+}
+
+func TestPrivateAccessGaps(t *testing.T) {
+	assert.Empty(t, PrivateAccessGaps("us-east-1", nil, true), "A NAT gateway reaches every service")
+	assert.Empty(t, PrivateAccessGaps("us-east-1", []string{"com.amazonaws.us-east-1.dynamodb", "com.amazonaws.us-east-1.states"}, false))
+
+	assert.Equal(t, []string{
+		"no VPC endpoint for com.amazonaws.us-east-1.states and no NAT gateway route, so states is unreachable",
+	}, PrivateAccessGaps("us-east-1", []string{"com.amazonaws.us-east-1.dynamodb", "com.amazonaws.eu-west-1.states"}, false))
+}
+
+func TestColdStartRegressionGaps(t *testing.T) {
+	assert.Empty(t, ColdStartRegressionGaps(2400, 2000, 500))
+	assert.Equal(t, []string{"p95 cold start 2600ms is 600ms above the previous run's 2000ms, more than the 500ms allowed"},
+		ColdStartRegressionGaps(2600, 2000, 500))
+}
@@ -0,0 +1,53 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// NotificationOrderConfirmation is the notification type sent when an order completes the workflow
+const NotificationOrderConfirmation = "ORDER_CONFIRMATION"
+
+// NotificationRequiredFields are the fields every notification payload must carry
+var NotificationRequiredFields = []string{"notificationType", "orderId", "customerId", "timestamp"}
+
+// snsEnvelope is the JSON body SNS delivers to an SQS subscription without raw message delivery
+type snsEnvelope struct {
+	Type     string `json:"Type"`
+	TopicArn string `json:"TopicArn"`
+	Message  string `json:"Message"`
+}
+
+// ParseNotification unwraps an SQS message body delivered from SNS and decodes its JSON payload.
+// Bodies delivered with raw message delivery are decoded as the payload itself.
+func ParseNotification(body string) (map[string]any, error) {
+	var envelope snsEnvelope
+	if err := json.Unmarshal([]byte(body), &envelope); err != nil {
+		return nil, fmt.Errorf("parsing message body: %w", err)
+	}
+
+	message := body
+	if envelope.Type == "Notification" {
+		message = envelope.Message
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(message), &payload); err != nil {
+		return nil, fmt.Errorf("parsing notification payload: %w", err)
+	}
+	return payload, nil
+}
+
+// MissingNotificationFields returns the required fields that are absent or not non-empty strings
+func MissingNotificationFields(payload map[string]any) []string {
+	var missing []string
+	for _, field := range NotificationRequiredFields {
+		value, ok := payload[field].(string)
+		if !ok || value == "" {
+			missing = append(missing, field)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
@@ -0,0 +1,45 @@
+package checks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNotificationUnwrapsSNSEnvelope(t *testing.T) {
+	payload := `{"notificationType":"ORDER_CONFIRMATION","orderId":"itest-1","customerId":"c-1","timestamp":"2024-06-01T12:00:00Z"}`
+	envelope, err := json.Marshal(map[string]string{
+		"Type":     "Notification",
+		"TopicArn": "arn:aws:sns:us-east-1:123456789012:lambda-java-template-dev-notifications",
+		"Message":  payload,
+	})
+	require.NoError(t, err)
+
+	notification, err := ParseNotification(string(envelope))
+	require.NoError(t, err)
+	assert.Equal(t, NotificationOrderConfirmation, notification["notificationType"])
+	assert.Empty(t, MissingNotificationFields(notification))
+}
+
+func TestParseNotificationAcceptsRawDelivery(t *testing.T) {
+	notification, err := ParseNotification(`{"notificationType":"ORDER_CONFIRMATION","orderId":"itest-1"}`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"customerId", "timestamp"}, MissingNotificationFields(notification))
+}
+
+func TestParseNotificationRejectsNonJSONMessage(t *testing.T) {
+	_, err := ParseNotification(`{"Type":"Notification","Message":"Your order shipped"}`)
+	assert.Error(t, err)
+}
+
+func TestMissingNotificationFieldsRejectsEmptyAndNonString(t *testing.T) {
+	missing := MissingNotificationFields(map[string]any{
+		"notificationType": "ORDER_CONFIRMATION",
+		"orderId":          "",
+		"customerId":       42,
+		"timestamp":        "2024-06-01T12:00:00Z",
+	})
+	assert.Equal(t, []string{"customerId", "orderId"}, missing)
+}
@@ -0,0 +1,104 @@
+package checks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// EventLoopPrincipals are the service principals whose invocations can feed back into a function
+var EventLoopPrincipals = []string{
+	"events.amazonaws.com",
+	"sqs.amazonaws.com",
+	"sns.amazonaws.com",
+}
+
+// PolicyServicePrincipals returns the service principals allowed by a resource policy document
+func PolicyServicePrincipals(document string) ([]string, error) {
+	var policy struct {
+		Statement []struct {
+			Effect    string          `json:"Effect"`
+			Principal json.RawMessage `json:"Principal"`
+		} `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(document), &policy); err != nil {
+		return nil, fmt.Errorf("parsing resource policy: %w", err)
+	}
+
+	var principals []string
+	for _, statement := range policy.Statement {
+		if statement.Effect != "Allow" || len(statement.Principal) == 0 {
+			continue
+		}
+
+		// A principal is either "*" or an object whose values are a string or a list of strings
+		var wildcard string
+		if json.Unmarshal(statement.Principal, &wildcard) == nil {
+			continue
+		}
+		var principal map[string]json.RawMessage
+		if err := json.Unmarshal(statement.Principal, &principal); err != nil {
+			return nil, fmt.Errorf("parsing policy principal: %w", err)
+		}
+
+		services, ok := principal["Service"]
+		if !ok {
+			continue
+		}
+		var single string
+		if json.Unmarshal(services, &single) == nil {
+			principals = append(principals, single)
+			continue
+		}
+		var list []string
+		if err := json.Unmarshal(services, &list); err != nil {
+			return nil, fmt.Errorf("parsing service principal: %w", err)
+		}
+		principals = append(principals, list...)
+	}
+	return principals, nil
+}
+
+// EventLoopSource returns the event source or service principal that can invoke a function in a loop,
+// or an empty string when the function is not event driven
+func EventLoopSource(ctx context.Context, api EventSourceAPI, functionName string) (string, error) {
+	mappings, err := api.ListEventSourceMappings(ctx, &lambda.ListEventSourceMappingsInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(mappings.EventSourceMappings) > 0 {
+		return aws.ToString(mappings.EventSourceMappings[0].EventSourceArn), nil
+	}
+
+	policy, err := api.GetPolicy(ctx, &lambda.GetPolicyInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		// Functions without a resource policy cannot be invoked by other services
+		var notFound *lambdatypes.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	principals, err := PolicyServicePrincipals(aws.ToString(policy.Policy))
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", functionName, err)
+	}
+	for _, principal := range principals {
+		for _, loopPrincipal := range EventLoopPrincipals {
+			if principal == loopPrincipal {
+				return principal, nil
+			}
+		}
+	}
+	return "", nil
+}
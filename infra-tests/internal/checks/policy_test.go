@@ -0,0 +1,85 @@
+package checks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks/mocks"
+)
+
+func TestPolicyServicePrincipals(t *testing.T) {
+	document := `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{"Effect": "Allow", "Principal": {"Service": "apigateway.amazonaws.com"}, "Action": "lambda:InvokeFunction"},
+			{"Effect": "Allow", "Principal": {"Service": ["events.amazonaws.com", "sns.amazonaws.com"]}, "Action": "lambda:InvokeFunction"},
+			{"Effect": "Deny", "Principal": {"Service": "sqs.amazonaws.com"}, "Action": "lambda:InvokeFunction"},
+			{"Effect": "Allow", "Principal": "*", "Action": "lambda:GetFunction"},
+			{"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:root"}, "Action": "lambda:InvokeFunction"}
+		]
+	}`
+
+	principals, err := PolicyServicePrincipals(document)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apigateway.amazonaws.com", "events.amazonaws.com", "sns.amazonaws.com"}, principals)
+}
+
+func TestPolicyServicePrincipalsRejectsInvalidJSON(t *testing.T) {
+	_, err := PolicyServicePrincipals("not json")
+	assert.Error(t, err)
+}
+
+func TestEventLoopSourcePrefersEventSourceMappings(t *testing.T) {
+	api := mocks.NewEventSourceAPI(t)
+	api.EXPECT().ListEventSourceMappings(mock.Anything, mock.Anything).Return(&lambda.ListEventSourceMappingsOutput{
+		EventSourceMappings: []lambdatypes.EventSourceMappingConfiguration{
+			{EventSourceArn: aws.String("arn:aws:sqs:us-east-1:123456789012:orders")},
+		},
+	}, nil)
+
+	source, err := EventLoopSource(context.TODO(), api, "fn")
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:sqs:us-east-1:123456789012:orders", source)
+}
+
+func TestEventLoopSourceReadsPolicyPrincipals(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   string
+		expected string
+	}{
+		{"EventBridge", `{"Statement":[{"Effect":"Allow","Principal":{"Service":"events.amazonaws.com"}}]}`, "events.amazonaws.com"},
+		{"API Gateway only", `{"Statement":[{"Effect":"Allow","Principal":{"Service":"apigateway.amazonaws.com"}}]}`, ""},
+		// Principal names appearing in conditions must not count as invokers
+		{"Condition mention", `{"Statement":[{"Effect":"Allow","Principal":{"Service":"apigateway.amazonaws.com"},"Condition":{"StringEquals":{"aws:SourceService":"sns.amazonaws.com"}}}]}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := mocks.NewEventSourceAPI(t)
+			api.EXPECT().ListEventSourceMappings(mock.Anything, mock.Anything).Return(&lambda.ListEventSourceMappingsOutput{}, nil)
+			api.EXPECT().GetPolicy(mock.Anything, mock.Anything).Return(&lambda.GetPolicyOutput{Policy: aws.String(tt.policy)}, nil)
+
+			source, err := EventLoopSource(context.TODO(), api, "fn")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, source)
+		})
+	}
+}
+
+func TestEventLoopSourceWithoutPolicy(t *testing.T) {
+	api := mocks.NewEventSourceAPI(t)
+	api.EXPECT().ListEventSourceMappings(mock.Anything, mock.Anything).Return(&lambda.ListEventSourceMappingsOutput{}, nil)
+	api.EXPECT().GetPolicy(mock.Anything, mock.Anything).Return(nil, &lambdatypes.ResourceNotFoundException{Message: aws.String("no policy")})
+
+	source, err := EventLoopSource(context.TODO(), api, "fn")
+	require.NoError(t, err)
+	assert.Empty(t, source)
+}
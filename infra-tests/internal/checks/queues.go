@@ -0,0 +1,57 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// VisibilityTimeoutFactor is how many function timeouts an SQS event source's visibility timeout must cover,
+// following the Lambda guidance so retried batches are not redelivered while still being processed
+const VisibilityTimeoutFactor = 6
+
+// RedrivePolicy is the decoded RedrivePolicy attribute of an SQS queue
+type RedrivePolicy struct {
+	DeadLetterTargetArn string `json:"deadLetterTargetArn"`
+	MaxReceiveCount     int    `json:"maxReceiveCount"`
+}
+
+// ParseRedrivePolicy decodes a RedrivePolicy attribute.
+// SQS returns maxReceiveCount as a number or a string depending on how the policy was written.
+func ParseRedrivePolicy(document string) (RedrivePolicy, error) {
+	var raw struct {
+		DeadLetterTargetArn string          `json:"deadLetterTargetArn"`
+		MaxReceiveCount     json.RawMessage `json:"maxReceiveCount"`
+	}
+	if err := json.Unmarshal([]byte(document), &raw); err != nil {
+		return RedrivePolicy{}, fmt.Errorf("parsing redrive policy: %w", err)
+	}
+
+	policy := RedrivePolicy{DeadLetterTargetArn: raw.DeadLetterTargetArn}
+	if len(raw.MaxReceiveCount) == 0 {
+		return policy, nil
+	}
+	if json.Unmarshal(raw.MaxReceiveCount, &policy.MaxReceiveCount) == nil {
+		return policy, nil
+	}
+	var text string
+	if err := json.Unmarshal(raw.MaxReceiveCount, &text); err != nil {
+		return RedrivePolicy{}, fmt.Errorf("parsing maxReceiveCount: %w", err)
+	}
+	count, err := strconv.Atoi(text)
+	if err != nil {
+		return RedrivePolicy{}, fmt.Errorf("parsing maxReceiveCount %q: %w", text, err)
+	}
+	policy.MaxReceiveCount = count
+	return policy, nil
+}
+
+// QueueEncrypted reports whether queue attributes enable SQS-managed or KMS server-side encryption
+func QueueEncrypted(attributes map[string]string) bool {
+	return attributes["SqsManagedSseEnabled"] == "true" || attributes["KmsMasterKeyId"] != ""
+}
+
+// MinVisibilityTimeout returns the smallest visibility timeout, in seconds, for a queue feeding a function
+func MinVisibilityTimeout(functionTimeoutSeconds int32) int {
+	return int(functionTimeoutSeconds) * VisibilityTimeoutFactor
+}
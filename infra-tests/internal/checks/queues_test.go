@@ -0,0 +1,47 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRedrivePolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		expected RedrivePolicy
+	}{
+		{"numeric count", `{"deadLetterTargetArn":"arn:aws:sqs:us-east-1:123456789012:orders-dlq","maxReceiveCount":5}`,
+			RedrivePolicy{DeadLetterTargetArn: "arn:aws:sqs:us-east-1:123456789012:orders-dlq", MaxReceiveCount: 5}},
+		{"string count", `{"deadLetterTargetArn":"arn:aws:sqs:us-east-1:123456789012:orders-dlq","maxReceiveCount":"3"}`,
+			RedrivePolicy{DeadLetterTargetArn: "arn:aws:sqs:us-east-1:123456789012:orders-dlq", MaxReceiveCount: 3}},
+		{"no count", `{"deadLetterTargetArn":"arn:aws:sqs:us-east-1:123456789012:orders-dlq"}`,
+			RedrivePolicy{DeadLetterTargetArn: "arn:aws:sqs:us-east-1:123456789012:orders-dlq"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := ParseRedrivePolicy(tt.document)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, policy)
+		})
+	}
+}
+
+func TestParseRedrivePolicyRejectsInvalidCount(t *testing.T) {
+	_, err := ParseRedrivePolicy(`{"deadLetterTargetArn":"arn","maxReceiveCount":"many"}`)
+	assert.Error(t, err)
+}
+
+func TestQueueEncrypted(t *testing.T) {
+	assert.True(t, QueueEncrypted(map[string]string{"SqsManagedSseEnabled": "true"}))
+	assert.True(t, QueueEncrypted(map[string]string{"SqsManagedSseEnabled": "false", "KmsMasterKeyId": "alias/aws/sqs"}))
+	assert.False(t, QueueEncrypted(map[string]string{"SqsManagedSseEnabled": "false"}))
+	assert.False(t, QueueEncrypted(nil))
+}
+
+func TestMinVisibilityTimeout(t *testing.T) {
+	assert.Equal(t, 180, MinVisibilityTimeout(30))
+}
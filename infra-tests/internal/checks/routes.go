@@ -0,0 +1,49 @@
+package checks
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+)
+
+// DeployedRoutes returns the route keys of an API, skipping reserved routes such as $default
+func DeployedRoutes(ctx context.Context, api RouteAPI, apiID string) (map[string]bool, error) {
+	routes := make(map[string]bool)
+	var nextToken *string
+	for {
+		page, err := api.GetRoutes(ctx, &apigatewayv2.GetRoutesInput{
+			ApiId:     aws.String(apiID),
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, route := range page.Items {
+			if strings.HasPrefix(aws.ToString(route.RouteKey), "$") {
+				continue
+			}
+			routes[*route.RouteKey] = true
+		}
+
+		if page.NextToken == nil {
+			return routes, nil
+		}
+		nextToken = page.NextToken
+	}
+}
+
+// RouteDifference returns the sorted route keys present in a but not in b
+func RouteDifference(a, b map[string]bool) []string {
+	var diff []string
+	for route := range a {
+		if !b[route] {
+			diff = append(diff, route)
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
@@ -0,0 +1,55 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks/mocks"
+)
+
+func TestDeployedRoutesFollowsPagesAndSkipsReserved(t *testing.T) {
+	api := mocks.NewRouteAPI(t)
+	api.EXPECT().GetRoutes(mock.Anything, mock.MatchedBy(func(in *apigatewayv2.GetRoutesInput) bool {
+		return in.NextToken == nil
+	})).Return(&apigatewayv2.GetRoutesOutput{
+		Items: []types.Route{
+			{RouteKey: aws.String("$default")},
+			{RouteKey: aws.String("GET /health")},
+		},
+		NextToken: aws.String("page-2"),
+	}, nil).Once()
+	api.EXPECT().GetRoutes(mock.Anything, mock.MatchedBy(func(in *apigatewayv2.GetRoutesInput) bool {
+		return aws.ToString(in.NextToken) == "page-2"
+	})).Return(&apigatewayv2.GetRoutesOutput{
+		Items: []types.Route{{RouteKey: aws.String("GET /products")}},
+	}, nil).Once()
+
+	routes, err := DeployedRoutes(context.TODO(), api, "api-id")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"GET /health": true, "GET /products": true}, routes)
+}
+
+func TestDeployedRoutesReturnsErrors(t *testing.T) {
+	api := mocks.NewRouteAPI(t)
+	api.EXPECT().GetRoutes(mock.Anything, mock.Anything).Return(nil, errors.New("throttled"))
+
+	_, err := DeployedRoutes(context.TODO(), api, "api-id")
+	assert.EqualError(t, err, "throttled")
+}
+
+func TestRouteDifference(t *testing.T) {
+	spec := map[string]bool{"GET /health": true, "GET /products": true, "POST /products": true}
+	deployed := map[string]bool{"GET /health": true, "DELETE /products/{id}": true}
+
+	assert.Equal(t, []string{"GET /products", "POST /products"}, RouteDifference(spec, deployed))
+	assert.Equal(t, []string{"DELETE /products/{id}"}, RouteDifference(deployed, spec))
+	assert.Empty(t, RouteDifference(spec, spec))
+}
@@ -0,0 +1,123 @@
+package checks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+)
+
+// StageGaps describes how an HTTP API stage differs from the one Terraform manages: auto-deploy off, so
+// route changes wait for a manual deployment; a failed last auto-deployment; no default throttling; and
+// stage variables other than want
+func StageGaps(stage *apigatewayv2.GetStageOutput, want map[string]string) []string {
+	var gaps []string
+	if !aws.ToBool(stage.AutoDeploy) {
+		gaps = append(gaps, "auto-deploy is disabled, so changes to routes are not deployed")
+	}
+	if message := aws.ToString(stage.LastDeploymentStatusMessage); message != "" {
+		gaps = append(gaps, "last auto-deployment failed: "+message)
+	}
+	gaps = append(gaps, ThrottlingGaps(stage.DefaultRouteSettings, nil, nil)...)
+
+	names := make([]string, 0, len(want)+len(stage.StageVariables))
+	for name := range want {
+		names = append(names, name)
+	}
+	for name := range stage.StageVariables {
+		if _, ok := want[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value, deployed := stage.StageVariables[name]
+		expected, declared := want[name]
+		switch {
+		case !deployed:
+			gaps = append(gaps, fmt.Sprintf("stage variable %s is missing", name))
+		case !declared:
+			gaps = append(gaps, fmt.Sprintf("stage variable %s is not declared", name))
+		case value != expected:
+			gaps = append(gaps, fmt.Sprintf("stage variable %s is %q, expected %q", name, value, expected))
+		}
+	}
+	return gaps
+}
+
+// DeploymentGaps describes problems with the deployment a stage serves: it is missing from deployments, it did
+// not deploy, or a newer deployment exists that the stage never picked up
+func DeploymentGaps(deploymentID string, deployments []types.Deployment) []string {
+	var current *types.Deployment
+	for i := range deployments {
+		if aws.ToString(deployments[i].DeploymentId) == deploymentID {
+			current = &deployments[i]
+		}
+	}
+	if current == nil {
+		return []string{fmt.Sprintf("stage serves deployment %s, which the API does not list", deploymentID)}
+	}
+
+	var gaps []string
+	if current.DeploymentStatus != types.DeploymentStatusDeployed {
+		gaps = append(gaps, fmt.Sprintf("deployment %s is %s: %s", deploymentID, current.DeploymentStatus, aws.ToString(current.DeploymentStatusMessage)))
+	}
+	for _, deployment := range deployments {
+		if aws.ToTime(deployment.CreatedDate).After(aws.ToTime(current.CreatedDate)) {
+			gaps = append(gaps, fmt.Sprintf("stage serves deployment %s but %s is newer (%s)",
+				deploymentID, aws.ToString(deployment.DeploymentId), deployment.DeploymentStatus))
+		}
+	}
+	return gaps
+}
+
+// UndeployedRoutes describes the difference between the routes a stage serves and the routes the API is
+// configured with: a route added since the last deployment is not served, and a removed one still is
+func UndeployedRoutes(deployed, configured []string) []string {
+	deployedSet, configuredSet := routeSet(deployed), routeSet(configured)
+	var gaps []string
+	for _, route := range RouteDifference(configuredSet, deployedSet) {
+		gaps = append(gaps, fmt.Sprintf("%s is configured but not deployed", route))
+	}
+	for _, route := range RouteDifference(deployedSet, configuredSet) {
+		gaps = append(gaps, fmt.Sprintf("%s is deployed but no longer configured", route))
+	}
+	return gaps
+}
+
+// routeSet returns route keys as a set
+func routeSet(keys []string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[key] = true
+	}
+	return set
+}
+
+// OrphanRoutes describes routes with no target or whose target names an integration the API no longer has;
+// API Gateway answers them with 500 instead of reaching a backend
+func OrphanRoutes(routes []types.Route, integrations []types.Integration) []string {
+	existing := make(map[string]bool, len(integrations))
+	for _, integration := range integrations {
+		existing[aws.ToString(integration.IntegrationId)] = true
+	}
+
+	var orphans []string
+	for _, route := range routes {
+		key, target := aws.ToString(route.RouteKey), aws.ToString(route.Target)
+		id, ok := strings.CutPrefix(target, "integrations/")
+		switch {
+		case target == "":
+			orphans = append(orphans, fmt.Sprintf("%s has no integration", key))
+		case !ok:
+			orphans = append(orphans, fmt.Sprintf("%s targets %s, which is not an integration", key, target))
+		case !existing[id]:
+			orphans = append(orphans, fmt.Sprintf("%s targets integration %s, which does not exist", key, id))
+		}
+	}
+	sort.Strings(orphans)
+	return orphans
+}
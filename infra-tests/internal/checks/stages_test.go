@@ -0,0 +1,81 @@
+package checks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStageGaps(t *testing.T) {
+	throttled := &types.RouteSettings{ThrottlingRateLimit: aws.Float64(1000), ThrottlingBurstLimit: aws.Int32(500)}
+
+	assert.Empty(t, StageGaps(&apigatewayv2.GetStageOutput{AutoDeploy: aws.Bool(true), DefaultRouteSettings: throttled}, nil))
+	assert.Empty(t, StageGaps(&apigatewayv2.GetStageOutput{
+		AutoDeploy:           aws.Bool(true),
+		DefaultRouteSettings: throttled,
+		StageVariables:       map[string]string{"backend": "v2"},
+	}, map[string]string{"backend": "v2"}))
+
+	assert.Equal(t, []string{
+		"auto-deploy is disabled, so changes to routes are not deployed",
+		"last auto-deployment failed: Unable to deploy API because no routes exist",
+		"stage has no default throttling limits",
+		"stage variable backend is \"v1\", expected \"v2\"",
+		"stage variable debug is not declared",
+		"stage variable region is missing",
+	}, StageGaps(&apigatewayv2.GetStageOutput{
+		LastDeploymentStatusMessage: aws.String("Unable to deploy API because no routes exist"),
+		StageVariables:              map[string]string{"backend": "v1", "debug": "true"},
+	}, map[string]string{"backend": "v2", "region": "us-east-1"}))
+}
+
+func TestDeploymentGaps(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	deployment := func(id string, status types.DeploymentStatus, minutes int) types.Deployment {
+		return types.Deployment{DeploymentId: aws.String(id), DeploymentStatus: status, CreatedDate: aws.Time(base.Add(time.Duration(minutes) * time.Minute))}
+	}
+	deployments := []types.Deployment{
+		deployment("old", types.DeploymentStatusDeployed, 0),
+		deployment("current", types.DeploymentStatusDeployed, 10),
+	}
+
+	assert.Empty(t, DeploymentGaps("current", deployments))
+	assert.Equal(t, []string{"stage serves deployment old but current is newer (DEPLOYED)"}, DeploymentGaps("old", deployments))
+	assert.Equal(t, []string{"stage serves deployment gone, which the API does not list"}, DeploymentGaps("gone", deployments))
+
+	failed := deployment("failed", types.DeploymentStatusFailed, 20)
+	failed.DeploymentStatusMessage = aws.String("Integration not found")
+	assert.Equal(t, []string{"deployment failed is FAILED: Integration not found"}, DeploymentGaps("failed", append(deployments, failed)))
+	assert.Equal(t, []string{"stage serves deployment current but failed is newer (FAILED)"}, DeploymentGaps("current", append(deployments, failed)))
+}
+
+func TestUndeployedRoutes(t *testing.T) {
+	assert.Empty(t, UndeployedRoutes([]string{"GET /health", "GET /products"}, []string{"GET /products", "GET /health"}))
+	assert.Equal(t, []string{
+		"POST /products is configured but not deployed",
+		"GET /orders is deployed but no longer configured",
+	}, UndeployedRoutes([]string{"GET /health", "GET /orders"}, []string{"GET /health", "POST /products"}))
+}
+
+func TestOrphanRoutes(t *testing.T) {
+	integrations := []types.Integration{{IntegrationId: aws.String("abc123")}}
+	route := func(key, target string) types.Route {
+		return types.Route{RouteKey: aws.String(key), Target: aws.String(target)}
+	}
+
+	assert.Empty(t, OrphanRoutes([]types.Route{route("GET /health", "integrations/abc123")}, integrations))
+	assert.Equal(t, []string{
+		"DELETE /products/{id} has no integration",
+		"GET /orders targets integration gone99, which does not exist",
+		"POST /products targets arn:aws:lambda:us-east-1:123456789012:function:x, which is not an integration",
+	}, OrphanRoutes([]types.Route{
+		route("GET /health", "integrations/abc123"),
+		route("GET /orders", "integrations/gone99"),
+		{RouteKey: aws.String("DELETE /products/{id}")},
+		route("POST /products", "arn:aws:lambda:us-east-1:123456789012:function:x"),
+	}, integrations))
+}
@@ -0,0 +1,131 @@
+package checks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// TableParityGaps describes how a table restored from source differs from it in key schema, attribute
+// definitions, secondary indexes, billing mode, and encryption. Restores never copy TTL, streams, tags,
+// or auto scaling, so those are not compared.
+func TableParityGaps(source, restored dynamodbtypes.TableDescription) []string {
+	var gaps []string
+	if want, got := keySchema(source.KeySchema), keySchema(restored.KeySchema); want != got {
+		gaps = append(gaps, fmt.Sprintf("key schema is %s, source has %s", got, want))
+	}
+	if want, got := attributeDefinitions(source.AttributeDefinitions), attributeDefinitions(restored.AttributeDefinitions); want != got {
+		gaps = append(gaps, fmt.Sprintf("attribute definitions are %s, source has %s", got, want))
+	}
+	if want, got := billingMode(source), billingMode(restored); want != got {
+		gaps = append(gaps, fmt.Sprintf("billing mode is %s, source has %s", got, want))
+	}
+	if want, got := encryption(source), encryption(restored); want != got {
+		gaps = append(gaps, fmt.Sprintf("encryption is %s, source has %s", got, want))
+	}
+
+	sourceIndexes, restoredIndexes := map[string]string{}, map[string]string{}
+	for _, index := range source.GlobalSecondaryIndexes {
+		sourceIndexes["global index "+aws.ToString(index.IndexName)] = indexShape(index.KeySchema, index.Projection)
+	}
+	for _, index := range source.LocalSecondaryIndexes {
+		sourceIndexes["local index "+aws.ToString(index.IndexName)] = indexShape(index.KeySchema, index.Projection)
+	}
+	for _, index := range restored.GlobalSecondaryIndexes {
+		restoredIndexes["global index "+aws.ToString(index.IndexName)] = indexShape(index.KeySchema, index.Projection)
+	}
+	for _, index := range restored.LocalSecondaryIndexes {
+		restoredIndexes["local index "+aws.ToString(index.IndexName)] = indexShape(index.KeySchema, index.Projection)
+	}
+	for _, name := range sortedKeys(sourceIndexes) {
+		got, ok := restoredIndexes[name]
+		switch {
+		case !ok:
+			gaps = append(gaps, fmt.Sprintf("%s is missing", name))
+		case got != sourceIndexes[name]:
+			gaps = append(gaps, fmt.Sprintf("%s is %s, source has %s", name, got, sourceIndexes[name]))
+		}
+	}
+	for _, name := range sortedKeys(restoredIndexes) {
+		if _, ok := sourceIndexes[name]; !ok {
+			gaps = append(gaps, fmt.Sprintf("%s is not on the source", name))
+		}
+	}
+	return gaps
+}
+
+// RestoreEncryption returns the encryption a restore must request to match source; restores otherwise
+// fall back to an AWS owned key. It returns nil when source uses the AWS owned key.
+func RestoreEncryption(source dynamodbtypes.TableDescription) *dynamodbtypes.SSESpecification {
+	if source.SSEDescription == nil || source.SSEDescription.Status != dynamodbtypes.SSEStatusEnabled {
+		return nil
+	}
+	return &dynamodbtypes.SSESpecification{
+		Enabled:        aws.Bool(true),
+		SSEType:        source.SSEDescription.SSEType,
+		KMSMasterKeyId: source.SSEDescription.KMSMasterKeyArn,
+	}
+}
+
+// keySchema renders key elements as "name HASH, name RANGE"
+func keySchema(elements []dynamodbtypes.KeySchemaElement) string {
+	parts := make([]string, len(elements))
+	for i, element := range elements {
+		parts[i] = fmt.Sprintf("%s %s", aws.ToString(element.AttributeName), element.KeyType)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// attributeDefinitions renders definitions sorted by name as "name S, name N"
+func attributeDefinitions(definitions []dynamodbtypes.AttributeDefinition) string {
+	parts := make([]string, len(definitions))
+	for i, definition := range definitions {
+		parts[i] = fmt.Sprintf("%s %s", aws.ToString(definition.AttributeName), definition.AttributeType)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+// indexShape renders an index's key schema and projection
+func indexShape(elements []dynamodbtypes.KeySchemaElement, projection *dynamodbtypes.Projection) string {
+	shape := "(" + keySchema(elements) + ")"
+	if projection == nil {
+		return shape
+	}
+	shape += " projecting " + string(projection.ProjectionType)
+	if len(projection.NonKeyAttributes) > 0 {
+		attributes := append([]string{}, projection.NonKeyAttributes...)
+		sort.Strings(attributes)
+		shape += " " + strings.Join(attributes, ", ")
+	}
+	return shape
+}
+
+// billingMode returns a table's billing mode; tables that never changed from provisioned capacity have no summary
+func billingMode(table dynamodbtypes.TableDescription) dynamodbtypes.BillingMode {
+	if table.BillingModeSummary == nil {
+		return dynamodbtypes.BillingModeProvisioned
+	}
+	return table.BillingModeSummary.BillingMode
+}
+
+// encryption describes a table's server-side encryption key
+func encryption(table dynamodbtypes.TableDescription) string {
+	if table.SSEDescription == nil || table.SSEDescription.Status != dynamodbtypes.SSEStatusEnabled {
+		return "an AWS owned key"
+	}
+	return fmt.Sprintf("%s key %s", table.SSEDescription.SSEType, aws.ToString(table.SSEDescription.KMSMasterKeyArn))
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,73 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// productsTable returns a description shaped like the template's products table
+func productsTable() dynamodbtypes.TableDescription {
+	return dynamodbtypes.TableDescription{
+		KeySchema: []dynamodbtypes.KeySchemaElement{{AttributeName: aws.String("id"), KeyType: dynamodbtypes.KeyTypeHash}},
+		AttributeDefinitions: []dynamodbtypes.AttributeDefinition{
+			{AttributeName: aws.String("name"), AttributeType: dynamodbtypes.ScalarAttributeTypeS},
+			{AttributeName: aws.String("id"), AttributeType: dynamodbtypes.ScalarAttributeTypeS},
+		},
+		BillingModeSummary: &dynamodbtypes.BillingModeSummary{BillingMode: dynamodbtypes.BillingModePayPerRequest},
+		SSEDescription: &dynamodbtypes.SSEDescription{
+			Status:          dynamodbtypes.SSEStatusEnabled,
+			SSEType:         dynamodbtypes.SSETypeKms,
+			KMSMasterKeyArn: aws.String("arn:aws:kms:us-east-1:123456789012:key/abcd"),
+		},
+		GlobalSecondaryIndexes: []dynamodbtypes.GlobalSecondaryIndexDescription{{
+			IndexName:  aws.String("name-index"),
+			KeySchema:  []dynamodbtypes.KeySchemaElement{{AttributeName: aws.String("name"), KeyType: dynamodbtypes.KeyTypeHash}},
+			Projection: &dynamodbtypes.Projection{ProjectionType: dynamodbtypes.ProjectionTypeAll},
+		}},
+	}
+}
+
+func TestTableParityGaps(t *testing.T) {
+	assert.Empty(t, TableParityGaps(productsTable(), productsTable()))
+
+	restored := productsTable()
+	restored.AttributeDefinitions[0], restored.AttributeDefinitions[1] = restored.AttributeDefinitions[1], restored.AttributeDefinitions[0]
+	assert.Empty(t, TableParityGaps(productsTable(), restored), "Attribute definitions are compared in any order")
+
+	restored = productsTable()
+	restored.BillingModeSummary = nil
+	restored.SSEDescription = nil
+	restored.GlobalSecondaryIndexes[0].Projection = &dynamodbtypes.Projection{
+		ProjectionType:   dynamodbtypes.ProjectionTypeInclude,
+		NonKeyAttributes: []string{"price"},
+	}
+	restored.LocalSecondaryIndexes = []dynamodbtypes.LocalSecondaryIndexDescription{{IndexName: aws.String("by-price")}}
+	assert.Equal(t, []string{
+		"billing mode is PROVISIONED, source has PAY_PER_REQUEST",
+		"encryption is an AWS owned key, source has KMS key arn:aws:kms:us-east-1:123456789012:key/abcd",
+		"global index name-index is (name HASH) projecting INCLUDE price, source has (name HASH) projecting ALL",
+		"local index by-price is not on the source",
+	}, TableParityGaps(productsTable(), restored))
+
+	restored = productsTable()
+	restored.KeySchema = append(restored.KeySchema, dynamodbtypes.KeySchemaElement{AttributeName: aws.String("name"), KeyType: dynamodbtypes.KeyTypeRange})
+	restored.GlobalSecondaryIndexes = nil
+	assert.Equal(t, []string{
+		"key schema is id HASH, name RANGE, source has id HASH",
+		"global index name-index is missing",
+	}, TableParityGaps(productsTable(), restored))
+}
+
+func TestRestoreEncryption(t *testing.T) {
+	encryption := RestoreEncryption(productsTable())
+	if assert.NotNil(t, encryption) {
+		assert.True(t, aws.ToBool(encryption.Enabled))
+		assert.Equal(t, dynamodbtypes.SSETypeKms, encryption.SSEType)
+		assert.Equal(t, "arn:aws:kms:us-east-1:123456789012:key/abcd", aws.ToString(encryption.KMSMasterKeyId))
+	}
+
+	assert.Nil(t, RestoreEncryption(dynamodbtypes.TableDescription{}))
+}
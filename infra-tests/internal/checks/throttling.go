@@ -0,0 +1,67 @@
+package checks
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+)
+
+// Account-level API Gateway throttling quotas per region. A stage limit above them never applies,
+// so the stage is effectively unthrottled.
+const (
+	AccountRateLimit  = 10000
+	AccountBurstLimit = 5000
+)
+
+// ThrottlingGaps describes problems with a stage's throttling: no default limits, limits of 0 that reject
+// every request, limits above the account quotas, and per-route overrides for routes that do not exist
+func ThrottlingGaps(defaults *types.RouteSettings, overrides map[string]types.RouteSettings, routeKeys []string) []string {
+	var gaps []string
+	if defaults == nil || defaults.ThrottlingRateLimit == nil || defaults.ThrottlingBurstLimit == nil {
+		gaps = append(gaps, "stage has no default throttling limits")
+	} else {
+		gaps = append(gaps, limitGaps("default", *defaults)...)
+	}
+
+	routes := map[string]bool{}
+	for _, key := range routeKeys {
+		routes[key] = true
+	}
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !routes[key] {
+			gaps = append(gaps, fmt.Sprintf("throttling override for %s matches no route", key))
+			continue
+		}
+		gaps = append(gaps, limitGaps(key, overrides[key])...)
+	}
+	return gaps
+}
+
+// limitGaps checks the limits a route settings value sets
+func limitGaps(scope string, settings types.RouteSettings) []string {
+	var gaps []string
+	if settings.ThrottlingRateLimit != nil {
+		switch rate := aws.ToFloat64(settings.ThrottlingRateLimit); {
+		case rate <= 0:
+			gaps = append(gaps, fmt.Sprintf("%s rate limit is %v, so every request is throttled", scope, rate))
+		case rate > AccountRateLimit:
+			gaps = append(gaps, fmt.Sprintf("%s rate limit %v exceeds the account quota of %d requests per second", scope, rate, AccountRateLimit))
+		}
+	}
+	if settings.ThrottlingBurstLimit != nil {
+		switch burst := aws.ToInt32(settings.ThrottlingBurstLimit); {
+		case burst <= 0:
+			gaps = append(gaps, fmt.Sprintf("%s burst limit is %d, so every request is throttled", scope, burst))
+		case burst > AccountBurstLimit:
+			gaps = append(gaps, fmt.Sprintf("%s burst limit %d exceeds the account quota of %d", scope, burst, AccountBurstLimit))
+		}
+	}
+	return gaps
+}
@@ -0,0 +1,35 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottlingGaps(t *testing.T) {
+	routes := []string{"GET /health", "GET /products", "POST /products"}
+	defaults := &types.RouteSettings{ThrottlingRateLimit: aws.Float64(1000), ThrottlingBurstLimit: aws.Int32(500)}
+
+	assert.Empty(t, ThrottlingGaps(defaults, map[string]types.RouteSettings{
+		"POST /products": {ThrottlingRateLimit: aws.Float64(50), ThrottlingBurstLimit: aws.Int32(20)},
+	}, routes))
+
+	assert.Equal(t, []string{"stage has no default throttling limits"}, ThrottlingGaps(nil, nil, routes))
+	assert.Equal(t, []string{"stage has no default throttling limits"}, ThrottlingGaps(&types.RouteSettings{}, nil, routes))
+
+	assert.Equal(t, []string{
+		"default rate limit 20000 exceeds the account quota of 10000 requests per second",
+		"default burst limit is 0, so every request is throttled",
+		"throttling override for GET /orders matches no route",
+		"GET /products rate limit is 0, so every request is throttled",
+	}, ThrottlingGaps(
+		&types.RouteSettings{ThrottlingRateLimit: aws.Float64(20000), ThrottlingBurstLimit: aws.Int32(0)},
+		map[string]types.RouteSettings{
+			"GET /products": {ThrottlingRateLimit: aws.Float64(0)},
+			"GET /orders":   {ThrottlingRateLimit: aws.Float64(10), ThrottlingBurstLimit: aws.Int32(5)},
+		},
+		routes,
+	))
+}
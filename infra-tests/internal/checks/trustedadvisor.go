@@ -0,0 +1,57 @@
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/support"
+	"github.com/aws/smithy-go"
+)
+
+// SubscriptionRequired reports whether err is the Support API refusing an account whose support plan
+// (Basic or Developer) does not include it
+func SubscriptionRequired(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "SubscriptionRequiredException"
+}
+
+// TrustedAdvisorWarnings describes each resource whose metadata names resourcePrefix and that a Trusted Advisor
+// check in one of categories flagged with a status other than ok
+func TrustedAdvisorWarnings(ctx context.Context, api TrustedAdvisorAPI, categories map[string]bool, resourcePrefix string) ([]string, error) {
+	checks, err := api.DescribeTrustedAdvisorChecks(ctx, &support.DescribeTrustedAdvisorChecksInput{Language: aws.String("en")})
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	for _, check := range checks.Checks {
+		category, name := aws.ToString(check.Category), aws.ToString(check.Name)
+		if !categories[category] {
+			continue
+		}
+
+		result, err := api.DescribeTrustedAdvisorCheckResult(ctx, &support.DescribeTrustedAdvisorCheckResultInput{
+			CheckId:  check.Id,
+			Language: aws.String("en"),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("getting result of check %s: %w", name, err)
+		}
+		if result.Result == nil {
+			continue
+		}
+
+		for _, resource := range result.Result.FlaggedResources {
+			metadata := strings.Join(aws.ToStringSlice(resource.Metadata), ", ")
+			status := aws.ToString(resource.Status)
+			if status == "ok" || !strings.Contains(metadata, resourcePrefix) {
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf("[%s] %s: %s flagged %s (%s)", category, name, status, aws.ToString(resource.ResourceId), metadata))
+		}
+	}
+	return warnings, nil
+}
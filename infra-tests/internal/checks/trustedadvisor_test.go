@@ -0,0 +1,78 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/support"
+	supporttypes "github.com/aws/aws-sdk-go-v2/service/support/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks/mocks"
+)
+
+// flagged builds a result of check id flagging each resource, given as resource ID, status, and metadata
+func flagged(id string, resources ...[]string) *support.DescribeTrustedAdvisorCheckResultOutput {
+	result := &supporttypes.TrustedAdvisorCheckResult{CheckId: aws.String(id)}
+	for _, resource := range resources {
+		result.FlaggedResources = append(result.FlaggedResources, supporttypes.TrustedAdvisorResourceDetail{
+			ResourceId: aws.String(resource[0]),
+			Status:     aws.String(resource[1]),
+			Metadata:   aws.StringSlice(resource[2:]),
+		})
+	}
+	return &support.DescribeTrustedAdvisorCheckResultOutput{Result: result}
+}
+
+func TestTrustedAdvisorWarnings(t *testing.T) {
+	api := mocks.NewTrustedAdvisorAPI(t)
+	api.EXPECT().DescribeTrustedAdvisorChecks(mock.Anything, mock.Anything).Return(&support.DescribeTrustedAdvisorChecksOutput{
+		Checks: []supporttypes.TrustedAdvisorCheckDescription{
+			{Id: aws.String("lambda-timeouts"), Name: aws.String("AWS Lambda Functions with High Error Rates"), Category: aws.String("fault_tolerance")},
+			{Id: aws.String("service-limits"), Name: aws.String("Service Limits"), Category: aws.String("service_limits")},
+		},
+	}, nil)
+	api.EXPECT().DescribeTrustedAdvisorCheckResult(mock.Anything, mock.MatchedBy(func(in *support.DescribeTrustedAdvisorCheckResultInput) bool {
+		return aws.ToString(in.CheckId) == "lambda-timeouts" && aws.ToString(in.Language) == "en"
+	})).Return(flagged("lambda-timeouts",
+		[]string{"r-1", "warning", "us-east-1", "lambda-java-template-dev-product-service"},
+		[]string{"r-2", "ok", "us-east-1", "lambda-java-template-dev-authorizer-service"},
+		[]string{"r-3", "error", "us-east-1", "another-app-dev-handler"},
+	), nil)
+
+	warnings, err := TrustedAdvisorWarnings(context.TODO(), api, map[string]bool{"fault_tolerance": true}, "lambda-java-template-dev")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"[fault_tolerance] AWS Lambda Functions with High Error Rates: warning flagged r-1 (us-east-1, lambda-java-template-dev-product-service)",
+	}, warnings, "only flagged project resources of the selected categories are reported")
+}
+
+func TestTrustedAdvisorWarningsResultError(t *testing.T) {
+	api := mocks.NewTrustedAdvisorAPI(t)
+	api.EXPECT().DescribeTrustedAdvisorChecks(mock.Anything, mock.Anything).Return(&support.DescribeTrustedAdvisorChecksOutput{
+		Checks: []supporttypes.TrustedAdvisorCheckDescription{{Id: aws.String("c"), Name: aws.String("Security Groups"), Category: aws.String("security")}},
+	}, nil)
+	api.EXPECT().DescribeTrustedAdvisorCheckResult(mock.Anything, mock.Anything).Return(nil, &supporttypes.InternalServerError{})
+
+	_, err := TrustedAdvisorWarnings(context.TODO(), api, map[string]bool{"security": true}, "lambda-java-template-dev")
+	assert.ErrorContains(t, err, "Security Groups")
+}
+
+func TestSubscriptionRequired(t *testing.T) {
+	api := mocks.NewTrustedAdvisorAPI(t)
+	api.EXPECT().DescribeTrustedAdvisorChecks(mock.Anything, mock.Anything).Return(nil, &smithy.OperationError{
+		ServiceID:     "Support",
+		OperationName: "DescribeTrustedAdvisorChecks",
+		Err:           &smithy.GenericAPIError{Code: "SubscriptionRequiredException", Message: "AWS Premium Support Subscription is required to use this service."},
+	})
+
+	_, err := TrustedAdvisorWarnings(context.TODO(), api, map[string]bool{"security": true}, "lambda-java-template-dev")
+	assert.True(t, SubscriptionRequired(err), "a support plan without the API is recognised through the operation error")
+	assert.False(t, SubscriptionRequired(&supporttypes.ThrottlingException{}))
+	assert.False(t, SubscriptionRequired(fmt.Errorf("dial tcp: connection refused")))
+}
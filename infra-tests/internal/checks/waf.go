@@ -0,0 +1,63 @@
+package checks
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RequiredManagedRuleGroups are the AWS managed rule groups every web ACL in front of the API must enforce
+var RequiredManagedRuleGroups = []string{
+	"AWSManagedRulesCommonRuleSet",
+	"AWSManagedRulesKnownBadInputsRuleSet",
+	"AWSManagedRulesSQLiRuleSet",
+}
+
+// ManagedRuleGroup is a managed rule group a web ACL references. CountOnly is set when the rule's
+// override action is Count, so matching requests are recorded but not blocked.
+type ManagedRuleGroup struct {
+	Vendor    string
+	Name      string
+	CountOnly bool
+}
+
+// WebACLGaps describes the required AWS managed rule groups a web ACL does not enforce: those it does
+// not reference and those it only counts
+func WebACLGaps(groups []ManagedRuleGroup, required []string) []string {
+	enforced := map[string]bool{}
+	counted := map[string]bool{}
+	for _, group := range groups {
+		if group.Vendor != "AWS" {
+			continue
+		}
+		if group.CountOnly {
+			counted[group.Name] = true
+		} else {
+			enforced[group.Name] = true
+		}
+	}
+
+	var gaps []string
+	for _, name := range required {
+		switch {
+		case enforced[name]:
+		case counted[name]:
+			gaps = append(gaps, fmt.Sprintf("%s only counts matching requests", name))
+		default:
+			gaps = append(gaps, fmt.Sprintf("%s is not attached", name))
+		}
+	}
+	return gaps
+}
+
+// ParseWebACLARN returns the name and ID of a WAFv2 web ACL from its ARN,
+// arn:aws:wafv2:<region>:<account>:<scope>/webacl/<name>/<id>
+func ParseWebACLARN(arn string) (name, id string, ok bool) {
+	if !strings.HasPrefix(arn, "arn:") {
+		return "", "", false
+	}
+	parts := strings.Split(arn, "/")
+	if len(parts) != 4 || parts[1] != "webacl" {
+		return "", "", false
+	}
+	return parts[2], parts[3], true
+}
@@ -0,0 +1,34 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebACLGaps(t *testing.T) {
+	groups := []ManagedRuleGroup{
+		{Vendor: "AWS", Name: "AWSManagedRulesCommonRuleSet"},
+		{Vendor: "AWS", Name: "AWSManagedRulesSQLiRuleSet", CountOnly: true},
+		{Vendor: "Fortinet", Name: "AWSManagedRulesKnownBadInputsRuleSet"},
+	}
+	assert.Equal(t, []string{
+		"AWSManagedRulesKnownBadInputsRuleSet is not attached",
+		"AWSManagedRulesSQLiRuleSet only counts matching requests",
+	}, WebACLGaps(groups, RequiredManagedRuleGroups))
+
+	groups[1].CountOnly = false
+	groups[2].Vendor = "AWS"
+	assert.Empty(t, WebACLGaps(groups, RequiredManagedRuleGroups))
+}
+
+func TestParseWebACLARN(t *testing.T) {
+	name, id, ok := ParseWebACLARN("arn:aws:wafv2:us-east-1:123456789012:global/webacl/api-acl/a1b2c3d4-5678-90ab-cdef-EXAMPLE11111")
+	assert.True(t, ok)
+	assert.Equal(t, "api-acl", name)
+	assert.Equal(t, "a1b2c3d4-5678-90ab-cdef-EXAMPLE11111", id)
+
+	// Classic WAF web ACLs are referenced by a bare ID
+	_, _, ok = ParseWebACLARN("473e64fd-f30b-4765-81a0-62ad96dd167a")
+	assert.False(t, ok)
+}
@@ -0,0 +1,213 @@
+// Package cleanup removes data written by the test suite from a deployed environment
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	logstypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	sfntypes "github.com/aws/aws-sdk-go-v2/service/sfn/types"
+)
+
+// Target names the resources of one environment that may hold test data
+type Target struct {
+	ProductsTable    string
+	AuditTable       string
+	StateMachineName string
+	LogGroups        []string
+}
+
+// Result counts what was removed from each resource
+type Result struct {
+	Products   int
+	AuditLogs  int
+	Executions int
+	LogStreams int
+}
+
+// NewTarget returns the standard resource names for a project and environment
+func NewTarget(projectName, environment string) Target {
+	baseName := fmt.Sprintf("%s-%s", projectName, environment)
+	return Target{
+		ProductsTable:    fmt.Sprintf("%s-products", baseName),
+		AuditTable:       fmt.Sprintf("%s-audit-logs", baseName),
+		StateMachineName: fmt.Sprintf("%s-order-processing", baseName),
+		LogGroups: []string{
+			fmt.Sprintf("/aws/lambda/%s-product-service", baseName),
+			fmt.Sprintf("/aws/lambda/%s-authorizer-service", baseName),
+		},
+	}
+}
+
+// Namespace deletes every product whose id starts with namespace and every audit record of such a product,
+// stops the running workflow executions whose name starts with it, and deletes the suite's log streams named
+// with it. Step Functions keeps the history of finished executions, which cannot be deleted.
+func Namespace(ctx context.Context, dynamoClient *dynamodb.Client, sfnClient *sfn.Client, logsClient *cloudwatchlogs.Client, target Target, namespace string) (Result, error) {
+	var result Result
+
+	// An empty prefix matches every item, which would wipe the environment
+	if namespace == "" {
+		return result, errors.New("refusing to clean up with an empty namespace")
+	}
+
+	products, err := deleteByPrefix(ctx, dynamoClient, target.ProductsTable, "id", namespace, "id")
+	result.Products = products
+	if err != nil {
+		return result, err
+	}
+
+	// Audit event ids are generated by whoever writes the record; the product they describe carries the namespace
+	auditLogs, err := deleteByPrefix(ctx, dynamoClient, target.AuditTable, "entity_id", namespace, "event_id", "timestamp")
+	result.AuditLogs = auditLogs
+	if err != nil {
+		return result, err
+	}
+
+	if target.StateMachineName != "" {
+		executions, err := stopExecutions(ctx, sfnClient, target.StateMachineName, namespace)
+		result.Executions = executions
+		if err != nil {
+			return result, err
+		}
+	}
+
+	for _, logGroup := range target.LogGroups {
+		streams, err := deleteLogStreams(ctx, logsClient, logGroup, namespace)
+		result.LogStreams += streams
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// deleteByPrefix scans tableName for items whose attribute starts with prefix and deletes them by their key attributes
+func deleteByPrefix(ctx context.Context, client *dynamodb.Client, tableName, attribute, prefix string, keyAttributes ...string) (int, error) {
+	names := map[string]string{"#match": attribute}
+	projection := ""
+	for i, key := range keyAttributes {
+		placeholder := fmt.Sprintf("#k%d", i)
+		names[placeholder] = key
+		if projection != "" {
+			projection += ", "
+		}
+		projection += placeholder
+	}
+
+	deleted := 0
+	paginator := dynamodb.NewScanPaginator(client, &dynamodb.ScanInput{
+		TableName:                 aws.String(tableName),
+		FilterExpression:          aws.String("begins_with(#match, :prefix)"),
+		ProjectionExpression:      aws.String(projection),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: map[string]types.AttributeValue{":prefix": &types.AttributeValueMemberS{Value: prefix}},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return deleted, fmt.Errorf("scanning %s: %w", tableName, err)
+		}
+
+		for _, item := range page.Items {
+			_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+				TableName: aws.String(tableName),
+				Key:       item,
+			})
+			if err != nil {
+				return deleted, fmt.Errorf("deleting item from %s: %w", tableName, err)
+			}
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// stopExecutions stops the running executions of the named state machine whose name starts with prefix.
+// A state machine that is not deployed has nothing to stop.
+func stopExecutions(ctx context.Context, client *sfn.Client, stateMachineName, prefix string) (int, error) {
+	stateMachineArn := ""
+	machines := sfn.NewListStateMachinesPaginator(client, &sfn.ListStateMachinesInput{})
+	for machines.HasMorePages() && stateMachineArn == "" {
+		page, err := machines.NextPage(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("listing state machines: %w", err)
+		}
+		for _, machine := range page.StateMachines {
+			if aws.ToString(machine.Name) == stateMachineName {
+				stateMachineArn = aws.ToString(machine.StateMachineArn)
+			}
+		}
+	}
+	if stateMachineArn == "" {
+		return 0, nil
+	}
+
+	stopped := 0
+	paginator := sfn.NewListExecutionsPaginator(client, &sfn.ListExecutionsInput{
+		StateMachineArn: aws.String(stateMachineArn),
+		StatusFilter:    sfntypes.ExecutionStatusRunning,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return stopped, fmt.Errorf("listing executions of %s: %w", stateMachineName, err)
+		}
+
+		for _, execution := range page.Executions {
+			if !strings.HasPrefix(aws.ToString(execution.Name), prefix) {
+				continue
+			}
+			_, err := client.StopExecution(ctx, &sfn.StopExecutionInput{
+				ExecutionArn: execution.ExecutionArn,
+				Cause:        aws.String("Stopped by test data cleanup"),
+			})
+			if err != nil {
+				return stopped, fmt.Errorf("stopping execution %s: %w", aws.ToString(execution.Name), err)
+			}
+			stopped++
+		}
+	}
+	return stopped, nil
+}
+
+// deleteLogStreams removes the log streams in logGroup whose name starts with prefix, which only the suite's
+// own streams carry. Lambda names its streams after the date and execution environment, and every invocation
+// that environment serves shares them, so they are never deleted, even when a test request was logged there.
+func deleteLogStreams(ctx context.Context, client *cloudwatchlogs.Client, logGroup, prefix string) (int, error) {
+	deleted := 0
+	paginator := cloudwatchlogs.NewDescribeLogStreamsPaginator(client, &cloudwatchlogs.DescribeLogStreamsInput{
+		LogGroupName:        aws.String(logGroup),
+		LogStreamNamePrefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			// Functions that have never been invoked have no log group yet
+			var notFound *logstypes.ResourceNotFoundException
+			if errors.As(err, &notFound) {
+				return deleted, nil
+			}
+			return deleted, fmt.Errorf("listing log streams in %s: %w", logGroup, err)
+		}
+
+		for _, stream := range page.LogStreams {
+			_, err := client.DeleteLogStream(ctx, &cloudwatchlogs.DeleteLogStreamInput{
+				LogGroupName:  aws.String(logGroup),
+				LogStreamName: stream.LogStreamName,
+			})
+			if err != nil {
+				return deleted, fmt.Errorf("deleting log stream %s: %w", aws.ToString(stream.LogStreamName), err)
+			}
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,67 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/dynamolocal"
+	"github.com/lambda-java-template/tests/internal/tfspec"
+)
+
+func TestNamespaceRejectsEmptyPrefix(t *testing.T) {
+	_, err := Namespace(context.TODO(), nil, nil, nil, NewTarget("lambda-java-template", "dev"), "")
+	assert.Error(t, err)
+}
+
+func TestDeleteByPrefixScansEveryPage(t *testing.T) {
+	client := dynamolocal.ForTest(t)
+
+	var spec tfspec.TableSpec
+	for _, table := range tfspec.Tables {
+		if table.NameSuffix == "audit-logs" {
+			spec = table
+		}
+	}
+	require.NotEmpty(t, spec.HashKey, "audit-logs table missing from tfspec")
+
+	tableName := "local-test-audit-logs"
+	require.NoError(t, dynamolocal.CreateTable(context.TODO(), client, tableName, spec))
+
+	// Padding pushes the table past the 1 MB scan page size so deletion has to paginate
+	padding := strings.Repeat("x", 8*1024)
+	for i := 0; i < 300; i++ {
+		prefix := "itest-"
+		if i%3 == 0 {
+			prefix = "real-"
+		}
+		_, err := client.PutItem(context.TODO(), &dynamodb.PutItemInput{
+			TableName: aws.String(tableName),
+			Item: map[string]types.AttributeValue{
+				"event_id":  &types.AttributeValueMemberS{Value: fmt.Sprintf("event-%03d", i)},
+				"timestamp": &types.AttributeValueMemberS{Value: "2024-01-01T00:00:00Z"},
+				"entity_id": &types.AttributeValueMemberS{Value: fmt.Sprintf("%sproduct-%03d", prefix, i)},
+				"padding":   &types.AttributeValueMemberS{Value: padding},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	deleted, err := deleteByPrefix(context.TODO(), client, tableName, "entity_id", "itest-", "event_id", "timestamp")
+	require.NoError(t, err)
+	assert.Equal(t, 200, deleted)
+
+	count, err := client.Scan(context.TODO(), &dynamodb.ScanInput{
+		TableName: aws.String(tableName),
+		Select:    types.SelectCount,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(100), count.Count, "Records of products outside the namespace must be kept")
+}
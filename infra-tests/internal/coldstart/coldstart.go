@@ -0,0 +1,133 @@
+// Package coldstart reads cold start timings from Lambda REPORT lines and keeps a history of
+// benchmark runs, so the effect of a runtime, memory, or SnapStart change can be compared run to run
+package coldstart
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lambda-java-template/tests/internal/stress"
+)
+
+// reportField matches a tab-separated "Name: 12.34 ms" field of a REPORT line, leaving out "Billed Restore Duration"
+var reportField = regexp.MustCompile(`\t(Init Duration|Restore Duration): ([0-9.]+) ms`)
+
+// Report is the start-up part of one invocation's REPORT line
+type Report struct {
+	// InitDuration is set when the invocation started a new execution environment
+	InitDuration time.Duration
+	// RestoreDuration is set when a SnapStart snapshot was restored instead
+	RestoreDuration time.Duration
+}
+
+// Cold reports whether the invocation started a new execution environment
+func (r Report) Cold() bool {
+	return r.InitDuration > 0 || r.RestoreDuration > 0
+}
+
+// StartUp returns the time spent starting the execution environment, whichever way it started
+func (r Report) StartUp() time.Duration {
+	return r.InitDuration + r.RestoreDuration
+}
+
+// ParseReport reads the REPORT line of a log tail; ok is false when the tail has none
+func ParseReport(logTail string) (report Report, ok bool) {
+	for _, line := range strings.Split(logTail, "\n") {
+		if !strings.HasPrefix(line, "REPORT ") {
+			continue
+		}
+		for _, match := range reportField.FindAllStringSubmatch(line, -1) {
+			ms, err := strconv.ParseFloat(match[2], 64)
+			if err != nil {
+				continue
+			}
+			duration := time.Duration(ms * float64(time.Millisecond))
+			if match[1] == "Init Duration" {
+				report.InitDuration = duration
+			} else {
+				report.RestoreDuration = duration
+			}
+		}
+		return report, true
+	}
+	return Report{}, false
+}
+
+// Result is the cold start timing of one function in one benchmark run
+type Result struct {
+	Samples int   `json:"samples"`
+	P50Ms   int64 `json:"p50Ms"`
+	P95Ms   int64 `json:"p95Ms"`
+}
+
+// Summarize computes the result of a function's cold start timings
+func Summarize(startUps []time.Duration) Result {
+	sorted := append([]time.Duration{}, startUps...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return Result{
+		Samples: len(sorted),
+		P50Ms:   stress.Percentile(sorted, 50).Milliseconds(),
+		P95Ms:   stress.Percentile(sorted, 95).Milliseconds(),
+	}
+}
+
+// Run is one benchmark run of a deployment
+type Run struct {
+	Timestamp   time.Time         `json:"timestamp"`
+	Environment string            `json:"environment"`
+	Functions   map[string]Result `json:"functions"`
+}
+
+// LoadHistory reads the runs recorded at path, oldest first; a missing file is an empty history
+func LoadHistory(path string) ([]Run, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading cold start history: %w", err)
+	}
+
+	var runs []Run
+	if err := json.Unmarshal(data, &runs); err != nil {
+		return nil, fmt.Errorf("parsing cold start history %s: %w", path, err)
+	}
+	return runs, nil
+}
+
+// AppendHistory adds a run to the history at path
+func AppendHistory(path string, run Run) error {
+	runs, err := LoadHistory(path)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(append(runs, run), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding cold start history: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing cold start history: %w", err)
+	}
+	return nil
+}
+
+// Previous returns the latest recorded result of a function in an environment
+func Previous(runs []Run, environment, function string) (Result, bool) {
+	for i := len(runs) - 1; i >= 0; i-- {
+		if runs[i].Environment != environment {
+			continue
+		}
+		if result, ok := runs[i].Functions[function]; ok {
+			return result, true
+		}
+	}
+	return Result{}, false
+}
@@ -0,0 +1,84 @@
+package coldstart
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		logTail  string
+		expected Report
+		cold     bool
+	}{
+		{
+			"Cold start",
+			"START RequestId: 1 Version: $LATEST\nEND RequestId: 1\n" +
+				"REPORT RequestId: 1\tDuration: 812.40 ms\tBilled Duration: 813 ms\tMemory Size: 512 MB\tMax Memory Used: 180 MB\tInit Duration: 2461.75 ms\t\n",
+			Report{InitDuration: 2461750 * time.Microsecond},
+			true,
+		},
+		{
+			"SnapStart restore",
+			"REPORT RequestId: 2\tDuration: 95.12 ms\tBilled Duration: 96 ms\tMemory Size: 512 MB\tMax Memory Used: 150 MB\tRestore Duration: 312.50 ms\tBilled Restore Duration: 120 ms\t\n",
+			Report{RestoreDuration: 312500 * time.Microsecond},
+			true,
+		},
+		{
+			"Warm invocation",
+			"REPORT RequestId: 3\tDuration: 12.03 ms\tBilled Duration: 13 ms\tMemory Size: 512 MB\tMax Memory Used: 181 MB\t\n",
+			Report{},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, ok := ParseReport(tt.logTail)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, report)
+			assert.Equal(t, tt.cold, report.Cold())
+		})
+	}
+
+	_, ok := ParseReport("START RequestId: 4 Version: $LATEST\n")
+	assert.False(t, ok, "A tail cut before the REPORT line has no report")
+}
+
+func TestSummarize(t *testing.T) {
+	startUps := []time.Duration{3 * time.Second, time.Second, 2 * time.Second, 4 * time.Second}
+	assert.Equal(t, Result{Samples: 4, P50Ms: 2000, P95Ms: 4000}, Summarize(startUps))
+	assert.Equal(t, 3*time.Second, startUps[0], "The timings passed in are not reordered")
+}
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cold-starts.json")
+
+	runs, err := LoadHistory(path)
+	require.NoError(t, err)
+	assert.Empty(t, runs, "A missing history is empty")
+
+	first := Run{Timestamp: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), Environment: "dev", Functions: map[string]Result{
+		"product-service": {Samples: 5, P50Ms: 2400, P95Ms: 2900},
+	}}
+	second := Run{Timestamp: first.Timestamp.Add(24 * time.Hour), Environment: "staging", Functions: map[string]Result{
+		"product-service": {Samples: 5, P50Ms: 400, P95Ms: 450},
+	}}
+	require.NoError(t, AppendHistory(path, first))
+	require.NoError(t, AppendHistory(path, second))
+
+	runs, err = LoadHistory(path)
+	require.NoError(t, err)
+	assert.Equal(t, []Run{first, second}, runs)
+
+	previous, ok := Previous(runs, "dev", "product-service")
+	require.True(t, ok)
+	assert.Equal(t, int64(2900), previous.P95Ms, "Runs of other environments are ignored")
+
+	_, ok = Previous(runs, "dev", "authorizer-service")
+	assert.False(t, ok)
+}
@@ -0,0 +1,180 @@
+// Package compliance maps validator outcomes to the controls auditors ask about, so a run can be handed
+// over as a control-coverage report.
+//
+// Each control lists the CIS Controls v8 safeguards and SOC 2 Trust Services Criteria it evidences.
+// Validators are annotated with control IDs where they are registered; a control is covered when at
+// least one of its validators passed and none failed.
+package compliance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lambda-java-template/tests/internal/results"
+)
+
+// Control IDs validators are annotated with
+const (
+	EncryptionAtRest    = "encryption-at-rest"
+	EncryptionInTransit = "encryption-in-transit"
+	LeastPrivilege      = "least-privilege"
+	Logging             = "logging"
+	Monitoring          = "monitoring"
+	BackupRecovery      = "backup-recovery"
+	NetworkProtection   = "network-protection"
+	AssetInventory      = "asset-inventory"
+	SecureConfiguration = "secure-configuration"
+	ChangeManagement    = "change-management"
+)
+
+// Control is a security objective and the framework requirements it evidences
+type Control struct {
+	ID    string   `json:"id"`
+	Title string   `json:"title"`
+	CIS   []string `json:"cis"`
+	SOC2  []string `json:"soc2"`
+}
+
+// Catalog is every control, in report order
+var Catalog = []Control{
+	{EncryptionAtRest, "Data is encrypted at rest", []string{"3.11 Encrypt Sensitive Data at Rest"}, []string{"CC6.1"}},
+	{EncryptionInTransit, "Data is encrypted in transit", []string{"3.10 Encrypt Sensitive Data in Transit"}, []string{"CC6.7"}},
+	{LeastPrivilege, "Access is limited to what each caller needs", []string{"3.3 Configure Data Access Control Lists", "6.8 Define and Maintain Role-Based Access Control"}, []string{"CC6.1", "CC6.3"}},
+	{Logging, "Activity is logged and the logs are retained", []string{"8.2 Collect Audit Logs", "8.5 Collect Detailed Audit Logs", "8.10 Retain Audit Logs"}, []string{"CC7.2"}},
+	{Monitoring, "Failures and anomalies raise alerts", []string{"8.11 Conduct Audit Log Reviews", "13.1 Centralize Security Event Alerting"}, []string{"CC7.2", "CC7.3"}},
+	{BackupRecovery, "Data can be restored", []string{"11.2 Perform Automated Backups", "11.5 Test Data Recovery"}, []string{"A1.2", "A1.3"}},
+	{NetworkProtection, "Network entry points are filtered", []string{"12.2 Establish and Maintain a Secure Network Architecture", "13.10 Perform Application Layer Filtering"}, []string{"CC6.6"}},
+	{AssetInventory, "Every deployed resource is known and owned", []string{"1.1 Establish and Maintain Detailed Enterprise Asset Inventory", "1.2 Address Unauthorized Assets"}, []string{"CC6.1"}},
+	{SecureConfiguration, "Resources follow a secure baseline", []string{"4.1 Establish and Maintain a Secure Configuration Process", "7.4 Perform Automated Application Patch Management"}, []string{"CC7.1"}},
+	{ChangeManagement, "Infrastructure changes go through code", []string{"4.1 Establish and Maintain a Secure Configuration Process"}, []string{"CC8.1"}},
+}
+
+// Coverage is the evidence a run produced for one control
+type Coverage struct {
+	Control
+	Covered bool     `json:"covered"`
+	Passed  []string `json:"passed"`
+	Failed  []string `json:"failed"`
+	Skipped []string `json:"skipped"`
+}
+
+// Report is the control coverage of one run
+type Report struct {
+	Project     string     `json:"project"`
+	Environment string     `json:"environment"`
+	GeneratedAt time.Time  `json:"generatedAt"`
+	Controls    []Coverage `json:"controls"`
+}
+
+// Validate returns an error naming every annotation that refers to a control missing from the Catalog
+func Validate(annotations map[string][]string) error {
+	known := map[string]bool{}
+	for _, control := range Catalog {
+		known[control.ID] = true
+	}
+	var unknown []string
+	for validator, controls := range annotations {
+		for _, id := range controls {
+			if !known[id] {
+				unknown = append(unknown, fmt.Sprintf("%s on %s", id, validator))
+			}
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown compliance controls: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// Build maps the checks of a run to the controls their validators are annotated with.
+// Checks without annotations evidence no control and are left out.
+func Build(run results.Report, annotations map[string][]string) Report {
+	report := Report{Project: run.Project, Environment: run.Environment, GeneratedAt: time.Now().UTC()}
+	byControl := map[string]*Coverage{}
+	for _, control := range Catalog {
+		report.Controls = append(report.Controls, Coverage{Control: control})
+	}
+	for i := range report.Controls {
+		byControl[report.Controls[i].ID] = &report.Controls[i]
+	}
+
+	for _, check := range run.Checks {
+		for _, id := range annotations[check.Name] {
+			coverage, ok := byControl[id]
+			if !ok {
+				continue
+			}
+			switch check.Status {
+			case results.StatusPassed:
+				coverage.Passed = append(coverage.Passed, check.Name)
+			case results.StatusFailed:
+				coverage.Failed = append(coverage.Failed, check.Name)
+			default:
+				coverage.Skipped = append(coverage.Skipped, check.Name)
+			}
+		}
+	}
+	for i := range report.Controls {
+		coverage := &report.Controls[i]
+		coverage.Covered = len(coverage.Passed) > 0 && len(coverage.Failed) == 0
+	}
+	return report
+}
+
+// Markdown renders the report as one table row per control
+func Markdown(report Report) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Control coverage: %s (%s)\n\n", report.Project, report.Environment)
+	fmt.Fprintf(&b, "Generated %s.\n\n", report.GeneratedAt.Format("2006-01-02 15:04:05 MST"))
+	b.WriteString("| Control | CIS Controls v8 | SOC 2 | Status | Evidence |\n")
+	b.WriteString("|---------|-----------------|-------|--------|----------|\n")
+	for _, coverage := range report.Controls {
+		status := "not covered"
+		switch {
+		case len(coverage.Failed) > 0:
+			status = "failed"
+		case coverage.Covered:
+			status = "covered"
+		}
+		var evidence []string
+		for _, name := range coverage.Passed {
+			evidence = append(evidence, name+" ✅")
+		}
+		for _, name := range coverage.Failed {
+			evidence = append(evidence, name+" ❌")
+		}
+		for _, name := range coverage.Skipped {
+			evidence = append(evidence, name+" ⏭️")
+		}
+		if len(evidence) == 0 {
+			evidence = []string{"—"}
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", coverage.Title, strings.Join(coverage.CIS, "<br>"),
+			strings.Join(coverage.SOC2, ", "), status, strings.Join(evidence, "<br>"))
+	}
+	return b.String()
+}
+
+// WriteFile writes the report to path, as Markdown when it ends in .md and as JSON otherwise
+func WriteFile(path string, report Report) error {
+	var data []byte
+	if filepath.Ext(path) == ".md" {
+		data = []byte(Markdown(report))
+	} else {
+		encoded, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encoding compliance report: %w", err)
+		}
+		data = append(encoded, '\n')
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing compliance report: %w", err)
+	}
+	return nil
+}
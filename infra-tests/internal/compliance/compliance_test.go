@@ -0,0 +1,81 @@
+package compliance
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/results"
+)
+
+func TestCatalog(t *testing.T) {
+	seen := map[string]bool{}
+	for _, control := range Catalog {
+		assert.False(t, seen[control.ID], "Control %s is declared twice", control.ID)
+		seen[control.ID] = true
+		assert.NotEmpty(t, control.CIS, "Control %s maps to no CIS safeguard", control.ID)
+		assert.NotEmpty(t, control.SOC2, "Control %s maps to no SOC 2 criterion", control.ID)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(map[string][]string{"Security_Configuration": {LeastPrivilege, EncryptionAtRest}}))
+	assert.EqualError(t, Validate(map[string][]string{"Log_Groups": {Logging, "loging"}}),
+		"unknown compliance controls: loging on Log_Groups")
+}
+
+func TestBuild(t *testing.T) {
+	run := results.Report{Project: "app", Environment: "dev", Checks: []results.Check{
+		{Name: "Tables", Status: results.StatusPassed},
+		{Name: "Security", Status: results.StatusFailed},
+		{Name: "Restore", Status: results.StatusSkipped},
+		{Name: "Unannotated", Status: results.StatusPassed},
+	}}
+	annotations := map[string][]string{
+		"Tables":   {EncryptionAtRest, BackupRecovery},
+		"Security": {EncryptionAtRest, LeastPrivilege},
+		"Restore":  {BackupRecovery},
+	}
+
+	report := Build(run, annotations)
+	require.Len(t, report.Controls, len(Catalog), "Every control is reported, covered or not")
+	byID := map[string]Coverage{}
+	for _, coverage := range report.Controls {
+		byID[coverage.ID] = coverage
+	}
+
+	assert.False(t, byID[EncryptionAtRest].Covered, "A failed check outweighs a passed one")
+	assert.Equal(t, []string{"Tables"}, byID[EncryptionAtRest].Passed)
+	assert.Equal(t, []string{"Security"}, byID[EncryptionAtRest].Failed)
+	assert.True(t, byID[BackupRecovery].Covered, "A skipped check neither covers nor fails a control")
+	assert.Equal(t, []string{"Restore"}, byID[BackupRecovery].Skipped)
+	assert.False(t, byID[Logging].Covered)
+
+	markdown := Markdown(report)
+	assert.Contains(t, markdown, "| Data is encrypted at rest | 3.11 Encrypt Sensitive Data at Rest | CC6.1 | failed | Tables ✅<br>Security ❌ |")
+	assert.Contains(t, markdown, "| Data can be restored | 11.2 Perform Automated Backups<br>11.5 Test Data Recovery | A1.2, A1.3 | covered | Tables ✅<br>Restore ⏭️ |")
+	assert.Contains(t, markdown, "| not covered | — |")
+}
+
+func TestWriteFile(t *testing.T) {
+	report := Build(results.Report{Project: "app", Environment: "dev"}, nil)
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "compliance.json")
+	require.NoError(t, WriteFile(jsonPath, report))
+	data, err := os.ReadFile(jsonPath)
+	require.NoError(t, err)
+	var decoded Report
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Len(t, decoded.Controls, len(Catalog))
+
+	markdownPath := filepath.Join(dir, "compliance.md")
+	require.NoError(t, WriteFile(markdownPath, report))
+	data, err = os.ReadFile(markdownPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Control coverage: app (dev)")
+}
@@ -0,0 +1,80 @@
+// Package cost projects the monthly bill of a deployment from its month-to-date cost and checks it against a budget.
+//
+// Month-to-date costs come from Cost Explorer, filtered by the deployment's Project and Environment cost allocation
+// tags and grouped by service. The projection assumes the rest of the month costs what the month has cost so far per day.
+package cost
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Services are the Cost Explorer names of the services the template deploys
+var Services = []string{"AWS Lambda", "Amazon DynamoDB", "AWS Step Functions", "Amazon API Gateway"}
+
+// Estimate is a projection of one month's cost, in USD by service
+type Estimate struct {
+	MonthToDate map[string]float64
+	Monthly     map[string]float64
+	// Elapsed is the part of the month the month-to-date costs cover
+	Elapsed time.Duration
+}
+
+// MonthStart returns midnight UTC on the first day of the month containing now
+func MonthStart(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Project scales month-to-date costs up to the whole month containing now.
+// Less than a day of the month counts as a full day, so an early projection is not inflated by a tiny divisor.
+func Project(monthToDate map[string]float64, now time.Time) Estimate {
+	start := MonthStart(now)
+	month := start.AddDate(0, 1, 0).Sub(start)
+	elapsed := max(now.UTC().Sub(start), 24*time.Hour)
+
+	monthly := make(map[string]float64, len(monthToDate))
+	for service, amount := range monthToDate {
+		monthly[service] = amount * float64(month) / float64(elapsed)
+	}
+	return Estimate{MonthToDate: monthToDate, Monthly: monthly, Elapsed: elapsed}
+}
+
+// Total is the projected cost of the month across services
+func (e Estimate) Total() float64 {
+	total := 0.0
+	for _, amount := range e.Monthly {
+		total += amount
+	}
+	return total
+}
+
+// String lists the projected monthly cost of each service, most expensive first
+func (e Estimate) String() string {
+	services := make([]string, 0, len(e.Monthly))
+	for service := range e.Monthly {
+		services = append(services, service)
+	}
+	sort.Slice(services, func(i, j int) bool {
+		if e.Monthly[services[i]] != e.Monthly[services[j]] {
+			return e.Monthly[services[i]] > e.Monthly[services[j]]
+		}
+		return services[i] < services[j]
+	})
+
+	parts := make([]string, 0, len(services))
+	for _, service := range services {
+		parts = append(parts, fmt.Sprintf("%s $%.2f", service, e.Monthly[service]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// BudgetGaps describes a projected monthly cost above budget USD
+func BudgetGaps(estimate Estimate, budget float64) []string {
+	if total := estimate.Total(); total > budget {
+		return []string{fmt.Sprintf("projected monthly cost $%.2f exceeds the budget of $%.2f (%s)", total, budget, estimate)}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package cost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonthStart(t *testing.T) {
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), MonthStart(time.Date(2024, 2, 29, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), MonthStart(time.Date(2024, 3, 1, 0, 30, 0, 0, time.FixedZone("CET", 3600))),
+		"Months are UTC months")
+}
+
+func TestProject(t *testing.T) {
+	// Ten of April's thirty days have passed
+	estimate := Project(map[string]float64{"AWS Lambda": 2, "Amazon DynamoDB": 1}, time.Date(2024, 4, 11, 0, 0, 0, 0, time.UTC))
+	assert.InDelta(t, 6, estimate.Monthly["AWS Lambda"], 1e-9)
+	assert.InDelta(t, 3, estimate.Monthly["Amazon DynamoDB"], 1e-9)
+	assert.InDelta(t, 9, estimate.Total(), 1e-9)
+	assert.Equal(t, 10*24*time.Hour, estimate.Elapsed)
+	assert.Equal(t, "AWS Lambda $6.00, Amazon DynamoDB $3.00", estimate.String())
+
+	early := Project(map[string]float64{"AWS Lambda": 1}, time.Date(2024, 4, 1, 1, 0, 0, 0, time.UTC))
+	assert.InDelta(t, 30, early.Total(), 1e-9, "The first hours of a month count as a full day")
+}
+
+func TestBudgetGaps(t *testing.T) {
+	estimate := Project(map[string]float64{"AWS Lambda": 20, "Amazon API Gateway": 20}, time.Date(2024, 4, 16, 0, 0, 0, 0, time.UTC))
+
+	assert.Empty(t, BudgetGaps(estimate, 100))
+	assert.Equal(t, []string{"projected monthly cost $80.00 exceeds the budget of $50.00 (AWS Lambda $40.00, Amazon API Gateway $40.00)"},
+		BudgetGaps(estimate, 50))
+}
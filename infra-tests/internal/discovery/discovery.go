@@ -0,0 +1,283 @@
+// Package discovery resolves the deployed resources of the environment under test.
+//
+// Names follow the template's "<project>-<environment>-<suffix>" convention. Resources
+// whose identifiers are generated by AWS (API IDs, state machine ARNs) are taken from the
+// test config, Terraform outputs, or AWS APIs in that order, and cached for the whole run.
+package discovery
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.7 --name "^(APIGatewayAPI|StepFunctionsAPI)$" --output mocks --outpkg mocks --with-expecter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+
+	"github.com/lambda-java-template/tests/internal/testconfig"
+)
+
+// ErrNotFound is returned when a resource is not deployed
+var ErrNotFound = errors.New("resource not found")
+
+// APIGatewayAPI is the API Gateway operation used to find the HTTP API
+type APIGatewayAPI interface {
+	GetApis(ctx context.Context, params *apigatewayv2.GetApisInput, optFns ...func(*apigatewayv2.Options)) (*apigatewayv2.GetApisOutput, error)
+}
+
+// StepFunctionsAPI is the Step Functions operation used to find state machines
+type StepFunctionsAPI interface {
+	ListStateMachines(ctx context.Context, params *sfn.ListStateMachinesInput, optFns ...func(*sfn.Options)) (*sfn.ListStateMachinesOutput, error)
+}
+
+// API is the deployed HTTP API
+type API struct {
+	ID       string
+	Name     string
+	Endpoint string
+}
+
+// Resolver finds the resources of one deployment and caches what it finds
+type Resolver struct {
+	settings      testconfig.Config
+	apiGateway    APIGatewayAPI
+	stepFunctions StepFunctionsAPI
+
+	mu            sync.Mutex
+	api           *API
+	stateMachines map[string]string
+	outputs       map[string]string
+
+	lookupsMu sync.Mutex
+	lookups   map[string]*lookup
+}
+
+// lookup is a value fetched for Cached; done is closed once value and err are set
+type lookup struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+// New returns a resolver using clients created from awsCfg
+func New(awsCfg aws.Config, settings testconfig.Config) *Resolver {
+	return NewWithClients(settings, apigatewayv2.NewFromConfig(awsCfg), sfn.NewFromConfig(awsCfg))
+}
+
+// NewWithClients returns a resolver using the given clients
+func NewWithClients(settings testconfig.Config, apiGateway APIGatewayAPI, stepFunctions StepFunctionsAPI) *Resolver {
+	return &Resolver{
+		settings:      settings,
+		apiGateway:    apiGateway,
+		stepFunctions: stepFunctions,
+		stateMachines: make(map[string]string),
+		lookups:       make(map[string]*lookup),
+	}
+}
+
+var (
+	sharedMu  sync.Mutex
+	resolvers = make(map[testconfig.Config]*Resolver)
+)
+
+// Shared returns one resolver per deployment so lookups are cached across validators and subtests
+func Shared(awsCfg aws.Config, settings testconfig.Config) *Resolver {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	resolver, ok := resolvers[settings]
+	if !ok {
+		resolver = New(awsCfg, settings)
+		resolvers[settings] = resolver
+	}
+	return resolver
+}
+
+// Settings returns the configuration the resolver was created with
+func (r *Resolver) Settings() testconfig.Config {
+	return r.settings
+}
+
+// ResourceName returns the name of a resource following the "<project>-<environment>-<suffix>" convention
+func (r *Resolver) ResourceName(suffix string) string {
+	return fmt.Sprintf("%s-%s", r.settings.BaseName(), suffix)
+}
+
+// FunctionName returns the name of a Lambda function, e.g. FunctionName("product-service")
+func (r *Resolver) FunctionName(suffix string) string {
+	return r.ResourceName(suffix)
+}
+
+// TableName returns the name of a DynamoDB table, e.g. TableName("products")
+func (r *Resolver) TableName(suffix string) string {
+	return r.ResourceName(suffix)
+}
+
+// APIName returns the name of the HTTP API
+func (r *Resolver) APIName() string {
+	return r.ResourceName("api")
+}
+
+// API returns the deployed HTTP API from the configured URL, the api_gateway_url output, or GetApis
+func (r *Resolver) API(ctx context.Context) (API, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.api != nil {
+		return *r.api, nil
+	}
+
+	endpoint := r.settings.APIURL
+	if endpoint == "" {
+		endpoint = r.outputLocked("api_gateway_url")
+	}
+	if endpoint != "" {
+		api, err := apiFromURL(endpoint, r.APIName())
+		if err != nil {
+			return API{}, err
+		}
+		r.api = &api
+		return api, nil
+	}
+
+	var nextToken *string
+	for {
+		page, err := r.apiGateway.GetApis(ctx, &apigatewayv2.GetApisInput{NextToken: nextToken})
+		if err != nil {
+			return API{}, fmt.Errorf("listing APIs: %w", err)
+		}
+
+		for _, item := range page.Items {
+			if aws.ToString(item.Name) == r.APIName() {
+				r.api = &API{ID: aws.ToString(item.ApiId), Name: r.APIName(), Endpoint: aws.ToString(item.ApiEndpoint)}
+				return *r.api, nil
+			}
+		}
+
+		if page.NextToken == nil {
+			return API{}, fmt.Errorf("API Gateway %s: %w", r.APIName(), ErrNotFound)
+		}
+		nextToken = page.NextToken
+	}
+}
+
+// apiFromURL derives the API ID from an execute-api endpoint such as https://abc123.execute-api.us-east-1.amazonaws.com
+func apiFromURL(endpoint, name string) (API, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return API{}, fmt.Errorf("parsing API URL %s: %w", endpoint, err)
+	}
+
+	id, _, _ := strings.Cut(parsed.Host, ".")
+	// Stage paths are kept out of the endpoint; the template deploys the $default stage
+	return API{ID: id, Name: name, Endpoint: fmt.Sprintf("%s://%s", parsed.Scheme, parsed.Host)}, nil
+}
+
+// StateMachineARN returns the ARN of the "<project>-<environment>-<suffix>" state machine
+func (r *Resolver) StateMachineARN(ctx context.Context, suffix string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.settings.StateMachineARN != "" {
+		return r.settings.StateMachineARN, nil
+	}
+	if arn, ok := r.stateMachines[suffix]; ok {
+		return arn, nil
+	}
+
+	name := r.ResourceName(suffix)
+	paginator := sfn.NewListStateMachinesPaginator(r.stepFunctions, &sfn.ListStateMachinesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return "", fmt.Errorf("listing state machines: %w", err)
+		}
+
+		for _, stateMachine := range page.StateMachines {
+			if aws.ToString(stateMachine.Name) == name {
+				r.stateMachines[suffix] = aws.ToString(stateMachine.StateMachineArn)
+				return r.stateMachines[suffix], nil
+			}
+		}
+	}
+	return "", fmt.Errorf("state machine %s: %w", name, ErrNotFound)
+}
+
+// Cached returns what fetch returns for key, calling it once per resolver however many validators ask, so
+// listings such as every alarm of the account are not repeated across validators. Callers asking while a fetch
+// is in flight wait for it and share its result. A failed fetch is not kept, so a later caller tries again.
+// Callers must not modify the value, which they share.
+func Cached[T any](r *Resolver, key string, fetch func() (T, error)) (T, error) {
+	r.lookupsMu.Lock()
+	entry, ok := r.lookups[key]
+	if !ok {
+		entry = &lookup{done: make(chan struct{})}
+		r.lookups[key] = entry
+	}
+	r.lookupsMu.Unlock()
+
+	if ok {
+		<-entry.done
+		if entry.err != nil {
+			var zero T
+			return zero, entry.err
+		}
+		return entry.value.(T), nil
+	}
+
+	value, err := fetch()
+	entry.value, entry.err = value, err
+	if err != nil {
+		r.lookupsMu.Lock()
+		delete(r.lookups, key)
+		r.lookupsMu.Unlock()
+	}
+	close(entry.done)
+	return value, err
+}
+
+// Output returns a Terraform output of the configured working directory, or "" when unavailable
+func (r *Resolver) Output(name string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.outputLocked(name)
+}
+
+// outputLocked reads Terraform outputs once; callers must hold r.mu
+func (r *Resolver) outputLocked(name string) string {
+	if r.settings.TerraformDir == "" {
+		return ""
+	}
+
+	if r.outputs == nil {
+		r.outputs = make(map[string]string)
+
+		cmd := exec.Command("terraform", "output", "-json")
+		cmd.Dir = r.settings.TerraformDir
+		data, err := cmd.Output()
+		if err != nil {
+			// Discovery falls back to AWS APIs when Terraform state is not available locally
+			return ""
+		}
+
+		var outputs map[string]struct {
+			Value interface{} `json:"value"`
+		}
+		if json.Unmarshal(data, &outputs) != nil {
+			return ""
+		}
+		for key, output := range outputs {
+			if value, ok := output.Value.(string); ok {
+				r.outputs[key] = value
+			}
+		}
+	}
+	return r.outputs[name]
+}
@@ -0,0 +1,140 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	apitypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	sfntypes "github.com/aws/aws-sdk-go-v2/service/sfn/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/discovery/mocks"
+	"github.com/lambda-java-template/tests/internal/testconfig"
+)
+
+func TestResourceNames(t *testing.T) {
+	resolver := NewWithClients(testconfig.Default(), nil, nil)
+
+	assert.Equal(t, "lambda-java-template-dev-product-service", resolver.FunctionName("product-service"))
+	assert.Equal(t, "lambda-java-template-dev-products", resolver.TableName("products"))
+	assert.Equal(t, "lambda-java-template-dev-api", resolver.APIName())
+}
+
+func TestAPIIsDiscoveredOnceAcrossPages(t *testing.T) {
+	apiGateway := mocks.NewAPIGatewayAPI(t)
+	apiGateway.EXPECT().GetApis(mock.Anything, mock.MatchedBy(func(in *apigatewayv2.GetApisInput) bool {
+		return in.NextToken == nil
+	})).Return(&apigatewayv2.GetApisOutput{
+		Items:     []apitypes.Api{{Name: aws.String("other-api"), ApiId: aws.String("zzz")}},
+		NextToken: aws.String("next"),
+	}, nil).Once()
+	apiGateway.EXPECT().GetApis(mock.Anything, mock.MatchedBy(func(in *apigatewayv2.GetApisInput) bool {
+		return aws.ToString(in.NextToken) == "next"
+	})).Return(&apigatewayv2.GetApisOutput{
+		Items: []apitypes.Api{{
+			Name:        aws.String("lambda-java-template-dev-api"),
+			ApiId:       aws.String("abc123"),
+			ApiEndpoint: aws.String("https://abc123.execute-api.us-east-1.amazonaws.com"),
+		}},
+	}, nil).Once()
+
+	resolver := NewWithClients(testconfig.Default(), apiGateway, nil)
+	for i := 0; i < 3; i++ {
+		api, err := resolver.API(context.TODO())
+		require.NoError(t, err)
+		assert.Equal(t, API{ID: "abc123", Name: "lambda-java-template-dev-api", Endpoint: "https://abc123.execute-api.us-east-1.amazonaws.com"}, api)
+	}
+}
+
+func TestAPINotFound(t *testing.T) {
+	apiGateway := mocks.NewAPIGatewayAPI(t)
+	apiGateway.EXPECT().GetApis(mock.Anything, mock.Anything).Return(&apigatewayv2.GetApisOutput{}, nil)
+
+	_, err := NewWithClients(testconfig.Default(), apiGateway, nil).API(context.TODO())
+	assert.True(t, errors.Is(err, ErrNotFound), err)
+}
+
+func TestAPIFromConfiguredURL(t *testing.T) {
+	settings := testconfig.Default()
+	settings.APIURL = "https://pg6dn51xz7.execute-api.us-east-1.amazonaws.com/"
+
+	// No client calls are expected when the URL is configured
+	api, err := NewWithClients(settings, mocks.NewAPIGatewayAPI(t), nil).API(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, "pg6dn51xz7", api.ID)
+	assert.Equal(t, "https://pg6dn51xz7.execute-api.us-east-1.amazonaws.com", api.Endpoint)
+}
+
+func TestStateMachineARN(t *testing.T) {
+	stepFunctions := mocks.NewStepFunctionsAPI(t)
+	stepFunctions.EXPECT().ListStateMachines(mock.Anything, mock.Anything, mock.Anything).Return(&sfn.ListStateMachinesOutput{
+		StateMachines: []sfntypes.StateMachineListItem{{
+			Name:            aws.String("lambda-java-template-dev-order-processing"),
+			StateMachineArn: aws.String("arn:aws:states:us-east-1:123456789012:stateMachine:lambda-java-template-dev-order-processing"),
+		}},
+	}, nil).Once()
+
+	resolver := NewWithClients(testconfig.Default(), nil, stepFunctions)
+	for i := 0; i < 2; i++ {
+		arn, err := resolver.StateMachineARN(context.TODO(), "order-processing")
+		require.NoError(t, err)
+		assert.Contains(t, arn, ":stateMachine:lambda-java-template-dev-order-processing")
+	}
+
+	settings := testconfig.Default()
+	settings.StateMachineARN = "arn:aws:states:us-east-1:123456789012:stateMachine:pinned"
+	arn, err := NewWithClients(settings, nil, nil).StateMachineARN(context.TODO(), "order-processing")
+	require.NoError(t, err)
+	assert.Equal(t, settings.StateMachineARN, arn)
+}
+
+func TestCachedFetchesOncePerResolver(t *testing.T) {
+	resolver := NewWithClients(testconfig.Default(), nil, nil)
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fetch := func() ([]string, error) {
+		calls.Add(1)
+		<-release
+		return []string{"lambda-java-template-dev-api-errors"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			alarms, err := Cached(resolver, "alarms", fetch)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"lambda-java-template-dev-api-errors"}, alarms)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.EqualValues(t, 1, calls.Load(), "callers during the fetch share it")
+
+	alarms, err := Cached(NewWithClients(testconfig.Default(), nil, nil), "alarms", func() ([]string, error) { return nil, nil })
+	require.NoError(t, err)
+	assert.Empty(t, alarms, "each resolver has its own cache")
+}
+
+func TestCachedRetriesFailedFetch(t *testing.T) {
+	resolver := NewWithClients(testconfig.Default(), nil, nil)
+
+	_, err := Cached(resolver, "alarms", func() (int, error) { return 0, errors.New("throttled") })
+	require.EqualError(t, err, "throttled")
+
+	count, err := Cached(resolver, "alarms", func() (int, error) { return 3, nil })
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
@@ -0,0 +1,169 @@
+// Package dynamolocal runs DynamoDB Local in a container so table code can be tested without AWS.
+//
+// Tests call ForTest, which skips when Docker is unavailable or the run is in -short mode.
+// Tables are created from the tfspec expectations, so the local schema follows Terraform.
+package dynamolocal
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/lambda-java-template/tests/internal/tfspec"
+)
+
+const (
+	// Image is the DynamoDB Local image the harness starts
+	Image = "amazon/dynamodb-local:2.5.3"
+
+	tableWaitTimeout = 30 * time.Second
+)
+
+// Instance is a running DynamoDB Local container
+type Instance struct {
+	container testcontainers.Container
+	endpoint  string
+}
+
+// Start launches an in-memory DynamoDB Local container
+func Start(ctx context.Context) (*Instance, error) {
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        Image,
+			Cmd:          []string{"-jar", "DynamoDBLocal.jar", "-inMemory", "-sharedDb"},
+			ExposedPorts: []string{"8000/tcp"},
+			WaitingFor:   wait.ForListeningPort("8000/tcp"),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("starting DynamoDB Local: %w", err)
+	}
+
+	endpoint, err := container.PortEndpoint(ctx, "8000/tcp", "http")
+	if err != nil {
+		_ = container.Terminate(ctx)
+		return nil, fmt.Errorf("resolving DynamoDB Local endpoint: %w", err)
+	}
+	return &Instance{container: container, endpoint: endpoint}, nil
+}
+
+// Endpoint returns the HTTP endpoint of the container
+func (i *Instance) Endpoint() string {
+	return i.endpoint
+}
+
+// Client returns a DynamoDB client pointed at the container
+func (i *Instance) Client() *dynamodb.Client {
+	return dynamodb.New(dynamodb.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(i.endpoint),
+		// DynamoDB Local accepts any credentials but the SDK still signs requests
+		Credentials: credentials.NewStaticCredentialsProvider("local", "local", ""),
+	})
+}
+
+// Terminate stops and removes the container
+func (i *Instance) Terminate(ctx context.Context) error {
+	return i.container.Terminate(ctx)
+}
+
+// ForTest starts DynamoDB Local for one test and removes it when the test ends.
+// The test is skipped in -short mode or when no container runtime is reachable.
+func ForTest(t *testing.T) *dynamodb.Client {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("Skipping DynamoDB Local test in short mode")
+	}
+	if !dockerAvailable(context.Background()) {
+		t.Skip("Skipping DynamoDB Local test: no Docker host is reachable")
+	}
+
+	instance, err := Start(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to start DynamoDB Local: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := instance.Terminate(context.Background()); err != nil {
+			t.Logf("Failed to terminate DynamoDB Local: %v", err)
+		}
+	})
+	return instance.Client()
+}
+
+// dockerAvailable reports whether a healthy Docker host is reachable.
+// testcontainers panics while resolving the host when none is configured, so that is recovered here.
+func dockerAvailable(ctx context.Context) (available bool) {
+	defer func() {
+		if recover() != nil {
+			available = false
+		}
+	}()
+
+	provider, err := testcontainers.NewDockerProvider()
+	if err != nil {
+		return false
+	}
+	defer provider.Close()
+	return provider.Health(ctx) == nil
+}
+
+// CreateTable creates tableName with the key schema and indexes of spec and waits until it is active
+func CreateTable(ctx context.Context, client *dynamodb.Client, tableName string, spec tfspec.TableSpec) error {
+	attributes := map[string]bool{}
+	var definitions []types.AttributeDefinition
+	define := func(name string) {
+		if name == "" || attributes[name] {
+			return
+		}
+		attributes[name] = true
+		// Every key attribute in the template is a string
+		definitions = append(definitions, types.AttributeDefinition{
+			AttributeName: aws.String(name),
+			AttributeType: types.ScalarAttributeTypeS,
+		})
+	}
+
+	input := &dynamodb.CreateTableInput{
+		TableName:   aws.String(tableName),
+		BillingMode: types.BillingModePayPerRequest,
+		KeySchema:   keySchema(spec.HashKey, spec.RangeKey),
+	}
+	define(spec.HashKey)
+	define(spec.RangeKey)
+
+	for _, index := range spec.GlobalSecondaryIndexes {
+		define(index.HashKey)
+		define(index.RangeKey)
+		input.GlobalSecondaryIndexes = append(input.GlobalSecondaryIndexes, types.GlobalSecondaryIndex{
+			IndexName:  aws.String(index.Name),
+			KeySchema:  keySchema(index.HashKey, index.RangeKey),
+			Projection: &types.Projection{ProjectionType: types.ProjectionType(index.ProjectionType)},
+		})
+	}
+	input.AttributeDefinitions = definitions
+
+	if _, err := client.CreateTable(ctx, input); err != nil {
+		return fmt.Errorf("creating table %s: %w", tableName, err)
+	}
+
+	waiter := dynamodb.NewTableExistsWaiter(client)
+	return waiter.Wait(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(tableName)}, tableWaitTimeout)
+}
+
+// keySchema builds a hash key schema with an optional range key
+func keySchema(hashKey, rangeKey string) []types.KeySchemaElement {
+	schema := []types.KeySchemaElement{{AttributeName: aws.String(hashKey), KeyType: types.KeyTypeHash}}
+	if rangeKey != "" {
+		schema = append(schema, types.KeySchemaElement{AttributeName: aws.String(rangeKey), KeyType: types.KeyTypeRange})
+	}
+	return schema
+}
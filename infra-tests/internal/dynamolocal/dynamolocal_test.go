@@ -0,0 +1,42 @@
+package dynamolocal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/tfspec"
+)
+
+func TestCreateTableMatchesSpec(t *testing.T) {
+	client := ForTest(t)
+
+	for _, spec := range tfspec.Tables {
+		t.Run(spec.NameSuffix, func(t *testing.T) {
+			tableName := spec.TableName("local", "test")
+			require.NoError(t, CreateTable(context.TODO(), client, tableName, spec))
+
+			table, err := client.DescribeTable(context.TODO(), &dynamodb.DescribeTableInput{TableName: aws.String(tableName)})
+			require.NoError(t, err)
+
+			keys := map[string]string{}
+			for _, key := range table.Table.KeySchema {
+				keys[string(key.KeyType)] = *key.AttributeName
+			}
+			assert.Equal(t, spec.HashKey, keys["HASH"])
+			assert.Equal(t, spec.RangeKey, keys["RANGE"])
+
+			indexes := map[string]string{}
+			for _, index := range table.Table.GlobalSecondaryIndexes {
+				indexes[*index.IndexName] = string(index.Projection.ProjectionType)
+			}
+			for _, index := range spec.GlobalSecondaryIndexes {
+				assert.Equal(t, index.ProjectionType, indexes[index.Name], "Index %s projection", index.Name)
+			}
+		})
+	}
+}
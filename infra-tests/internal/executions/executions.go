@@ -0,0 +1,167 @@
+// Package executions lists the recent runs of a STANDARD state machine and measures how many of them succeeded,
+// so the order workflow's success rate over a trailing window can be held to a service level objective and
+// tracked from one CI run to the next. EXPRESS state machines keep no execution list to read.
+package executions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	sfntypes "github.com/aws/aws-sdk-go-v2/service/sfn/types"
+)
+
+// SuitePrefixes start the names the suite gives the executions it starts. Many of them are made to fail on
+// purpose, so they are left out of the success rate.
+var SuitePrefixes = []string{"itest-", "bdd-"}
+
+// API is the Step Functions operation used to list executions
+type API interface {
+	ListExecutions(ctx context.Context, params *sfn.ListExecutionsInput, optFns ...func(*sfn.Options)) (*sfn.ListExecutionsOutput, error)
+}
+
+// Execution is one run of a state machine
+type Execution struct {
+	Name    string
+	ARN     string
+	Status  sfntypes.ExecutionStatus
+	Started time.Time
+	// Stopped is zero while the execution runs
+	Stopped time.Time
+}
+
+// Duration returns how long the execution ran, or 0 while it runs
+func (e Execution) Duration() time.Duration {
+	if e.Stopped.IsZero() {
+		return 0
+	}
+	return e.Stopped.Sub(e.Started)
+}
+
+// List returns the executions of a state machine started at or after since, newest first. Step Functions lists
+// executions newest first, so paging stops at the first one started earlier.
+func List(ctx context.Context, api API, stateMachineArn string, since time.Time) ([]Execution, error) {
+	var executions []Execution
+	paginator := sfn.NewListExecutionsPaginator(api, &sfn.ListExecutionsInput{StateMachineArn: aws.String(stateMachineArn)})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Executions {
+			started := aws.ToTime(item.StartDate)
+			if started.Before(since) {
+				return executions, nil
+			}
+			executions = append(executions, Execution{
+				Name:    aws.ToString(item.Name),
+				ARN:     aws.ToString(item.ExecutionArn),
+				Status:  item.Status,
+				Started: started,
+				Stopped: aws.ToTime(item.StopDate),
+			})
+		}
+	}
+	return executions, nil
+}
+
+// Exclude returns the executions whose names start with none of prefixes
+func Exclude(executions []Execution, prefixes []string) []Execution {
+	var kept []Execution
+next:
+	for _, execution := range executions {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(execution.Name, prefix) {
+				continue next
+			}
+		}
+		kept = append(kept, execution)
+	}
+	return kept
+}
+
+// Summary counts executions by status
+type Summary struct {
+	Succeeded int
+	Failed    int
+	TimedOut  int
+	Aborted   int
+	Running   int
+}
+
+// Summarize counts executions by status. Redriven executions are counted by the status they have now.
+func Summarize(executions []Execution) Summary {
+	var summary Summary
+	for _, execution := range executions {
+		switch execution.Status {
+		case sfntypes.ExecutionStatusSucceeded:
+			summary.Succeeded++
+		case sfntypes.ExecutionStatusFailed:
+			summary.Failed++
+		case sfntypes.ExecutionStatusTimedOut:
+			summary.TimedOut++
+		case sfntypes.ExecutionStatusAborted:
+			summary.Aborted++
+		default:
+			summary.Running++
+		}
+	}
+	return summary
+}
+
+// Finished returns how many executions have stopped
+func (s Summary) Finished() int {
+	return s.Succeeded + s.Failed + s.TimedOut + s.Aborted
+}
+
+// SuccessRate returns the fraction of finished executions that succeeded, or 1 when none has finished; running
+// executions have no outcome yet and are left out
+func (s Summary) SuccessRate() float64 {
+	if s.Finished() == 0 {
+		return 1
+	}
+	return float64(s.Succeeded) / float64(s.Finished())
+}
+
+// SLO is the success rate a state machine's executions must keep
+type SLO struct {
+	// MinSuccessRate is the lowest fraction of finished executions that may succeed; 0 enforces nothing
+	MinSuccessRate float64
+	// MinExecutions is how many executions must have finished before the rate is judged; a few failures
+	// among a handful of runs say little
+	MinExecutions int
+}
+
+// DefaultMinExecutions is the traffic a window needs before its success rate is judged
+const DefaultMinExecutions = 20
+
+// Enforced reports whether the objective judges a summary: it sets a rate and enough executions have finished
+func (o SLO) Enforced(summary Summary) bool {
+	return o.MinSuccessRate > 0 && summary.Finished() >= o.MinExecutions
+}
+
+// Violation describes how a summary misses the objective, or returns "" when it meets it or is not judged
+func (o SLO) Violation(summary Summary) string {
+	if !o.Enforced(summary) || summary.SuccessRate() >= o.MinSuccessRate {
+		return ""
+	}
+	return fmt.Sprintf("%.2f%% of %d finished executions succeeded, below the %.2f%% objective (%d failed, %d timed out, %d aborted)",
+		summary.SuccessRate()*100, summary.Finished(), o.MinSuccessRate*100, summary.Failed, summary.TimedOut, summary.Aborted)
+}
+
+// Unsuccessful returns the finished executions that did not succeed, oldest first
+func Unsuccessful(executions []Execution) []Execution {
+	var unsuccessful []Execution
+	for _, execution := range executions {
+		switch execution.Status {
+		case sfntypes.ExecutionStatusFailed, sfntypes.ExecutionStatusTimedOut, sfntypes.ExecutionStatusAborted:
+			unsuccessful = append(unsuccessful, execution)
+		}
+	}
+	sort.SliceStable(unsuccessful, func(i, j int) bool { return unsuccessful[i].Started.Before(unsuccessful[j].Started) })
+	return unsuccessful
+}
@@ -0,0 +1,106 @@
+package executions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	sfntypes "github.com/aws/aws-sdk-go-v2/service/sfn/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExecutions serves pages of executions, newest first, and counts the pages read
+type fakeExecutions struct {
+	pages [][]sfntypes.ExecutionListItem
+	read  int
+}
+
+func (f *fakeExecutions) ListExecutions(ctx context.Context, params *sfn.ListExecutionsInput, optFns ...func(*sfn.Options)) (*sfn.ListExecutionsOutput, error) {
+	page := f.pages[f.read]
+	f.read++
+	output := &sfn.ListExecutionsOutput{Executions: page}
+	if f.read < len(f.pages) {
+		output.NextToken = aws.String("next")
+	}
+	return output, nil
+}
+
+// item returns an execution listed as started minutes after base
+func item(name string, status sfntypes.ExecutionStatus, minutes int) sfntypes.ExecutionListItem {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	started := base.Add(time.Duration(minutes) * time.Minute)
+	listed := sfntypes.ExecutionListItem{Name: aws.String(name), ExecutionArn: aws.String("arn:" + name), Status: status, StartDate: aws.Time(started)}
+	if status != sfntypes.ExecutionStatusRunning {
+		listed.StopDate = aws.Time(started.Add(3 * time.Second))
+	}
+	return listed
+}
+
+func TestListStopsAtTheWindow(t *testing.T) {
+	api := &fakeExecutions{pages: [][]sfntypes.ExecutionListItem{
+		{item("order-4", sfntypes.ExecutionStatusRunning, 40), item("order-3", sfntypes.ExecutionStatusSucceeded, 30)},
+		{item("order-2", sfntypes.ExecutionStatusFailed, 20), item("order-1", sfntypes.ExecutionStatusSucceeded, 10)},
+		{item("order-0", sfntypes.ExecutionStatusSucceeded, 0)},
+	}}
+
+	listed, err := List(context.Background(), api, "arn:machine", time.Date(2026, 1, 1, 12, 15, 0, 0, time.UTC))
+	require.NoError(t, err)
+	var names []string
+	for _, execution := range listed {
+		names = append(names, execution.Name)
+	}
+	assert.Equal(t, []string{"order-4", "order-3", "order-2"}, names)
+	assert.Equal(t, 2, api.read, "pages past the window are not read")
+
+	assert.Equal(t, sfntypes.ExecutionStatusFailed, listed[2].Status)
+	assert.Equal(t, 3*time.Second, listed[2].Duration())
+	assert.Zero(t, listed[0].Duration(), "a running execution has no duration yet")
+}
+
+func TestExclude(t *testing.T) {
+	listed := []Execution{{Name: "itest-1"}, {Name: "order-1"}, {Name: "bdd-1"}, {Name: "order-itest-2"}}
+	assert.Equal(t, []Execution{{Name: "order-1"}, {Name: "order-itest-2"}}, Exclude(listed, SuitePrefixes))
+}
+
+func TestSummarize(t *testing.T) {
+	listed := []Execution{
+		{Status: sfntypes.ExecutionStatusSucceeded},
+		{Status: sfntypes.ExecutionStatusSucceeded},
+		{Status: sfntypes.ExecutionStatusSucceeded},
+		{Status: sfntypes.ExecutionStatusFailed},
+		{Status: sfntypes.ExecutionStatusRunning},
+		{Status: sfntypes.ExecutionStatusPendingRedrive},
+	}
+	summary := Summarize(listed)
+	assert.Equal(t, Summary{Succeeded: 3, Failed: 1, Running: 2}, summary)
+	assert.Equal(t, 4, summary.Finished())
+	assert.Equal(t, 0.75, summary.SuccessRate(), "running executions are left out")
+	assert.Equal(t, 1.0, Summary{Running: 3}.SuccessRate())
+}
+
+func TestSLOViolation(t *testing.T) {
+	objective := SLO{MinSuccessRate: 0.95, MinExecutions: 20}
+
+	assert.Empty(t, objective.Violation(Summary{Succeeded: 19, Failed: 1}))
+	assert.Equal(t, "90.00% of 20 finished executions succeeded, below the 95.00% objective (1 failed, 1 timed out, 0 aborted)",
+		objective.Violation(Summary{Succeeded: 18, Failed: 1, TimedOut: 1}))
+	assert.Empty(t, objective.Violation(Summary{Succeeded: 5, Failed: 5, Running: 30}), "too few finished executions are not judged")
+	assert.Empty(t, SLO{MinExecutions: 20}.Violation(Summary{Failed: 40}), "no rate enforces nothing")
+}
+
+func TestUnsuccessful(t *testing.T) {
+	now := time.Now()
+	listed := []Execution{
+		{Name: "timed-out", Status: sfntypes.ExecutionStatusTimedOut, Started: now},
+		{Name: "succeeded", Status: sfntypes.ExecutionStatusSucceeded, Started: now.Add(-time.Minute)},
+		{Name: "failed", Status: sfntypes.ExecutionStatusFailed, Started: now.Add(-2 * time.Minute)},
+		{Name: "running", Status: sfntypes.ExecutionStatusRunning, Started: now.Add(-3 * time.Minute)},
+	}
+	unsuccessful := Unsuccessful(listed)
+	require.Len(t, unsuccessful, 2)
+	assert.Equal(t, "failed", unsuccessful[0].Name)
+	assert.Equal(t, "timed-out", unsuccessful[1].Name)
+}
@@ -0,0 +1,355 @@
+// Package expectations declares, as data, what each environment's deployment must look like, so one suite
+// validates dev, staging, and prod without hard-coding one environment or tolerating every setting.
+// Profiles mirror terraform/environments/*.tfvars and must change with them.
+package expectations
+
+import (
+	"slices"
+	"strings"
+
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// DefaultProfile names the profile used for unknown environments, such as developer namespaces
+const DefaultProfile = "dev"
+
+// Runtime and architecture every function is deployed with; Architecture matches lambda_architecture in the tfvars
+const (
+	Runtime      = "java21"
+	Architecture = "x86_64"
+)
+
+// AlarmTopic is the suffix of the SNS topic every alarm notifies
+const AlarmTopic = "alerts"
+
+// Alarm is a CloudWatch metric alarm the deployment must have, as declared in terraform/cloudwatch.tf
+type Alarm struct {
+	// Resource is the suffix of the name of the resource the alarm watches, e.g. "product-service" or "api";
+	// empty for alarms on the account, such as the monthly bill
+	Resource string
+	// Name follows the watched resource's name in the alarm name, e.g. "error-rate"
+	Name string
+
+	Namespace string
+	Metric    string
+	// Dimension selects the watched resource's metric; its value is the resource's full name unless
+	// DimensionValue is set
+	Dimension      string
+	DimensionValue string
+
+	Statistic  cwtypes.Statistic
+	Comparison cwtypes.ComparisonOperator
+	Threshold  float64
+	// Period in seconds, and how many periods must breach before the alarm fires
+	Period            int32
+	EvaluationPeriods int32
+
+	// NotifiesOK requires the alarm to notify AlarmTopic when it recovers as well as when it fires
+	NotifiesOK bool
+
+	// AnomalyBand, when above 0, makes this an anomaly detection alarm: it fires when the metric leaves the band
+	// CloudWatch learned for it, this many standard deviations wide, and Threshold is unused
+	AnomalyBand float64
+}
+
+// AlarmName returns the alarm's name in the deployment named baseName, e.g. "lambda-java-template-dev"
+func (a Alarm) AlarmName(baseName string) string {
+	if a.Resource == "" {
+		return baseName + "-" + a.Name
+	}
+	return baseName + "-" + a.Resource + "-" + a.Name
+}
+
+// Dimensions returns the metric dimensions the alarm must select in the deployment named baseName
+func (a Alarm) Dimensions(baseName string) map[string]string {
+	value := a.DimensionValue
+	if value == "" {
+		value = baseName + "-" + a.Resource
+	}
+	return map[string]string{a.Dimension: value}
+}
+
+// CompositeAlarm rolls child alarms up into one that is in ALARM while any child is, as declared in
+// terraform/cloudwatch.tf
+type CompositeAlarm struct {
+	// Name follows the deployment's name in the alarm name, e.g. "service-health"
+	Name     string
+	Children []Alarm
+}
+
+// AlarmName returns the composite alarm's name in the deployment named baseName
+func (c CompositeAlarm) AlarmName(baseName string) string {
+	return baseName + "-" + c.Name
+}
+
+// ChildNames returns the names of the child alarms in the deployment named baseName
+func (c CompositeAlarm) ChildNames(baseName string) []string {
+	names := make([]string, len(c.Children))
+	for i, child := range c.Children {
+		names[i] = child.AlarmName(baseName)
+	}
+	return names
+}
+
+// Profile is what validators expect of one environment
+type Profile struct {
+	Name string
+
+	// Runtime and Architecture of every function
+	Runtime      string
+	Architecture string
+
+	// MemorySizes in MB by function suffix, e.g. "product-service"
+	MemorySizes map[string]int32
+
+	// Timeout of every function in seconds
+	Timeout int32
+
+	// LogRetentionDays of every log group
+	LogRetentionDays int32
+
+	// BillingMode of every table
+	BillingMode string
+
+	// ReadCapacity and WriteCapacity are the units provisioned for every table and index; 0 for on-demand tables
+	ReadCapacity  int64
+	WriteCapacity int64
+
+	// AutoScaling requires Application Auto Scaling to manage provisioned capacity;
+	// without it tables keep exactly ReadCapacity and WriteCapacity
+	AutoScaling bool
+
+	// StreamViewTypes are the stream view types of the tables, by table suffix, that a consumer reads a stream of;
+	// every other table must have streams disabled
+	StreamViewTypes map[string]string
+
+	// PointInTimeRecovery requires point-in-time recovery on every table, not only those Terraform always enables it on
+	PointInTimeRecovery bool
+
+	// AuditedMutations declares that the product service writes an audit record for every create, update, and
+	// delete. It writes none yet, so no profile sets it and audit completeness is skipped.
+	AuditedMutations bool
+
+	// RuntimeUpdateModes are the Lambda runtime update modes functions may use
+	RuntimeUpdateModes []lambdatypes.UpdateRuntimeOn
+
+	// Alarms are every metric alarm of the deployment; an alarm not listed here is unexpected
+	Alarms []Alarm
+
+	// CompositeAlarms roll Alarms up into service-level health
+	CompositeAlarms []CompositeAlarm
+
+	// StageVariables of the API's $default stage; Terraform declares none, so a variable found was set by hand
+	StageVariables map[string]string
+
+	// PublicRoutes are the route keys callers may reach without the API key authorizer; every other route must use it
+	PublicRoutes []string
+
+	// ProtectedRoutes are the route keys that must never be public, even if added to PublicRoutes by mistake
+	ProtectedRoutes []string
+
+	// MonthlyBudget is the most, in USD, the deployment's projected monthly cost may reach
+	MonthlyBudget float64
+
+	// WorkflowSuccessRate is the lowest fraction of the order workflow's executions, apart from the suite's own,
+	// that may succeed over the trailing window; 0 where traffic is too sparse or experimental to hold to one
+	WorkflowSuccessRate float64
+}
+
+// StreamViewType returns the view type a table's stream must have, or "" when it must have none
+func (p Profile) StreamViewType(table string) string {
+	return p.StreamViewTypes[table]
+}
+
+// MemorySize returns the memory size a function must have
+func (p Profile) MemorySize(function string) int32 {
+	return p.MemorySizes[function]
+}
+
+// memorySizes returns the memory sizes of a deployment giving the product service memory MB;
+// the authorizer's memory is fixed in Terraform
+func memorySizes(memory int32) map[string]int32 {
+	return map[string]int32{
+		"product-service":    memory,
+		"authorizer-service": 256,
+	}
+}
+
+// alarms returns the alarms of a deployment: error rate, duration, duration anomalies, and throttles per function,
+// read and write throttles per table, and 4xx, 5xx, latency, and latency anomalies on the API, plus the monthly
+// bill when monthlyCost is set
+func alarms(monthlyCost bool) []Alarm {
+	const fiveMinutes = 300
+	var list []Alarm
+	for _, function := range []string{"product-service", "authorizer-service"} {
+		lambdaAlarm := func(name, metric string, statistic cwtypes.Statistic, threshold float64, evaluationPeriods int32) Alarm {
+			return Alarm{
+				Resource: function, Name: name, Namespace: "AWS/Lambda", Metric: metric, Dimension: "FunctionName",
+				Statistic: statistic, Comparison: cwtypes.ComparisonOperatorGreaterThanThreshold, Threshold: threshold,
+				Period: fiveMinutes, EvaluationPeriods: evaluationPeriods,
+			}
+		}
+		errorRate := lambdaAlarm("error-rate", "Errors", cwtypes.StatisticSum, 5, 2)
+		errorRate.NotifiesOK = true
+		durationAnomaly := lambdaAlarm("duration-anomaly", "Duration", cwtypes.StatisticAverage, 0, 3)
+		durationAnomaly.Comparison = cwtypes.ComparisonOperatorGreaterThanUpperThreshold
+		durationAnomaly.AnomalyBand = 2
+		list = append(list,
+			errorRate,
+			// 25 seconds, below the 30 second timeout
+			lambdaAlarm("duration", "Duration", cwtypes.StatisticAverage, 25000, 2),
+			durationAnomaly,
+			lambdaAlarm("throttles", "Throttles", cwtypes.StatisticSum, 0, 1),
+		)
+	}
+	for _, table := range []string{"products", "audit-logs"} {
+		for _, metric := range []string{"Read", "Write"} {
+			list = append(list, Alarm{
+				Resource: table, Name: strings.ToLower(metric) + "-throttles", Namespace: "AWS/DynamoDB", Metric: metric + "Throttles",
+				Dimension: "TableName", Statistic: cwtypes.StatisticSum, Comparison: cwtypes.ComparisonOperatorGreaterThanThreshold,
+				Threshold: 0, Period: fiveMinutes, EvaluationPeriods: 1,
+			})
+		}
+	}
+	apiAlarm := func(name, metric string, statistic cwtypes.Statistic, threshold float64, evaluationPeriods int32) Alarm {
+		return Alarm{
+			Resource: "api", Name: name, Namespace: "AWS/ApiGateway", Metric: metric, Dimension: "ApiName",
+			Statistic: statistic, Comparison: cwtypes.ComparisonOperatorGreaterThanThreshold, Threshold: threshold,
+			Period: fiveMinutes, EvaluationPeriods: evaluationPeriods,
+		}
+	}
+	latencyAnomaly := apiAlarm("latency-anomaly", "Latency", cwtypes.StatisticAverage, 0, 3)
+	latencyAnomaly.Comparison = cwtypes.ComparisonOperatorGreaterThanUpperThreshold
+	latencyAnomaly.AnomalyBand = 2
+	list = append(list,
+		apiAlarm("4xx-errors", "4XXError", cwtypes.StatisticSum, 10, 2),
+		apiAlarm("5xx-errors", "5XXError", cwtypes.StatisticSum, 2, 1),
+		// 5 seconds
+		apiAlarm("latency", "Latency", cwtypes.StatisticAverage, 5000, 2),
+		latencyAnomaly,
+	)
+	if monthlyCost {
+		list = append(list, Alarm{
+			Name: "monthly-cost", Namespace: "AWS/Billing", Metric: "EstimatedCharges", Dimension: "Currency", DimensionValue: "USD",
+			Statistic: cwtypes.StatisticMaximum, Comparison: cwtypes.ComparisonOperatorGreaterThanThreshold,
+			Threshold: 100, Period: 86400, EvaluationPeriods: 1,
+		})
+	}
+	return list
+}
+
+// serviceHealthSignals name the alarms rolled up into service-health: failures and slowdowns users notice
+var serviceHealthSignals = []string{"error-rate", "duration-anomaly", "5xx-errors", "latency-anomaly"}
+
+// compositeAlarms returns the composite alarms of a deployment with the given alarms
+func compositeAlarms(list []Alarm) []CompositeAlarm {
+	health := CompositeAlarm{Name: "service-health"}
+	for _, alarm := range list {
+		if slices.Contains(serviceHealthSignals, alarm.Name) {
+			health.Children = append(health.Children, alarm)
+		}
+	}
+	return []CompositeAlarm{health}
+}
+
+// publicRoutes is the allowlist of routes served without authorization: load balancers and uptime checks call /health
+var publicRoutes = []string{"GET /health"}
+
+// protectedRoutes is the denylist of routes that read or change the product catalogue
+var protectedRoutes = []string{"GET /products", "GET /products/{id}", "POST /products", "PUT /products/{id}", "DELETE /products/{id}"}
+
+// profiles are the expectations of each environment.
+// Production-like environments must not take runtime updates implicitly, and must be able to restore every table.
+var profiles = map[string]Profile{
+	"dev": {
+		Name:             "dev",
+		Runtime:          Runtime,
+		Architecture:     Architecture,
+		MemorySizes:      memorySizes(512),
+		Timeout:          30,
+		LogRetentionDays: 7,
+		BillingMode:      "PAY_PER_REQUEST",
+		RuntimeUpdateModes: []lambdatypes.UpdateRuntimeOn{
+			lambdatypes.UpdateRuntimeOnAuto,
+			lambdatypes.UpdateRuntimeOnFunctionUpdate,
+			lambdatypes.UpdateRuntimeOnManual,
+		},
+		Alarms:          alarms(false),
+		CompositeAlarms: compositeAlarms(alarms(false)),
+		PublicRoutes:    publicRoutes,
+		ProtectedRoutes: protectedRoutes,
+		MonthlyBudget:   25,
+	},
+	"ephemeral": {
+		Name:             "ephemeral",
+		Runtime:          Runtime,
+		Architecture:     Architecture,
+		MemorySizes:      memorySizes(256),
+		Timeout:          15,
+		LogRetentionDays: 3,
+		BillingMode:      "PAY_PER_REQUEST",
+		RuntimeUpdateModes: []lambdatypes.UpdateRuntimeOn{
+			lambdatypes.UpdateRuntimeOnAuto,
+			lambdatypes.UpdateRuntimeOnFunctionUpdate,
+			lambdatypes.UpdateRuntimeOnManual,
+		},
+		Alarms:          alarms(false),
+		CompositeAlarms: compositeAlarms(alarms(false)),
+		PublicRoutes:    publicRoutes,
+		ProtectedRoutes: protectedRoutes,
+		MonthlyBudget:   10,
+	},
+	"staging": {
+		Name:                "staging",
+		Runtime:             Runtime,
+		Architecture:        Architecture,
+		MemorySizes:         memorySizes(1024),
+		Timeout:             30,
+		LogRetentionDays:    14,
+		BillingMode:         "PAY_PER_REQUEST",
+		PointInTimeRecovery: true,
+		RuntimeUpdateModes: []lambdatypes.UpdateRuntimeOn{
+			lambdatypes.UpdateRuntimeOnFunctionUpdate,
+			lambdatypes.UpdateRuntimeOnManual,
+		},
+		Alarms:              alarms(false),
+		CompositeAlarms:     compositeAlarms(alarms(false)),
+		PublicRoutes:        publicRoutes,
+		ProtectedRoutes:     protectedRoutes,
+		MonthlyBudget:       50,
+		WorkflowSuccessRate: 0.95,
+	},
+	// Production also alarms on the monthly bill
+	"prod": {
+		Name:                "prod",
+		Runtime:             Runtime,
+		Architecture:        Architecture,
+		MemorySizes:         memorySizes(1024),
+		Timeout:             30,
+		LogRetentionDays:    30,
+		BillingMode:         "PROVISIONED",
+		ReadCapacity:        5,
+		WriteCapacity:       5,
+		PointInTimeRecovery: true,
+		RuntimeUpdateModes: []lambdatypes.UpdateRuntimeOn{
+			lambdatypes.UpdateRuntimeOnFunctionUpdate,
+			lambdatypes.UpdateRuntimeOnManual,
+		},
+		Alarms:          alarms(true),
+		CompositeAlarms: compositeAlarms(alarms(true)),
+		PublicRoutes:    publicRoutes,
+		ProtectedRoutes: protectedRoutes,
+		// Matches the threshold of the monthly-cost alarm
+		MonthlyBudget:       100,
+		WorkflowSuccessRate: 0.99,
+	},
+}
+
+// For returns the profile of an environment; unknown environments follow the dev profile
+func For(environment string) Profile {
+	if profile, ok := profiles[environment]; ok {
+		return profile
+	}
+	return profiles[DefaultProfile]
+}
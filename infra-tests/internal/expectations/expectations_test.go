@@ -0,0 +1,119 @@
+package expectations
+
+import (
+	"testing"
+
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/tfspec"
+)
+
+func TestFor(t *testing.T) {
+	assert.False(t, For("dev").PointInTimeRecovery)
+	assert.True(t, For("staging").PointInTimeRecovery)
+	assert.True(t, For("prod").PointInTimeRecovery)
+
+	assert.Equal(t, "ephemeral", For("ephemeral").Name)
+	assert.Equal(t, "dev", For("pr-142").Name)
+
+	assert.Zero(t, For("dev").WorkflowSuccessRate)
+	assert.Greater(t, For("prod").WorkflowSuccessRate, For("staging").WorkflowSuccessRate)
+}
+
+func TestProductionTakesNoImplicitRuntimeUpdates(t *testing.T) {
+	assert.Contains(t, For("dev").RuntimeUpdateModes, lambdatypes.UpdateRuntimeOnAuto)
+	for _, name := range []string{"staging", "prod"} {
+		assert.NotContains(t, For(name).RuntimeUpdateModes, lambdatypes.UpdateRuntimeOnAuto, name)
+	}
+}
+
+func TestProfilesAreComplete(t *testing.T) {
+	for name, profile := range profiles {
+		assert.Equal(t, name, profile.Name)
+		assert.Equal(t, Runtime, profile.Runtime, name)
+		assert.Equal(t, Architecture, profile.Architecture, name)
+		assert.Positive(t, profile.MemorySize("product-service"), name)
+		assert.Equal(t, int32(256), profile.MemorySize("authorizer-service"), name)
+		assert.Positive(t, profile.Timeout, name)
+		assert.Positive(t, profile.LogRetentionDays, name)
+		assert.Contains(t, []string{"PAY_PER_REQUEST", "PROVISIONED"}, profile.BillingMode, name)
+		assert.NotEmpty(t, profile.RuntimeUpdateModes, name)
+		assert.Positive(t, profile.MonthlyBudget, name)
+		assert.LessOrEqual(t, profile.WorkflowSuccessRate, 1.0, name)
+		if profile.BillingMode == "PROVISIONED" {
+			assert.Positive(t, profile.ReadCapacity, name)
+			assert.Positive(t, profile.WriteCapacity, name)
+		} else {
+			assert.Zero(t, profile.ReadCapacity+profile.WriteCapacity, "On-demand tables have no provisioned capacity in %s", name)
+			assert.False(t, profile.AutoScaling, "On-demand tables cannot auto scale in %s", name)
+		}
+	}
+	assert.Len(t, For("prod").Alarms, len(For("dev").Alarms)+1, "Production alarms on the monthly bill")
+	assert.Empty(t, For("prod").StreamViewType("audit-logs"), "Nothing consumes the template's table streams")
+}
+
+func TestAlarmNamesAndDimensions(t *testing.T) {
+	names := make(map[string]bool)
+	for _, alarm := range For("prod").Alarms {
+		name := alarm.AlarmName("lambda-java-template-prod")
+		assert.False(t, names[name], "%s is declared twice", name)
+		names[name] = true
+	}
+	assert.True(t, names["lambda-java-template-prod-product-service-error-rate"])
+	assert.True(t, names["lambda-java-template-prod-api-5xx-errors"])
+	assert.True(t, names["lambda-java-template-prod-monthly-cost"], "Account alarms are named after the deployment alone")
+
+	errorRate := For("dev").Alarms[0]
+	assert.Equal(t, map[string]string{"FunctionName": "lambda-java-template-dev-product-service"}, errorRate.Dimensions("lambda-java-template-dev"))
+	monthlyCost := For("prod").Alarms[len(For("prod").Alarms)-1]
+	assert.Equal(t, map[string]string{"Currency": "USD"}, monthlyCost.Dimensions("lambda-java-template-prod"))
+	assert.Equal(t, For("prod").MonthlyBudget, monthlyCost.Threshold, "The budget matches the monthly-cost alarm")
+}
+
+func TestServiceHealthRollsUpDeclaredAlarms(t *testing.T) {
+	profile := For("prod")
+	require.Len(t, profile.CompositeAlarms, 1)
+	health := profile.CompositeAlarms[0]
+	assert.Equal(t, "lambda-java-template-prod-service-health", health.AlarmName("lambda-java-template-prod"))
+
+	declared := make(map[string]Alarm)
+	for _, alarm := range profile.Alarms {
+		declared[alarm.AlarmName("lambda-java-template-prod")] = alarm
+	}
+	for _, child := range health.ChildNames("lambda-java-template-prod") {
+		assert.Contains(t, declared, child, "Child %s is not a declared alarm", child)
+	}
+	assert.Equal(t, []string{
+		"lambda-java-template-prod-product-service-error-rate",
+		"lambda-java-template-prod-product-service-duration-anomaly",
+		"lambda-java-template-prod-authorizer-service-error-rate",
+		"lambda-java-template-prod-authorizer-service-duration-anomaly",
+		"lambda-java-template-prod-api-5xx-errors",
+		"lambda-java-template-prod-api-latency-anomaly",
+	}, health.ChildNames("lambda-java-template-prod"))
+
+	for _, alarm := range profile.Alarms {
+		if alarm.AnomalyBand > 0 {
+			assert.Equal(t, cwtypes.ComparisonOperatorGreaterThanUpperThreshold, alarm.Comparison, alarm.Name)
+			assert.Contains(t, []string{"Duration", "Latency"}, alarm.Metric, "Anomaly detection watches latency")
+		}
+	}
+}
+
+func TestRouteListsClassifyTheTerraformRoutes(t *testing.T) {
+	var unauthorized, authorized []string
+	for _, route := range tfspec.Routes {
+		if route.AuthorizationType == "NONE" {
+			unauthorized = append(unauthorized, route.RouteKey)
+		} else {
+			authorized = append(authorized, route.RouteKey)
+		}
+	}
+	for name, profile := range profiles {
+		assert.ElementsMatch(t, unauthorized, profile.PublicRoutes, name)
+		assert.ElementsMatch(t, authorized, profile.ProtectedRoutes, name)
+	}
+}
@@ -0,0 +1,105 @@
+// Package findings decides which Security Hub findings on a project's resources are new since a recorded
+// baseline, so existing findings can be worked off while new HIGH and CRITICAL ones fail the suite.
+//
+// Security Hub aggregates GuardDuty, Inspector, and its own security standards, so one query covers all of them.
+package findings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"time"
+)
+
+// GatedSeverities are the severity labels that fail the gate
+var GatedSeverities = []string{"HIGH", "CRITICAL"}
+
+// MaxFilterValues is the most values Security Hub accepts in one filter, so resource ARNs are queried in batches
+const MaxFilterValues = 20
+
+// Finding is one active Security Hub finding on a resource of the project
+type Finding struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Severity  string    `json:"severity"`
+	Product   string    `json:"product"`
+	Resource  string    `json:"resource"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s %s finding %q on %s, created %s (%s)", f.Severity, f.Product, f.Title, f.Resource, f.CreatedAt.Format(time.RFC3339), f.ID)
+}
+
+// Baseline is the set of findings accepted when it was recorded
+type Baseline struct {
+	RecordedAt time.Time `json:"recordedAt"`
+	FindingIDs []string  `json:"findingIds"`
+}
+
+// NewBaseline accepts every finding as of now
+func NewBaseline(findings []Finding, now time.Time) Baseline {
+	baseline := Baseline{RecordedAt: now.UTC(), FindingIDs: []string{}}
+	for _, finding := range findings {
+		baseline.FindingIDs = append(baseline.FindingIDs, finding.ID)
+	}
+	sort.Strings(baseline.FindingIDs)
+	return baseline
+}
+
+// New returns the findings of a gated severity that the baseline does not accept and that were created after it
+// was recorded, oldest first
+func New(findings []Finding, baseline Baseline) []Finding {
+	var fresh []Finding
+	for _, finding := range findings {
+		if !slices.Contains(GatedSeverities, finding.Severity) || slices.Contains(baseline.FindingIDs, finding.ID) {
+			continue
+		}
+		if finding.CreatedAt.After(baseline.RecordedAt) {
+			fresh = append(fresh, finding)
+		}
+	}
+	sort.Slice(fresh, func(i, j int) bool { return fresh[i].CreatedAt.Before(fresh[j].CreatedAt) })
+	return fresh
+}
+
+// Batches splits resource ARNs into groups small enough for one Security Hub filter
+func Batches(arns []string) [][]string {
+	var batches [][]string
+	for start := 0; start < len(arns); start += MaxFilterValues {
+		batches = append(batches, arns[start:min(start+MaxFilterValues, len(arns))])
+	}
+	return batches
+}
+
+// LoadBaseline reads the baseline at path. It returns false when none has been recorded yet.
+func LoadBaseline(path string) (Baseline, bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Baseline{}, false, nil
+	}
+	if err != nil {
+		return Baseline{}, false, fmt.Errorf("reading findings baseline: %w", err)
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return Baseline{}, false, fmt.Errorf("parsing findings baseline %s: %w", path, err)
+	}
+	return baseline, true, nil
+}
+
+// WriteBaseline records a baseline at path
+func WriteBaseline(path string, baseline Baseline) error {
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding findings baseline: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing findings baseline: %w", err)
+	}
+	return nil
+}
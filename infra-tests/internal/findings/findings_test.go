@@ -0,0 +1,52 @@
+package findings
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	recorded := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	existing := Finding{ID: "existing", Severity: "CRITICAL", CreatedAt: recorded.Add(-time.Hour)}
+	baseline := NewBaseline([]Finding{existing}, recorded)
+
+	later := Finding{ID: "later", Severity: "HIGH", CreatedAt: recorded.Add(2 * time.Hour)}
+	sooner := Finding{ID: "sooner", Severity: "CRITICAL", CreatedAt: recorded.Add(time.Hour)}
+	medium := Finding{ID: "medium", Severity: "MEDIUM", CreatedAt: recorded.Add(time.Hour)}
+	predating := Finding{ID: "predating", Severity: "HIGH", CreatedAt: recorded.Add(-time.Minute)}
+
+	assert.Equal(t, []Finding{sooner, later}, New([]Finding{existing, later, medium, predating, sooner}, baseline),
+		"Only HIGH and CRITICAL findings created after the baseline and not accepted by it are new, oldest first")
+}
+
+func TestBatches(t *testing.T) {
+	var arns []string
+	for i := range 45 {
+		arns = append(arns, fmt.Sprintf("arn-%d", i))
+	}
+	batches := Batches(arns)
+	require.Len(t, batches, 3)
+	assert.Len(t, batches[0], MaxFilterValues)
+	assert.Equal(t, []string{"arn-40", "arn-41", "arn-42", "arn-43", "arn-44"}, batches[2])
+	assert.Empty(t, Batches(nil))
+}
+
+func TestBaselineRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	_, found, err := LoadBaseline(path)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	baseline := NewBaseline([]Finding{{ID: "b"}, {ID: "a"}}, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, WriteBaseline(path, baseline))
+	loaded, found, err := LoadBaseline(path)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []string{"a", "b"}, loaded.FindingIDs)
+	assert.True(t, baseline.RecordedAt.Equal(loaded.RecordedAt))
+}
@@ -0,0 +1,173 @@
+// Package fixtures seeds deterministic products and audit records for one test and removes them when it ends.
+//
+// Every item a Set writes is keyed under a namespace derived from the test name and tagged with
+// seed.SeededByAttribute, so leftovers from a crashed run are still found by cleanup.Namespace.
+// Removal is registered with t.Cleanup, which runs even when the test fails or panics.
+package fixtures
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/lambda-java-template/tests/internal/cleanup"
+	"github.com/lambda-java-template/tests/internal/seed"
+)
+
+// auditRecordTTL is how long seeded audit records live if teardown never runs; the audit table expires items on "ttl"
+const auditRecordTTL = 24 * time.Hour
+
+// DefaultProducts are the products Products seeds when none are given
+var DefaultProducts = []seed.Product{
+	{ID: "fixture-widget", Name: "Fixture Widget", Price: 9.99},
+	{ID: "fixture-gadget", Name: "Fixture Gadget", Price: 24.5},
+}
+
+// AuditRecord is an audit-log entry as the product service writes it
+type AuditRecord struct {
+	EventID   string
+	Timestamp time.Time
+	Action    string
+	EntityID  string
+}
+
+// Set is the fixture data of one test
+type Set struct {
+	client    *dynamodb.Client
+	target    cleanup.Target
+	namespace string
+
+	mu      sync.Mutex
+	written []writtenItem
+}
+
+// writtenItem is the key of an item a Set has written
+type writtenItem struct {
+	table string
+	key   map[string]types.AttributeValue
+}
+
+// New returns a fixture set for t under the suite namespace and removes its items when t ends
+func New(t testing.TB, client *dynamodb.Client, target cleanup.Target, namespace string) *Set {
+	t.Helper()
+
+	set := &Set{
+		client:    client,
+		target:    target,
+		namespace: Namespace(namespace, t.Name()),
+	}
+	t.Cleanup(func() {
+		if err := set.Teardown(context.Background()); err != nil {
+			t.Errorf("Failed to remove fixtures under %q: %v", set.namespace, err)
+		}
+	})
+	return set
+}
+
+// Namespace returns the key prefix of a test's fixtures. It is stable for a test name, so
+// re-running a test overwrites its fixtures instead of adding more, and starts with the suite
+// namespace so cleanup.Namespace removes anything teardown missed.
+func Namespace(suiteNamespace, testName string) string {
+	hash := fnv.New32a()
+	hash.Write([]byte(testName))
+	return fmt.Sprintf("%sfx%08x-", suiteNamespace, hash.Sum32())
+}
+
+// Namespace returns the key prefix of the set's items
+func (s *Set) Namespace() string {
+	return s.namespace
+}
+
+// Products writes products, or DefaultProducts when none are given, and returns them as stored,
+// with ids carrying the set's namespace
+func (s *Set) Products(t testing.TB, products ...seed.Product) []seed.Product {
+	t.Helper()
+	if len(products) == 0 {
+		products = DefaultProducts
+	}
+
+	stored := make([]seed.Product, 0, len(products))
+	for _, product := range products {
+		item := seed.Item(s.namespace, product)
+		s.put(t, s.target.ProductsTable, item, "id")
+
+		product.ID = s.namespace + product.ID
+		stored = append(stored, product)
+	}
+	return stored
+}
+
+// AuditRecords writes audit records and returns them as stored, with event ids carrying the set's namespace
+func (s *Set) AuditRecords(t testing.TB, records ...AuditRecord) []AuditRecord {
+	t.Helper()
+
+	stored := make([]AuditRecord, 0, len(records))
+	for _, record := range records {
+		record.EventID = s.namespace + record.EventID
+		if record.Timestamp.IsZero() {
+			record.Timestamp = time.Now().UTC()
+		}
+
+		item := map[string]types.AttributeValue{
+			"event_id":             &types.AttributeValueMemberS{Value: record.EventID},
+			"timestamp":            &types.AttributeValueMemberS{Value: record.Timestamp.Format(time.RFC3339Nano)},
+			"action":               &types.AttributeValueMemberS{Value: record.Action},
+			"entity_id":            &types.AttributeValueMemberS{Value: record.EntityID},
+			seed.SeededByAttribute: &types.AttributeValueMemberS{Value: s.namespace},
+			"ttl":                  &types.AttributeValueMemberN{Value: strconv.FormatInt(time.Now().Add(auditRecordTTL).Unix(), 10)},
+		}
+		s.put(t, s.target.AuditTable, item, "event_id", "timestamp")
+		stored = append(stored, record)
+	}
+	return stored
+}
+
+// put writes an item and remembers its key attributes for teardown
+func (s *Set) put(t testing.TB, table string, item map[string]types.AttributeValue, keyAttributes ...string) {
+	t.Helper()
+
+	_, err := s.client.PutItem(context.TODO(), &dynamodb.PutItemInput{
+		TableName: aws.String(table),
+		Item:      item,
+	})
+	if err != nil {
+		t.Fatalf("Failed to write fixture into %s: %v", table, err)
+	}
+
+	key := make(map[string]types.AttributeValue, len(keyAttributes))
+	for _, attribute := range keyAttributes {
+		key[attribute] = item[attribute]
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.written = append(s.written, writtenItem{table: table, key: key})
+}
+
+// Teardown deletes every item the set wrote. It is safe to call more than once, so callers
+// outside tests can defer it.
+func (s *Set) Teardown(ctx context.Context) error {
+	s.mu.Lock()
+	written := s.written
+	s.written = nil
+	s.mu.Unlock()
+
+	for _, item := range written {
+		_, err := s.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+			TableName: aws.String(item.table),
+			Key:       item.key,
+		})
+		if err != nil {
+			return fmt.Errorf("deleting fixture from %s: %w", item.table, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package fixtures
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/cleanup"
+	"github.com/lambda-java-template/tests/internal/dynamolocal"
+	"github.com/lambda-java-template/tests/internal/seed"
+	"github.com/lambda-java-template/tests/internal/tfspec"
+)
+
+// localTarget creates the products and audit tables in DynamoDB Local and returns them as a cleanup target
+func localTarget(t *testing.T, client *dynamodb.Client) cleanup.Target {
+	target := cleanup.NewTarget("local", "fixtures")
+	for _, spec := range tfspec.Tables {
+		switch spec.NameSuffix {
+		case "products":
+			require.NoError(t, dynamolocal.CreateTable(context.TODO(), client, target.ProductsTable, spec))
+		case "audit-logs":
+			require.NoError(t, dynamolocal.CreateTable(context.TODO(), client, target.AuditTable, spec))
+		}
+	}
+	return target
+}
+
+// itemCount returns how many items a table holds
+func itemCount(t *testing.T, client *dynamodb.Client, tableName string) int {
+	scan, err := client.Scan(context.TODO(), &dynamodb.ScanInput{TableName: aws.String(tableName)})
+	require.NoError(t, err)
+	return len(scan.Items)
+}
+
+func TestNamespaceIsDeterministic(t *testing.T) {
+	first := Namespace(seed.DefaultNamespace, "TestLambdaIntegration/API_Gateway_Integration")
+	assert.Equal(t, first, Namespace(seed.DefaultNamespace, "TestLambdaIntegration/API_Gateway_Integration"))
+	assert.NotEqual(t, first, Namespace(seed.DefaultNamespace, "TestLambdaIntegration/Product_CRUD_Round_Trip"))
+	assert.True(t, strings.HasPrefix(first, seed.DefaultNamespace), "Fixture keys stay inside the suite namespace")
+}
+
+func TestSetSeedsAndTearsDown(t *testing.T) {
+	client := dynamolocal.ForTest(t)
+	target := localTarget(t, client)
+
+	t.Run("Seed", func(t *testing.T) {
+		set := New(t, client, target, seed.DefaultNamespace)
+
+		products := set.Products(t)
+		require.Len(t, products, len(DefaultProducts))
+		for _, product := range products {
+			assert.True(t, strings.HasPrefix(product.ID, set.Namespace()))
+		}
+
+		records := set.AuditRecords(t, AuditRecord{EventID: "event-1", Action: "CREATE", EntityID: products[0].ID})
+		require.Len(t, records, 1)
+		assert.False(t, records[0].Timestamp.IsZero(), "Missing timestamps default to now")
+
+		assert.Equal(t, len(DefaultProducts), itemCount(t, client, target.ProductsTable))
+		assert.Equal(t, 1, itemCount(t, client, target.AuditTable))
+	})
+
+	// The subtest's cleanup has run by now
+	assert.Zero(t, itemCount(t, client, target.ProductsTable), "Fixture products outlived their test")
+	assert.Zero(t, itemCount(t, client, target.AuditTable), "Fixture audit records outlived their test")
+}
+
+func TestTeardownIsRepeatable(t *testing.T) {
+	client := dynamolocal.ForTest(t)
+	target := localTarget(t, client)
+
+	set := New(t, client, target, seed.DefaultNamespace)
+	set.Products(t, seed.Product{ID: "only", Name: "Only", Price: 1})
+
+	require.NoError(t, set.Teardown(context.TODO()))
+	require.NoError(t, set.Teardown(context.TODO()))
+	assert.Zero(t, itemCount(t, client, target.ProductsTable))
+}
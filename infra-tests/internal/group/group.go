@@ -0,0 +1,97 @@
+// Package group sorts validators by the area of the deployment they check, so an operator can validate one
+// concern of an environment, such as its security or monitoring, without running the rest of the suite.
+package group
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Group is an area of the deployment validators check
+type Group string
+
+// Groups of validators
+const (
+	Compute     Group = "compute"
+	Data        Group = "data"
+	API         Group = "api"
+	Security    Group = "security"
+	Workflow    Group = "workflow"
+	Monitoring  Group = "monitoring"
+	Deployment  Group = "deployment"
+	Performance Group = "performance"
+	Governance  Group = "governance"
+)
+
+// All lists every group in the order validators are registered
+var All = []Group{Compute, Data, API, Security, Workflow, Monitoring, Deployment, Performance, Governance}
+
+// Parallel reports whether validators of g may run alongside other groups. Workflow validators inject faults
+// into shared functions and count side effects across the environment, and performance validators measure
+// latency and concurrency, so both need the deployment to themselves.
+func (g Group) Parallel() bool {
+	return g != Workflow && g != Performance
+}
+
+// RunLanes calls the functions of each lane in order, running up to limit lanes at once, and returns when every
+// lane is done. A limit below 2 runs the lanes one after another.
+func RunLanes(lanes [][]func(), limit int) {
+	slots := make(chan struct{}, max(limit, 1))
+	var wg sync.WaitGroup
+	for _, lane := range lanes {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			for _, run := range lane {
+				run()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Selection is the groups a run is limited to; an empty selection runs every group
+type Selection []Group
+
+// Parse reads a comma-separated list of group names, such as "security,monitoring"; "" selects every group
+func Parse(list string) (Selection, error) {
+	var selection Selection
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(All, Group(name)) {
+			return nil, fmt.Errorf("group must be one of %s, got %q", names(), name)
+		}
+		if !slices.Contains(selection, Group(name)) {
+			selection = append(selection, Group(name))
+		}
+	}
+	return selection, nil
+}
+
+// Includes reports whether the selection runs validators of group g
+func (s Selection) Includes(g Group) bool {
+	return len(s) == 0 || slices.Contains(s, g)
+}
+
+// String returns the selection as Parse reads it
+func (s Selection) String() string {
+	parts := make([]string, len(s))
+	for i, g := range s {
+		parts[i] = string(g)
+	}
+	return strings.Join(parts, ",")
+}
+
+// names lists every group for error messages
+func names() string {
+	return strings.ReplaceAll(Selection(All).String(), ",", ", ")
+}
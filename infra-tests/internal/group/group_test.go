@@ -0,0 +1,77 @@
+package group
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	selection, err := Parse("security, monitoring,security")
+	require.NoError(t, err)
+	assert.Equal(t, Selection{Security, Monitoring}, selection, "Names are trimmed and listed once")
+	assert.Equal(t, "security,monitoring", selection.String())
+
+	_, err = Parse("security,networking")
+	assert.EqualError(t, err, `group must be one of compute, data, api, security, workflow, monitoring, deployment, performance, governance, got "networking"`)
+}
+
+func TestIncludes(t *testing.T) {
+	selection, err := Parse("security")
+	require.NoError(t, err)
+	assert.True(t, selection.Includes(Security))
+	assert.False(t, selection.Includes(Data))
+
+	every, err := Parse("")
+	require.NoError(t, err)
+	for _, g := range All {
+		assert.True(t, every.Includes(g), "An empty selection runs %s", g)
+	}
+}
+
+func TestParallel(t *testing.T) {
+	assert.True(t, Compute.Parallel())
+	assert.True(t, Monitoring.Parallel())
+	assert.False(t, Workflow.Parallel())
+	assert.False(t, Performance.Parallel())
+}
+
+func TestRunLanesKeepsOrderWithinLaneAndLimitsLanes(t *testing.T) {
+	var mu sync.Mutex
+	var running, peak int
+	order := map[int][]int{}
+
+	var lanes [][]func()
+	for lane := 0; lane < 5; lane++ {
+		var runs []func()
+		for step := 0; step < 3; step++ {
+			runs = append(runs, func() {
+				mu.Lock()
+				running++
+				peak = max(peak, running)
+				order[lane] = append(order[lane], step)
+				mu.Unlock()
+
+				time.Sleep(time.Millisecond)
+
+				mu.Lock()
+				running--
+				mu.Unlock()
+			})
+		}
+		lanes = append(lanes, runs)
+	}
+
+	RunLanes(lanes, 2)
+	assert.LessOrEqual(t, peak, 2)
+	for lane := 0; lane < 5; lane++ {
+		assert.Equal(t, []int{0, 1, 2}, order[lane], "lane %d", lane)
+	}
+
+	peak = 0
+	RunLanes(lanes, 0)
+	assert.Equal(t, 1, peak, "a limit below 2 runs one lane at a time")
+}
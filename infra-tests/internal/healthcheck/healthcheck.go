@@ -0,0 +1,121 @@
+// Package healthcheck reads the product service's deep health response: its overall status, the status of
+// each dependency it probes, the build it runs, and how long its execution environment has been up.
+//
+// The response schema is GET /health in openapi/product-api.yaml, shared with the service. Parse validates a
+// body against that schema before decoding it, so a change on either side that breaks the contract fails here
+// rather than passing as an empty field.
+package healthcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/lambda-java-template/tests/internal/openapi"
+)
+
+// Route is the operation of the specification the response is validated against
+const Route = "GET /health"
+
+// Overall and dependency statuses
+const (
+	StatusHealthy      = "healthy"
+	StatusDegraded     = "degraded"
+	DependencyOK       = "ok"
+	DependencyDegraded = "degraded"
+)
+
+// Dependencies the service probes
+const (
+	DynamoDB    = "dynamodb"
+	EventBridge = "eventbridge"
+)
+
+// UnknownVersion is reported by a function deployed without APP_VERSION
+const UnknownVersion = "unknown"
+
+// Dependency is the outcome of probing one dependency
+type Dependency struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Report is a decoded health response
+type Report struct {
+	StatusCode    int                   `json:"-"`
+	Status        string                `json:"status"`
+	Service       string                `json:"service"`
+	Version       string                `json:"version"`
+	UptimeSeconds int64                 `json:"uptimeSeconds"`
+	Dependencies  map[string]Dependency `json:"dependencies"`
+}
+
+// Parse validates a health response against the specification and decodes it
+func Parse(spec *openapi.Spec, statusCode int, body []byte) (Report, error) {
+	schema, ok := spec.ResponseSchema(Route, statusCode)
+	if !ok || schema == nil {
+		return Report{}, fmt.Errorf("%s returned %d, which is not documented with a body: %s", Route, statusCode, body)
+	}
+	if problems := spec.ValidateJSON(body, schema); len(problems) > 0 {
+		return Report{}, fmt.Errorf("%s response does not match its schema: %s", Route, strings.Join(problems, "; "))
+	}
+
+	report := Report{StatusCode: statusCode}
+	if err := json.Unmarshal(body, &report); err != nil {
+		return Report{}, fmt.Errorf("decoding %s response: %w", Route, err)
+	}
+	return report, nil
+}
+
+// Gaps reports why the service is not healthy: a degraded or missing dependency of those required, an
+// overall status or status code that disagrees with the dependencies, no recorded build, or a negative uptime
+func (r Report) Gaps(required []string) []string {
+	var gaps []string
+	names := make([]string, 0, len(r.Dependencies))
+	for name := range r.Dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	degraded := false
+	for _, name := range names {
+		dependency := r.Dependencies[name]
+		if dependency.Status == DependencyDegraded {
+			degraded = true
+			gaps = append(gaps, fmt.Sprintf("%s is degraded after %dms: %s", name, dependency.LatencyMs, dependency.Error))
+		}
+	}
+	for _, name := range required {
+		if _, ok := r.Dependencies[name]; !ok {
+			gaps = append(gaps, fmt.Sprintf("%s is not probed", name))
+		}
+	}
+
+	switch {
+	case degraded && r.Status != StatusDegraded:
+		gaps = append(gaps, fmt.Sprintf("status is %s although a dependency is degraded", r.Status))
+	case !degraded && r.Status != StatusHealthy:
+		gaps = append(gaps, fmt.Sprintf("status is %s although every dependency is ok", r.Status))
+	}
+	if want := statusCode(r.Status); r.StatusCode != want {
+		gaps = append(gaps, fmt.Sprintf("status %s is returned with HTTP %d, expected %d", r.Status, r.StatusCode, want))
+	}
+	if r.Version == "" || r.Version == UnknownVersion {
+		gaps = append(gaps, "the service does not report the build it runs; deploy with APP_VERSION set")
+	}
+	if r.UptimeSeconds < 0 {
+		gaps = append(gaps, fmt.Sprintf("uptime is negative (%ds)", r.UptimeSeconds))
+	}
+	return gaps
+}
+
+// statusCode is the HTTP status code the service answers an overall status with
+func statusCode(status string) int {
+	if status == StatusHealthy {
+		return http.StatusOK
+	}
+	return http.StatusServiceUnavailable
+}
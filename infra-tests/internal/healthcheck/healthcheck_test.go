@@ -0,0 +1,75 @@
+package healthcheck
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/openapi"
+)
+
+// productAPIPath is the OpenAPI document committed with the product service
+const productAPIPath = "../../../openapi/product-api.yaml"
+
+func loadSpec(t *testing.T) *openapi.Spec {
+	spec, err := openapi.Load(productAPIPath)
+	require.NoError(t, err)
+	return spec
+}
+
+func TestParseHealthy(t *testing.T) {
+	report, err := Parse(loadSpec(t), http.StatusOK, []byte(`{"status":"healthy","service":"product-service","version":"d1e7014",`+
+		`"uptimeSeconds":42,"dependencies":{"dynamodb":{"status":"ok","latencyMs":12},"eventbridge":{"status":"ok","latencyMs":8}}}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, StatusHealthy, report.Status)
+	assert.Equal(t, "d1e7014", report.Version)
+	assert.Equal(t, int64(42), report.UptimeSeconds)
+	assert.Equal(t, Dependency{Status: DependencyOK, LatencyMs: 12}, report.Dependencies[DynamoDB])
+	assert.Empty(t, report.Gaps([]string{DynamoDB, EventBridge}))
+}
+
+func TestParseRejectsResponsesOutsideTheSchema(t *testing.T) {
+	spec := loadSpec(t)
+
+	_, err := Parse(spec, http.StatusOK, []byte(`{"status":"healthy","service":"product-service"}`))
+	assert.ErrorContains(t, err, `missing required property "dependencies"`, "The shallow response no longer satisfies the contract")
+
+	_, err = Parse(spec, http.StatusInternalServerError, []byte(`{"error":"HTTP 500"}`))
+	assert.ErrorContains(t, err, "not documented")
+}
+
+func TestGapsOfDegradedDependency(t *testing.T) {
+	report, err := Parse(loadSpec(t), http.StatusServiceUnavailable, []byte(`{"status":"degraded","service":"product-service","version":"d1e7014",`+
+		`"uptimeSeconds":42,"dependencies":{"dynamodb":{"status":"degraded","latencyMs":3000,"error":"table is UPDATING"}}}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"dynamodb is degraded after 3000ms: table is UPDATING",
+		"eventbridge is not probed",
+	}, report.Gaps([]string{DynamoDB, EventBridge}))
+}
+
+func TestGapsOfInconsistentReport(t *testing.T) {
+	report := Report{
+		StatusCode:    http.StatusOK,
+		Status:        StatusHealthy,
+		Version:       UnknownVersion,
+		UptimeSeconds: -1,
+		Dependencies:  map[string]Dependency{DynamoDB: {Status: DependencyDegraded, LatencyMs: 5, Error: "AccessDenied"}},
+	}
+	assert.Equal(t, []string{
+		"dynamodb is degraded after 5ms: AccessDenied",
+		"status is healthy although a dependency is degraded",
+		"the service does not report the build it runs; deploy with APP_VERSION set",
+		"uptime is negative (-1s)",
+	}, report.Gaps([]string{DynamoDB}))
+
+	report = Report{StatusCode: http.StatusOK, Status: StatusDegraded, Version: "d1e7014", Dependencies: map[string]Dependency{DynamoDB: {Status: DependencyOK}}}
+	assert.Equal(t, []string{
+		"status is degraded although every dependency is ok",
+		"status degraded is returned with HTTP 200, expected 503",
+	}, report.Gaps([]string{DynamoDB}))
+}
@@ -0,0 +1,396 @@
+// Package history reads Step Functions execution histories: which states ran and where an execution ended
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	sfntypes "github.com/aws/aws-sdk-go-v2/service/sfn/types"
+)
+
+// API is the Step Functions operation used to read an execution's history
+type API interface {
+	GetExecutionHistory(ctx context.Context, params *sfn.GetExecutionHistoryInput, optFns ...func(*sfn.Options)) (*sfn.GetExecutionHistoryOutput, error)
+}
+
+// Load returns every event of an execution in the order they happened, without the payloads states passed on
+func Load(ctx context.Context, api API, executionArn string) ([]sfntypes.HistoryEvent, error) {
+	return load(ctx, api, executionArn, false)
+}
+
+// LoadWithData returns every event of an execution in the order they happened, with the input and output of
+// each state and task, for Payloads
+func LoadWithData(ctx context.Context, api API, executionArn string) ([]sfntypes.HistoryEvent, error) {
+	return load(ctx, api, executionArn, true)
+}
+
+// load reads every page of an execution's history
+func load(ctx context.Context, api API, executionArn string, includeData bool) ([]sfntypes.HistoryEvent, error) {
+	var events []sfntypes.HistoryEvent
+	paginator := sfn.NewGetExecutionHistoryPaginator(api, &sfn.GetExecutionHistoryInput{
+		ExecutionArn:         aws.String(executionArn),
+		IncludeExecutionData: aws.Bool(includeData),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, page.Events...)
+	}
+	return events, nil
+}
+
+// EnteredStates returns the name of each state entered, in order, once per entry
+func EnteredStates(events []sfntypes.HistoryEvent) []string {
+	var names []string
+	for _, event := range events {
+		if event.StateEnteredEventDetails != nil && strings.HasSuffix(string(event.Type), "StateEntered") {
+			names = append(names, aws.ToString(event.StateEnteredEventDetails.Name))
+		}
+	}
+	return names
+}
+
+// Transitions counts the state transitions Step Functions bills a STANDARD execution for:
+// one per state entered plus one per retry, which shows up as a task scheduled again within the same state
+func Transitions(events []sfntypes.HistoryEvent) int {
+	transitions := 0
+	scheduled := 0
+	for _, event := range events {
+		switch event.Type {
+		case sfntypes.HistoryEventTypeTaskScheduled, sfntypes.HistoryEventTypeLambdaFunctionScheduled, sfntypes.HistoryEventTypeActivityScheduled:
+			scheduled++
+			if scheduled > 1 {
+				transitions++
+			}
+			continue
+		}
+		if strings.HasSuffix(string(event.Type), "StateEntered") {
+			transitions++
+			scheduled = 0
+		}
+	}
+	return transitions
+}
+
+// TerminalState returns the Succeed or Fail state an execution ended in, or "" when it ended
+// any other way, such as through a Task with End set, a timeout, or an abort
+func TerminalState(events []sfntypes.HistoryEvent) string {
+	for i := len(events) - 1; i >= 0; i-- {
+		switch events[i].Type {
+		case sfntypes.HistoryEventTypeSucceedStateEntered, sfntypes.HistoryEventTypeFailStateEntered:
+			if events[i].StateEnteredEventDetails != nil {
+				return aws.ToString(events[i].StateEnteredEventDetails.Name)
+			}
+		}
+	}
+	return ""
+}
+
+// MapRun is one run of a Map state and the states its iterations entered
+type MapRun struct {
+	State          string
+	Iterations     int
+	IteratorStates []string
+}
+
+// MapRuns returns every Map state run in the order they started. Iterator states are listed once
+// each, in the order first entered; states of a nested Map count toward the innermost run only.
+func MapRuns(events []sfntypes.HistoryEvent) []MapRun {
+	var runs []MapRun
+	var open []int
+	seen := map[int]map[string]bool{}
+
+	for _, event := range events {
+		switch event.Type {
+		case sfntypes.HistoryEventTypeMapStateExited:
+			if len(open) > 0 {
+				open = open[:len(open)-1]
+			}
+			continue
+		case sfntypes.HistoryEventTypeMapIterationStarted:
+			if len(open) > 0 {
+				runs[open[len(open)-1]].Iterations++
+			}
+			continue
+		}
+
+		if event.StateEnteredEventDetails == nil || !strings.HasSuffix(string(event.Type), "StateEntered") {
+			continue
+		}
+		name := aws.ToString(event.StateEnteredEventDetails.Name)
+
+		if len(open) > 0 {
+			current := open[len(open)-1]
+			if !seen[current][name] {
+				seen[current][name] = true
+				runs[current].IteratorStates = append(runs[current].IteratorStates, name)
+			}
+		}
+		if event.Type == sfntypes.HistoryEventTypeMapStateEntered {
+			runs = append(runs, MapRun{State: name})
+			open = append(open, len(runs)-1)
+			seen[len(runs)-1] = map[string]bool{}
+		}
+	}
+	return runs
+}
+
+// Step is one entry into a state: how long it took, how often its task was retried, and whether it failed
+type Step struct {
+	State string
+	// Type is the kind of state, such as Task, Choice, or Fail
+	Type     string
+	Entered  time.Time
+	Duration time.Duration
+	// LongestAttempt is the longest any attempt of the step's task took, from being scheduled to ending
+	LongestAttempt time.Duration
+	Retries        int
+	Failed         bool
+	// Error and Cause are those of the last failed attempt, or of the execution for a Fail state
+	Error string
+	Cause string
+}
+
+// failureDetails returns the error and cause of an event that reports a failed task or execution
+func failureDetails(event sfntypes.HistoryEvent) (errorName, cause *string, ok bool) {
+	switch {
+	case event.TaskFailedEventDetails != nil:
+		return event.TaskFailedEventDetails.Error, event.TaskFailedEventDetails.Cause, true
+	case event.TaskTimedOutEventDetails != nil:
+		return event.TaskTimedOutEventDetails.Error, event.TaskTimedOutEventDetails.Cause, true
+	case event.LambdaFunctionFailedEventDetails != nil:
+		return event.LambdaFunctionFailedEventDetails.Error, event.LambdaFunctionFailedEventDetails.Cause, true
+	case event.LambdaFunctionTimedOutEventDetails != nil:
+		return event.LambdaFunctionTimedOutEventDetails.Error, event.LambdaFunctionTimedOutEventDetails.Cause, true
+	case event.ActivityFailedEventDetails != nil:
+		return event.ActivityFailedEventDetails.Error, event.ActivityFailedEventDetails.Cause, true
+	case event.ActivityTimedOutEventDetails != nil:
+		return event.ActivityTimedOutEventDetails.Error, event.ActivityTimedOutEventDetails.Cause, true
+	case event.ExecutionFailedEventDetails != nil:
+		return event.ExecutionFailedEventDetails.Error, event.ExecutionFailedEventDetails.Cause, true
+	case event.ExecutionTimedOutEventDetails != nil:
+		return event.ExecutionTimedOutEventDetails.Error, event.ExecutionTimedOutEventDetails.Cause, true
+	case event.ExecutionAbortedEventDetails != nil:
+		return event.ExecutionAbortedEventDetails.Error, event.ExecutionAbortedEventDetails.Cause, true
+	}
+	return nil, nil, false
+}
+
+// Steps returns every state entry in the order they happened. A step that never exited, because the execution
+// stopped in it, lasts until the last event; a task that failed on its last attempt fails its step, as does
+// reaching a Fail state or the execution timing out or being aborted in a step.
+func Steps(events []sfntypes.HistoryEvent) []Step {
+	var steps []Step
+	var open []int
+	var last time.Time
+	// scheduled holds when the running attempt of each step's task was scheduled
+	var scheduled []time.Time
+
+	for _, event := range events {
+		at := aws.ToTime(event.Timestamp)
+		if !at.IsZero() {
+			last = at
+		}
+		eventType := string(event.Type)
+
+		if event.StateEnteredEventDetails != nil && strings.HasSuffix(eventType, "StateEntered") {
+			stateType := strings.TrimSuffix(eventType, "StateEntered")
+			steps = append(steps, Step{
+				State:   aws.ToString(event.StateEnteredEventDetails.Name),
+				Type:    stateType,
+				Entered: at,
+				Failed:  stateType == "Fail",
+			})
+			open = append(open, len(steps)-1)
+			scheduled = append(scheduled, time.Time{})
+			continue
+		}
+		if len(open) == 0 {
+			continue
+		}
+		current := open[len(open)-1]
+		// An attempt ends when its task succeeds, fails, or times out
+		endAttempt := func() {
+			if !scheduled[current].IsZero() {
+				steps[current].LongestAttempt = max(steps[current].LongestAttempt, at.Sub(scheduled[current]))
+				scheduled[current] = time.Time{}
+			}
+		}
+
+		switch {
+		case strings.HasSuffix(eventType, "StateExited"):
+			steps[current].Duration = at.Sub(steps[current].Entered)
+			open = open[:len(open)-1]
+		case strings.HasSuffix(eventType, "Scheduled"):
+			// A task scheduled again after it failed is a retry
+			if steps[current].Failed {
+				steps[current].Retries++
+			}
+			scheduled[current] = at
+		case strings.HasSuffix(eventType, "Succeeded") && eventType != string(sfntypes.HistoryEventTypeExecutionSucceeded):
+			endAttempt()
+			steps[current].Failed = false
+			steps[current].Error, steps[current].Cause = "", ""
+		default:
+			if errorName, cause, ok := failureDetails(event); ok {
+				endAttempt()
+				steps[current].Failed = true
+				steps[current].Error, steps[current].Cause = aws.ToString(errorName), aws.ToString(cause)
+			}
+		}
+	}
+
+	for _, i := range open {
+		steps[i].Duration = last.Sub(steps[i].Entered)
+	}
+	return steps
+}
+
+// FailedStep returns the step an execution failed in: the last failed step other than a Fail state, since a
+// Fail state is usually reached through the Catch of the task that actually failed, or the Fail state itself
+// when no task failed
+func FailedStep(steps []Step) (Step, bool) {
+	var fail *Step
+	for i := len(steps) - 1; i >= 0; i-- {
+		switch {
+		case !steps[i].Failed:
+		case steps[i].Type != "Fail":
+			return steps[i], true
+		case fail == nil:
+			fail = &steps[i]
+		}
+	}
+	if fail == nil {
+		return Step{}, false
+	}
+	return *fail, true
+}
+
+// maxLabelCause is how much of a failed step's cause its diagram node shows
+const maxLabelCause = 120
+
+// Mermaid renders steps as a Mermaid flowchart, one node per step in the order they ran, each labelled with its
+// duration and retries. Failed steps are highlighted and show their error and the start of its cause.
+func Mermaid(steps []Step) string {
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+	var failed []string
+
+	for i, step := range steps {
+		summary := fmt.Sprintf("%s · %s", step.Type, step.Duration.Round(time.Millisecond))
+		if step.Retries > 0 {
+			summary += fmt.Sprintf(" · %d retries", step.Retries)
+		}
+		lines := []string{step.State, summary}
+		if step.Failed {
+			failed = append(failed, fmt.Sprintf("s%d", i))
+			if step.Error != "" {
+				lines = append(lines, step.Error)
+			}
+			if cause := []rune(step.Cause); len(cause) > 0 {
+				if len(cause) > maxLabelCause {
+					cause = append(cause[:maxLabelCause], '…')
+				}
+				lines = append(lines, string(cause))
+			}
+		}
+		for j := range lines {
+			lines[j] = labelEscapes.Replace(lines[j])
+		}
+		fmt.Fprintf(&b, "    s%d[\"%s\"]\n", i, strings.Join(lines, "<br/>"))
+		if i > 0 {
+			fmt.Fprintf(&b, "    s%d --> s%d\n", i-1, i)
+		}
+	}
+
+	if len(failed) > 0 {
+		b.WriteString("    classDef failed fill:#fdd,stroke:#c00,stroke-width:2px\n")
+		fmt.Fprintf(&b, "    class %s failed\n", strings.Join(failed, ","))
+	}
+	return b.String()
+}
+
+// labelEscapes replaces what would end a line of a quoted Mermaid label or be read as markup in it
+var labelEscapes = strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "\n", " ")
+
+// Payload is what one state entry was given, what its task returned, and what it passed on
+type Payload struct {
+	State string
+	Input string
+	// Result is what the state's task returned before ResultSelector and ResultPath were applied, or the
+	// function's Payload for the lambda:invoke integration; empty unless the task succeeded
+	Result string
+	// Output is what the state passed on; empty when it never exited or its task failed and a Catch passed
+	// the error on instead
+	Output string
+}
+
+// Payloads returns the payloads of every state entry in the order the states were entered. The history
+// must have been loaded with LoadWithData.
+func Payloads(events []sfntypes.HistoryEvent) []Payload {
+	var payloads []Payload
+	var failed []bool
+	var open []int
+
+	for _, event := range events {
+		eventType := string(event.Type)
+		if event.StateEnteredEventDetails != nil && strings.HasSuffix(eventType, "StateEntered") {
+			payloads = append(payloads, Payload{
+				State: aws.ToString(event.StateEnteredEventDetails.Name),
+				Input: aws.ToString(event.StateEnteredEventDetails.Input),
+			})
+			failed = append(failed, false)
+			open = append(open, len(payloads)-1)
+			continue
+		}
+		if len(open) == 0 {
+			continue
+		}
+		current := open[len(open)-1]
+
+		switch {
+		case event.StateExitedEventDetails != nil:
+			if !failed[current] {
+				payloads[current].Output = aws.ToString(event.StateExitedEventDetails.Output)
+			}
+			open = open[:len(open)-1]
+		case event.TaskSucceededEventDetails != nil:
+			details := event.TaskSucceededEventDetails
+			payloads[current].Result = aws.ToString(details.Output)
+			if aws.ToString(details.ResourceType) == "lambda" && aws.ToString(details.Resource) == "invoke" {
+				payloads[current].Result = invokePayload(payloads[current].Result)
+			}
+			failed[current] = false
+		case event.LambdaFunctionSucceededEventDetails != nil:
+			payloads[current].Result = aws.ToString(event.LambdaFunctionSucceededEventDetails.Output)
+			failed[current] = false
+		case event.ActivitySucceededEventDetails != nil:
+			payloads[current].Result = aws.ToString(event.ActivitySucceededEventDetails.Output)
+			failed[current] = false
+		default:
+			if _, _, ok := failureDetails(event); ok {
+				payloads[current].Result = ""
+				failed[current] = true
+			}
+		}
+	}
+	return payloads
+}
+
+// invokePayload returns the Payload of a lambda:invoke response, or the response unchanged when it has none
+func invokePayload(output string) string {
+	var response struct {
+		Payload json.RawMessage `json:"Payload"`
+	}
+	if err := json.Unmarshal([]byte(output), &response); err != nil || len(response.Payload) == 0 {
+		return output
+	}
+	return string(response.Payload)
+}
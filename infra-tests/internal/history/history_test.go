@@ -0,0 +1,220 @@
+package history
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	sfntypes "github.com/aws/aws-sdk-go-v2/service/sfn/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// entered returns a StateEntered event of the given type
+func entered(eventType sfntypes.HistoryEventType, name string) sfntypes.HistoryEvent {
+	return sfntypes.HistoryEvent{
+		Type:                     eventType,
+		StateEnteredEventDetails: &sfntypes.StateEnteredEventDetails{Name: aws.String(name)},
+	}
+}
+
+// declinedPayment is the history of an order whose payment was declined
+var declinedPayment = []sfntypes.HistoryEvent{
+	{Type: sfntypes.HistoryEventTypeExecutionStarted},
+	entered(sfntypes.HistoryEventTypeTaskStateEntered, "ValidateOrder"),
+	{Type: sfntypes.HistoryEventTypeTaskStateExited},
+	entered(sfntypes.HistoryEventTypeTaskStateEntered, "ProcessPayment"),
+	{Type: sfntypes.HistoryEventTypeTaskStateExited},
+	entered(sfntypes.HistoryEventTypeChoiceStateEntered, "PaymentApproved"),
+	{Type: sfntypes.HistoryEventTypeChoiceStateExited},
+	entered(sfntypes.HistoryEventTypeFailStateEntered, "PaymentDeclined"),
+	{Type: sfntypes.HistoryEventTypeExecutionFailed},
+}
+
+func TestEnteredStates(t *testing.T) {
+	assert.Equal(t, []string{"ValidateOrder", "ProcessPayment", "PaymentApproved", "PaymentDeclined"}, EnteredStates(declinedPayment))
+}
+
+func TestTransitions(t *testing.T) {
+	assert.Equal(t, 4, Transitions(declinedPayment))
+
+	scheduled := sfntypes.HistoryEvent{Type: sfntypes.HistoryEventTypeTaskScheduled}
+	retried := []sfntypes.HistoryEvent{
+		entered(sfntypes.HistoryEventTypeTaskStateEntered, "ProcessPayment"),
+		scheduled,
+		{Type: sfntypes.HistoryEventTypeTaskFailed},
+		scheduled,
+		{Type: sfntypes.HistoryEventTypeTaskFailed},
+		scheduled,
+		{Type: sfntypes.HistoryEventTypeTaskSucceeded},
+		entered(sfntypes.HistoryEventTypeTaskStateEntered, "SendNotification"),
+		scheduled,
+	}
+	assert.Equal(t, 4, Transitions(retried), "Each retry of a task is billed as a transition")
+}
+
+func TestTerminalState(t *testing.T) {
+	assert.Equal(t, "PaymentDeclined", TerminalState(declinedPayment))
+
+	succeeded := []sfntypes.HistoryEvent{
+		entered(sfntypes.HistoryEventTypeTaskStateEntered, "SendNotification"),
+		entered(sfntypes.HistoryEventTypeSucceedStateEntered, "OrderSuccess"),
+		{Type: sfntypes.HistoryEventTypeExecutionSucceeded},
+	}
+	assert.Equal(t, "OrderSuccess", TerminalState(succeeded))
+
+	timedOut := []sfntypes.HistoryEvent{
+		entered(sfntypes.HistoryEventTypeTaskStateEntered, "ProcessPayment"),
+		{Type: sfntypes.HistoryEventTypeExecutionTimedOut},
+	}
+	assert.Empty(t, TerminalState(timedOut), "Executions that stop inside a Task have no terminal state")
+}
+
+func TestMapRuns(t *testing.T) {
+	iteration := sfntypes.HistoryEvent{Type: sfntypes.HistoryEventTypeMapIterationStarted}
+	events := []sfntypes.HistoryEvent{
+		entered(sfntypes.HistoryEventTypeTaskStateEntered, "ValidateOrder"),
+		entered(sfntypes.HistoryEventTypeMapStateEntered, "ProcessItems"),
+		iteration,
+		entered(sfntypes.HistoryEventTypeTaskStateEntered, "ReserveItem"),
+		entered(sfntypes.HistoryEventTypeSucceedStateEntered, "ItemReserved"),
+		iteration,
+		entered(sfntypes.HistoryEventTypeTaskStateEntered, "ReserveItem"),
+		entered(sfntypes.HistoryEventTypeMapStateEntered, "ReserveWarehouses"),
+		iteration,
+		entered(sfntypes.HistoryEventTypeTaskStateEntered, "ReserveInWarehouse"),
+		{Type: sfntypes.HistoryEventTypeMapStateExited},
+		entered(sfntypes.HistoryEventTypeSucceedStateEntered, "ItemReserved"),
+		{Type: sfntypes.HistoryEventTypeMapStateExited},
+		entered(sfntypes.HistoryEventTypeSucceedStateEntered, "OrderSuccess"),
+	}
+
+	assert.Equal(t, []MapRun{
+		{State: "ProcessItems", Iterations: 2, IteratorStates: []string{"ReserveItem", "ItemReserved", "ReserveWarehouses"}},
+		{State: "ReserveWarehouses", Iterations: 1, IteratorStates: []string{"ReserveInWarehouse"}},
+	}, MapRuns(events))
+
+	assert.Empty(t, MapRuns(declinedPayment), "Executions without a Map state have no runs")
+}
+
+// at returns event stamped the given number of milliseconds after the execution started
+func at(ms int, event sfntypes.HistoryEvent) sfntypes.HistoryEvent {
+	event.Timestamp = aws.Time(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(ms) * time.Millisecond))
+	return event
+}
+
+// retriedThenFailed is the history of an order whose payment task failed three times and ended the execution
+var retriedThenFailed = []sfntypes.HistoryEvent{
+	at(0, sfntypes.HistoryEvent{Type: sfntypes.HistoryEventTypeExecutionStarted}),
+	at(10, entered(sfntypes.HistoryEventTypeTaskStateEntered, "ValidateOrder")),
+	at(20, sfntypes.HistoryEvent{Type: sfntypes.HistoryEventTypeTaskScheduled}),
+	at(400, sfntypes.HistoryEvent{Type: sfntypes.HistoryEventTypeTaskSucceeded}),
+	at(410, sfntypes.HistoryEvent{Type: sfntypes.HistoryEventTypeTaskStateExited}),
+	at(420, entered(sfntypes.HistoryEventTypeTaskStateEntered, "ProcessPayment")),
+	at(430, sfntypes.HistoryEvent{Type: sfntypes.HistoryEventTypeTaskScheduled}),
+	at(900, sfntypes.HistoryEvent{Type: sfntypes.HistoryEventTypeTaskFailed, TaskFailedEventDetails: &sfntypes.TaskFailedEventDetails{
+		Error: aws.String("Lambda.ServiceException"), Cause: aws.String("Rate exceeded"),
+	}}),
+	at(1900, sfntypes.HistoryEvent{Type: sfntypes.HistoryEventTypeTaskScheduled}),
+	at(2400, sfntypes.HistoryEvent{Type: sfntypes.HistoryEventTypeTaskFailed, TaskFailedEventDetails: &sfntypes.TaskFailedEventDetails{
+		Error: aws.String("Lambda.ServiceException"), Cause: aws.String("Rate exceeded"),
+	}}),
+	at(4400, sfntypes.HistoryEvent{Type: sfntypes.HistoryEventTypeTaskScheduled}),
+	at(5420, sfntypes.HistoryEvent{Type: sfntypes.HistoryEventTypeTaskFailed, TaskFailedEventDetails: &sfntypes.TaskFailedEventDetails{
+		Error: aws.String("PaymentError"), Cause: aws.String(`{"errorMessage":"card <declined>"}`),
+	}}),
+	at(5430, sfntypes.HistoryEvent{Type: sfntypes.HistoryEventTypeExecutionFailed, ExecutionFailedEventDetails: &sfntypes.ExecutionFailedEventDetails{
+		Error: aws.String("PaymentError"), Cause: aws.String(`{"errorMessage":"card <declined>"}`),
+	}}),
+}
+
+func TestSteps(t *testing.T) {
+	assert.Equal(t, []Step{
+		{State: "ValidateOrder", Type: "Task", Entered: retriedThenFailed[1].Timestamp.UTC(), Duration: 400 * time.Millisecond,
+			LongestAttempt: 380 * time.Millisecond},
+		{State: "ProcessPayment", Type: "Task", Entered: retriedThenFailed[5].Timestamp.UTC(), Duration: 5010 * time.Millisecond,
+			LongestAttempt: 1020 * time.Millisecond, Retries: 2, Failed: true,
+			Error: "PaymentError", Cause: `{"errorMessage":"card <declined>"}`},
+	}, Steps(retriedThenFailed), "A step the execution stopped in lasts until its last event")
+
+	steps := Steps(declinedPayment)
+	assert.Len(t, steps, 4)
+	assert.False(t, steps[1].Failed)
+	assert.Equal(t, Step{State: "PaymentDeclined", Type: "Fail", Failed: true}, steps[3], "Entering a Fail state fails the step")
+
+	recovered := []sfntypes.HistoryEvent{
+		entered(sfntypes.HistoryEventTypeTaskStateEntered, "ProcessPayment"),
+		{Type: sfntypes.HistoryEventTypeTaskScheduled},
+		{Type: sfntypes.HistoryEventTypeTaskTimedOut, TaskTimedOutEventDetails: &sfntypes.TaskTimedOutEventDetails{Error: aws.String("States.Timeout")}},
+		{Type: sfntypes.HistoryEventTypeTaskScheduled},
+		{Type: sfntypes.HistoryEventTypeTaskSucceeded},
+		{Type: sfntypes.HistoryEventTypeTaskStateExited},
+	}
+	assert.Equal(t, []Step{{State: "ProcessPayment", Type: "Task", Retries: 1}}, Steps(recovered), "A retry that succeeds clears the failure")
+}
+
+func TestFailedStep(t *testing.T) {
+	step, ok := FailedStep(Steps(retriedThenFailed))
+	assert.True(t, ok)
+	assert.Equal(t, "ProcessPayment", step.State)
+
+	caught := []Step{
+		{State: "ProcessPayment", Type: "Task", Failed: true, Error: "PaymentError"},
+		{State: "NotifyFailure", Type: "Task"},
+		{State: "ProcessingFailed", Type: "Fail", Failed: true, Error: "ProcessingFailed"},
+	}
+	step, _ = FailedStep(caught)
+	assert.Equal(t, "ProcessPayment", step.State, "The task whose failure was caught is blamed, not the Fail state")
+
+	step, ok = FailedStep(Steps(declinedPayment))
+	assert.True(t, ok)
+	assert.Equal(t, "PaymentDeclined", step.State, "A Fail state no task failed before is the failure")
+
+	_, ok = FailedStep([]Step{{State: "OrderSuccess", Type: "Succeed"}})
+	assert.False(t, ok)
+}
+
+func TestMermaid(t *testing.T) {
+	assert.Equal(t, `flowchart TD
+    s0["ValidateOrder<br/>Task · 400ms"]
+    s1["ProcessPayment<br/>Task · 5.01s · 2 retries<br/>PaymentError<br/>{#quot;errorMessage#quot;:#quot;card #lt;declined#gt;#quot;}"]
+    s0 --> s1
+    classDef failed fill:#fdd,stroke:#c00,stroke-width:2px
+    class s1 failed
+`, Mermaid(Steps(retriedThenFailed)))
+
+	long := Mermaid([]Step{{State: "ProcessPayment", Type: "Task", Failed: true, Cause: strings.Repeat("x", 500)}})
+	assert.Contains(t, long, "…\"]", "Long causes are cut short")
+	assert.Equal(t, "flowchart TD\n", Mermaid(nil))
+}
+
+func TestPayloads(t *testing.T) {
+	events := []sfntypes.HistoryEvent{
+		{Type: sfntypes.HistoryEventTypeTaskStateEntered, StateEnteredEventDetails: &sfntypes.StateEnteredEventDetails{
+			Name: aws.String("ValidateOrder"), Input: aws.String(`{"orderId":"o-1"}`),
+		}},
+		{Type: sfntypes.HistoryEventTypeTaskSucceeded, TaskSucceededEventDetails: &sfntypes.TaskSucceededEventDetails{
+			Resource: aws.String("invoke"), ResourceType: aws.String("lambda"),
+			Output: aws.String(`{"ExecutedVersion":"$LATEST","Payload":{"valid":true},"StatusCode":200}`),
+		}},
+		{Type: sfntypes.HistoryEventTypeTaskStateExited, StateExitedEventDetails: &sfntypes.StateExitedEventDetails{
+			Name: aws.String("ValidateOrder"), Output: aws.String(`{"orderId":"o-1","validationResult":{"valid":true}}`),
+		}},
+		{Type: sfntypes.HistoryEventTypeTaskStateEntered, StateEnteredEventDetails: &sfntypes.StateEnteredEventDetails{
+			Name: aws.String("ProcessPayment"), Input: aws.String(`{"orderId":"o-1","validationResult":{"valid":true}}`),
+		}},
+		{Type: sfntypes.HistoryEventTypeTaskFailed, TaskFailedEventDetails: &sfntypes.TaskFailedEventDetails{Error: aws.String("PaymentError")}},
+		{Type: sfntypes.HistoryEventTypeTaskStateExited, StateExitedEventDetails: &sfntypes.StateExitedEventDetails{
+			Name: aws.String("ProcessPayment"), Output: aws.String(`{"Error":"PaymentError"}`),
+		}},
+		{Type: sfntypes.HistoryEventTypeFailStateEntered, StateEnteredEventDetails: &sfntypes.StateEnteredEventDetails{
+			Name: aws.String("ProcessingFailed"), Input: aws.String(`{"Error":"PaymentError"}`),
+		}},
+	}
+
+	assert.Equal(t, []Payload{
+		{State: "ValidateOrder", Input: `{"orderId":"o-1"}`, Result: `{"valid":true}`, Output: `{"orderId":"o-1","validationResult":{"valid":true}}`},
+		{State: "ProcessPayment", Input: `{"orderId":"o-1","validationResult":{"valid":true}}`},
+		{State: "ProcessingFailed", Input: `{"Error":"PaymentError"}`},
+	}, Payloads(events), "A lambda:invoke result is the function's payload, and a caught failure passes nothing on")
+}
@@ -0,0 +1,227 @@
+// Package httpclient sends the suite's requests to the deployed API, retrying transient failures and recording
+// every exchange for the results summary.
+//
+// A request is retried when the connection fails or the API answers with a 5xx status, after a delay drawn
+// with full jitter from an exponentially growing window, so concurrent validators do not retry in step. Only
+// idempotent methods are retried: a POST that timed out may have created its product, and sending it again
+// would hide that. Each attempt has its own timeout, and the response body is read within it, so a Response
+// returned by Do is complete and its body can be read after the attempt's context is gone.
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// MaxCapturedBody is the most bytes of a request or response body kept in an Exchange
+const MaxCapturedBody = 2048
+
+// Defaults used for zero Options fields
+const (
+	DefaultTimeout   = 30 * time.Second
+	DefaultBaseDelay = 200 * time.Millisecond
+	DefaultMaxDelay  = 5 * time.Second
+)
+
+// Exchange is one request as sent and the response it finally got, over every attempt
+type Exchange struct {
+	Method       string
+	URL          string
+	StatusCode   int
+	Attempts     int
+	Duration     time.Duration
+	RequestBody  string
+	ResponseBody string
+	Error        string
+}
+
+// Options configure a Client
+type Options struct {
+	// Timeout bounds each attempt, including reading the response body
+	Timeout time.Duration
+	// Retries is how many times a failed attempt is repeated; 0 sends every request once
+	Retries int
+	// BaseDelay is the window the first retry's delay is drawn from; each retry doubles it, up to MaxDelay
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Transport sends each attempt; http.DefaultTransport is used when nil
+	Transport http.RoundTripper
+	// OnExchange receives every finished request, e.g. to add it to the results summary
+	OnExchange func(Exchange)
+}
+
+// Client sends requests with retries and captures them. It is safe for concurrent use.
+type Client struct {
+	options Options
+	http    *http.Client
+	sleep   func(ctx context.Context, d time.Duration) error
+
+	mu     sync.Mutex
+	random *rand.Rand
+}
+
+// New returns a client with options, filling in defaults for zero durations
+func New(options Options) *Client {
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultTimeout
+	}
+	if options.BaseDelay <= 0 {
+		options.BaseDelay = DefaultBaseDelay
+	}
+	if options.MaxDelay <= 0 {
+		options.MaxDelay = DefaultMaxDelay
+	}
+	return &Client{
+		options: options,
+		http:    &http.Client{Transport: options.Transport},
+		sleep:   sleep,
+		random:  rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Get sends a GET request to url
+func (c *Client) Get(url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(req)
+}
+
+// Do sends req, retrying as described in the package documentation, and returns the last response with its
+// body already read. The request body is read once and sent again on each retry.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	exchange := Exchange{Method: req.Method, URL: req.URL.String()}
+
+	requestBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	exchange.RequestBody = capture(requestBody)
+
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		exchange.Attempts = attempt + 1
+		resp, err = c.attempt(req, requestBody)
+		if attempt >= c.options.Retries || !Retryable(req.Method, resp, err) {
+			break
+		}
+		if sleepErr := c.sleep(req.Context(), c.backoff(attempt)); sleepErr != nil {
+			break
+		}
+	}
+
+	exchange.Duration = time.Since(start)
+	if err != nil {
+		exchange.Error = err.Error()
+	} else {
+		exchange.StatusCode = resp.StatusCode
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		exchange.ResponseBody = capture(body)
+	}
+	if c.options.OnExchange != nil {
+		c.options.OnExchange(exchange)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s %s after %d attempts: %w", req.Method, exchange.URL, exchange.Attempts, err)
+	}
+	return resp, nil
+}
+
+// attempt sends one copy of req and reads the whole response within the attempt's timeout
+func (c *Client) attempt(req *http.Request, body []byte) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), c.options.Timeout)
+	defer cancel()
+
+	copied := req.Clone(ctx)
+	if body != nil {
+		copied.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := c.http.Do(copied)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return resp, nil
+}
+
+// backoff draws the delay before retry attempt+1 from [0, min(MaxDelay, BaseDelay*2^attempt)]
+func (c *Client) backoff(attempt int) time.Duration {
+	window := c.options.MaxDelay
+	if attempt < 30 {
+		window = min(c.options.BaseDelay<<attempt, c.options.MaxDelay)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Duration(c.random.Int63n(int64(window) + 1))
+}
+
+// Retryable reports whether an attempt that ended with resp and err is worth repeating: the method is
+// idempotent, and the connection failed or the API answered with a server error. Cancelled requests are not
+// retried, since the caller gave up on them.
+func Retryable(method string, resp *http.Response, err error) bool {
+	if !idempotent(method) {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// idempotent reports whether sending a request with method twice has the effect of sending it once
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// readRequestBody returns the body of req, which is read once and replayed on every attempt
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading request body: %w", err)
+	}
+	return body, nil
+}
+
+// capture returns body as kept in an Exchange, cut to MaxCapturedBody bytes
+func capture(body []byte) string {
+	if len(body) > MaxCapturedBody {
+		return string(body[:MaxCapturedBody]) + "..."
+	}
+	return string(body)
+}
+
+// sleep waits for d unless ctx is done first
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
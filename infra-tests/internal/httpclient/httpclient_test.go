@@ -0,0 +1,155 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newClient returns a client whose retries do not wait, with every exchange appended to exchanges
+func newClient(options Options, exchanges *[]Exchange) *Client {
+	options.OnExchange = func(exchange Exchange) { *exchanges = append(*exchanges, exchange) }
+	client := New(options)
+	client.sleep = func(context.Context, time.Duration) error { return nil }
+	return client
+}
+
+func TestRetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write(append([]byte("echo "), body...))
+	}))
+	defer server.Close()
+
+	var exchanges []Exchange
+	client := newClient(Options{Retries: 3}, &exchanges)
+	req, err := http.NewRequest(http.MethodPut, server.URL+"/products/1", strings.NewReader(`{"name":"Widget"}`))
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `echo {"name":"Widget"}`, string(body), "The request body is sent again on each attempt")
+	require.Len(t, exchanges, 1, "Retries are one exchange")
+	assert.Equal(t, 3, exchanges[0].Attempts)
+	assert.Equal(t, http.MethodPut, exchanges[0].Method)
+	assert.Equal(t, server.URL+"/products/1", exchanges[0].URL)
+	assert.Equal(t, `{"name":"Widget"}`, exchanges[0].RequestBody)
+	assert.Equal(t, `echo {"name":"Widget"}`, exchanges[0].ResponseBody)
+	assert.Equal(t, http.StatusOK, exchanges[0].StatusCode)
+}
+
+func TestReturnsLastResponseWhenRetriesRunOut(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"status":"degraded"}`))
+	}))
+	defer server.Close()
+
+	var exchanges []Exchange
+	resp, err := newClient(Options{Retries: 2}, &exchanges).Get(server.URL + "/health")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.JSONEq(t, `{"status":"degraded"}`, string(body))
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, 3, exchanges[0].Attempts)
+}
+
+func TestDoesNotRetryClientErrorsOrPosts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	var exchanges []Exchange
+	client := newClient(Options{Retries: 3}, &exchanges)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/products", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	assert.Equal(t, int32(2), calls.Load(), "Each request is sent once")
+}
+
+func TestRetriesConnectionErrors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	var exchanges []Exchange
+	_, err := newClient(Options{Retries: 2}, &exchanges).Get(url)
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "after 3 attempts")
+	assert.NotEmpty(t, exchanges[0].Error)
+	assert.Zero(t, exchanges[0].StatusCode)
+}
+
+func TestTimeoutBoundsEachAttempt(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	var exchanges []Exchange
+	_, err := newClient(Options{Timeout: 50 * time.Millisecond, Retries: 1}, &exchanges).Get(server.URL)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "got %v", err)
+	assert.Equal(t, 2, exchanges[0].Attempts, "A timed out GET is retried")
+}
+
+func TestBackoffStaysWithinJitterWindow(t *testing.T) {
+	client := New(Options{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second})
+	for attempt, window := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		for range 50 {
+			delay := client.backoff(attempt)
+			assert.GreaterOrEqual(t, delay, time.Duration(0))
+			assert.LessOrEqual(t, delay, window, "attempt %d", attempt)
+		}
+	}
+	assert.LessOrEqual(t, client.backoff(64), time.Second, "Large attempts do not overflow the window")
+}
+
+func TestCaptureTruncatesLargeBodies(t *testing.T) {
+	captured := capture([]byte(strings.Repeat("a", MaxCapturedBody+10)))
+	assert.Len(t, captured, MaxCapturedBody+len("..."))
+	assert.Equal(t, "short", capture([]byte("short")))
+}
@@ -0,0 +1,261 @@
+// Package inventory lists every resource tagged with a project and summarizes its configuration.
+//
+// Resources are found through the Resource Groups Tagging API, described with the service
+// API that owns them, and linked to the CloudWatch alarms whose dimensions name them.
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	taggingtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// Resource is one tagged resource and a summary of its key configuration
+type Resource struct {
+	ARN     string            `json:"arn"`
+	Service string            `json:"service"`
+	Type    string            `json:"type"`
+	Name    string            `json:"name"`
+	Summary map[string]string `json:"summary,omitempty"`
+	Alarms  []string          `json:"alarms,omitempty"`
+	Tags    map[string]string `json:"tags"`
+}
+
+// Inventory is every resource of one project environment
+type Inventory struct {
+	Project     string     `json:"project"`
+	Environment string     `json:"environment"`
+	GeneratedAt time.Time  `json:"generatedAt"`
+	Resources   []Resource `json:"resources"`
+}
+
+// Clients are the AWS clients used to build an inventory
+type Clients struct {
+	Tagging    *resourcegroupstaggingapi.Client
+	Lambda     *lambda.Client
+	DynamoDB   *dynamodb.Client
+	APIGateway *apigatewayv2.Client
+	S3         *s3.Client
+	CloudWatch *cloudwatch.Client
+}
+
+// NewClients creates every client from one AWS config
+func NewClients(cfg aws.Config) Clients {
+	return Clients{
+		Tagging:    resourcegroupstaggingapi.NewFromConfig(cfg),
+		Lambda:     lambda.NewFromConfig(cfg),
+		DynamoDB:   dynamodb.NewFromConfig(cfg),
+		APIGateway: apigatewayv2.NewFromConfig(cfg),
+		S3:         s3.NewFromConfig(cfg),
+		CloudWatch: cloudwatch.NewFromConfig(cfg),
+	}
+}
+
+// Collect builds the inventory of resources tagged with the project and environment.
+// An empty environment collects the project's resources in every environment and namespace.
+func Collect(ctx context.Context, clients Clients, projectName, environment string) (Inventory, error) {
+	inventory := Inventory{Project: projectName, Environment: environment, GeneratedAt: time.Now().UTC()}
+
+	filters := []taggingtypes.TagFilter{{Key: aws.String("Project"), Values: []string{projectName}}}
+	if environment != "" {
+		filters = append(filters, taggingtypes.TagFilter{Key: aws.String("Environment"), Values: []string{environment}})
+	}
+	paginator := resourcegroupstaggingapi.NewGetResourcesPaginator(clients.Tagging, &resourcegroupstaggingapi.GetResourcesInput{
+		TagFilters: filters,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return inventory, fmt.Errorf("listing tagged resources: %w", err)
+		}
+
+		for _, mapping := range page.ResourceTagMappingList {
+			resource, err := NewResource(aws.ToString(mapping.ResourceARN))
+			if err != nil {
+				return inventory, err
+			}
+			resource.Tags = make(map[string]string, len(mapping.Tags))
+			for _, tag := range mapping.Tags {
+				resource.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+
+			resource.Summary, err = describe(ctx, clients, resource)
+			if err != nil {
+				return inventory, fmt.Errorf("describing %s: %w", resource.ARN, err)
+			}
+			inventory.Resources = append(inventory.Resources, resource)
+		}
+	}
+
+	alarms, err := alarmDimensions(ctx, clients.CloudWatch)
+	if err != nil {
+		return inventory, err
+	}
+	AttachAlarms(inventory.Resources, alarms)
+
+	sort.Slice(inventory.Resources, func(i, j int) bool {
+		a, b := inventory.Resources[i], inventory.Resources[j]
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		return a.Name < b.Name
+	})
+	return inventory, nil
+}
+
+// NewResource derives the service, type, and name of a resource from its ARN
+func NewResource(resourceARN string) (Resource, error) {
+	parsed, err := arn.Parse(resourceARN)
+	if err != nil {
+		return Resource{}, fmt.Errorf("parsing ARN %s: %w", resourceARN, err)
+	}
+
+	resource := Resource{ARN: resourceARN, Service: parsed.Service}
+	switch {
+	case parsed.Service == "s3":
+		// S3 bucket ARNs are just the bucket name
+		resource.Type, resource.Name = "bucket", parsed.Resource
+	case parsed.Service == "apigateway":
+		// API Gateway resources are paths such as /apis/abc123 or /apis/abc123/stages/$default
+		parts := strings.Split(strings.TrimPrefix(parsed.Resource, "/"), "/")
+		resource.Type = strings.TrimSuffix(parts[len(parts)-2], "s")
+		resource.Name = parts[len(parts)-1]
+	case parsed.Service == "sns":
+		// SNS topic ARNs end in the bare topic name
+		resource.Type, resource.Name = "topic", parsed.Resource
+	case strings.Contains(parsed.Resource, ":"):
+		// Lambda functions, log groups, and alarms use type:name
+		resource.Type, resource.Name, _ = strings.Cut(parsed.Resource, ":")
+	case strings.Contains(parsed.Resource, "/"):
+		resource.Type, resource.Name, _ = strings.Cut(parsed.Resource, "/")
+	default:
+		resource.Type, resource.Name = parsed.Service, parsed.Resource
+	}
+	return resource, nil
+}
+
+// describe summarizes the key configuration of the resource types the template deploys
+func describe(ctx context.Context, clients Clients, resource Resource) (map[string]string, error) {
+	switch {
+	case resource.Service == "lambda" && resource.Type == "function":
+		function, err := clients.Lambda.GetFunctionConfiguration(ctx, &lambda.GetFunctionConfigurationInput{
+			FunctionName: aws.String(resource.ARN),
+		})
+		if err != nil {
+			return nil, err
+		}
+		summary := map[string]string{
+			"runtime": string(function.Runtime),
+			"memory":  fmt.Sprintf("%d MB", aws.ToInt32(function.MemorySize)),
+			"timeout": fmt.Sprintf("%ds", aws.ToInt32(function.Timeout)),
+			"state":   string(function.State),
+		}
+		if len(function.Architectures) > 0 {
+			summary["architecture"] = string(function.Architectures[0])
+		}
+		if function.TracingConfig != nil {
+			summary["tracing"] = string(function.TracingConfig.Mode)
+		}
+		return summary, nil
+
+	case resource.Service == "dynamodb" && resource.Type == "table":
+		table, err := clients.DynamoDB.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+			TableName: aws.String(resource.Name),
+		})
+		if err != nil {
+			return nil, err
+		}
+		// Tables without an SSE description use the AWS owned key
+		summary := map[string]string{"billing mode": "PROVISIONED", "encryption": "AWS_OWNED", "status": string(table.Table.TableStatus)}
+		if table.Table.BillingModeSummary != nil {
+			summary["billing mode"] = string(table.Table.BillingModeSummary.BillingMode)
+		}
+		if table.Table.SSEDescription != nil {
+			summary["encryption"] = string(table.Table.SSEDescription.SSEType)
+		}
+		return summary, nil
+
+	case resource.Service == "apigateway" && resource.Type == "api":
+		api, err := clients.APIGateway.GetApi(ctx, &apigatewayv2.GetApiInput{ApiId: aws.String(resource.Name)})
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{
+			"name":     aws.ToString(api.Name),
+			"protocol": string(api.ProtocolType),
+			"endpoint": aws.ToString(api.ApiEndpoint),
+		}, nil
+
+	case resource.Service == "s3":
+		encryption, err := clients.S3.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{Bucket: aws.String(resource.Name)})
+		if err != nil {
+			return nil, err
+		}
+		summary := map[string]string{}
+		if encryption.ServerSideEncryptionConfiguration != nil {
+			for _, rule := range encryption.ServerSideEncryptionConfiguration.Rules {
+				if rule.ApplyServerSideEncryptionByDefault != nil {
+					summary["encryption"] = string(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm)
+				}
+			}
+		}
+		return summary, nil
+	}
+	return nil, nil
+}
+
+// alarmDimensions maps each metric alarm name to the dimension values it watches
+func alarmDimensions(ctx context.Context, client *cloudwatch.Client) (map[string][]string, error) {
+	alarms := make(map[string][]string)
+	paginator := cloudwatch.NewDescribeAlarmsPaginator(client, &cloudwatch.DescribeAlarmsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing alarms: %w", err)
+		}
+
+		for _, alarm := range page.MetricAlarms {
+			var values []string
+			for _, dimension := range alarm.Dimensions {
+				values = append(values, aws.ToString(dimension.Value))
+			}
+			// Metric math alarms carry their dimensions on each metric
+			for _, query := range alarm.Metrics {
+				if query.MetricStat != nil && query.MetricStat.Metric != nil {
+					for _, dimension := range query.MetricStat.Metric.Dimensions {
+						values = append(values, aws.ToString(dimension.Value))
+					}
+				}
+			}
+			alarms[aws.ToString(alarm.AlarmName)] = values
+		}
+	}
+	return alarms, nil
+}
+
+// AttachAlarms links each resource to the alarms with a dimension naming it
+func AttachAlarms(resources []Resource, alarms map[string][]string) {
+	for i := range resources {
+		for alarmName, values := range alarms {
+			for _, value := range values {
+				if value == resources[i].Name {
+					resources[i].Alarms = append(resources[i].Alarms, alarmName)
+					break
+				}
+			}
+		}
+		sort.Strings(resources[i].Alarms)
+	}
+}
@@ -0,0 +1,73 @@
+package inventory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResource(t *testing.T) {
+	tests := []struct {
+		arn     string
+		service string
+		kind    string
+		name    string
+	}{
+		{"arn:aws:lambda:us-east-1:123456789012:function:lambda-java-template-dev-product-service", "lambda", "function", "lambda-java-template-dev-product-service"},
+		{"arn:aws:dynamodb:us-east-1:123456789012:table/lambda-java-template-dev-products", "dynamodb", "table", "lambda-java-template-dev-products"},
+		{"arn:aws:apigateway:us-east-1::/apis/abc123", "apigateway", "api", "abc123"},
+		{"arn:aws:apigateway:us-east-1::/apis/abc123/stages/$default", "apigateway", "stage", "$default"},
+		{"arn:aws:s3:::lambda-java-template-dev-artifacts", "s3", "bucket", "lambda-java-template-dev-artifacts"},
+		{"arn:aws:sns:us-east-1:123456789012:lambda-java-template-dev-alerts", "sns", "topic", "lambda-java-template-dev-alerts"},
+		{"arn:aws:logs:us-east-1:123456789012:log-group:/aws/lambda/fn", "logs", "log-group", "/aws/lambda/fn"},
+		{"arn:aws:cloudwatch:us-east-1:123456789012:alarm:fn-errors", "cloudwatch", "alarm", "fn-errors"},
+	}
+
+	for _, tt := range tests {
+		resource, err := NewResource(tt.arn)
+		require.NoError(t, err, tt.arn)
+		assert.Equal(t, tt.service, resource.Service, tt.arn)
+		assert.Equal(t, tt.kind, resource.Type, tt.arn)
+		assert.Equal(t, tt.name, resource.Name, tt.arn)
+	}
+
+	_, err := NewResource("not-an-arn")
+	assert.Error(t, err)
+}
+
+func TestAttachAlarms(t *testing.T) {
+	resources := []Resource{
+		{Name: "lambda-java-template-dev-product-service"},
+		{Name: "lambda-java-template-dev-products"},
+		{Name: "lambda-java-template-dev-artifacts"},
+	}
+	AttachAlarms(resources, map[string][]string{
+		"product-service-errors":   {"lambda-java-template-dev-product-service"},
+		"product-service-duration": {"lambda-java-template-dev-product-service"},
+		"products-throttles":       {"lambda-java-template-dev-products", "GetItem"},
+	})
+
+	assert.Equal(t, []string{"product-service-duration", "product-service-errors"}, resources[0].Alarms)
+	assert.Equal(t, []string{"products-throttles"}, resources[1].Alarms)
+	assert.Empty(t, resources[2].Alarms)
+}
+
+func TestMarkdown(t *testing.T) {
+	inventory := Inventory{
+		Project:     "lambda-java-template",
+		Environment: "dev",
+		GeneratedAt: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+		Resources: []Resource{
+			{Service: "dynamodb", Type: "table", Name: "products", Summary: map[string]string{"encryption": "KMS", "billing mode": "PAY_PER_REQUEST"}},
+			{Service: "lambda", Type: "function", Name: "product-service", Alarms: []string{"errors"}},
+		},
+	}
+
+	report := Markdown(inventory)
+	assert.Contains(t, report, "# Inventory: lambda-java-template (dev)")
+	assert.Contains(t, report, "## dynamodb")
+	assert.Contains(t, report, "| products | table | billing mode: PAY_PER_REQUEST<br>encryption: KMS | — |")
+	assert.Contains(t, report, "| product-service | function | — | errors |")
+}
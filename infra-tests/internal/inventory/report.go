@@ -0,0 +1,56 @@
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// JSON renders the inventory as indented JSON
+func JSON(inventory Inventory) ([]byte, error) {
+	return json.MarshalIndent(inventory, "", "  ")
+}
+
+// Markdown renders the inventory as a table per service
+func Markdown(inventory Inventory) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Inventory: %s (%s)\n\n", inventory.Project, inventory.Environment)
+	fmt.Fprintf(&b, "Generated %s, %d resources.\n", inventory.GeneratedAt.Format("2006-01-02 15:04:05 MST"), len(inventory.Resources))
+
+	service := ""
+	for _, resource := range inventory.Resources {
+		if resource.Service != service {
+			service = resource.Service
+			fmt.Fprintf(&b, "\n## %s\n\n", service)
+			b.WriteString("| Name | Type | Configuration | Alarms |\n")
+			b.WriteString("|------|------|---------------|--------|\n")
+		}
+
+		alarms := "—"
+		if len(resource.Alarms) > 0 {
+			alarms = strings.Join(resource.Alarms, "<br>")
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", resource.Name, resource.Type, summaryCell(resource.Summary), alarms)
+	}
+	return b.String()
+}
+
+// summaryCell renders summary entries sorted by key
+func summaryCell(summary map[string]string) string {
+	if len(summary) == 0 {
+		return "—"
+	}
+
+	keys := make([]string, 0, len(summary))
+	for key := range summary {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]string, len(keys))
+	for i, key := range keys {
+		entries[i] = fmt.Sprintf("%s: %s", key, summary[key])
+	}
+	return strings.Join(entries, "<br>")
+}
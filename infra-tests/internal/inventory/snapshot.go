@@ -0,0 +1,144 @@
+package inventory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// snapshotTimeLayout names snapshot files so they sort by the time they were taken
+const snapshotTimeLayout = "20060102T150405Z"
+
+// Entry is one resource of a snapshot: what it is and how it is tagged, without its configuration
+type Entry struct {
+	Type string            `json:"type"`
+	ARN  string            `json:"arn"`
+	Tags map[string]string `json:"tags"`
+}
+
+// Snapshot is the set of resources carrying a project's tags at one point in time
+type Snapshot struct {
+	Project     string    `json:"project"`
+	Environment string    `json:"environment"`
+	TakenAt     time.Time `json:"takenAt"`
+	Resources   []Entry   `json:"resources"`
+}
+
+// Diff is how the resources changed between two snapshots
+type Diff struct {
+	Added    []Entry
+	Removed  []Entry
+	Retagged []Entry
+}
+
+// Empty reports whether no resource was added, removed, or retagged
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Retagged) == 0
+}
+
+// NewSnapshot reduces an inventory to a snapshot, in ARN order
+func NewSnapshot(inventory Inventory) Snapshot {
+	snapshot := Snapshot{Project: inventory.Project, Environment: inventory.Environment, TakenAt: inventory.GeneratedAt}
+	for _, resource := range inventory.Resources {
+		snapshot.Resources = append(snapshot.Resources, Entry{
+			Type: resource.Service + "/" + resource.Type,
+			ARN:  resource.ARN,
+			Tags: resource.Tags,
+		})
+	}
+	sort.Slice(snapshot.Resources, func(i, j int) bool { return snapshot.Resources[i].ARN < snapshot.Resources[j].ARN })
+	return snapshot
+}
+
+// Compare returns the resources added to and removed from previous, and those whose tags changed
+func Compare(previous, current Snapshot) Diff {
+	before := make(map[string]Entry, len(previous.Resources))
+	for _, entry := range previous.Resources {
+		before[entry.ARN] = entry
+	}
+
+	var diff Diff
+	for _, entry := range current.Resources {
+		old, ok := before[entry.ARN]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, entry)
+		case !maps.Equal(old.Tags, entry.Tags):
+			diff.Retagged = append(diff.Retagged, entry)
+		}
+		delete(before, entry.ARN)
+	}
+	for _, entry := range previous.Resources {
+		if _, ok := before[entry.ARN]; ok {
+			diff.Removed = append(diff.Removed, entry)
+		}
+	}
+	return diff
+}
+
+// snapshotPrefix starts the file name of every snapshot of a project environment
+func snapshotPrefix(projectName, environment string) string {
+	return fmt.Sprintf("%s-%s-", projectName, environment)
+}
+
+// WriteSnapshot saves a snapshot in dir as <project>-<environment>-<time>.json and returns its path
+func WriteSnapshot(dir string, snapshot Snapshot) (string, error) {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encoding inventory snapshot: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("creating snapshot directory: %w", err)
+	}
+	path := filepath.Join(dir, snapshotPrefix(snapshot.Project, snapshot.Environment)+snapshot.TakenAt.UTC().Format(snapshotTimeLayout)+".json")
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return "", fmt.Errorf("writing inventory snapshot: %w", err)
+	}
+	return path, nil
+}
+
+// LatestSnapshot returns the most recent snapshot of a project environment in dir.
+// It returns false when dir holds none.
+func LatestSnapshot(dir, projectName, environment string) (Snapshot, bool, error) {
+	prefix := snapshotPrefix(projectName, environment)
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return Snapshot{}, false, nil
+	}
+	if err != nil {
+		return Snapshot{}, false, fmt.Errorf("listing inventory snapshots: %w", err)
+	}
+
+	latest := ""
+	for _, entry := range entries {
+		name := entry.Name()
+		stamp, ok := strings.CutPrefix(strings.TrimSuffix(name, ".json"), prefix)
+		if !ok || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		// The time stamp also rules out environments whose name starts with this one, such as dev-2
+		if _, err := time.Parse(snapshotTimeLayout, stamp); err == nil && name > latest {
+			latest = name
+		}
+	}
+	if latest == "" {
+		return Snapshot{}, false, nil
+	}
+
+	path := filepath.Join(dir, latest)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Snapshot{}, false, fmt.Errorf("reading inventory snapshot: %w", err)
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return Snapshot{}, false, fmt.Errorf("parsing inventory snapshot %s: %w", path, err)
+	}
+	return snapshot, true, nil
+}
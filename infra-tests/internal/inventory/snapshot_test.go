@@ -0,0 +1,63 @@
+package inventory
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	previous := Snapshot{Resources: []Entry{
+		{Type: "lambda/function", ARN: "kept", Tags: map[string]string{"Project": "app"}},
+		{Type: "lambda/function", ARN: "retagged", Tags: map[string]string{"Project": "app"}},
+		{Type: "dynamodb/table", ARN: "removed"},
+	}}
+	current := Snapshot{Resources: []Entry{
+		{Type: "lambda/function", ARN: "kept", Tags: map[string]string{"Project": "app"}},
+		{Type: "lambda/function", ARN: "retagged", Tags: map[string]string{"Project": "app", "Owner": "payments"}},
+		{Type: "sqs/queue", ARN: "added"},
+	}}
+
+	diff := Compare(previous, current)
+	assert.Equal(t, []Entry{current.Resources[2]}, diff.Added)
+	assert.Equal(t, []Entry{previous.Resources[2]}, diff.Removed)
+	assert.Equal(t, []Entry{current.Resources[1]}, diff.Retagged)
+	assert.False(t, diff.Empty())
+	assert.True(t, Compare(current, current).Empty())
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	_, found, err := LatestSnapshot(filepath.Join(dir, "missing"), "app", "dev")
+	require.NoError(t, err)
+	assert.False(t, found, "A directory that does not exist yet holds no snapshot")
+
+	first := NewSnapshot(Inventory{Project: "app", Environment: "dev", GeneratedAt: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC), Resources: []Resource{
+		{ARN: "b", Service: "lambda", Type: "function", Tags: map[string]string{"Project": "app"}},
+		{ARN: "a", Service: "dynamodb", Type: "table", Tags: map[string]string{"Project": "app"}},
+	}})
+	assert.Equal(t, "a", first.Resources[0].ARN, "Entries are in ARN order")
+	assert.Equal(t, "dynamodb/table", first.Resources[0].Type)
+
+	second := first
+	second.TakenAt = first.TakenAt.Add(time.Hour)
+	second.Resources = first.Resources[:1]
+	other := first
+	other.Environment = "dev-2"
+	other.TakenAt = first.TakenAt.Add(2 * time.Hour)
+	for _, snapshot := range []Snapshot{first, second, other} {
+		_, err := WriteSnapshot(dir, snapshot)
+		require.NoError(t, err)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app-dev-notes.json"), []byte("{}"), 0o644))
+
+	latest, found, err := LatestSnapshot(dir, "app", "dev")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.True(t, second.TakenAt.Equal(latest.TakenAt), "The newest snapshot of the environment is returned, not dev-2's")
+	assert.Len(t, latest.Resources, 1)
+}
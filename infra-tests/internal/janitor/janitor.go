@@ -0,0 +1,109 @@
+// Package janitor finds ephemeral test namespaces that outlived their time to live.
+//
+// Test runs deploy the stack into a namespace such as lambda-java-test-<unique id> and tag every resource
+// with Namespace, CreatedAt, and Ephemeral=true. A run that fails before destroying the namespace leaves it behind;
+// the janitor groups tagged resources by namespace and selects those created longer ago than the TTL.
+package janitor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lambda-java-template/tests/internal/inventory"
+)
+
+// DefaultPrefix starts the namespaces test runs deploy into
+const DefaultPrefix = "lambda-java-test-"
+
+// CreatedAtTag is the tag holding a namespace's RFC 3339 creation time
+const CreatedAtTag = "CreatedAt"
+
+// EphemeralTag is "true" on every resource of a namespace deployed with is_ephemeral
+const EphemeralTag = "Ephemeral"
+
+// Namespace is the tagged resources of one ephemeral namespace
+type Namespace struct {
+	Name string
+	// CreatedAt is the earliest CreatedAt tag of the namespace's resources; zero when none carries one
+	CreatedAt time.Time
+	Resources []inventory.Resource
+}
+
+// Group collects resources by their Namespace tag, keeping the namespaces that start with prefix, sorted by name
+func Group(resources []inventory.Resource, prefix string) ([]Namespace, error) {
+	byName := map[string]*Namespace{}
+	for _, resource := range resources {
+		name := resource.Tags["Namespace"]
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		namespace, ok := byName[name]
+		if !ok {
+			namespace = &Namespace{Name: name}
+			byName[name] = namespace
+		}
+		namespace.Resources = append(namespace.Resources, resource)
+
+		value, ok := resource.Tags[CreatedAtTag]
+		if !ok {
+			continue
+		}
+		createdAt, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s has %s=%q, which is not RFC 3339: %w", resource.ARN, CreatedAtTag, value, err)
+		}
+		if namespace.CreatedAt.IsZero() || createdAt.Before(namespace.CreatedAt) {
+			namespace.CreatedAt = createdAt
+		}
+	}
+
+	namespaces := make([]Namespace, 0, len(byName))
+	for _, namespace := range byName {
+		namespaces = append(namespaces, *namespace)
+	}
+	sort.Slice(namespaces, func(i, j int) bool { return namespaces[i].Name < namespaces[j].Name })
+	return namespaces, nil
+}
+
+// Expired reports whether a namespace was created more than ttl before now.
+// Namespaces without a CreatedAt tag never expire, since their age is unknown.
+func (n Namespace) Expired(now time.Time, ttl time.Duration) bool {
+	return !n.CreatedAt.IsZero() && now.Sub(n.CreatedAt) > ttl
+}
+
+// Ephemeral reports whether every resource of a namespace is tagged Ephemeral=true. A namespace with any
+// other resource may be a long-lived deployment that only matches the prefix, so none of it is deleted.
+func (n Namespace) Ephemeral() bool {
+	for _, resource := range n.Resources {
+		if resource.Tags[EphemeralTag] != "true" {
+			return false
+		}
+	}
+	return len(n.Resources) > 0
+}
+
+// teardownOrder deletes the entry points of a namespace before what they depend on
+var teardownOrder = map[string]int{
+	"apigateway/api":      0,
+	"states/stateMachine": 1,
+	"lambda/function":     2,
+	"dynamodb/table":      3,
+	"cloudwatch/alarm":    4,
+	"logs/log-group":      5,
+}
+
+// TeardownOrder returns a namespace's resources in the order they should be deleted;
+// resources of other types come last
+func TeardownOrder(resources []inventory.Resource) []inventory.Resource {
+	ordered := append([]inventory.Resource{}, resources...)
+	rank := func(resource inventory.Resource) int {
+		if position, ok := teardownOrder[resource.Service+"/"+resource.Type]; ok {
+			return position
+		}
+		return len(teardownOrder)
+	}
+	sort.SliceStable(ordered, func(i, j int) bool { return rank(ordered[i]) < rank(ordered[j]) })
+	return ordered
+}
@@ -0,0 +1,72 @@
+package janitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/inventory"
+)
+
+// tagged returns the inventory resource of an ARN with tags
+func tagged(t *testing.T, arn string, tags map[string]string) inventory.Resource {
+	resource, err := inventory.NewResource(arn)
+	require.NoError(t, err)
+	resource.Tags = tags
+	return resource
+}
+
+func TestGroup(t *testing.T) {
+	resources := []inventory.Resource{
+		tagged(t, "arn:aws:lambda:us-east-1:123456789012:function:app-lambda-java-test-b1-product-service",
+			map[string]string{"Namespace": "lambda-java-test-b1", CreatedAtTag: "2024-05-01T12:00:00Z"}),
+		tagged(t, "arn:aws:dynamodb:us-east-1:123456789012:table/app-lambda-java-test-b1-products",
+			map[string]string{"Namespace": "lambda-java-test-b1", CreatedAtTag: "2024-05-01T11:00:00Z"}),
+		tagged(t, "arn:aws:dynamodb:us-east-1:123456789012:table/app-lambda-java-test-a9-products",
+			map[string]string{"Namespace": "lambda-java-test-a9"}),
+		tagged(t, "arn:aws:dynamodb:us-east-1:123456789012:table/app-dev-products",
+			map[string]string{"Namespace": "dev", CreatedAtTag: "2020-01-01T00:00:00Z"}),
+	}
+
+	namespaces, err := Group(resources, DefaultPrefix)
+	require.NoError(t, err)
+	require.Len(t, namespaces, 2, "Namespaces without the prefix are never touched")
+	assert.Equal(t, "lambda-java-test-a9", namespaces[0].Name)
+	assert.True(t, namespaces[0].CreatedAt.IsZero())
+	assert.Equal(t, "lambda-java-test-b1", namespaces[1].Name)
+	assert.Equal(t, time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC), namespaces[1].CreatedAt, "The earliest resource dates the namespace")
+	assert.Len(t, namespaces[1].Resources, 2)
+
+	now := time.Date(2024, 5, 1, 18, 0, 0, 0, time.UTC)
+	assert.True(t, namespaces[1].Expired(now, 6*time.Hour))
+	assert.False(t, namespaces[1].Expired(now, 12*time.Hour))
+	assert.False(t, namespaces[0].Expired(now, 0), "A namespace of unknown age is kept")
+
+	_, err = Group([]inventory.Resource{
+		tagged(t, "arn:aws:dynamodb:us-east-1:123456789012:table/t", map[string]string{"Namespace": "lambda-java-test-x", CreatedAtTag: "yesterday"}),
+	}, DefaultPrefix)
+	assert.Error(t, err)
+}
+
+func TestEphemeral(t *testing.T) {
+	function := tagged(t, "arn:aws:lambda:us-east-1:123456789012:function:f", map[string]string{EphemeralTag: "true"})
+	table := tagged(t, "arn:aws:dynamodb:us-east-1:123456789012:table/t", map[string]string{EphemeralTag: "true"})
+	kept := tagged(t, "arn:aws:dynamodb:us-east-1:123456789012:table/k", map[string]string{EphemeralTag: "false"})
+	untagged := tagged(t, "arn:aws:dynamodb:us-east-1:123456789012:table/u", nil)
+
+	assert.True(t, Namespace{Resources: []inventory.Resource{function, table}}.Ephemeral())
+	assert.False(t, Namespace{Resources: []inventory.Resource{function, kept}}.Ephemeral())
+	assert.False(t, Namespace{Resources: []inventory.Resource{untagged, function}}.Ephemeral(), "A resource without the tag keeps the namespace")
+	assert.False(t, Namespace{}.Ephemeral())
+}
+
+func TestTeardownOrder(t *testing.T) {
+	table := tagged(t, "arn:aws:dynamodb:us-east-1:123456789012:table/t", nil)
+	function := tagged(t, "arn:aws:lambda:us-east-1:123456789012:function:f", nil)
+	api := tagged(t, "arn:aws:apigateway:us-east-1::/apis/abc123", nil)
+	topic := tagged(t, "arn:aws:sns:us-east-1:123456789012:alerts", nil)
+
+	assert.Equal(t, []inventory.Resource{api, function, table, topic}, TeardownOrder([]inventory.Resource{topic, table, function, api}))
+}
@@ -0,0 +1,161 @@
+// Package load drives endpoints at a fixed request rate and checks the latencies against SLOs
+package load
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lambda-java-template/tests/internal/stress"
+)
+
+// Options shape a load run
+type Options struct {
+	// RPS is the total request rate across all targets
+	RPS int
+	// Duration is how long requests are started for; the run ends once the last one completes
+	Duration time.Duration
+	// Concurrency caps the requests in flight. When every slot is busy the next request waits,
+	// so a slow target lowers the achieved rate rather than queueing unbounded work.
+	Concurrency int
+}
+
+// Target is one endpoint under load. Do sends a single request and returns an error when it failed.
+type Target struct {
+	Name string
+	Do   func(ctx context.Context) error
+}
+
+// Sample is the outcome of one request
+type Sample struct {
+	Target  string
+	Latency time.Duration
+	Err     error
+}
+
+// Run sends requests to the targets in turn at opts.RPS for opts.Duration and returns every sample
+func Run(ctx context.Context, opts Options, targets []Target) ([]Sample, error) {
+	if opts.RPS <= 0 || opts.Duration <= 0 || opts.Concurrency <= 0 {
+		return nil, fmt.Errorf("load options need a positive rate, duration, and concurrency: %+v", opts)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("load run has no targets")
+	}
+
+	var (
+		mu      sync.Mutex
+		samples []Sample
+		wg      sync.WaitGroup
+	)
+	slots := make(chan struct{}, opts.Concurrency)
+	ticker := time.NewTicker(time.Second / time.Duration(opts.RPS))
+	defer ticker.Stop()
+	deadline := time.After(opts.Duration)
+
+	for i := 0; ; i++ {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return samples, ctx.Err()
+		case <-deadline:
+			wg.Wait()
+			return samples, nil
+		case <-ticker.C:
+		}
+
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(target Target) {
+			defer func() { <-slots; wg.Done() }()
+
+			start := time.Now()
+			err := target.Do(ctx)
+			sample := Sample{Target: target.Name, Latency: time.Since(start), Err: err}
+
+			mu.Lock()
+			samples = append(samples, sample)
+			mu.Unlock()
+		}(targets[i%len(targets)])
+	}
+}
+
+// Stats summarizes the samples of one target
+type Stats struct {
+	Requests int
+	Errors   int
+	P50      time.Duration
+	P90      time.Duration
+	P99      time.Duration
+	// Latencies holds every latency, failed requests included, in ascending order
+	Latencies []time.Duration
+}
+
+// ErrorRate returns failed requests per request
+func (s Stats) ErrorRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Requests)
+}
+
+// Summarize groups samples by target
+func Summarize(samples []Sample) map[string]Stats {
+	stats := make(map[string]Stats)
+	for _, sample := range samples {
+		target := stats[sample.Target]
+		target.Requests++
+		if sample.Err != nil {
+			target.Errors++
+		}
+		target.Latencies = append(target.Latencies, sample.Latency)
+		stats[sample.Target] = target
+	}
+
+	for name, target := range stats {
+		sort.Slice(target.Latencies, func(i, j int) bool { return target.Latencies[i] < target.Latencies[j] })
+		target.P50 = stress.Percentile(target.Latencies, 50)
+		target.P90 = stress.Percentile(target.Latencies, 90)
+		target.P99 = stress.Percentile(target.Latencies, 99)
+		stats[name] = target
+	}
+	return stats
+}
+
+// SLO bounds the latency percentiles and error rate of a target; zero fields are not checked
+type SLO struct {
+	P50          time.Duration
+	P90          time.Duration
+	P99          time.Duration
+	MaxErrorRate float64
+}
+
+// DefaultSLO suits the template's Java functions behind an HTTP API, with room in the tail for cold starts
+var DefaultSLO = SLO{
+	P50:          500 * time.Millisecond,
+	P90:          time.Second,
+	P99:          5 * time.Second,
+	MaxErrorRate: 0.01,
+}
+
+// Violations describes every objective the stats miss
+func (s SLO) Violations(stats Stats) []string {
+	var violations []string
+	for _, percentile := range []struct {
+		name          string
+		actual, limit time.Duration
+	}{
+		{"p50", stats.P50, s.P50},
+		{"p90", stats.P90, s.P90},
+		{"p99", stats.P99, s.P99},
+	} {
+		if percentile.limit > 0 && percentile.actual > percentile.limit {
+			violations = append(violations, fmt.Sprintf("%s latency %s exceeds %s", percentile.name, percentile.actual, percentile.limit))
+		}
+	}
+	if s.MaxErrorRate > 0 && stats.ErrorRate() > s.MaxErrorRate {
+		violations = append(violations, fmt.Sprintf("error rate %.2f%% exceeds %.2f%%", stats.ErrorRate()*100, s.MaxErrorRate*100))
+	}
+	return violations
+}
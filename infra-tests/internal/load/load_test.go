@@ -0,0 +1,78 @@
+package load
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	var health, products atomic.Int32
+	targets := []Target{
+		{Name: "GET /health", Do: func(ctx context.Context) error { health.Add(1); return nil }},
+		{Name: "GET /products", Do: func(ctx context.Context) error {
+			products.Add(1)
+			time.Sleep(5 * time.Millisecond)
+			return errors.New("500")
+		}},
+	}
+
+	samples, err := Run(context.Background(), Options{RPS: 200, Duration: 200 * time.Millisecond, Concurrency: 4}, targets)
+	require.NoError(t, err)
+
+	// Timer granularity varies between machines, so only the order of magnitude is checked
+	assert.InDelta(t, 40, len(samples), 20)
+	assert.InDelta(t, health.Load(), products.Load(), 1, "Targets are driven in turn")
+
+	stats := Summarize(samples)
+	assert.Zero(t, stats["GET /health"].Errors)
+	assert.Equal(t, 1.0, stats["GET /products"].ErrorRate())
+	assert.GreaterOrEqual(t, stats["GET /products"].P50, 5*time.Millisecond)
+}
+
+func TestRunRejectsInvalidOptions(t *testing.T) {
+	target := []Target{{Name: "noop", Do: func(ctx context.Context) error { return nil }}}
+
+	_, err := Run(context.Background(), Options{RPS: 0, Duration: time.Second, Concurrency: 1}, target)
+	assert.Error(t, err)
+	_, err = Run(context.Background(), Options{RPS: 1, Duration: time.Second, Concurrency: 1}, nil)
+	assert.Error(t, err)
+}
+
+func TestSummarize(t *testing.T) {
+	var samples []Sample
+	for i := 1; i <= 100; i++ {
+		var err error
+		if i%25 == 0 {
+			err = errors.New("timeout")
+		}
+		samples = append(samples, Sample{Target: "GET /health", Latency: time.Duration(101-i) * time.Millisecond, Err: err})
+	}
+
+	stats := Summarize(samples)["GET /health"]
+	assert.Equal(t, 100, stats.Requests)
+	assert.Equal(t, 4, stats.Errors)
+	assert.Equal(t, 50*time.Millisecond, stats.P50)
+	assert.Equal(t, 90*time.Millisecond, stats.P90)
+	assert.Equal(t, 99*time.Millisecond, stats.P99)
+	assert.Equal(t, time.Millisecond, stats.Latencies[0], "Latencies are sorted")
+}
+
+func TestSLOViolations(t *testing.T) {
+	slo := SLO{P50: 100 * time.Millisecond, P99: time.Second, MaxErrorRate: 0.01}
+
+	healthy := Stats{Requests: 100, Errors: 1, P50: 80 * time.Millisecond, P90: 5 * time.Second, P99: 900 * time.Millisecond}
+	assert.Empty(t, slo.Violations(healthy), "Unset objectives are not checked")
+
+	degraded := Stats{Requests: 100, Errors: 5, P50: 120 * time.Millisecond, P99: 2 * time.Second}
+	assert.Equal(t, []string{
+		"p50 latency 120ms exceeds 100ms",
+		"p99 latency 2s exceeds 1s",
+		"error rate 5.00% exceeds 1.00%",
+	}, slo.Violations(degraded))
+}
@@ -0,0 +1,28 @@
+package load
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryAfter returns the wait a Retry-After header asks for, given either as delay seconds or as an HTTP date
+func RetryAfter(header string, now time.Time) (time.Duration, error) {
+	header = strings.TrimSpace(header)
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, fmt.Errorf("Retry-After %q is negative", header)
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+	date, err := http.ParseTime(header)
+	if err != nil {
+		return 0, fmt.Errorf("Retry-After %q is neither delay seconds nor an HTTP date", header)
+	}
+	if wait := date.Sub(now); wait > 0 {
+		return wait, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,30 @@
+package load
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	wait, err := RetryAfter("2", now)
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, wait)
+
+	wait, err = RetryAfter("Sat, 01 Jun 2024 12:00:30 GMT", now)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, wait)
+
+	wait, err = RetryAfter("Sat, 01 Jun 2024 11:59:00 GMT", now)
+	require.NoError(t, err)
+	assert.Zero(t, wait, "A date in the past allows retrying at once")
+
+	_, err = RetryAfter("-1", now)
+	assert.Error(t, err)
+	_, err = RetryAfter("soon", now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,119 @@
+// Package negative builds malformed requests for the product API and judges its responses to them.
+// Every case is invalid input, so the API must answer with a 4xx and a structured error body; a 5xx
+// or a leaked stack trace means the input reached code that does not handle it.
+package negative
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// OversizedBodyBytes is larger than the 6 MB a synchronous Lambda invocation accepts, so the request
+// must be rejected before or by the integration rather than failing inside it
+const OversizedBodyBytes = 7 << 20
+
+// Case is one malformed request. Path is relative to the API endpoint and already escaped.
+type Case struct {
+	Name        string
+	Method      string
+	Path        string
+	ContentType string
+	Body        []byte
+}
+
+// traversalIDs are product ids that try to climb out of /products/{id}; they are sent escaped,
+// so the client does not normalize them away before they reach the API
+var traversalIDs = map[string]string{
+	"Dot_Segments":         "..%2F..%2Fhealth",
+	"Encoded_Dots":         "%2e%2e%2f%2e%2e%2fetc%2fpasswd",
+	"Backslash_Segments":   "..%5C..%5Cwindows%5Cwin.ini",
+	"Null_Byte":            "product%00.json",
+	"Double_Encoded_Slash": "..%252F..%252Fhealth",
+}
+
+// bodies are request bodies no products route accepts, by name
+func bodies() map[string]struct {
+	contentType string
+	body        []byte
+} {
+	return map[string]struct {
+		contentType string
+		body        []byte
+	}{
+		"Truncated_JSON":  {"application/json", []byte(`{"name": "widget", "price": `)},
+		"JSON_Array":      {"application/json", []byte(`[{"name": "widget", "price": 1}]`)},
+		"Wrong_Types":     {"application/json", []byte(`{"name": 123, "price": "free"}`)},
+		"Empty_Body":      {"application/json", nil},
+		"Invalid_UTF8":    {"application/json", []byte("{\"name\": \"\xff\xfe\xfd\", \"price\": 1}")},
+		"XML_Body":        {"application/xml", []byte(`<product><name>widget</name><price>1</price></product>`)},
+		"Form_Body":       {"application/x-www-form-urlencoded", []byte(`name=widget&price=1`)},
+		"Oversized_Body":  {"application/json", oversizedBody()},
+		"Deeply_Nested":   {"application/json", []byte(strings.Repeat("[", 10000) + strings.Repeat("]", 10000))},
+		"Negative_Price":  {"application/json", []byte(`{"name": "widget", "price": -1}`)},
+		"Duplicate_Names": {"application/json", []byte(`{"name": "widget", "name": {"$ne": null}, "price": 1}`)},
+	}
+}
+
+// oversizedBody returns a product whose name alone exceeds OversizedBodyBytes
+func oversizedBody() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"name": "`)
+	buf.Write(bytes.Repeat([]byte("a"), OversizedBodyBytes))
+	buf.WriteString(`", "price": 1}`)
+	return buf.Bytes()
+}
+
+// Cases returns the malformed requests for every products route. missingID is a product id that does
+// not exist, used where a route needs a valid-looking id to reach its body handling.
+func Cases(missingID string) []Case {
+	var cases []Case
+	for name, body := range bodies() {
+		cases = append(cases,
+			Case{Name: "POST_/products/" + name, Method: http.MethodPost, Path: "/products", ContentType: body.contentType, Body: body.body},
+			Case{Name: "PUT_/products/{id}/" + name, Method: http.MethodPut, Path: "/products/" + missingID, ContentType: body.contentType, Body: body.body},
+		)
+	}
+	for name, id := range traversalIDs {
+		for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+			c := Case{Name: method + "_/products/{id}/" + name, Method: method, Path: "/products/" + id}
+			if method == http.MethodPut {
+				c.ContentType = "application/json"
+				c.Body = []byte(`{"name": "widget", "price": 1}`)
+			}
+			cases = append(cases, c)
+		}
+	}
+	return cases
+}
+
+// stackTraceMarkers are fragments of JVM stack traces and exception names that must never reach a client
+var stackTraceMarkers = []string{"\tat ", "at java.", "at software.amazon", "at org.springframework", ".java:", "Exception:", "Caused by:"}
+
+// Problems describes why a response is not an acceptable rejection of a malformed request: a status
+// outside 4xx, a body that is not a JSON object with an error message, or a leaked stack trace
+func Problems(status int, body []byte) []string {
+	var problems []string
+	if status < 400 || status > 499 {
+		problems = append(problems, fmt.Sprintf("status %d is not a 4xx", status))
+	}
+
+	// Errors from the product service carry message and statusCode; errors API Gateway raises
+	// itself, such as 413, carry only message
+	var parsed map[string]any
+	if err := json.Unmarshal(body, &parsed); err != nil || parsed == nil {
+		problems = append(problems, "body is not a JSON object")
+	} else if message, _ := parsed["message"].(string); message == "" {
+		problems = append(problems, "body has no error message")
+	}
+
+	for _, marker := range stackTraceMarkers {
+		if strings.Contains(string(body), marker) {
+			problems = append(problems, fmt.Sprintf("body leaks a stack trace (%q)", strings.TrimSpace(marker)))
+			break
+		}
+	}
+	return problems
+}
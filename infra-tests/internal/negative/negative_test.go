@@ -0,0 +1,66 @@
+package negative
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCasesCoverEveryProductsRoute(t *testing.T) {
+	cases := Cases("itest-missing")
+
+	routes := map[string]int{}
+	names := map[string]bool{}
+	for _, c := range cases {
+		route := c.Method + " " + c.Path
+		if strings.HasPrefix(c.Path, "/products/") {
+			route = c.Method + " /products/{id}"
+		}
+		routes[route]++
+
+		assert.False(t, names[c.Name], "Duplicate case name %s", c.Name)
+		names[c.Name] = true
+		if c.Body != nil {
+			assert.NotEmpty(t, c.ContentType, "Case %s sends a body without a content type", c.Name)
+		}
+	}
+	for _, route := range []string{"POST /products", "GET /products/{id}", "PUT /products/{id}", "DELETE /products/{id}"} {
+		assert.NotZero(t, routes[route], "No negative cases for %s", route)
+	}
+}
+
+func TestCasesKeepTraversalEscaped(t *testing.T) {
+	for _, c := range Cases("itest-missing") {
+		if c.Method == http.MethodGet {
+			assert.NotContains(t, c.Path, "../", "Case %s would be normalized by the client", c.Name)
+		}
+	}
+}
+
+func TestOversizedBodyExceedsInvocationLimit(t *testing.T) {
+	assert.Greater(t, len(oversizedBody()), 6<<20)
+}
+
+func TestProblems(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		problems []string
+	}{
+		{"Service_Error", 400, `{"error":"HTTP 400","message":"Invalid JSON","statusCode":400}`, nil},
+		{"Gateway_Error", 413, `{"message":"Request Entity Too Large"}`, nil},
+		{"Server_Error", 500, `{"message":"Internal Server Error"}`, []string{"status 500 is not a 4xx"}},
+		{"Accepted", 201, `{"id":"1","name":"widget","price":1}`, []string{"status 201 is not a 4xx", "body has no error message"}},
+		{"Plain_Text", 400, `Bad Request`, []string{"body is not a JSON object"}},
+		{"Stack_Trace", 400, `{"message":"com.fasterxml.jackson.core.JsonParseException: Unexpected end-of-input\n\tat com.fasterxml.jackson.core.base.ParserMinimalBase._reportInvalidEOF(ParserMinimalBase.java:664)"}`,
+			[]string{`body leaks a stack trace (".java:")`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.problems, Problems(tt.status, []byte(tt.body)))
+		})
+	}
+}
@@ -0,0 +1,223 @@
+// Package openapi reads OpenAPI 3 documents, compares two of them operation by operation,
+// and validates JSON values against their schemas.
+//
+// Only the parts of the specification the product API uses are modelled: paths, path
+// parameters, response codes, and schemas built from type, required, properties, items,
+// allOf, nullable, format date-time, and local $ref.
+package openapi
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// methods are the operation keys of a path item, in the order operations are listed
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// Spec is an OpenAPI document
+type Spec struct {
+	Paths      map[string]map[string]any `yaml:"paths"`
+	Components struct {
+		Schemas map[string]*Schema `yaml:"schemas"`
+	} `yaml:"components"`
+
+	operations map[string]Operation
+}
+
+// Operation is one method of a path
+type Operation struct {
+	Parameters []Parameter          `yaml:"parameters"`
+	Responses  map[string]*Response `yaml:"responses"`
+}
+
+// Parameter is an operation or path item parameter
+type Parameter struct {
+	Name     string `yaml:"name"`
+	In       string `yaml:"in"`
+	Required bool   `yaml:"required"`
+}
+
+// Response is a documented response of an operation
+type Response struct {
+	Content map[string]struct {
+		Schema *Schema `yaml:"schema"`
+	} `yaml:"content"`
+}
+
+// Schema is a JSON schema as OpenAPI 3.0 writes it
+type Schema struct {
+	Ref        string             `yaml:"$ref"`
+	Type       string             `yaml:"type"`
+	Format     string             `yaml:"format"`
+	Nullable   bool               `yaml:"nullable"`
+	Enum       []any              `yaml:"enum"`
+	Required   []string           `yaml:"required"`
+	Properties map[string]*Schema `yaml:"properties"`
+	Items      *Schema            `yaml:"items"`
+	AllOf      []*Schema          `yaml:"allOf"`
+	// AdditionalProperties is the schema of every property Properties does not name, as in a map
+	AdditionalProperties *Schema `yaml:"additionalProperties"`
+}
+
+// Load reads a YAML or JSON OpenAPI document
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading OpenAPI document: %w", err)
+	}
+	spec, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// Parse decodes a YAML or JSON OpenAPI document
+func Parse(data []byte) (*Spec, error) {
+	// JSON is valid YAML, so one decoder handles both formats
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parsing OpenAPI document: %w", err)
+	}
+
+	spec.operations = make(map[string]Operation)
+	for path, item := range spec.Paths {
+		// Parameters declared on the path item apply to every operation of the path
+		var shared []Parameter
+		if err := decode(item["parameters"], &shared); err != nil {
+			return nil, fmt.Errorf("parsing parameters of %s: %w", path, err)
+		}
+
+		for _, method := range methods {
+			raw, ok := item[method]
+			if !ok {
+				continue
+			}
+			var operation Operation
+			if err := decode(raw, &operation); err != nil {
+				return nil, fmt.Errorf("parsing %s %s: %w", strings.ToUpper(method), path, err)
+			}
+			operation.Parameters = append(append([]Parameter{}, shared...), operation.Parameters...)
+			spec.operations[strings.ToUpper(method)+" "+path] = operation
+		}
+	}
+	return &spec, nil
+}
+
+// decode converts a generically decoded YAML value into target
+func decode(value any, target any) error {
+	if value == nil {
+		return nil
+	}
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, target)
+}
+
+// Routes returns the route key ("GET /products/{id}") of every operation, sorted
+func (s *Spec) Routes() []string {
+	routes := make([]string, 0, len(s.operations))
+	for route := range s.operations {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+// Operation returns the operation with a route key
+func (s *Spec) Operation(route string) (Operation, bool) {
+	operation, ok := s.operations[route]
+	return operation, ok
+}
+
+// PathParameters returns the names of the operation's path parameters, sorted
+func (o Operation) PathParameters() []string {
+	var names []string
+	for _, parameter := range o.Parameters {
+		if parameter.In == "path" {
+			names = append(names, parameter.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// StatusCodes returns the documented response codes, sorted, leaving out "default"
+func (o Operation) StatusCodes() []string {
+	var codes []string
+	for code := range o.Responses {
+		if code != "default" {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// ResponseSchema returns the JSON schema of a route's response with a status code.
+// ok is false when the code is undocumented; the schema is nil when the response has no JSON body.
+func (s *Spec) ResponseSchema(route string, status int) (schema *Schema, ok bool) {
+	operation, found := s.operations[route]
+	if !found {
+		return nil, false
+	}
+	response, found := operation.Responses[strconv.Itoa(status)]
+	if !found {
+		return nil, false
+	}
+	if response == nil {
+		return nil, true
+	}
+	return response.Content["application/json"].Schema, true
+}
+
+// Diff describes how the operations of deployed differ from those of documented: routes only one of them
+// has, and for shared routes differing path parameters or response codes. API Gateway exports a single
+// "default" response unless responses were declared on import, so response codes are only compared when
+// deployed declares some.
+func Diff(documented, deployed *Spec) []string {
+	var differences []string
+	for _, route := range documented.Routes() {
+		if _, ok := deployed.operations[route]; !ok {
+			differences = append(differences, route+" is documented but not deployed")
+		}
+	}
+	for _, route := range deployed.Routes() {
+		want, ok := documented.operations[route]
+		if !ok {
+			differences = append(differences, route+" is deployed but not documented")
+			continue
+		}
+		got := deployed.operations[route]
+
+		if w, g := want.PathParameters(), got.PathParameters(); !equal(w, g) {
+			differences = append(differences, fmt.Sprintf("%s path parameters are %v, documented %v", route, g, w))
+		}
+		if g := got.StatusCodes(); len(g) > 0 {
+			if w := want.StatusCodes(); !equal(w, g) {
+				differences = append(differences, fmt.Sprintf("%s response codes are %v, documented %v", route, g, w))
+			}
+		}
+	}
+	return differences
+}
+
+// equal reports whether two sorted slices hold the same strings
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// productAPIPath is the OpenAPI document committed with the product service
+const productAPIPath = "../../../openapi/product-api.yaml"
+
+func TestLoadProductAPI(t *testing.T) {
+	spec, err := Load(productAPIPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"DELETE /products/{id}",
+		"GET /health",
+		"GET /products",
+		"GET /products/{id}",
+		"POST /products",
+		"PUT /products/{id}",
+	}, spec.Routes())
+
+	operation, ok := spec.Operation("PUT /products/{id}")
+	require.True(t, ok)
+	assert.Equal(t, []string{"id"}, operation.PathParameters())
+	assert.Equal(t, []string{"200", "400", "404", "500"}, operation.StatusCodes())
+
+	schema, ok := spec.ResponseSchema("GET /products/{id}", 200)
+	require.True(t, ok)
+	assert.Equal(t, "#/components/schemas/ProductResponse", schema.Ref)
+
+	schema, ok = spec.ResponseSchema("DELETE /products/{id}", 204)
+	assert.True(t, ok)
+	assert.Nil(t, schema, "204 responses have no body")
+
+	schema, ok = spec.ResponseSchema("GET /health", 503)
+	require.True(t, ok)
+	assert.Equal(t, "#/components/schemas/HealthResponse", schema.Ref)
+
+	_, ok = spec.ResponseSchema("GET /health", 500)
+	assert.False(t, ok)
+}
+
+func TestPathItemParameters(t *testing.T) {
+	spec, err := Parse([]byte(`
+paths:
+  /orders/{orderId}/items/{itemId}:
+    parameters:
+      - {name: orderId, in: path, required: true}
+    get:
+      parameters:
+        - {name: itemId, in: path, required: true}
+        - {name: expand, in: query}
+      responses:
+        default: {description: Default response}
+    delete:
+      responses:
+        '204': {description: Deleted}
+`))
+	require.NoError(t, err)
+
+	get, _ := spec.Operation("GET /orders/{orderId}/items/{itemId}")
+	assert.Equal(t, []string{"itemId", "orderId"}, get.PathParameters())
+	assert.Empty(t, get.StatusCodes(), "The default response is not a status code")
+
+	remove, _ := spec.Operation("DELETE /orders/{orderId}/items/{itemId}")
+	assert.Equal(t, []string{"orderId"}, remove.PathParameters(), "Each operation has its own copy of shared parameters")
+}
+
+func TestDiff(t *testing.T) {
+	documented, err := Load(productAPIPath)
+	require.NoError(t, err)
+
+	// API Gateway exports in JSON with a default response per route
+	deployed, err := Parse([]byte(`{
+		"openapi": "3.0.1",
+		"paths": {
+			"/health": {"get": {"responses": {"default": {"description": "Default response for GET /health"}}}},
+			"/products": {
+				"get": {"responses": {"default": {"description": "Default response for GET /products"}}},
+				"post": {"responses": {"default": {"description": "Default response for POST /products"}}}
+			},
+			"/products/{productId}": {
+				"parameters": [{"name": "productId", "in": "path", "required": true, "schema": {"type": "string"}}],
+				"get": {"responses": {"200": {"description": "OK"}}}
+			},
+			"/products/{id}": {
+				"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}],
+				"put": {"responses": {"default": {"description": "Default response"}}},
+				"delete": {"responses": {"204": {"description": "Deleted"}, "404": {"description": "Missing"}}}
+			}
+		}
+	}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"GET /products/{id} is documented but not deployed",
+		"DELETE /products/{id} response codes are [204 404], documented [204 404 500]",
+		"GET /products/{productId} is deployed but not documented",
+	}, Diff(documented, deployed))
+}
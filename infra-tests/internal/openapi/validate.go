@@ -0,0 +1,158 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ValidateJSON decodes body and validates it against schema
+func (s *Spec) ValidateJSON(body []byte, schema *Schema) []string {
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []string{fmt.Sprintf("body is not JSON: %v", err)}
+	}
+	return s.Validate(value, schema)
+}
+
+// Validate checks a decoded JSON value against schema and describes every mismatch with its JSON path
+func (s *Spec) Validate(value any, schema *Schema) []string {
+	var problems []string
+	s.validate("$", value, schema, &problems, map[string]bool{})
+	return problems
+}
+
+// validate checks value at path, following $ref through components. following holds the refs followed
+// without descending into the value, so a cycle of refs that never reaches a property terminates.
+func (s *Spec) validate(path string, value any, schema *Schema, problems *[]string, following map[string]bool) {
+	if schema == nil {
+		return
+	}
+	if schema.Ref != "" {
+		if following[schema.Ref] {
+			return
+		}
+		resolved, ok := s.resolve(schema.Ref)
+		if !ok {
+			*problems = append(*problems, fmt.Sprintf("%s: unresolved $ref %s", path, schema.Ref))
+			return
+		}
+		following[schema.Ref] = true
+		s.validate(path, value, resolved, problems, following)
+		delete(following, schema.Ref)
+		return
+	}
+	for _, part := range schema.AllOf {
+		s.validate(path, value, part, problems, following)
+	}
+
+	if value == nil {
+		if schema.Type != "" && !schema.Nullable {
+			*problems = append(*problems, fmt.Sprintf("%s: expected %s, got null", path, schema.Type))
+		}
+		return
+	}
+
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			*problems = append(*problems, fmt.Sprintf("%s: expected object, got %s", path, kind(value)))
+			return
+		}
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				*problems = append(*problems, fmt.Sprintf("%s: missing required property %q", path, name))
+			}
+		}
+		names := make([]string, 0, len(schema.Properties))
+		for name := range schema.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, ok := object[name]; ok {
+				s.validate(path+"."+name, property, schema.Properties[name], problems, map[string]bool{})
+			}
+		}
+		if schema.AdditionalProperties != nil {
+			var additional []string
+			for name := range object {
+				if _, ok := schema.Properties[name]; !ok {
+					additional = append(additional, name)
+				}
+			}
+			sort.Strings(additional)
+			for _, name := range additional {
+				s.validate(path+"."+name, object[name], schema.AdditionalProperties, problems, map[string]bool{})
+			}
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			*problems = append(*problems, fmt.Sprintf("%s: expected array, got %s", path, kind(value)))
+			return
+		}
+		for i, item := range items {
+			s.validate(fmt.Sprintf("%s[%d]", path, i), item, schema.Items, problems, map[string]bool{})
+		}
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			*problems = append(*problems, fmt.Sprintf("%s: expected string, got %s", path, kind(value)))
+			return
+		}
+		if schema.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, text); err != nil {
+				*problems = append(*problems, fmt.Sprintf("%s: %q is not an RFC 3339 date-time", path, text))
+			}
+		}
+		if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, any(text)) {
+			*problems = append(*problems, fmt.Sprintf("%s: %q is not one of %v", path, text, schema.Enum))
+		}
+	case "number", "integer":
+		number, ok := value.(float64)
+		if !ok {
+			*problems = append(*problems, fmt.Sprintf("%s: expected %s, got %s", path, schema.Type, kind(value)))
+			return
+		}
+		if schema.Type == "integer" && number != math.Trunc(number) {
+			*problems = append(*problems, fmt.Sprintf("%s: expected integer, got %v", path, number))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			*problems = append(*problems, fmt.Sprintf("%s: expected boolean, got %s", path, kind(value)))
+		}
+	}
+}
+
+// resolve returns the component schema a local reference such as "#/components/schemas/Product" names
+func (s *Spec) resolve(ref string) (*Schema, bool) {
+	name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+	if !ok {
+		return nil, false
+	}
+	schema, ok := s.Components.Schemas[name]
+	return schema, ok
+}
+
+// kind names the JSON type of a decoded value
+func kind(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,93 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateJSON(t *testing.T) {
+	spec, err := Load(productAPIPath)
+	require.NoError(t, err)
+	product, _ := spec.ResponseSchema("GET /products/{id}", 200)
+	list, _ := spec.ResponseSchema("GET /products", 200)
+	notFound, _ := spec.ResponseSchema("GET /products/{id}", 404)
+	health, _ := spec.ResponseSchema("GET /health", 503)
+
+	tests := []struct {
+		name     string
+		schema   *Schema
+		body     string
+		problems []string
+	}{
+		{"Valid product", product, `{"id":"p-1","name":"Laptop","price":1299.99,"createdAt":"2024-06-01T12:00:00Z"}`, nil},
+		{"Missing and mistyped fields", product, `{"id":"p-1","price":"free","createdAt":"yesterday"}`, []string{
+			`$: missing required property "name"`,
+			`$.createdAt: "yesterday" is not an RFC 3339 date-time`,
+			"$.price: expected number, got string",
+		}},
+		{"Product list", list, `{"products":[{"id":"p-1","name":"Laptop","price":10},{"id":"p-2","name":null,"price":5}]}`, []string{
+			"$.products[1].name: expected string, got null",
+		}},
+		{"List body that is an array", list, `[]`, []string{"$: expected object, got array"}},
+		{"Error body", notFound, `{"error":"HTTP 404","message":"Product not found","statusCode":404}`, nil},
+		{"Error status that is not an integer", notFound, `{"error":"HTTP 404","message":"Product not found","statusCode":404.5}`, []string{
+			"$.statusCode: expected integer, got 404.5",
+		}},
+		{"Health with dependencies", health, `{"status":"degraded","service":"product-service","version":"unknown","uptimeSeconds":42,` +
+			`"dependencies":{"dynamodb":{"status":"ok","latencyMs":12},"eventbridge":{"status":"degraded","latencyMs":3000,"error":"timed out"}}}`, nil},
+		{"Health with an unknown status and malformed dependency", health, `{"status":"sick","service":"product-service","version":"unknown","uptimeSeconds":42,` +
+			`"dependencies":{"dynamodb":{"status":"ok","latencyMs":12},"eventbridge":{"status":"down"}}}`, []string{
+			`$.dependencies.eventbridge: missing required property "latencyMs"`,
+			`$.dependencies.eventbridge.status: "down" is not one of [ok degraded]`,
+			`$.status: "sick" is not one of [healthy degraded]`,
+		}},
+		{"Not JSON", product, `<html>Internal Server Error</html>`, []string{
+			"body is not JSON: invalid character '<' looking for beginning of value",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.problems, spec.ValidateJSON([]byte(tt.body), tt.schema))
+		})
+	}
+}
+
+func TestValidateUnresolvedRef(t *testing.T) {
+	spec, err := Parse([]byte(`paths: {}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"$: unresolved $ref #/components/schemas/Missing"},
+		spec.Validate(map[string]any{}, &Schema{Ref: "#/components/schemas/Missing"}))
+}
+
+func TestValidateRecursiveSchema(t *testing.T) {
+	spec, err := Parse([]byte(`
+paths: {}
+components:
+  schemas:
+    Category:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string}
+        parent: {$ref: '#/components/schemas/Category'}
+`))
+	require.NoError(t, err)
+
+	value := map[string]any{"name": "Laptops", "parent": map[string]any{"name": 3.0}}
+	assert.Equal(t, []string{"$.parent.name: expected string, got number"},
+		spec.Validate(value, &Schema{Ref: "#/components/schemas/Category"}))
+}
+
+func TestValidateRefCycle(t *testing.T) {
+	spec, err := Parse([]byte(`
+paths: {}
+components:
+  schemas:
+    A: {$ref: '#/components/schemas/B'}
+    B: {allOf: [{$ref: '#/components/schemas/A'}]}
+`))
+	require.NoError(t, err)
+	assert.Empty(t, spec.Validate("anything", &Schema{Ref: "#/components/schemas/A"}), "A cycle of refs terminates")
+}
@@ -0,0 +1,175 @@
+// Package orderprops generates random orders for property-based checks of the order-processing workflow and
+// states the invariants every run must keep. Valid orders cover amount boundaries, long item lists, and unicode
+// customer ids; invalid orders are valid ones broken in one way the validation step must reject. Outcomes of
+// random orders are not predicted exactly: a valid order may still be declined or find too little stock, but
+// no order may be charged twice or notified twice, and an invalid one may not be charged at all.
+package orderprops
+
+import (
+	"fmt"
+	"math"
+	"unicode"
+
+	"pgregory.net/rapid"
+
+	"github.com/lambda-java-template/tests/internal/payloads"
+)
+
+// Terminal states the invariants refer to
+const (
+	Succeeded = "OrderSuccess"
+	Rejected  = "ValidationFailed"
+	Failed    = "ProcessingFailed"
+)
+
+// MaxItems is the longest item list generated; at about 80 bytes an item it keeps the order far below the
+// 256 KB Step Functions payload limit
+const MaxItems = 500
+
+// MaxPrice is the highest item price generated, the price the payment limit scenario is declined for
+const MaxPrice = 99999999.99
+
+// MaxQuantity is the highest item quantity generated, the largest the Java handlers' int holds
+const MaxQuantity = math.MaxInt32
+
+// Defects are the ways an invalid order is broken, each one the validation step must reject
+var Defects = []string{"no items", "no customer id", "item without product id", "item quantity below 1", "negative item price"}
+
+// Orders generates valid and invalid orders in about equal numbers. Customer and product ids start with
+// namespace; the caller sets OrderID.
+func Orders(namespace string) *rapid.Generator[payloads.Order] {
+	return rapid.OneOf(ValidOrders(namespace), InvalidOrders(namespace))
+}
+
+// ValidOrders generates orders the validation step must accept
+func ValidOrders(namespace string) *rapid.Generator[payloads.Order] {
+	customerSuffix := rapid.StringOfN(rapid.RuneFrom(nil, unicode.L, unicode.M, unicode.N, unicode.So), 1, 40, -1)
+	// Short lists are the common case; long ones check the workflow copes with what a cart may hold
+	itemCount := rapid.OneOf(rapid.IntRange(1, 5), rapid.IntRange(100, MaxItems))
+	item := validItem(namespace)
+
+	return rapid.Custom(func(t *rapid.T) payloads.Order {
+		order := payloads.Order{CustomerID: namespace + customerSuffix.Draw(t, "customerSuffix")}
+		count := itemCount.Draw(t, "itemCount")
+		for i := 0; i < count; i++ {
+			order.Items = append(order.Items, item.Draw(t, fmt.Sprintf("item%d", i)))
+		}
+		return order
+	})
+}
+
+// validItem generates an item with a quantity and price at or between their boundaries
+func validItem(namespace string) *rapid.Generator[payloads.OrderItem] {
+	quantity := rapid.OneOf(rapid.Just(1), rapid.IntRange(1, 1000), rapid.Just(MaxQuantity))
+	cents := rapid.Map(rapid.IntRange(1, int(MaxPrice*100)), func(n int) float64 { return float64(n) / 100 })
+	price := rapid.OneOf(rapid.Just(0.01), cents, rapid.Just(MaxPrice))
+	product := rapid.IntRange(1, 20)
+
+	return rapid.Custom(func(t *rapid.T) payloads.OrderItem {
+		return payloads.OrderItem{
+			ProductID: fmt.Sprintf("%sproduct-%d", namespace, product.Draw(t, "product")),
+			Quantity:  quantity.Draw(t, "quantity"),
+			Price:     price.Draw(t, "price"),
+		}
+	})
+}
+
+// InvalidOrders generates valid orders with one of Defects
+func InvalidOrders(namespace string) *rapid.Generator[payloads.Order] {
+	valid := ValidOrders(namespace)
+	return rapid.Custom(func(t *rapid.T) payloads.Order {
+		order := valid.Draw(t, "order")
+		item := rapid.IntRange(0, len(order.Items)-1).Draw(t, "defectiveItem")
+
+		switch rapid.SampledFrom(Defects).Draw(t, "defect") {
+		case "no items":
+			order.Items = rapid.SampledFrom([][]payloads.OrderItem{nil, {}}).Draw(t, "items")
+		case "no customer id":
+			order.CustomerID = ""
+		case "item without product id":
+			order.Items[item].ProductID = ""
+		case "item quantity below 1":
+			order.Items[item].Quantity = rapid.IntRange(math.MinInt32, 0).Draw(t, "quantity")
+		case "negative item price":
+			order.Items[item].Price = -rapid.Float64Range(0.01, MaxPrice).Draw(t, "price")
+		}
+		return order
+	})
+}
+
+// Defect returns the first of Defects an order has, or "" for a valid order
+func Defect(order payloads.Order) string {
+	if len(order.Items) == 0 {
+		return "no items"
+	}
+	if order.CustomerID == "" {
+		return "no customer id"
+	}
+	for _, item := range order.Items {
+		switch {
+		case item.ProductID == "":
+			return "item without product id"
+		case item.Quantity < 1:
+			return "item quantity below 1"
+		case item.Price < 0:
+			return "negative item price"
+		}
+	}
+	return ""
+}
+
+// Describe summarizes an order for a failure message; the full input of a long order is too large to read
+func Describe(order payloads.Order) string {
+	total := 0.0
+	for _, item := range order.Items {
+		total += float64(item.Quantity) * item.Price
+	}
+	description := fmt.Sprintf("customer %q, %d items totalling %.2f", order.CustomerID, len(order.Items), total)
+	if defect := Defect(order); defect != "" {
+		description += ", invalid: " + defect
+	}
+	return description
+}
+
+// ChargeViolations describes how a run of order that ended in terminal and wrote charges payment audit records
+// broke the workflow's invariants: an invalid order must be rejected without reaching the payment step, a
+// valid one must not be rejected or make a step fail, and no order may be charged more than once
+func ChargeViolations(order payloads.Order, terminal string, charges int) []string {
+	var violations []string
+	if defect := Defect(order); defect != "" {
+		if terminal == Succeeded {
+			violations = append(violations, fmt.Sprintf("invalid order (%s) ended in %s", defect, terminal))
+		}
+		if charges > 0 {
+			violations = append(violations, fmt.Sprintf("invalid order (%s) reached the payment step %d times", defect, charges))
+		}
+	} else if terminal == Rejected || terminal == Failed {
+		violations = append(violations, fmt.Sprintf("valid order ended in %s", terminal))
+	}
+	if charges > 1 {
+		violations = append(violations, fmt.Sprintf("order was charged %d times", charges))
+	}
+	return violations
+}
+
+// NotificationViolations describes how the notifications an order that ended in terminal was sent, by
+// notificationType, broke the workflow's invariants: a completed order is sent exactly one notification, a
+// confirmation, and no order is sent more than one or confirmed without completing
+func NotificationViolations(terminal string, notificationTypes []string, confirmationType string) []string {
+	var violations []string
+	switch {
+	case terminal == Succeeded && len(notificationTypes) == 0:
+		violations = append(violations, "completed order was sent no notification")
+	case len(notificationTypes) > 1:
+		violations = append(violations, fmt.Sprintf("order was sent %d notifications: %v", len(notificationTypes), notificationTypes))
+	}
+	for _, notificationType := range notificationTypes {
+		if terminal == Succeeded && notificationType != confirmationType {
+			violations = append(violations, fmt.Sprintf("completed order was sent %s instead of %s", notificationType, confirmationType))
+		}
+		if terminal != Succeeded && notificationType == confirmationType {
+			violations = append(violations, fmt.Sprintf("order ended in %s but was sent %s", terminal, confirmationType))
+		}
+	}
+	return violations
+}
@@ -0,0 +1,89 @@
+package orderprops
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"pgregory.net/rapid"
+
+	"github.com/lambda-java-template/tests/internal/payloads"
+)
+
+func TestValidOrdersHaveNoDefect(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		order := ValidOrders("itest-").Draw(t, "order")
+		if defect := Defect(order); defect != "" {
+			t.Fatalf("generated valid order has defect %q: %s", defect, Describe(order))
+		}
+
+		data, err := json.Marshal(order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data) > 256*1024 {
+			t.Fatalf("generated order is %d bytes, above the Step Functions payload limit", len(data))
+		}
+	})
+}
+
+func TestInvalidOrdersHaveADefect(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		order := InvalidOrders("itest-").Draw(t, "order")
+		if Defect(order) == "" {
+			t.Fatalf("generated invalid order has no defect: %s", Describe(order))
+		}
+	})
+}
+
+func TestOrdersAreReproducibleFromASeed(t *testing.T) {
+	orders := Orders("itest-")
+	assert.Equal(t, orders.Example(42), orders.Example(42))
+}
+
+func TestDefect(t *testing.T) {
+	valid := payloads.SampleOrder("itest-", "order-1")
+	assert.Empty(t, Defect(valid))
+
+	withItem := func(change func(*payloads.OrderItem)) payloads.Order {
+		order := payloads.SampleOrder("itest-", "order-1")
+		change(&order.Items[0])
+		return order
+	}
+	assert.Equal(t, "no items", Defect(payloads.Order{CustomerID: "itest-customer", Items: []payloads.OrderItem{}}))
+	assert.Equal(t, "no customer id", Defect(payloads.Order{Items: valid.Items}))
+	assert.Equal(t, "item without product id", Defect(withItem(func(item *payloads.OrderItem) { item.ProductID = "" })))
+	assert.Equal(t, "item quantity below 1", Defect(withItem(func(item *payloads.OrderItem) { item.Quantity = 0 })))
+	assert.Equal(t, "negative item price", Defect(withItem(func(item *payloads.OrderItem) { item.Price = -0.01 })))
+	assert.Empty(t, Defect(withItem(func(item *payloads.OrderItem) { item.Price = 0 })), "a free item is valid")
+}
+
+func TestChargeViolations(t *testing.T) {
+	valid := payloads.SampleOrder("itest-", "order-1")
+	invalid := payloads.Order{CustomerID: "itest-customer"}
+
+	assert.Empty(t, ChargeViolations(valid, Succeeded, 1))
+	assert.Empty(t, ChargeViolations(valid, "PaymentDeclined", 1))
+	assert.Empty(t, ChargeViolations(valid, "InventoryUnavailable", 1))
+	assert.Empty(t, ChargeViolations(invalid, Rejected, 0))
+
+	assert.Equal(t, []string{"invalid order (no items) ended in OrderSuccess", "invalid order (no items) reached the payment step 1 times"},
+		ChargeViolations(invalid, Succeeded, 1))
+	assert.Equal(t, []string{"valid order ended in ValidationFailed"}, ChargeViolations(valid, Rejected, 0))
+	assert.Equal(t, []string{"valid order ended in ProcessingFailed"}, ChargeViolations(valid, Failed, 1))
+	assert.Equal(t, []string{"order was charged 2 times"}, ChargeViolations(valid, Succeeded, 2))
+}
+
+func TestNotificationViolations(t *testing.T) {
+	assert.Empty(t, NotificationViolations(Succeeded, []string{"ORDER_CONFIRMATION"}, "ORDER_CONFIRMATION"))
+	assert.Empty(t, NotificationViolations(Rejected, nil, "ORDER_CONFIRMATION"))
+	assert.Empty(t, NotificationViolations("PaymentDeclined", []string{"PAYMENT_DECLINED"}, "ORDER_CONFIRMATION"))
+
+	assert.Equal(t, []string{"completed order was sent no notification"}, NotificationViolations(Succeeded, nil, "ORDER_CONFIRMATION"))
+	assert.Equal(t, []string{"order was sent 2 notifications: [ORDER_CONFIRMATION ORDER_CONFIRMATION]"},
+		NotificationViolations(Succeeded, []string{"ORDER_CONFIRMATION", "ORDER_CONFIRMATION"}, "ORDER_CONFIRMATION"))
+	assert.Equal(t, []string{"completed order was sent ORDER_SHIPPED instead of ORDER_CONFIRMATION"},
+		NotificationViolations(Succeeded, []string{"ORDER_SHIPPED"}, "ORDER_CONFIRMATION"))
+	assert.Equal(t, []string{"order ended in ValidationFailed but was sent ORDER_CONFIRMATION"},
+		NotificationViolations(Rejected, []string{"ORDER_CONFIRMATION"}, "ORDER_CONFIRMATION"))
+}
@@ -0,0 +1,170 @@
+// Package orphans finds tagged resources of a project that Terraform no longer manages, and deletes
+// those left behind by ephemeral namespaces.
+//
+// Managed resources are read from `terraform show -json`. A tagged resource is an orphan when neither
+// its ARN nor its name appears among the identifiers of the managed resources in the state.
+package orphans
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+
+	"github.com/lambda-java-template/tests/internal/inventory"
+)
+
+// identifierAttributes are the state attributes that identify a resource to AWS APIs
+var identifierAttributes = []string{"arn", "id", "name", "function_name", "alarm_name"}
+
+// sweptTypes are the service/type pairs the sweep considers, and can delete
+var sweptTypes = map[string]bool{
+	"lambda/function":     true,
+	"dynamodb/table":      true,
+	"states/stateMachine": true,
+	"apigateway/api":      true,
+	"logs/log-group":      true,
+	"cloudwatch/alarm":    true,
+}
+
+// state is the subset of `terraform show -json` output the sweep reads
+type state struct {
+	Values *struct {
+		RootModule module `json:"root_module"`
+	} `json:"values"`
+}
+
+type module struct {
+	Resources []struct {
+		Mode   string         `json:"mode"`
+		Values map[string]any `json:"values"`
+	} `json:"resources"`
+	ChildModules []module `json:"child_modules"`
+}
+
+// ShowState returns `terraform show -json` of the Terraform working directory dir
+func ShowState(ctx context.Context, dir string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "terraform", "show", "-json")
+	cmd.Dir = dir
+	data, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("reading Terraform state in %s: %w", dir, err)
+	}
+	return data, nil
+}
+
+// ManagedIdentifiers returns the ARNs, names, and ids of every managed resource in a `terraform show -json` document
+func ManagedIdentifiers(showJSON []byte) (map[string]bool, error) {
+	var document state
+	if err := json.Unmarshal(showJSON, &document); err != nil {
+		return nil, fmt.Errorf("parsing Terraform state: %w", err)
+	}
+	if document.Values == nil {
+		return nil, errors.New("Terraform state has no values, so the configuration has not been applied")
+	}
+
+	identifiers := map[string]bool{}
+	collectIdentifiers(document.Values.RootModule, identifiers)
+	return identifiers, nil
+}
+
+// collectIdentifiers adds the identifiers of a module's managed resources and those of its child modules
+func collectIdentifiers(m module, identifiers map[string]bool) {
+	for _, resource := range m.Resources {
+		if resource.Mode != "managed" {
+			continue
+		}
+		for _, attribute := range identifierAttributes {
+			if value, ok := resource.Values[attribute].(string); ok && value != "" {
+				identifiers[value] = true
+			}
+		}
+	}
+	for _, child := range m.ChildModules {
+		collectIdentifiers(child, identifiers)
+	}
+}
+
+// Find returns the swept resources whose ARN and name are both missing from managed
+func Find(resources []inventory.Resource, managed map[string]bool) []inventory.Resource {
+	var orphans []inventory.Resource
+	for _, resource := range resources {
+		if !sweptTypes[resource.Service+"/"+resource.Type] {
+			continue
+		}
+		if !managed[resource.ARN] && !managed[resource.Name] {
+			orphans = append(orphans, resource)
+		}
+	}
+	return orphans
+}
+
+// Deletable reports whether an orphan belongs to an ephemeral namespace, whose Terraform state is gone
+// once its test run has failed, and so may be deleted
+func Deletable(resource inventory.Resource) bool {
+	return resource.Tags["Ephemeral"] == "true" && sweptTypes[resource.Service+"/"+resource.Type]
+}
+
+// Clients are the AWS clients used to delete orphans
+type Clients struct {
+	Lambda        *lambda.Client
+	DynamoDB      *dynamodb.Client
+	StepFunctions *sfn.Client
+	APIGateway    *apigatewayv2.Client
+	Logs          *cloudwatchlogs.Client
+	CloudWatch    *cloudwatch.Client
+}
+
+// NewClients creates the clients for an AWS configuration
+func NewClients(cfg aws.Config) Clients {
+	return Clients{
+		Lambda:        lambda.NewFromConfig(cfg),
+		DynamoDB:      dynamodb.NewFromConfig(cfg),
+		StepFunctions: sfn.NewFromConfig(cfg),
+		APIGateway:    apigatewayv2.NewFromConfig(cfg),
+		Logs:          cloudwatchlogs.NewFromConfig(cfg),
+		CloudWatch:    cloudwatch.NewFromConfig(cfg),
+	}
+}
+
+// Delete deletes an orphan that Deletable allows
+func Delete(ctx context.Context, clients Clients, resource inventory.Resource) error {
+	if !Deletable(resource) {
+		return fmt.Errorf("%s is not an orphan of an ephemeral namespace", resource.ARN)
+	}
+	return DeleteResource(ctx, clients, resource)
+}
+
+// DeleteResource deletes a function, table, state machine, API, log group, or alarm without checking it is an orphan
+func DeleteResource(ctx context.Context, clients Clients, resource inventory.Resource) error {
+	var err error
+	switch resource.Service + "/" + resource.Type {
+	case "lambda/function":
+		_, err = clients.Lambda.DeleteFunction(ctx, &lambda.DeleteFunctionInput{FunctionName: aws.String(resource.ARN)})
+	case "dynamodb/table":
+		_, err = clients.DynamoDB.DeleteTable(ctx, &dynamodb.DeleteTableInput{TableName: aws.String(resource.Name)})
+	case "states/stateMachine":
+		_, err = clients.StepFunctions.DeleteStateMachine(ctx, &sfn.DeleteStateMachineInput{StateMachineArn: aws.String(resource.ARN)})
+	case "apigateway/api":
+		_, err = clients.APIGateway.DeleteApi(ctx, &apigatewayv2.DeleteApiInput{ApiId: aws.String(resource.Name)})
+	case "logs/log-group":
+		_, err = clients.Logs.DeleteLogGroup(ctx, &cloudwatchlogs.DeleteLogGroupInput{LogGroupName: aws.String(resource.Name)})
+	case "cloudwatch/alarm":
+		_, err = clients.CloudWatch.DeleteAlarms(ctx, &cloudwatch.DeleteAlarmsInput{AlarmNames: []string{resource.Name}})
+	default:
+		return fmt.Errorf("deleting %s: %s %s resources are not supported", resource.ARN, resource.Service, resource.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("deleting %s: %w", resource.ARN, err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package orphans
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/inventory"
+)
+
+func TestManagedIdentifiers(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "testdata", "terraform-show-dev.json"))
+	require.NoError(t, err)
+
+	managed, err := ManagedIdentifiers(data)
+	require.NoError(t, err)
+	assert.True(t, managed["lambda-java-template-dev-products"], "Tables are identified by name")
+	assert.True(t, managed["lambda-java-template-dev-product-service"], "Functions in child modules are collected")
+
+	_, err = ManagedIdentifiers([]byte(`{"format_version":"1.0"}`))
+	assert.Error(t, err, "A workspace that was never applied has no state to compare with")
+}
+
+func TestFind(t *testing.T) {
+	resource := func(arn string, tags map[string]string) inventory.Resource {
+		parsed, err := inventory.NewResource(arn)
+		require.NoError(t, err)
+		parsed.Tags = tags
+		return parsed
+	}
+	managedFunction := resource("arn:aws:lambda:us-east-1:123456789012:function:app-dev-product-service", nil)
+	managedTable := resource("arn:aws:dynamodb:us-east-1:123456789012:table/app-dev-products", nil)
+	leakedFunction := resource("arn:aws:lambda:us-east-1:123456789012:function:app-pr-7-product-service", map[string]string{"Ephemeral": "true"})
+	leakedMachine := resource("arn:aws:states:us-east-1:123456789012:stateMachine:app-dev-order-processing", map[string]string{"Ephemeral": "false"})
+	stage := resource("arn:aws:apigateway:us-east-1::/apis/abc123/stages/$default", nil)
+
+	managed := map[string]bool{managedFunction.ARN: true, "app-dev-products": true}
+	orphans := Find([]inventory.Resource{managedFunction, managedTable, leakedFunction, leakedMachine, stage}, managed)
+
+	assert.Equal(t, []inventory.Resource{leakedFunction, leakedMachine}, orphans, "Stages go with their API and are not swept")
+	assert.True(t, Deletable(leakedFunction))
+	assert.False(t, Deletable(leakedMachine), "Only ephemeral namespaces are deleted")
+}
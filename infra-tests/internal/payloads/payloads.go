@@ -0,0 +1,185 @@
+// Package payloads builds the events the template's functions receive from API Gateway and
+// Step Functions, so functions can be invoked directly without going through their triggers.
+package payloads
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// httpRequestContext is the requestContext of an HTTP API payload format 2.0 event
+type httpRequestContext struct {
+	AccountID  string `json:"accountId"`
+	APIID      string `json:"apiId"`
+	DomainName string `json:"domainName"`
+	HTTP       struct {
+		Method    string `json:"method"`
+		Path      string `json:"path"`
+		Protocol  string `json:"protocol"`
+		SourceIP  string `json:"sourceIp"`
+		UserAgent string `json:"userAgent"`
+	} `json:"http"`
+	RequestID string `json:"requestId"`
+	RouteKey  string `json:"routeKey"`
+	Stage     string `json:"stage"`
+	Time      string `json:"time"`
+	TimeEpoch int64  `json:"timeEpoch"`
+}
+
+// HTTPRequest is an HTTP API payload format 2.0 proxy integration event
+type HTTPRequest struct {
+	Version         string             `json:"version"`
+	RouteKey        string             `json:"routeKey"`
+	RawPath         string             `json:"rawPath"`
+	RawQueryString  string             `json:"rawQueryString"`
+	Headers         map[string]string  `json:"headers"`
+	PathParameters  map[string]string  `json:"pathParameters,omitempty"`
+	RequestContext  httpRequestContext `json:"requestContext"`
+	Body            string             `json:"body,omitempty"`
+	IsBase64Encoded bool               `json:"isBase64Encoded"`
+}
+
+// AuthorizerRequest is a REQUEST authorizer payload format 2.0 event
+type AuthorizerRequest struct {
+	Version        string             `json:"version"`
+	Type           string             `json:"type"`
+	RouteArn       string             `json:"routeArn"`
+	IdentitySource []string           `json:"identitySource"`
+	RouteKey       string             `json:"routeKey"`
+	RawPath        string             `json:"rawPath"`
+	RawQueryString string             `json:"rawQueryString"`
+	Headers        map[string]string  `json:"headers"`
+	RequestContext httpRequestContext `json:"requestContext"`
+}
+
+// NewHTTPRequest returns the event API Gateway sends for a request to routeKey, e.g. "GET /health".
+// path is the concrete request path; header names are lower-cased as HTTP APIs deliver them.
+func NewHTTPRequest(routeKey, path string, headers map[string]string, body string) HTTPRequest {
+	method, _, _ := strings.Cut(routeKey, " ")
+	lowered := make(map[string]string, len(headers)+1)
+	for name, value := range headers {
+		lowered[strings.ToLower(name)] = value
+	}
+	if body != "" && lowered["content-type"] == "" {
+		lowered["content-type"] = "application/json"
+	}
+
+	return HTTPRequest{
+		Version:        "2.0",
+		RouteKey:       routeKey,
+		RawPath:        path,
+		Headers:        lowered,
+		RequestContext: newRequestContext(method, path, routeKey),
+		Body:           body,
+	}
+}
+
+// NewAuthorizerRequest returns the event the API key authorizer receives for a request to routeKey
+func NewAuthorizerRequest(routeKey, path string, headers map[string]string) AuthorizerRequest {
+	method, _, _ := strings.Cut(routeKey, " ")
+	lowered := make(map[string]string, len(headers))
+	var identitySource []string
+	for name, value := range headers {
+		lowered[strings.ToLower(name)] = value
+		if strings.EqualFold(name, "x-api-key") {
+			identitySource = append(identitySource, value)
+		}
+	}
+
+	return AuthorizerRequest{
+		Version:        "2.0",
+		Type:           "REQUEST",
+		RouteArn:       fmt.Sprintf("arn:aws:execute-api:us-east-1:123456789012:infratests/$default/%s%s", method, path),
+		IdentitySource: identitySource,
+		RouteKey:       routeKey,
+		RawPath:        path,
+		Headers:        lowered,
+		RequestContext: newRequestContext(method, path, routeKey),
+	}
+}
+
+// newRequestContext returns a request context for a direct invocation by the test suite
+func newRequestContext(method, path, routeKey string) httpRequestContext {
+	now := time.Now().UTC()
+	requestContext := httpRequestContext{
+		AccountID:  "123456789012",
+		APIID:      "infratests",
+		DomainName: "infratests.execute-api.us-east-1.amazonaws.com",
+		RequestID:  fmt.Sprintf("infra-tests-%d", now.UnixNano()),
+		RouteKey:   routeKey,
+		Stage:      "$default",
+		Time:       now.Format("02/Jan/2006:15:04:05 -0700"),
+		TimeEpoch:  now.UnixMilli(),
+	}
+	requestContext.HTTP.Method = method
+	requestContext.HTTP.Path = path
+	requestContext.HTTP.Protocol = "HTTP/1.1"
+	requestContext.HTTP.SourceIP = "127.0.0.1"
+	requestContext.HTTP.UserAgent = "infra-tests"
+	return requestContext
+}
+
+// ProxyResponse is the result a function returns to an HTTP API proxy integration
+type ProxyResponse struct {
+	StatusCode      int               `json:"statusCode"`
+	Headers         map[string]string `json:"headers"`
+	Body            string            `json:"body"`
+	IsBase64Encoded bool              `json:"isBase64Encoded"`
+}
+
+// ParseProxyResponse decodes a proxy integration result and rejects results without a status code
+func ParseProxyResponse(data []byte) (ProxyResponse, error) {
+	var response ProxyResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return ProxyResponse{}, fmt.Errorf("parsing proxy response: %w", err)
+	}
+	if response.StatusCode == 0 {
+		return ProxyResponse{}, fmt.Errorf("proxy response has no statusCode: %s", data)
+	}
+	return response, nil
+}
+
+// AuthorizerResponse is the simple response format of an HTTP API Lambda authorizer
+type AuthorizerResponse struct {
+	IsAuthorized *bool          `json:"isAuthorized"`
+	Context      map[string]any `json:"context"`
+}
+
+// ParseAuthorizerResponse decodes an authorizer result and rejects results without isAuthorized
+func ParseAuthorizerResponse(data []byte) (AuthorizerResponse, error) {
+	var response AuthorizerResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return AuthorizerResponse{}, fmt.Errorf("parsing authorizer response: %w", err)
+	}
+	if response.IsAuthorized == nil {
+		return AuthorizerResponse{}, fmt.Errorf("authorizer response has no isAuthorized: %s", data)
+	}
+	return response, nil
+}
+
+// OrderItem is one line of an order
+type OrderItem struct {
+	ProductID string  `json:"productId"`
+	Quantity  int     `json:"quantity"`
+	Price     float64 `json:"price"`
+}
+
+// Order is the input of the order-processing workflow and the task input of its step functions
+type Order struct {
+	OrderID    string      `json:"orderId"`
+	CustomerID string      `json:"customerId"`
+	Items      []OrderItem `json:"items"`
+}
+
+// SampleOrder returns a one-item order whose customer and product ids start with namespace
+func SampleOrder(namespace, orderID string) Order {
+	return Order{
+		OrderID:    orderID,
+		CustomerID: namespace + "customer",
+		Items: []OrderItem{
+			{ProductID: namespace + "product-1", Quantity: 1, Price: 19.99},
+		},
+	}
+}
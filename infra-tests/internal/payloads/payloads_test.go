@@ -0,0 +1,66 @@
+package payloads
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPRequest(t *testing.T) {
+	request := NewHTTPRequest("GET /products/{id}", "/products/itest-1", map[string]string{"X-API-Key": "key"}, "")
+
+	data, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "2.0", decoded["version"])
+	assert.Equal(t, "/products/itest-1", decoded["rawPath"])
+	assert.Equal(t, map[string]any{"x-api-key": "key"}, decoded["headers"], "Header names are lower-cased")
+
+	requestContext := decoded["requestContext"].(map[string]any)
+	assert.Equal(t, "$default", requestContext["stage"])
+	assert.Equal(t, "GET", requestContext["http"].(map[string]any)["method"])
+}
+
+func TestNewHTTPRequestDefaultsJSONContentType(t *testing.T) {
+	request := NewHTTPRequest("POST /products", "/products", nil, `{"name":"Widget"}`)
+	assert.Equal(t, "application/json", request.Headers["content-type"])
+}
+
+func TestNewAuthorizerRequest(t *testing.T) {
+	request := NewAuthorizerRequest("GET /products", "/products", map[string]string{"X-API-Key": "key"})
+	assert.Equal(t, "REQUEST", request.Type)
+	assert.Equal(t, []string{"key"}, request.IdentitySource)
+	assert.Equal(t, "key", request.Headers["x-api-key"])
+}
+
+func TestParseProxyResponse(t *testing.T) {
+	response, err := ParseProxyResponse([]byte(`{"statusCode":200,"headers":{"Content-Type":"application/json"},"body":"{\"status\":\"healthy\"}"}`))
+	require.NoError(t, err)
+	assert.Equal(t, 200, response.StatusCode)
+	assert.JSONEq(t, `{"status":"healthy"}`, response.Body)
+
+	_, err = ParseProxyResponse([]byte(`{"body":"no status"}`))
+	assert.Error(t, err)
+}
+
+func TestParseAuthorizerResponse(t *testing.T) {
+	response, err := ParseAuthorizerResponse([]byte(`{"isAuthorized":false,"context":{"apiKey":"invalid"}}`))
+	require.NoError(t, err)
+	assert.False(t, *response.IsAuthorized)
+	assert.Equal(t, "invalid", response.Context["apiKey"])
+
+	_, err = ParseAuthorizerResponse([]byte(`{"policyDocument":{}}`))
+	assert.Error(t, err)
+}
+
+func TestSampleOrder(t *testing.T) {
+	order := SampleOrder("itest-", "itest-order-1")
+	assert.Equal(t, "itest-order-1", order.OrderID)
+	assert.Equal(t, "itest-customer", order.CustomerID)
+	require.Len(t, order.Items, 1)
+	assert.Equal(t, "itest-product-1", order.Items[0].ProductID)
+}
@@ -0,0 +1,172 @@
+// Package permissions checks the suite's credentials before validators run: that they belong to the partition of
+// the target region, and that IAM policy simulation allows the read APIs validators call on the deployment's
+// resources. A run with the wrong profile or a role missing a grant then stops with one message naming what is
+// wrong, instead of failing dozens of validators with AccessDenied.
+package permissions
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/lambda-java-template/tests/internal/discovery"
+)
+
+// Identity is the principal the suite's credentials resolve to, from STS GetCallerIdentity
+type Identity struct {
+	ARN       string
+	Partition string
+	Account   string
+	// RoleName is set for assumed-role sessions, whose ARN names the role without its path
+	RoleName string
+	// Root is set for the account's root user, which policies cannot restrict
+	Root bool
+}
+
+// ParseIdentity parses the ARN returned by GetCallerIdentity, e.g.
+// arn:aws:sts::123456789012:assumed-role/infra-tests/ci or arn:aws:iam::123456789012:user/alice
+func ParseIdentity(callerARN string) (Identity, error) {
+	parts := strings.SplitN(callerARN, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || parts[1] == "" || parts[4] == "" {
+		return Identity{}, fmt.Errorf("caller ARN %q is not an ARN", callerARN)
+	}
+	identity := Identity{ARN: callerARN, Partition: parts[1], Account: parts[4]}
+
+	switch resource := parts[5]; {
+	case resource == "root":
+		identity.Root = true
+	case parts[2] == "sts" && strings.HasPrefix(resource, "assumed-role/"):
+		segments := strings.Split(resource, "/")
+		if len(segments) != 3 || segments[1] == "" {
+			return Identity{}, fmt.Errorf("caller ARN %q names no role", callerARN)
+		}
+		identity.RoleName = segments[1]
+	case parts[2] == "iam" && (strings.HasPrefix(resource, "user/") || strings.HasPrefix(resource, "role/")):
+	default:
+		return Identity{}, fmt.Errorf("caller ARN %q is not a user, role, or assumed role", callerARN)
+	}
+	return identity, nil
+}
+
+// PolicySourceARN returns the ARN whose policies are simulated: the user or role itself. It is empty for assumed
+// roles, whose role ARN, path included, has to be looked up by RoleName, and for the root user.
+func (i Identity) PolicySourceARN() string {
+	if i.RoleName != "" || i.Root {
+		return ""
+	}
+	return i.ARN
+}
+
+// regionPattern matches region names such as us-east-1, ap-southeast-2, and us-gov-west-1
+var regionPattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d+$`)
+
+// RegionPartition returns the partition a region belongs to
+func RegionPartition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	case strings.HasPrefix(region, "us-iso-"):
+		return "aws-iso"
+	case strings.HasPrefix(region, "us-isob-"):
+		return "aws-iso-b"
+	default:
+		return "aws"
+	}
+}
+
+// CheckRegion returns an error when no region is configured, the region is malformed, or it lies in another
+// partition than the credentials, where none of its resources can be reached
+func (i Identity) CheckRegion(region string) error {
+	if region == "" {
+		return fmt.Errorf("no AWS region is configured; set TEST_AWS_REGION or AWS_REGION")
+	}
+	if !regionPattern.MatchString(region) {
+		return fmt.Errorf("AWS region %q is not a region name such as us-east-1", region)
+	}
+	if partition := RegionPartition(region); partition != i.Partition {
+		return fmt.Errorf("region %s is in the %s partition, but the credentials of %s are for %s", region, partition, i.ARN, i.Partition)
+	}
+	return nil
+}
+
+// Requirement is a resource and the read actions validators call on it
+type Requirement struct {
+	Resource string
+	Actions  []string
+}
+
+// Required returns the read actions validators call, on the deployment's resources where the service supports
+// resource-level permissions, so a policy scoped to the project's resources passes
+func Required(identity Identity, region string, resolver *discovery.Resolver) []Requirement {
+	arn := func(service, resource string) string {
+		return fmt.Sprintf("arn:%s:%s:%s:%s:%s", identity.Partition, service, region, identity.Account, resource)
+	}
+
+	var requirements []Requirement
+	for _, suffix := range []string{"product-service", "authorizer-service"} {
+		requirements = append(requirements, Requirement{
+			Resource: arn("lambda", "function:"+resolver.FunctionName(suffix)),
+			Actions:  []string{"lambda:GetFunction", "lambda:GetFunctionConfiguration", "lambda:GetFunctionConcurrency", "lambda:GetPolicy", "lambda:ListTags"},
+		})
+	}
+	for _, suffix := range []string{"products", "audit-logs"} {
+		requirements = append(requirements, Requirement{
+			Resource: arn("dynamodb", "table/"+resolver.TableName(suffix)),
+			Actions:  []string{"dynamodb:DescribeTable", "dynamodb:DescribeContinuousBackups", "dynamodb:DescribeTimeToLive", "dynamodb:ListTagsOfResource", "dynamodb:Query"},
+		})
+	}
+	return append(requirements,
+		Requirement{
+			Resource: arn("states", "stateMachine:"+resolver.ResourceName("order-processing")),
+			Actions:  []string{"states:DescribeStateMachine"},
+		},
+		// HTTP API resources carry no account
+		Requirement{
+			Resource: fmt.Sprintf("arn:%s:apigateway:%s::/apis", identity.Partition, region),
+			Actions:  []string{"apigateway:GET"},
+		},
+		Requirement{
+			Resource: fmt.Sprintf("arn:%s:apigateway:%s::/apis/*", identity.Partition, region),
+			Actions:  []string{"apigateway:GET"},
+		},
+		// DescribeAlarms supports no resource-level permissions
+		Requirement{
+			Resource: "*",
+			Actions:  []string{"cloudwatch:DescribeAlarms"},
+		},
+	)
+}
+
+// Denial is an action policy simulation did not allow on a resource
+type Denial struct {
+	Action   string
+	Resource string
+	// Decision is the simulator's verdict, explicitDeny or implicitDeny
+	Decision string
+}
+
+// Error returns nil without denials, or an error listing each one, sorted, with what to do about them
+func Error(identity Identity, denials []Denial) error {
+	if len(denials) == 0 {
+		return nil
+	}
+	sorted := append([]Denial{}, denials...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Action != sorted[j].Action {
+			return sorted[i].Action < sorted[j].Action
+		}
+		return sorted[i].Resource < sorted[j].Resource
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s is not allowed %d read calls the validators make:", identity.ARN, len(sorted))
+	for _, denial := range sorted {
+		fmt.Fprintf(&b, "\n  %s on %s (%s)", denial.Action, denial.Resource, denial.Decision)
+	}
+	b.WriteString("\ngrant them to the principal, or set TEST_ROLE_ARN to a role that has them")
+	return errors.New(b.String())
+}
@@ -0,0 +1,76 @@
+package permissions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/discovery"
+	"github.com/lambda-java-template/tests/internal/testconfig"
+)
+
+func TestParseIdentity(t *testing.T) {
+	assumed, err := ParseIdentity("arn:aws:sts::123456789012:assumed-role/infra-tests/ci-1234")
+	require.NoError(t, err)
+	assert.Equal(t, Identity{ARN: "arn:aws:sts::123456789012:assumed-role/infra-tests/ci-1234", Partition: "aws", Account: "123456789012", RoleName: "infra-tests"}, assumed)
+	assert.Empty(t, assumed.PolicySourceARN())
+
+	user, err := ParseIdentity("arn:aws-cn:iam::123456789012:user/ops/alice")
+	require.NoError(t, err)
+	assert.Equal(t, "aws-cn", user.Partition)
+	assert.Equal(t, "arn:aws-cn:iam::123456789012:user/ops/alice", user.PolicySourceARN())
+
+	root, err := ParseIdentity("arn:aws:iam::123456789012:root")
+	require.NoError(t, err)
+	assert.True(t, root.Root)
+	assert.Empty(t, root.PolicySourceARN())
+
+	for _, invalid := range []string{"", "infra-tests", "arn:aws:sts::123456789012:assumed-role/", "arn:aws:iam::123456789012:group/ops"} {
+		_, err := ParseIdentity(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestCheckRegion(t *testing.T) {
+	identity := Identity{ARN: "arn:aws:iam::123456789012:user/alice", Partition: "aws", Account: "123456789012"}
+
+	assert.NoError(t, identity.CheckRegion("us-east-1"))
+	assert.NoError(t, identity.CheckRegion("ap-southeast-2"))
+	assert.ErrorContains(t, identity.CheckRegion(""), "TEST_AWS_REGION")
+	assert.ErrorContains(t, identity.CheckRegion("virginia"), "not a region name")
+	assert.EqualError(t, identity.CheckRegion("cn-north-1"),
+		"region cn-north-1 is in the aws-cn partition, but the credentials of arn:aws:iam::123456789012:user/alice are for aws")
+
+	gov := Identity{ARN: "arn:aws-us-gov:iam::123456789012:user/alice", Partition: "aws-us-gov", Account: "123456789012"}
+	assert.NoError(t, gov.CheckRegion("us-gov-west-1"))
+}
+
+func TestRequiredScopesToDeployment(t *testing.T) {
+	resolver := discovery.NewWithClients(testconfig.Config{ProjectName: "demo", Environment: "dev"}, nil, nil)
+	identity := Identity{Partition: "aws", Account: "123456789012"}
+
+	resources := map[string][]string{}
+	for _, requirement := range Required(identity, "eu-west-1", resolver) {
+		resources[requirement.Resource] = requirement.Actions
+	}
+	assert.Contains(t, resources["arn:aws:lambda:eu-west-1:123456789012:function:demo-dev-product-service"], "lambda:GetFunction")
+	assert.Contains(t, resources["arn:aws:dynamodb:eu-west-1:123456789012:table/demo-dev-audit-logs"], "dynamodb:DescribeTable")
+	assert.Contains(t, resources["arn:aws:states:eu-west-1:123456789012:stateMachine:demo-dev-order-processing"], "states:DescribeStateMachine")
+	assert.Contains(t, resources["arn:aws:apigateway:eu-west-1::/apis/*"], "apigateway:GET")
+	assert.Contains(t, resources["*"], "cloudwatch:DescribeAlarms")
+}
+
+func TestError(t *testing.T) {
+	identity := Identity{ARN: "arn:aws:sts::123456789012:assumed-role/infra-tests/ci"}
+	assert.NoError(t, Error(identity, nil))
+
+	err := Error(identity, []Denial{
+		{Action: "states:DescribeStateMachine", Resource: "arn:aws:states:us-east-1:123456789012:stateMachine:demo-dev-order-processing", Decision: "implicitDeny"},
+		{Action: "lambda:GetFunction", Resource: "arn:aws:lambda:us-east-1:123456789012:function:demo-dev-product-service", Decision: "explicitDeny"},
+	})
+	assert.EqualError(t, err, `arn:aws:sts::123456789012:assumed-role/infra-tests/ci is not allowed 2 read calls the validators make:
+  lambda:GetFunction on arn:aws:lambda:us-east-1:123456789012:function:demo-dev-product-service (explicitDeny)
+  states:DescribeStateMachine on arn:aws:states:us-east-1:123456789012:stateMachine:demo-dev-order-processing (implicitDeny)
+grant them to the principal, or set TEST_ROLE_ARN to a role that has them`)
+}
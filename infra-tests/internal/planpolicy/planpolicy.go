@@ -0,0 +1,182 @@
+// Package planpolicy enforces deployment policies on a Terraform plan before it is applied.
+//
+// Plans are read from `terraform show -json <planfile>`. Each policy returns one gap per violation, so the
+// same checks run in CI through cmd/planpolicy and locally through `task tf:policy`.
+package planpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// openCIDRs are the destinations that expose a security group to the whole internet
+var openCIDRs = []string{"0.0.0.0/0", "::/0"}
+
+// upperBound matches a version constraint operator that stops the provider moving to a new major release
+var upperBound = regexp.MustCompile(`^(~>|<=?|=)?\s*v?\d`)
+
+// Plan is the subset of `terraform show -json` plan output the policies read
+type Plan struct {
+	ResourceChanges []ResourceChange `json:"resource_changes"`
+	Configuration   struct {
+		ProviderConfig map[string]ProviderConfig `json:"provider_config"`
+	} `json:"configuration"`
+}
+
+// ResourceChange is a planned change to one resource instance
+type ResourceChange struct {
+	Address string `json:"address"`
+	Mode    string `json:"mode"`
+	Type    string `json:"type"`
+	Change  struct {
+		Actions []string       `json:"actions"`
+		After   map[string]any `json:"after"`
+	} `json:"change"`
+}
+
+// ProviderConfig is a provider requirement of the configuration
+type ProviderConfig struct {
+	FullName          string `json:"full_name"`
+	VersionConstraint string `json:"version_constraint"`
+}
+
+// ShowPlan returns `terraform show -json` of the saved plan planFile in the Terraform working directory dir
+func ShowPlan(ctx context.Context, dir, planFile string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "terraform", "show", "-json", planFile)
+	cmd.Dir = dir
+	data, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("reading Terraform plan %s in %s: %w", planFile, dir, err)
+	}
+	return data, nil
+}
+
+// Parse decodes a `terraform show -json` plan document
+func Parse(planJSON []byte) (Plan, error) {
+	var plan Plan
+	if err := json.Unmarshal(planJSON, &plan); err != nil {
+		return Plan{}, fmt.Errorf("parsing Terraform plan: %w", err)
+	}
+	if plan.ResourceChanges == nil && plan.Configuration.ProviderConfig == nil {
+		return Plan{}, errors.New("document is not a Terraform plan; run terraform show -json on a saved plan file")
+	}
+	return plan, nil
+}
+
+// Evaluate runs every policy against a plan for environment
+func Evaluate(plan Plan, environment string) []string {
+	var gaps []string
+	if environment == "prod" {
+		gaps = append(gaps, DeletionGaps(plan)...)
+	}
+	gaps = append(gaps, OpenIngressGaps(plan)...)
+	return append(gaps, UnpinnedProviderGaps(plan)...)
+}
+
+// DeletionGaps reports every managed resource the plan deletes, including those it replaces
+func DeletionGaps(plan Plan) []string {
+	var gaps []string
+	for _, change := range plan.ResourceChanges {
+		if change.Mode == "data" || !slices.Contains(change.Change.Actions, "delete") {
+			continue
+		}
+		if slices.Contains(change.Change.Actions, "create") {
+			gaps = append(gaps, fmt.Sprintf("%s is replaced, which deletes it first", change.Address))
+		} else {
+			gaps = append(gaps, fmt.Sprintf("%s is deleted", change.Address))
+		}
+	}
+	return gaps
+}
+
+// OpenIngressGaps reports security group ingress the plan opens to 0.0.0.0/0 or ::/0, whether declared
+// inline on aws_security_group or as separate rule resources
+func OpenIngressGaps(plan Plan) []string {
+	var gaps []string
+	for _, change := range plan.ResourceChanges {
+		after := change.Change.After
+		if change.Mode == "data" || after == nil {
+			continue
+		}
+		var open []string
+		switch change.Type {
+		case "aws_security_group":
+			rules, _ := after["ingress"].([]any)
+			for _, rule := range rules {
+				if attributes, ok := rule.(map[string]any); ok {
+					open = append(open, openDestinations(attributes, "cidr_blocks", "ipv6_cidr_blocks")...)
+				}
+			}
+		case "aws_security_group_rule":
+			if after["type"] == "ingress" {
+				open = openDestinations(after, "cidr_blocks", "ipv6_cidr_blocks")
+			}
+		case "aws_vpc_security_group_ingress_rule":
+			open = openDestinations(after, "cidr_ipv4", "cidr_ipv6")
+		}
+		for _, cidr := range open {
+			gaps = append(gaps, fmt.Sprintf("%s allows ingress from %s", change.Address, cidr))
+		}
+	}
+	return gaps
+}
+
+// openDestinations returns the open CIDRs among the values of attributes, each a string or list of strings
+func openDestinations(values map[string]any, attributes ...string) []string {
+	var open []string
+	for _, attribute := range attributes {
+		var cidrs []any
+		switch value := values[attribute].(type) {
+		case string:
+			cidrs = []any{value}
+		case []any:
+			cidrs = value
+		}
+		for _, cidr := range cidrs {
+			if s, ok := cidr.(string); ok && slices.Contains(openCIDRs, s) {
+				open = append(open, s)
+			}
+		}
+	}
+	return open
+}
+
+// UnpinnedProviderGaps reports providers whose version constraint is missing or has no upper bound, so a
+// new major release could be installed by the next terraform init
+func UnpinnedProviderGaps(plan Plan) []string {
+	names := make([]string, 0, len(plan.Configuration.ProviderConfig))
+	for name := range plan.Configuration.ProviderConfig {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var gaps []string
+	for _, name := range names {
+		provider := plan.Configuration.ProviderConfig[name]
+		source := provider.FullName
+		if source == "" {
+			source = name
+		}
+		if !Pinned(provider.VersionConstraint) {
+			gaps = append(gaps, fmt.Sprintf("provider %s has version constraint %q, which is not pinned below a major release", source, provider.VersionConstraint))
+		}
+	}
+	return gaps
+}
+
+// Pinned reports whether a version constraint has an upper bound: ~>, <, <=, or an exact version
+func Pinned(constraint string) bool {
+	for _, part := range strings.Split(constraint, ",") {
+		if upperBound.MatchString(strings.TrimSpace(part)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package planpolicy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadPlan(t *testing.T) Plan {
+	data, err := os.ReadFile(filepath.Join("..", "..", "testdata", "terraform-plan-prod.json"))
+	require.NoError(t, err)
+	plan, err := Parse(data)
+	require.NoError(t, err)
+	return plan
+}
+
+func TestParse(t *testing.T) {
+	_, err := Parse([]byte(`{"format_version":"1.0","values":{}}`))
+	assert.Error(t, err, "State from terraform show without a plan file is not a plan")
+
+	_, err = Parse([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestDeletionGaps(t *testing.T) {
+	assert.Equal(t, []string{
+		"aws_dynamodb_table.audit_logs is replaced, which deletes it first",
+		"aws_cloudwatch_log_group.legacy is deleted",
+	}, DeletionGaps(loadPlan(t)))
+}
+
+func TestOpenIngressGaps(t *testing.T) {
+	assert.Equal(t, []string{
+		"aws_security_group.lambda allows ingress from 0.0.0.0/0",
+		"aws_security_group.lambda allows ingress from ::/0",
+		"aws_vpc_security_group_ingress_rule.https allows ingress from 0.0.0.0/0",
+	}, OpenIngressGaps(loadPlan(t)), "Open egress is allowed")
+}
+
+func TestUnpinnedProviderGaps(t *testing.T) {
+	assert.Equal(t, []string{
+		`provider registry.terraform.io/hashicorp/archive has version constraint "", which is not pinned below a major release`,
+		`provider registry.terraform.io/hashicorp/random has version constraint ">= 3.6", which is not pinned below a major release`,
+	}, UnpinnedProviderGaps(loadPlan(t)))
+}
+
+func TestPinned(t *testing.T) {
+	for constraint, pinned := range map[string]bool{
+		"~> 6.12":       true,
+		"6.12.0":        true,
+		"= 6.12.0":      true,
+		">= 6.0, < 7.0": true,
+		">= 6.0":        false,
+		"> 6.0, != 6.3": false,
+		"":              false,
+	} {
+		assert.Equal(t, pinned, Pinned(constraint), "%q", constraint)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	plan := loadPlan(t)
+	assert.Len(t, Evaluate(plan, "prod"), 7)
+	assert.Len(t, Evaluate(plan, "dev"), 5, "Deletions are only blocked in prod")
+}
@@ -0,0 +1,115 @@
+// Package preflight finds which components of the template a deployment has before validators run, so a suite
+// pointed at an account where only part of the template is deployed skips what is absent, saying what is
+// missing, instead of failing on the first not-found error.
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/smithy-go"
+
+	"github.com/lambda-java-template/tests/internal/discovery"
+)
+
+// Component is a part of the template validators depend on
+type Component string
+
+// Components of the template
+const (
+	ProductService    Component = "product-service function"
+	AuthorizerService Component = "authorizer-service function"
+	ProductsTable     Component = "products table"
+	AuditLogsTable    Component = "audit-logs table"
+	API               Component = "HTTP API"
+	OrderWorkflow     Component = "order-processing state machine"
+)
+
+// notFoundCodes are the error codes AWS services answer a lookup of a missing resource with
+var notFoundCodes = map[string]bool{
+	"ResourceNotFoundException":               true,
+	"NotFoundException":                       true,
+	"NotFound":                                true,
+	"StateMachineDoesNotExist":                true,
+	"ParameterNotFound":                       true,
+	"NoSuchEntity":                            true,
+	"NoSuchBucket":                            true,
+	"QueueDoesNotExist":                       true,
+	"AWS.SimpleQueueService.NonExistentQueue": true,
+}
+
+// IsNotFound reports whether err means a resource is not deployed: discovery.ErrNotFound, or an AWS error
+// whose code names a missing resource
+func IsNotFound(err error) bool {
+	if errors.Is(err, discovery.ErrNotFound) {
+		return true
+	}
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && notFoundCodes[apiErr.ErrorCode()]
+}
+
+// Probe looks up the resource of one component; Lookup returns a not-found error when it is absent
+type Probe struct {
+	Component Component
+	Resource  string
+	Lookup    func(ctx context.Context) error
+}
+
+// Result is the components a deployment lacks, with the resource looked up for each
+type Result struct {
+	Missing map[Component]string
+}
+
+// Run looks up every component. Errors other than not-found, such as denied access, are returned together,
+// since they say nothing about whether the component exists.
+func Run(ctx context.Context, probes []Probe) (Result, error) {
+	result := Result{Missing: map[Component]string{}}
+	var errs []error
+	for _, probe := range probes {
+		err := probe.Lookup(ctx)
+		switch {
+		case err == nil:
+		case IsNotFound(err):
+			result.Missing[probe.Component] = probe.Resource
+		default:
+			errs = append(errs, fmt.Errorf("looking up %s %s: %w", probe.Component, probe.Resource, err))
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// Absent returns the components of required the deployment lacks, in the order required lists them
+func (r Result) Absent(required []Component) []Component {
+	var absent []Component
+	for _, component := range required {
+		if _, ok := r.Missing[component]; ok {
+			absent = append(absent, component)
+		}
+	}
+	return absent
+}
+
+// SkipReason explains why a validator needing the absent components is skipped
+func (r Result) SkipReason(absent []Component) string {
+	parts := make([]string, 0, len(absent))
+	for _, component := range absent {
+		parts = append(parts, fmt.Sprintf("%s (%s)", component, r.Missing[component]))
+	}
+	return "Not deployed: " + strings.Join(parts, ", ")
+}
+
+// Summary lists the missing components, one per line, or returns "" when the deployment has them all
+func (r Result) Summary() string {
+	if len(r.Missing) == 0 {
+		return ""
+	}
+	lines := make([]string, 0, len(r.Missing))
+	for component, resource := range r.Missing {
+		lines = append(lines, fmt.Sprintf("  %s: %s", component, resource))
+	}
+	sort.Strings(lines)
+	return fmt.Sprintf("%d components of the template are not deployed:\n%s", len(lines), strings.Join(lines, "\n"))
+}
@@ -0,0 +1,63 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/discovery"
+)
+
+// found and missing are probe lookups of a present and an absent resource
+func found(context.Context) error { return nil }
+
+func missing(code string) func(context.Context) error {
+	return func(context.Context) error {
+		return fmt.Errorf("operation error: %w", &smithy.GenericAPIError{Code: code, Message: "not found"})
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	assert.True(t, IsNotFound(fmt.Errorf("API Gateway demo-dev-api: %w", discovery.ErrNotFound)))
+	assert.True(t, IsNotFound(missing("ResourceNotFoundException")(context.TODO())))
+	assert.True(t, IsNotFound(missing("StateMachineDoesNotExist")(context.TODO())))
+
+	assert.False(t, IsNotFound(missing("AccessDeniedException")(context.TODO())))
+	assert.False(t, IsNotFound(errors.New("connection reset")))
+	assert.False(t, IsNotFound(nil))
+}
+
+func TestRun(t *testing.T) {
+	result, err := Run(context.TODO(), []Probe{
+		{Component: ProductService, Resource: "demo-dev-product-service", Lookup: found},
+		{Component: ProductsTable, Resource: "demo-dev-products", Lookup: missing("ResourceNotFoundException")},
+		{Component: API, Resource: "demo-dev-api", Lookup: func(context.Context) error {
+			return fmt.Errorf("API Gateway demo-dev-api: %w", discovery.ErrNotFound)
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[Component]string{ProductsTable: "demo-dev-products", API: "demo-dev-api"}, result.Missing)
+
+	absent := result.Absent([]Component{ProductService, API, ProductsTable})
+	assert.Equal(t, []Component{API, ProductsTable}, absent)
+	assert.Equal(t, "Not deployed: HTTP API (demo-dev-api), products table (demo-dev-products)", result.SkipReason(absent))
+	assert.Empty(t, result.Absent([]Component{ProductService}))
+
+	assert.Equal(t, "2 components of the template are not deployed:\n  HTTP API: demo-dev-api\n  products table: demo-dev-products", result.Summary())
+	assert.Empty(t, Result{}.Summary())
+}
+
+func TestRunReturnsErrorsThatAreNotNotFound(t *testing.T) {
+	result, err := Run(context.TODO(), []Probe{
+		{Component: ProductService, Resource: "demo-dev-product-service", Lookup: missing("AccessDeniedException")},
+		{Component: OrderWorkflow, Resource: "demo-dev-order-processing", Lookup: missing("StateMachineDoesNotExist")},
+	})
+	assert.ErrorContains(t, err, "looking up product-service function demo-dev-product-service")
+	assert.Equal(t, map[Component]string{OrderWorkflow: "demo-dev-order-processing"}, result.Missing,
+		"Denied access says nothing about whether the function exists")
+}
@@ -0,0 +1,106 @@
+// Package quarantine retries known-flaky validators and tracks how often they fail
+package quarantine
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Result records every attempt made at one quarantined validator
+type Result struct {
+	Name       string
+	Attempts   int
+	Failures   int
+	LastOutput string
+}
+
+// Passed reports whether any attempt succeeded
+func (r Result) Passed() bool {
+	return r.Failures < r.Attempts
+}
+
+// Flaky reports whether the validator failed at least once before passing
+func (r Result) Flaky() bool {
+	return r.Failures > 0 && r.Passed()
+}
+
+// FlakeRate is the fraction of attempts that failed
+func (r Result) FlakeRate() float64 {
+	if r.Attempts == 0 {
+		return 0
+	}
+	return float64(r.Failures) / float64(r.Attempts)
+}
+
+// Status is "failed" when every attempt failed, "flaky" when one passed after a failure, and "passed" otherwise
+func (r Result) Status() string {
+	switch {
+	case !r.Passed():
+		return "failed"
+	case r.Flaky():
+		return "flaky"
+	}
+	return "passed"
+}
+
+// String summarizes the result on one line
+func (r Result) String() string {
+	return fmt.Sprintf("%s: %s after %d attempt(s), %d failure(s), flake rate %.0f%%",
+		r.Name, r.Status(), r.Attempts, r.Failures, r.FlakeRate()*100)
+}
+
+// Run calls attempt until it passes or maxAttempts is reached.
+// attempt returns whether it passed and the output worth keeping if it did not.
+func Run(name string, maxAttempts int, attempt func() (bool, string)) Result {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	result := Result{Name: name}
+	for result.Attempts < maxAttempts {
+		result.Attempts++
+		passed, output := attempt()
+		if passed {
+			break
+		}
+		result.Failures++
+		result.LastOutput = output
+	}
+	return result
+}
+
+// Report collects results from concurrent validators
+type Report struct {
+	mu      sync.Mutex
+	results []Result
+}
+
+// Add records a result
+func (r *Report) Add(result Result) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results = append(r.results, result)
+}
+
+// Result returns the recorded result of the validator with the given name
+func (r *Report) Result(name string) (Result, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, result := range r.results {
+		if result.Name == name {
+			return result, true
+		}
+	}
+	return Result{}, false
+}
+
+// Results returns the recorded results sorted by name
+func (r *Report) Results() []Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := append([]Result(nil), r.results...)
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+	return results
+}
@@ -0,0 +1,62 @@
+package quarantine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunStopsAtFirstPass(t *testing.T) {
+	calls := 0
+	result := Run("Eventually_Passes", 5, func() (bool, string) {
+		calls++
+		return calls == 2, "not yet"
+	})
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 2, result.Attempts)
+	assert.Equal(t, 1, result.Failures)
+	assert.True(t, result.Passed())
+	assert.True(t, result.Flaky())
+	assert.InDelta(t, 0.5, result.FlakeRate(), 0.001)
+	assert.Equal(t, "not yet", result.LastOutput)
+}
+
+func TestRunGivesUpAfterMaxAttempts(t *testing.T) {
+	result := Run("Always_Fails", 3, func() (bool, string) {
+		return false, "boom"
+	})
+
+	assert.Equal(t, 3, result.Attempts)
+	assert.False(t, result.Passed())
+	assert.False(t, result.Flaky())
+	assert.Equal(t, 1.0, result.FlakeRate())
+}
+
+func TestReportSortsResults(t *testing.T) {
+	var report Report
+	report.Add(Result{Name: "b", Attempts: 1})
+	report.Add(Result{Name: "a", Attempts: 1})
+
+	results := report.Results()
+	assert.Equal(t, "a", results[0].Name)
+	assert.Equal(t, "b", results[1].Name)
+}
+
+func TestReportResult(t *testing.T) {
+	var report Report
+	report.Add(Result{Name: "Access_Log_Analytics", Attempts: 3, Failures: 3})
+
+	result, ok := report.Result("Access_Log_Analytics")
+	require.True(t, ok)
+	assert.Equal(t, "failed", result.Status())
+	_, ok = report.Result("Function_Logs")
+	assert.False(t, ok)
+}
+
+func TestResultStatus(t *testing.T) {
+	assert.Equal(t, "passed", Result{Attempts: 1}.Status())
+	assert.Equal(t, "flaky", Result{Attempts: 2, Failures: 1}.Status())
+	assert.Equal(t, "failed", Result{Attempts: 3, Failures: 3}.Status())
+}
@@ -0,0 +1,121 @@
+// Package ratelimit paces the suite's AWS API calls with a token bucket per operation, so a run sharing an
+// account with other CI jobs stays under the account's API quotas instead of failing validators with
+// ThrottlingException. Operations the suite calls in loops get buckets well below their documented quota.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// Limit is the sustained rate of an operation, in calls per second, and how many calls may go out at once
+// after it has been idle
+type Limit struct {
+	PerSecond float64
+	Burst     int
+}
+
+// Limits are the operations the suite calls repeatedly, keyed "<service ID>.<operation>", with about a third of
+// their account-wide quota so concurrent jobs in the account keep the rest
+var Limits = map[string]Limit{
+	"SFN.ListStateMachines":       {PerSecond: 1, Burst: 5},
+	"SFN.DescribeStateMachine":    {PerSecond: 5, Burst: 10},
+	"CloudWatch.DescribeAlarms":   {PerSecond: 3, Burst: 5},
+	"ApiGatewayV2.GetApis":        {PerSecond: 2, Burst: 5},
+	"ApiGatewayV2.GetRoutes":      {PerSecond: 2, Burst: 5},
+	"ApiGatewayV2.GetStages":      {PerSecond: 2, Burst: 5},
+	"CloudWatchLogs.StartQuery":   {PerSecond: 1, Burst: 3},
+	"IAM.SimulatePrincipalPolicy": {PerSecond: 2, Burst: 5},
+}
+
+// DefaultFallback paces the operations Limits does not list
+var DefaultFallback = Limit{PerSecond: 10, Burst: 20}
+
+// Shared paces every client created through testconfig.Config.AWSConfig, so all validators of a process draw
+// from the same buckets. TestMain replaces it to apply -aws-call-rate.
+var Shared = New(DefaultFallback, Limits)
+
+// Limiter holds one token bucket per operation, created on its first call
+type Limiter struct {
+	fallback Limit
+	limits   map[string]Limit
+	now      func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// bucket is the tokens left for an operation as of updated; it goes negative while calls wait for tokens
+type bucket struct {
+	limit   Limit
+	tokens  float64
+	updated time.Time
+}
+
+// New returns a limiter applying limits to the operations they list and fallback to every other one.
+// A fallback of zero calls per second leaves unlisted operations unpaced.
+func New(fallback Limit, limits map[string]Limit) *Limiter {
+	return &Limiter{fallback: fallback, limits: limits, now: time.Now, buckets: map[string]*bucket{}}
+}
+
+// Wait blocks until operation may be called, or ctx is done
+func (l *Limiter) Wait(ctx context.Context, operation string) error {
+	delay := l.reserve(operation)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token for operation and returns how long the caller must wait for it to be available
+func (l *Limiter) reserve(operation string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[operation]
+	if !ok {
+		limit, listed := l.limits[operation]
+		if !listed {
+			limit = l.fallback
+		}
+		b = &bucket{limit: limit, tokens: float64(limit.Burst), updated: now}
+		l.buckets[operation] = b
+	}
+	if b.limit.PerSecond <= 0 {
+		return 0
+	}
+
+	b.tokens = min(float64(max(b.limit.Burst, 1)), b.tokens+now.Sub(b.updated).Seconds()*b.limit.PerSecond)
+	b.updated = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.limit.PerSecond * float64(time.Second))
+}
+
+// Middleware adds the limiter to an AWS client's stack, e.g. through aws.Config.APIOptions. It waits after
+// the retry step, so each retried attempt of a throttled call is paced too.
+func (l *Limiter) Middleware(stack *middleware.Stack) error {
+	return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("RateLimit",
+		func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			operation := fmt.Sprintf("%s.%s", awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx))
+			if err := l.Wait(ctx, operation); err != nil {
+				return middleware.FinalizeOutput{}, middleware.Metadata{}, fmt.Errorf("waiting to call %s: %w", operation, err)
+			}
+			return next.HandleFinalize(ctx, in)
+		}), middleware.After)
+}
@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// clock is a time source the test advances by hand
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time           { return c.now }
+func (c *clock) Advance(by time.Duration) { c.now = c.now.Add(by) }
+
+func newTestLimiter(fallback Limit, limits map[string]Limit) (*Limiter, *clock) {
+	c := &clock{now: time.Unix(1700000000, 0)}
+	limiter := New(fallback, limits)
+	limiter.now = c.Now
+	return limiter, c
+}
+
+func TestReserveAllowsBurstThenPaces(t *testing.T) {
+	limiter, c := newTestLimiter(Limit{}, map[string]Limit{"SFN.ListStateMachines": {PerSecond: 2, Burst: 3}})
+
+	for i := 0; i < 3; i++ {
+		assert.Zero(t, limiter.reserve("SFN.ListStateMachines"), "call %d of the burst", i+1)
+	}
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve("SFN.ListStateMachines"))
+	// Waiting callers queue behind each other
+	assert.Equal(t, time.Second, limiter.reserve("SFN.ListStateMachines"))
+
+	// Two seconds refill four tokens, of which the queued calls took two
+	c.Advance(2 * time.Second)
+	assert.Zero(t, limiter.reserve("SFN.ListStateMachines"))
+	assert.Zero(t, limiter.reserve("SFN.ListStateMachines"))
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve("SFN.ListStateMachines"))
+
+	// An idle bucket refills only up to its burst
+	c.Advance(time.Minute)
+	for i := 0; i < 3; i++ {
+		assert.Zero(t, limiter.reserve("SFN.ListStateMachines"))
+	}
+	assert.Positive(t, limiter.reserve("SFN.ListStateMachines"))
+}
+
+func TestReserveKeepsOperationsApart(t *testing.T) {
+	limiter, _ := newTestLimiter(Limit{PerSecond: 10, Burst: 1}, map[string]Limit{"CloudWatch.DescribeAlarms": {PerSecond: 1, Burst: 1}})
+
+	assert.Zero(t, limiter.reserve("CloudWatch.DescribeAlarms"))
+	assert.Equal(t, time.Second, limiter.reserve("CloudWatch.DescribeAlarms"))
+
+	assert.Zero(t, limiter.reserve("Lambda.GetFunction"), "unlisted operations have a bucket of their own")
+	assert.Equal(t, 100*time.Millisecond, limiter.reserve("Lambda.GetFunction"), "unlisted operations use the fallback")
+}
+
+func TestReserveWithoutFallbackLeavesUnlistedOperationsUnpaced(t *testing.T) {
+	limiter, _ := newTestLimiter(Limit{}, nil)
+	for i := 0; i < 100; i++ {
+		assert.Zero(t, limiter.reserve("Lambda.GetFunction"))
+	}
+}
+
+func TestWaitStopsWithContext(t *testing.T) {
+	limiter, _ := newTestLimiter(Limit{}, map[string]Limit{"SFN.ListStateMachines": {PerSecond: 0.001, Burst: 1}})
+	assert.NoError(t, limiter.Wait(context.Background(), "SFN.ListStateMachines"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx, "SFN.ListStateMachines"), context.DeadlineExceeded)
+}
@@ -0,0 +1,160 @@
+// Package responses holds the product API's response bodies as typed structs and decodes them
+// strictly, so a test fails on a malformed payload rather than on a missing substring.
+//
+// A field is required unless its json tag has omitempty. Decode fails when the body is not a JSON
+// object, a required field is missing or null, a field has the wrong JSON type, or the decoded
+// value fails its own Validate.
+package responses
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Body is a response body that checks the values of its fields once decoded
+type Body interface {
+	Validate() error
+}
+
+// HealthResponse is the body of GET /health
+type HealthResponse struct {
+	Status    string `json:"status"`
+	Service   string `json:"service,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+	Version   string `json:"version,omitempty"`
+}
+
+// Validate checks the status is set and the timestamp, when present, is RFC 3339
+func (h *HealthResponse) Validate() error {
+	if h.Status == "" {
+		return errors.New("status is empty")
+	}
+	return validateTimestamp("timestamp", h.Timestamp)
+}
+
+// Product is a product as the API returns it
+type Product struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Price       float64 `json:"price"`
+	Description string  `json:"description,omitempty"`
+	Category    string  `json:"category,omitempty"`
+	CreatedAt   string  `json:"createdAt,omitempty"`
+	UpdatedAt   string  `json:"updatedAt,omitempty"`
+}
+
+// Validate checks the product has an id and a name, a non-negative price, and RFC 3339 timestamps
+func (p *Product) Validate() error {
+	switch {
+	case p.ID == "":
+		return errors.New("id is empty")
+	case p.Name == "":
+		return errors.New("name is empty")
+	case p.Price < 0 || math.IsInf(p.Price, 0):
+		return fmt.Errorf("price %v is not a valid amount", p.Price)
+	}
+	if err := validateTimestamp("createdAt", p.CreatedAt); err != nil {
+		return err
+	}
+	return validateTimestamp("updatedAt", p.UpdatedAt)
+}
+
+// ProductList is the body of GET /products
+type ProductList struct {
+	Products []Product `json:"products"`
+}
+
+// Validate checks every product in the list
+func (l *ProductList) Validate() error {
+	for i := range l.Products {
+		if err := l.Products[i].Validate(); err != nil {
+			return fmt.Errorf("products[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ErrorResponse is the body of every 4xx and 5xx response
+type ErrorResponse struct {
+	Error      string `json:"error"`
+	Message    string `json:"message"`
+	StatusCode int    `json:"statusCode"`
+}
+
+// Validate checks the error has a message and an error status code
+func (e *ErrorResponse) Validate() error {
+	if e.Message == "" {
+		return errors.New("message is empty")
+	}
+	if e.StatusCode < 400 || e.StatusCode > 599 {
+		return fmt.Errorf("statusCode %d is not an error status", e.StatusCode)
+	}
+	return nil
+}
+
+// Decode decodes body into target after checking its required fields, then validates it
+func Decode(body []byte, target Body) error {
+	if err := checkRequired("$", body, reflect.TypeOf(target).Elem()); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, target); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return fmt.Errorf("$.%s: expected %s, got JSON %s", typeErr.Field, typeErr.Type, typeErr.Value)
+		}
+		return err
+	}
+	return target.Validate()
+}
+
+// checkRequired fails when data, the JSON at path decoded into typ, lacks a field typ requires.
+// Structs nested in fields or slices are checked too.
+func checkRequired(path string, data json.RawMessage, typ reflect.Type) error {
+	switch typ.Kind() {
+	case reflect.Slice:
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return fmt.Errorf("%s: expected an array: %w", path, err)
+		}
+		for i, item := range items {
+			if err := checkRequired(fmt.Sprintf("%s[%d]", path, i), item, typ.Elem()); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+			return fmt.Errorf("%s: expected a JSON object, got %s", path, data)
+		}
+		for i := 0; i < typ.NumField(); i++ {
+			name, options, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+			value, present := fields[name]
+			if !present || string(value) == "null" {
+				if options != "omitempty" {
+					return fmt.Errorf("%s: missing required field %q", path, name)
+				}
+				continue
+			}
+			if err := checkRequired(path+"."+name, value, typ.Field(i).Type); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateTimestamp checks an optional timestamp field is RFC 3339
+func validateTimestamp(field, value string) error {
+	if value == "" {
+		return nil
+	}
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		return fmt.Errorf("%s %q is not an RFC 3339 timestamp", field, value)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package responses
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeHealthResponse(t *testing.T) {
+	var health HealthResponse
+	require.NoError(t, Decode([]byte(`{"status":"healthy","service":"product-service","timestamp":"2024-05-01T12:00:00Z"}`), &health))
+	assert.Equal(t, "healthy", health.Status)
+	assert.Equal(t, "product-service", health.Service)
+}
+
+func TestDecodeRejectsMalformedBodies(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		target  Body
+		message string
+	}{
+		{"Not_JSON", `healthy`, &HealthResponse{}, "expected a JSON object"},
+		{"Array", `["healthy"]`, &HealthResponse{}, "expected a JSON object"},
+		{"Null", `null`, &HealthResponse{}, "expected a JSON object"},
+		{"Missing_Field", `{"service":"product-service"}`, &HealthResponse{}, `$: missing required field "status"`},
+		{"Null_Field", `{"status":null}`, &HealthResponse{}, `$: missing required field "status"`},
+		{"Wrong_Type", `{"id":"1","name":"Widget","price":"9.99"}`, &Product{}, "$.price: expected float64, got JSON string"},
+		{"Bad_Timestamp", `{"status":"healthy","timestamp":"yesterday"}`, &HealthResponse{}, `timestamp "yesterday" is not an RFC 3339 timestamp`},
+		{"Empty_Id", `{"id":"","name":"Widget","price":1}`, &Product{}, "id is empty"},
+		{"Negative_Price", `{"id":"1","name":"Widget","price":-1}`, &Product{}, "price -1 is not a valid amount"},
+		{"Nested_Missing_Field", `{"products":[{"id":"1","name":"Widget","price":1},{"id":"2","price":1}]}`, &ProductList{}, `$.products[1]: missing required field "name"`},
+		{"Nested_Invalid", `{"products":[{"id":"1","name":"","price":1}]}`, &ProductList{}, "products[0]: name is empty"},
+		{"Error_Status", `{"error":"HTTP 200","message":"ok","statusCode":200}`, &ErrorResponse{}, "statusCode 200 is not an error status"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Decode([]byte(tt.body), tt.target)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
+
+func TestDecodeErrorResponse(t *testing.T) {
+	var response ErrorResponse
+	require.NoError(t, Decode([]byte(`{"error":"HTTP 404","message":"Product not found","statusCode":404}`), &response))
+	assert.Equal(t, 404, response.StatusCode)
+}
+
+func TestDecodeProductListAllowsOptionalFields(t *testing.T) {
+	var list ProductList
+	require.NoError(t, Decode([]byte(`{"products":[{"id":"1","name":"Widget","price":9.99,"category":"Tools","createdAt":"2024-05-01T12:00:00.123Z"}]}`), &list))
+	require.Len(t, list.Products, 1)
+	assert.Equal(t, "Tools", list.Products[0].Category)
+}
@@ -0,0 +1,392 @@
+// Package results collects a machine-readable summary of a validation run for downstream tooling.
+//
+// A Collector is created once in TestMain, filled in by validators as they run and written as JSON
+// when the run ends. Any test package can share it the same way.
+package results
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Status is the outcome of a check or resource validation
+type Status string
+
+// Outcomes reported for checks and resources
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+	// StatusQuarantinedFailed is a quarantined validator that failed every attempt; it does not fail the run
+	StatusQuarantinedFailed Status = "quarantined-failed"
+)
+
+// StatusOf returns the outcome of a finished test
+func StatusOf(t testing.TB) Status {
+	switch {
+	case t.Failed():
+		return StatusFailed
+	case t.Skipped():
+		return StatusSkipped
+	}
+	return StatusPassed
+}
+
+// Check is the outcome of one named validation
+type Check struct {
+	Name       string `json:"name"`
+	Status     Status `json:"status"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// Resource is the outcome of validating one deployed resource
+type Resource struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+}
+
+// Latency is one timed request
+type Latency struct {
+	Name       string `json:"name"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// HistogramBucket counts latencies no longer than UpperBoundMs. The last bucket has no upper bound.
+type HistogramBucket struct {
+	UpperBoundMs int64 `json:"upperBoundMs,omitempty"`
+	Count        int   `json:"count"`
+}
+
+// LatencyHistogram is the latency distribution of a load-tested endpoint
+type LatencyHistogram struct {
+	Name    string            `json:"name"`
+	Buckets []HistogramBucket `json:"buckets"`
+}
+
+// HistogramBounds are the upper bounds of the latency histogram buckets
+var HistogramBounds = []time.Duration{
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// Transitions is the number of state transitions a workflow execution took against its budget
+type Transitions struct {
+	Name        string `json:"name"`
+	Execution   string `json:"execution"`
+	Transitions int    `json:"transitions"`
+	Budget      int    `json:"budget"`
+}
+
+// ExecutionDiagram is a Mermaid flowchart of the states a workflow execution went through, failed ones highlighted
+type ExecutionDiagram struct {
+	Name      string `json:"name"`
+	Execution string `json:"execution"`
+	Mermaid   string `json:"mermaid"`
+}
+
+// WorkflowRun is one execution of a state machine as Step Functions lists it
+type WorkflowRun struct {
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	StartedAt time.Time `json:"startedAt"`
+	// StoppedAt and DurationMs are unset while the execution runs
+	StoppedAt  *time.Time `json:"stoppedAt,omitempty"`
+	DurationMs int64      `json:"durationMs,omitempty"`
+}
+
+// WorkflowRuns archives the executions of a state machine over a trailing window and their success rate, so
+// the rate can be followed from one CI run to the next
+type WorkflowRuns struct {
+	StateMachine string    `json:"stateMachine"`
+	WindowStart  time.Time `json:"windowStart"`
+	WindowEnd    time.Time `json:"windowEnd"`
+	Succeeded    int       `json:"succeeded"`
+	Failed       int       `json:"failed"`
+	TimedOut     int       `json:"timedOut"`
+	Aborted      int       `json:"aborted"`
+	Running      int       `json:"running"`
+	SuccessRate  float64   `json:"successRate"`
+	// Objective is the lowest success rate allowed; 0 when none is enforced
+	Objective float64       `json:"objective,omitempty"`
+	Runs      []WorkflowRun `json:"runs"`
+}
+
+// TagCompliance records which required tags a resource is missing
+type TagCompliance struct {
+	Resource  string   `json:"resource"`
+	Compliant bool     `json:"compliant"`
+	Missing   []string `json:"missing,omitempty"`
+}
+
+// MissingComponent is a part of the template the deployment lacks and the checks skipped because of it
+type MissingComponent struct {
+	Component string   `json:"component"`
+	Resource  string   `json:"resource"`
+	Skipped   []string `json:"skipped"`
+}
+
+// Request is one HTTP request a validator sent and the response it finally got, bodies cut short
+type Request struct {
+	Method       string `json:"method"`
+	URL          string `json:"url"`
+	StatusCode   int    `json:"statusCode,omitempty"`
+	Attempts     int    `json:"attempts"`
+	DurationMs   int64  `json:"durationMs"`
+	RequestBody  string `json:"requestBody,omitempty"`
+	ResponseBody string `json:"responseBody,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// Quarantined is every attempt made at one quarantined validator
+type Quarantined struct {
+	Name string `json:"name"`
+	// Status is passed, flaky (passed after failing), or failed (no attempt passed)
+	Status    string  `json:"status"`
+	Attempts  int     `json:"attempts"`
+	Failures  int     `json:"failures"`
+	FlakeRate float64 `json:"flakeRate"`
+}
+
+// Summary counts checks by status
+type Summary struct {
+	Passed            int `json:"passed"`
+	Failed            int `json:"failed"`
+	Skipped           int `json:"skipped"`
+	QuarantinedFailed int `json:"quarantinedFailed"`
+}
+
+// Report is the JSON document written at the end of a run
+type Report struct {
+	Project       string             `json:"project"`
+	Environment   string             `json:"environment"`
+	Region        string             `json:"region"`
+	Target        string             `json:"target"`
+	StartedAt     time.Time          `json:"startedAt"`
+	FinishedAt    time.Time          `json:"finishedAt"`
+	Summary       Summary            `json:"summary"`
+	Checks        []Check            `json:"checks"`
+	Resources     []Resource         `json:"resources"`
+	Latencies     []Latency          `json:"latencies"`
+	Histograms    []LatencyHistogram `json:"latencyHistograms"`
+	TagCompliance []TagCompliance    `json:"tagCompliance"`
+	Transitions   []Transitions      `json:"transitions"`
+	Executions    []ExecutionDiagram `json:"executions"`
+	WorkflowRuns  []WorkflowRuns     `json:"workflowRuns"`
+	Missing       []MissingComponent `json:"missing"`
+	Requests      []Request          `json:"requests"`
+	Quarantined   []Quarantined      `json:"quarantined"`
+}
+
+// Collector accumulates results from concurrent tests
+type Collector struct {
+	mu     sync.Mutex
+	report Report
+}
+
+// NewCollector starts a report for one deployment
+func NewCollector(project, environment, region, target string) *Collector {
+	return &Collector{report: Report{
+		Project:       project,
+		Environment:   environment,
+		Region:        region,
+		Target:        target,
+		StartedAt:     time.Now().UTC(),
+		Checks:        []Check{},
+		Resources:     []Resource{},
+		Latencies:     []Latency{},
+		Histograms:    []LatencyHistogram{},
+		TagCompliance: []TagCompliance{},
+		Transitions:   []Transitions{},
+		Executions:    []ExecutionDiagram{},
+		WorkflowRuns:  []WorkflowRuns{},
+		Missing:       []MissingComponent{},
+		Requests:      []Request{},
+		Quarantined:   []Quarantined{},
+	}}
+}
+
+// RecordCheck records the outcome of a named validation
+func (c *Collector) RecordCheck(name string, status Status, duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.report.Checks = append(c.report.Checks, Check{Name: name, Status: status, DurationMs: duration.Milliseconds()})
+}
+
+// RecordResource records the outcome of validating a resource
+func (c *Collector) RecordResource(resourceType, name string, status Status) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.report.Resources = append(c.report.Resources, Resource{Type: resourceType, Name: name, Status: status})
+}
+
+// RecordLatency records a timed request
+func (c *Collector) RecordLatency(name string, duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.report.Latencies = append(c.report.Latencies, Latency{Name: name, DurationMs: duration.Milliseconds()})
+}
+
+// RecordHistogram records the distribution of an endpoint's latencies over HistogramBounds
+func (c *Collector) RecordHistogram(name string, latencies []time.Duration) {
+	buckets := make([]HistogramBucket, len(HistogramBounds)+1)
+	for i, bound := range HistogramBounds {
+		buckets[i].UpperBoundMs = bound.Milliseconds()
+	}
+	for _, latency := range latencies {
+		i := sort.Search(len(HistogramBounds), func(i int) bool { return latency <= HistogramBounds[i] })
+		buckets[i].Count++
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.report.Histograms = append(c.report.Histograms, LatencyHistogram{Name: name, Buckets: buckets})
+}
+
+// RecordTransitions records the state transitions a workflow execution took
+func (c *Collector) RecordTransitions(name, execution string, transitions, budget int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.report.Transitions = append(c.report.Transitions, Transitions{Name: name, Execution: execution, Transitions: transitions, Budget: budget})
+}
+
+// RecordExecution records the diagram of a workflow execution
+func (c *Collector) RecordExecution(name, execution, mermaid string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.report.Executions = append(c.report.Executions, ExecutionDiagram{Name: name, Execution: execution, Mermaid: mermaid})
+}
+
+// RecordWorkflowRuns records the executions of a state machine over a trailing window
+func (c *Collector) RecordWorkflowRuns(runs WorkflowRuns) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.report.WorkflowRuns = append(c.report.WorkflowRuns, runs)
+}
+
+// RecordTags records whether a resource carries every required tag
+func (c *Collector) RecordTags(resource string, tags map[string]string, required []string) {
+	var missing []string
+	for _, key := range required {
+		if tags[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.report.TagCompliance = append(c.report.TagCompliance, TagCompliance{
+		Resource:  resource,
+		Compliant: len(missing) == 0,
+		Missing:   missing,
+	})
+}
+
+// RecordMissing records that a check was skipped because a component of the template is not deployed
+func (c *Collector) RecordMissing(component, resource, check string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.report.Missing {
+		if c.report.Missing[i].Component == component {
+			c.report.Missing[i].Skipped = append(c.report.Missing[i].Skipped, check)
+			return
+		}
+	}
+	c.report.Missing = append(c.report.Missing, MissingComponent{Component: component, Resource: resource, Skipped: []string{check}})
+}
+
+// RecordRequest records an HTTP request in the order it finished
+func (c *Collector) RecordRequest(request Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.report.Requests = append(c.report.Requests, request)
+}
+
+// RecordQuarantined records the attempts made at a quarantined validator
+func (c *Collector) RecordQuarantined(quarantined Quarantined) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.report.Quarantined = append(c.report.Quarantined, quarantined)
+}
+
+// Report returns a finished copy of the collected results with entries in a stable order
+func (c *Collector) Report() Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	report := c.report
+	report.FinishedAt = time.Now().UTC()
+	report.Checks = append([]Check{}, c.report.Checks...)
+	report.Resources = append([]Resource{}, c.report.Resources...)
+	report.Latencies = append([]Latency{}, c.report.Latencies...)
+	report.Histograms = append([]LatencyHistogram{}, c.report.Histograms...)
+	report.TagCompliance = append([]TagCompliance{}, c.report.TagCompliance...)
+	report.Transitions = append([]Transitions{}, c.report.Transitions...)
+	report.Executions = append([]ExecutionDiagram{}, c.report.Executions...)
+	report.WorkflowRuns = append([]WorkflowRuns{}, c.report.WorkflowRuns...)
+	report.Requests = append([]Request{}, c.report.Requests...)
+	report.Quarantined = append([]Quarantined{}, c.report.Quarantined...)
+	report.Missing = make([]MissingComponent, 0, len(c.report.Missing))
+	for _, missing := range c.report.Missing {
+		missing.Skipped = append([]string{}, missing.Skipped...)
+		sort.Strings(missing.Skipped)
+		report.Missing = append(report.Missing, missing)
+	}
+
+	sort.SliceStable(report.Checks, func(i, j int) bool { return report.Checks[i].Name < report.Checks[j].Name })
+	sort.SliceStable(report.Resources, func(i, j int) bool {
+		if report.Resources[i].Type != report.Resources[j].Type {
+			return report.Resources[i].Type < report.Resources[j].Type
+		}
+		return report.Resources[i].Name < report.Resources[j].Name
+	})
+	sort.SliceStable(report.TagCompliance, func(i, j int) bool {
+		return report.TagCompliance[i].Resource < report.TagCompliance[j].Resource
+	})
+	sort.SliceStable(report.Histograms, func(i, j int) bool { return report.Histograms[i].Name < report.Histograms[j].Name })
+	sort.SliceStable(report.Transitions, func(i, j int) bool { return report.Transitions[i].Name < report.Transitions[j].Name })
+	sort.SliceStable(report.Executions, func(i, j int) bool { return report.Executions[i].Name < report.Executions[j].Name })
+	sort.SliceStable(report.WorkflowRuns, func(i, j int) bool {
+		return report.WorkflowRuns[i].StateMachine < report.WorkflowRuns[j].StateMachine
+	})
+	sort.SliceStable(report.Missing, func(i, j int) bool { return report.Missing[i].Component < report.Missing[j].Component })
+	sort.SliceStable(report.Quarantined, func(i, j int) bool { return report.Quarantined[i].Name < report.Quarantined[j].Name })
+
+	report.Summary = Summary{}
+	for _, check := range report.Checks {
+		switch check.Status {
+		case StatusPassed:
+			report.Summary.Passed++
+		case StatusFailed:
+			report.Summary.Failed++
+		case StatusSkipped:
+			report.Summary.Skipped++
+		case StatusQuarantinedFailed:
+			report.Summary.QuarantinedFailed++
+		}
+	}
+	return report
+}
+
+// WriteFile writes the report as indented JSON
+func (c *Collector) WriteFile(path string) error {
+	data, err := json.MarshalIndent(c.Report(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding results: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing results: %w", err)
+	}
+	return nil
+}
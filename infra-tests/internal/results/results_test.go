@@ -0,0 +1,156 @@
+package results
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectorReport(t *testing.T) {
+	collector := NewCollector("lambda-java-template", "dev", "us-east-1", "aws")
+	collector.RecordCheck("Security_Configuration", StatusFailed, 2*time.Second)
+	collector.RecordCheck("API_Gateway_Integration", StatusPassed, 1500*time.Millisecond)
+	collector.RecordCheck("Trusted_Advisor_Checks", StatusSkipped, 0)
+	collector.RecordResource("lambda", "lambda-java-template-dev-product-service", StatusPassed)
+	collector.RecordResource("dynamodb", "lambda-java-template-dev-products", StatusFailed)
+	collector.RecordLatency("GET /health", 250*time.Millisecond)
+	collector.RecordTransitions("Successful_Order", "arn:aws:states:us-east-1:123456789012:execution:orders:b", 12, 25)
+	collector.RecordTransitions("Order_Without_Items", "arn:aws:states:us-east-1:123456789012:execution:orders:a", 4, 25)
+	collector.RecordTags("products", map[string]string{"Project": "lambda-java-template"}, []string{"Project", "Environment"})
+
+	report := collector.Report()
+	assert.Equal(t, Summary{Passed: 1, Failed: 1, Skipped: 1}, report.Summary)
+	assert.Equal(t, "API_Gateway_Integration", report.Checks[0].Name, "Checks are sorted by name")
+	assert.Equal(t, int64(1500), report.Checks[0].DurationMs)
+	assert.Equal(t, "dynamodb", report.Resources[0].Type, "Resources are sorted by type")
+	assert.Equal(t, []string{"Environment"}, report.TagCompliance[0].Missing)
+	assert.False(t, report.TagCompliance[0].Compliant)
+	assert.Equal(t, "Order_Without_Items", report.Transitions[0].Name, "Transitions are sorted by name")
+	assert.False(t, report.FinishedAt.Before(report.StartedAt))
+}
+
+func TestRecordQuarantined(t *testing.T) {
+	collector := NewCollector("lambda-java-template", "dev", "us-east-1", "aws")
+	collector.RecordCheck("Access_Log_Analytics", StatusQuarantinedFailed, time.Minute)
+	collector.RecordQuarantined(Quarantined{Name: "Access_Log_Analytics", Status: "failed", Attempts: 3, Failures: 3, FlakeRate: 1})
+	collector.RecordQuarantined(Quarantined{Name: "Access_Log_Delivery", Status: "flaky", Attempts: 2, Failures: 1, FlakeRate: 0.5})
+
+	report := collector.Report()
+	assert.Equal(t, Summary{QuarantinedFailed: 1}, report.Summary, "A quarantined failure is neither passed nor failed")
+	require.Len(t, report.Quarantined, 2)
+	assert.Equal(t, "Access_Log_Analytics", report.Quarantined[0].Name, "Quarantined validators are sorted by name")
+	assert.Equal(t, 3, report.Quarantined[0].Failures)
+	assert.Empty(t, NewCollector("lambda-java-template", "dev", "us-east-1", "aws").Report().Quarantined)
+}
+
+func TestRecordMissing(t *testing.T) {
+	collector := NewCollector("lambda-java-template", "dev", "us-east-1", "aws")
+	collector.RecordMissing("order-processing state machine", "lambda-java-template-dev-order-processing", "Step_Functions_Workflow")
+	collector.RecordMissing("audit-logs table", "lambda-java-template-dev-audit-logs", "Audit_Trail")
+	collector.RecordMissing("order-processing state machine", "lambda-java-template-dev-order-processing", "Order_Compensation")
+
+	assert.Equal(t, []MissingComponent{
+		{Component: "audit-logs table", Resource: "lambda-java-template-dev-audit-logs", Skipped: []string{"Audit_Trail"}},
+		{Component: "order-processing state machine", Resource: "lambda-java-template-dev-order-processing", Skipped: []string{"Order_Compensation", "Step_Functions_Workflow"}},
+	}, collector.Report().Missing, "Components are sorted and collect every check they skipped")
+}
+
+func TestRecordExecution(t *testing.T) {
+	collector := NewCollector("lambda-java-template", "dev", "us-east-1", "aws")
+	collector.RecordExecution("Successful_Order", "arn:aws:states:us-east-1:123456789012:execution:orders:b", "flowchart TD\n")
+	collector.RecordExecution("Order_Without_Items", "arn:aws:states:us-east-1:123456789012:execution:orders:a", "flowchart TD\n")
+
+	report := collector.Report()
+	require.Len(t, report.Executions, 2)
+	assert.Equal(t, "Order_Without_Items", report.Executions[0].Name, "Executions are sorted by name")
+	assert.Equal(t, "flowchart TD\n", report.Executions[0].Mermaid)
+	assert.Empty(t, NewCollector("lambda-java-template", "dev", "us-east-1", "aws").Report().Executions)
+}
+
+func TestRecordWorkflowRuns(t *testing.T) {
+	collector := NewCollector("lambda-java-template", "prod", "us-east-1", "aws")
+	started := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	stopped := started.Add(4 * time.Second)
+	collector.RecordWorkflowRuns(WorkflowRuns{
+		StateMachine: "lambda-java-template-prod-order-processing",
+		WindowStart:  started.Add(-24 * time.Hour),
+		WindowEnd:    started,
+		Succeeded:    1,
+		Running:      1,
+		SuccessRate:  1,
+		Objective:    0.99,
+		Runs: []WorkflowRun{
+			{Name: "order-2", Status: "RUNNING", StartedAt: started},
+			{Name: "order-1", Status: "SUCCEEDED", StartedAt: started, StoppedAt: &stopped, DurationMs: 4000},
+		},
+	})
+
+	data, err := json.Marshal(collector.Report().WorkflowRuns)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `{"name":"order-2","status":"RUNNING","startedAt":"2026-01-01T12:00:00Z"}`, "A running execution has no stop time")
+	assert.Contains(t, string(data), `"stoppedAt":"2026-01-01T12:00:04Z","durationMs":4000`)
+	assert.Contains(t, string(data), `"successRate":1,"objective":0.99`)
+	assert.Empty(t, NewCollector("lambda-java-template", "dev", "us-east-1", "aws").Report().WorkflowRuns)
+}
+
+func TestRecordRequest(t *testing.T) {
+	collector := NewCollector("lambda-java-template", "dev", "us-east-1", "aws")
+	collector.RecordRequest(Request{Method: "GET", URL: "https://api.example.com/health", StatusCode: 200, Attempts: 2, DurationMs: 480})
+	collector.RecordRequest(Request{Method: "POST", URL: "https://api.example.com/products", Attempts: 1, Error: "connection reset by peer"})
+
+	report := collector.Report()
+	require.Len(t, report.Requests, 2)
+	assert.Equal(t, "GET", report.Requests[0].Method, "Requests stay in the order they finished")
+	assert.Equal(t, 2, report.Requests[0].Attempts)
+	assert.Equal(t, "connection reset by peer", report.Requests[1].Error)
+}
+
+func TestRecordHistogram(t *testing.T) {
+	collector := NewCollector("lambda-java-template", "dev", "us-east-1", "aws")
+	collector.RecordHistogram("GET /products", []time.Duration{
+		20 * time.Millisecond,
+		50 * time.Millisecond,
+		300 * time.Millisecond,
+		30 * time.Second,
+	})
+	collector.RecordHistogram("GET /health", nil)
+
+	histograms := collector.Report().Histograms
+	require.Len(t, histograms, 2)
+	assert.Equal(t, "GET /health", histograms[0].Name, "Histograms are sorted by name")
+
+	buckets := histograms[1].Buckets
+	require.Len(t, buckets, len(HistogramBounds)+1)
+	assert.Equal(t, HistogramBucket{UpperBoundMs: 50, Count: 2}, buckets[0], "Bounds are inclusive")
+	assert.Equal(t, HistogramBucket{UpperBoundMs: 500, Count: 1}, buckets[3])
+	assert.Equal(t, HistogramBucket{Count: 1}, buckets[len(buckets)-1], "The last bucket counts everything slower")
+}
+
+func TestWriteFile(t *testing.T) {
+	collector := NewCollector("lambda-java-template", "dev", "us-east-1", "aws")
+	path := filepath.Join(t.TempDir(), "results.json")
+	require.NoError(t, collector.WriteFile(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "dev", decoded["environment"])
+	assert.Equal(t, []any{}, decoded["checks"], "Empty sections are arrays, not null")
+}
+
+func TestStatusOf(t *testing.T) {
+	t.Run("skipped", func(t *testing.T) {
+		t.Cleanup(func() { assert.Equal(t, StatusSkipped, StatusOf(t)) })
+		t.Skip("recorded as skipped")
+	})
+	t.Run("passed", func(t *testing.T) {
+		t.Cleanup(func() { assert.Equal(t, StatusPassed, StatusOf(t)) })
+	})
+}
@@ -0,0 +1,108 @@
+// Package runtimes holds the Lambda runtime deprecation schedule and checks functions against it.
+//
+// The schedule is maintained by hand from https://docs.aws.amazon.com/lambda/latest/dg/lambda-runtimes.html.
+// Add a runtime before deploying it and update a date when AWS moves it; a runtime missing from the
+// schedule is reported so the table cannot silently fall behind.
+package runtimes
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// date parses a schedule entry
+func date(value string) time.Time {
+	parsed, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// Deprecations is the date each runtime is deprecated and stops receiving security patches
+var Deprecations = map[string]time.Time{
+	"java21":          date("2029-06-30"),
+	"java17":          date("2026-06-30"),
+	"java11":          date("2026-06-30"),
+	"java8.al2":       date("2026-06-30"),
+	"java8":           date("2024-01-08"),
+	"provided.al2023": date("2029-06-30"),
+	"provided.al2":    date("2026-06-30"),
+	"provided":        date("2024-01-08"),
+	"python3.13":      date("2029-06-30"),
+	"python3.12":      date("2028-10-31"),
+	"python3.11":      date("2027-06-30"),
+	"python3.10":      date("2026-06-30"),
+	"python3.9":       date("2025-12-15"),
+	"python3.8":       date("2024-10-14"),
+	"nodejs22.x":      date("2027-04-30"),
+	"nodejs20.x":      date("2026-04-30"),
+	"nodejs18.x":      date("2025-09-01"),
+	"nodejs16.x":      date("2024-06-12"),
+	"dotnet8":         date("2026-11-10"),
+	"dotnet6":         date("2024-12-20"),
+	"ruby3.3":         date("2027-03-31"),
+	"ruby3.2":         date("2026-03-31"),
+	"go1.x":           date("2024-01-08"),
+}
+
+// DeprecationGaps reports a runtime that is deprecated, is deprecated within window of now, or is missing
+// from the schedule. Container image functions have no runtime and pass.
+func DeprecationGaps(runtime string, now time.Time, window time.Duration) []string {
+	if runtime == "" {
+		return nil
+	}
+	deprecated, ok := Deprecations[runtime]
+	if !ok {
+		return []string{fmt.Sprintf("runtime %s is not in the deprecation schedule; add it to runtimes.Deprecations", runtime)}
+	}
+
+	days := int(deprecated.Sub(now).Hours() / 24)
+	switch {
+	case !now.Before(deprecated):
+		return []string{fmt.Sprintf("runtime %s was deprecated on %s", runtime, deprecated.Format(time.DateOnly))}
+	case deprecated.Sub(now) <= window:
+		return []string{fmt.Sprintf("runtime %s is deprecated on %s, in %d days", runtime, deprecated.Format(time.DateOnly), days)}
+	}
+	return nil
+}
+
+// NeedsBootstrap reports whether a runtime starts the function from a bootstrap executable in its package
+func NeedsBootstrap(runtime string) bool {
+	return strings.HasPrefix(runtime, "provided")
+}
+
+// LayerGaps reports differences between the layer version ARNs attached to a function and those expected,
+// naming a layer attached at another version by both ARNs
+func LayerGaps(attached, expected []string) []string {
+	var gaps []string
+	for _, want := range expected {
+		if slices.Contains(attached, want) {
+			continue
+		}
+		if other := sameLayer(attached, want); other != "" {
+			gaps = append(gaps, fmt.Sprintf("layer %s is attached at %s", want, other))
+		} else {
+			gaps = append(gaps, fmt.Sprintf("layer %s is not attached", want))
+		}
+	}
+	for _, have := range attached {
+		if !slices.Contains(expected, have) && sameLayer(expected, have) == "" {
+			gaps = append(gaps, fmt.Sprintf("layer %s is attached but not expected", have))
+		}
+	}
+	return gaps
+}
+
+// sameLayer returns the ARN in arns of another version of the layer versionArn belongs to
+func sameLayer(arns []string, versionArn string) string {
+	layer := versionArn[:max(strings.LastIndex(versionArn, ":"), 0)]
+	for _, candidate := range arns {
+		if candidate != versionArn && strings.HasPrefix(candidate, layer+":") {
+			return candidate
+		}
+	}
+	return ""
+}
@@ -0,0 +1,40 @@
+package runtimes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecationGaps(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := 180 * 24 * time.Hour
+
+	assert.Empty(t, DeprecationGaps("java21", now, window))
+	assert.Empty(t, DeprecationGaps("", now, window), "Container images have no runtime")
+	assert.Equal(t, []string{"runtime java17 is deprecated on 2026-06-30, in 180 days"}, DeprecationGaps("java17", now, window))
+	assert.Equal(t, []string{"runtime java8 was deprecated on 2024-01-08"}, DeprecationGaps("java8", now, window))
+	assert.Equal(t, []string{"runtime java25 is not in the deprecation schedule; add it to runtimes.Deprecations"}, DeprecationGaps("java25", now, window))
+}
+
+func TestNeedsBootstrap(t *testing.T) {
+	assert.True(t, NeedsBootstrap("provided.al2"))
+	assert.True(t, NeedsBootstrap("provided.al2023"))
+	assert.False(t, NeedsBootstrap("java21"))
+}
+
+func TestLayerGaps(t *testing.T) {
+	const (
+		otel1   = "arn:aws:lambda:us-east-1:123456789012:layer:otel:1"
+		otel2   = "arn:aws:lambda:us-east-1:123456789012:layer:otel:2"
+		insight = "arn:aws:lambda:us-east-1:580247275435:layer:LambdaInsightsExtension:38"
+		stray   = "arn:aws:lambda:us-east-1:123456789012:layer:debug:4"
+	)
+	assert.Empty(t, LayerGaps([]string{otel2, insight}, []string{otel2, insight}))
+	assert.Equal(t, []string{
+		"layer " + otel2 + " is attached at " + otel1,
+		"layer " + insight + " is not attached",
+		"layer " + stray + " is attached but not expected",
+	}, LayerGaps([]string{otel1, stray}, []string{otel2, insight}))
+}
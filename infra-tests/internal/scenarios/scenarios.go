@@ -0,0 +1,262 @@
+// Package scenarios loads workflow and API test cases described in YAML, so a case can be added by writing a
+// file under testdata/scenarios instead of Go. A workflow scenario gives the order the state machine is
+// started with and the terminal state, notification, and audit records it must produce; an API scenario
+// gives one request and the status, response fields, and audit records it must produce.
+//
+//	scenarios:
+//	  - name: Successful_Order
+//	    workflow:
+//	      orderId: "{{orderId}}"
+//	      customerId: "{{namespace}}customer"
+//	      items: [{productId: "{{namespace}}product-1", quantity: 1, price: 19.99}]
+//	    expect:
+//	      executionStatus: SUCCEEDED
+//	      terminalState: OrderSuccess
+//	      notification: ORDER_CONFIRMATION
+//	      audit: [{action: payment}, {action: inventory}]
+//
+// Every workflow scenario states whether its execution must succeed or fail, so an order meant to complete
+// never passes by ending in a Fail state, and orders meant to fail are cases of their own.
+//
+// Strings may hold {{namespace}}, the test namespace; {{orderId}}, the id a workflow scenario's order is given;
+// and {{unique}}, a suffix unique to the run.
+package scenarios
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Dir is where scenario files live, relative to the suite
+const Dir = "testdata/scenarios"
+
+// Scenario is one test case
+type Scenario struct {
+	Name string `yaml:"name"`
+	// Workflow is the input the order-processing state machine is started with
+	Workflow any `yaml:"workflow"`
+	// Request is the API request sent
+	Request *Request    `yaml:"request"`
+	Expect  Expectation `yaml:"expect"`
+
+	// Source is the file the scenario was read from
+	Source string `yaml:"-"`
+}
+
+// Request is an API request; Path is appended to the API endpoint
+type Request struct {
+	Method string `yaml:"method"`
+	Path   string `yaml:"path"`
+	Body   any    `yaml:"body"`
+}
+
+// Execution statuses a workflow scenario may expect
+const (
+	ExecutionSucceeded = "SUCCEEDED"
+	ExecutionFailed    = "FAILED"
+)
+
+// Expectation is what a scenario must produce
+type Expectation struct {
+	// ExecutionStatus is ExecutionSucceeded or ExecutionFailed, the status a workflow execution must end with
+	ExecutionStatus string `yaml:"executionStatus"`
+	// TerminalState is the state a workflow execution must end in
+	TerminalState string `yaml:"terminalState"`
+	// Notification is the notificationType of the notification the order must produce; empty skips the check
+	Notification string `yaml:"notification"`
+	// Status is the HTTP status an API response must have
+	Status int `yaml:"status"`
+	// Body holds fields the JSON response must have with these values; others are ignored
+	Body map[string]any `yaml:"body"`
+	// Audit is the records the scenario must write to the audit log, one each, for the order of a workflow
+	// scenario or the product whose id an API response returns
+	Audit []AuditEntry `yaml:"audit"`
+}
+
+// AuditEntry is an audit record a scenario must write
+type AuditEntry struct {
+	// Action is matched, ignoring case, against the record's action, e.g. "create" matches "ProductCreated"
+	Action string `yaml:"action"`
+	// Before and After require the record to carry the entity's state before and after the action
+	Before bool `yaml:"before"`
+	After  bool `yaml:"after"`
+}
+
+// file is the layout of a scenario file
+type file struct {
+	Scenarios []Scenario `yaml:"scenarios"`
+}
+
+// IsWorkflow reports whether the scenario starts a workflow execution rather than sending an API request
+func (s Scenario) IsWorkflow() bool {
+	return s.Workflow != nil
+}
+
+// Validate returns an error describing what makes the scenario unusable
+func (s Scenario) Validate() error {
+	var problems []string
+	switch {
+	case s.Name == "":
+		problems = append(problems, "has no name")
+	case strings.ContainsAny(s.Name, " /"):
+		problems = append(problems, "name must not contain spaces or slashes, since it names a subtest")
+	}
+
+	switch {
+	case s.Workflow != nil && s.Request != nil:
+		problems = append(problems, "has both a workflow input and a request")
+	case s.Workflow != nil:
+		if s.Expect.ExecutionStatus != ExecutionSucceeded && s.Expect.ExecutionStatus != ExecutionFailed {
+			problems = append(problems, fmt.Sprintf("executionStatus must be %s or %s, got %q", ExecutionSucceeded, ExecutionFailed, s.Expect.ExecutionStatus))
+		}
+		if s.Expect.TerminalState == "" {
+			problems = append(problems, "expects no terminalState")
+		}
+		if s.Expect.Status != 0 || s.Expect.Body != nil {
+			problems = append(problems, "expects an HTTP status or body from a workflow")
+		}
+	case s.Request != nil:
+		if !slices.Contains([]string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete}, s.Request.Method) {
+			problems = append(problems, fmt.Sprintf("request method must be GET, POST, PUT, or DELETE, got %q", s.Request.Method))
+		}
+		if !strings.HasPrefix(s.Request.Path, "/") {
+			problems = append(problems, fmt.Sprintf("request path %q does not start with /", s.Request.Path))
+		}
+		if s.Expect.Status == 0 {
+			problems = append(problems, "expects no status")
+		}
+		if s.Expect.ExecutionStatus != "" || s.Expect.TerminalState != "" || s.Expect.Notification != "" {
+			problems = append(problems, "expects an execution status, terminal state, or notification from an API request")
+		}
+	default:
+		problems = append(problems, "has neither a workflow input nor a request")
+	}
+
+	for _, entry := range s.Expect.Audit {
+		if entry.Action == "" {
+			problems = append(problems, "expects an audit record without an action")
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("scenario %q in %s %s", s.Name, s.Source, strings.Join(problems, "; "))
+}
+
+// Load reads every .yaml and .yml file in dir, in name order, and returns their scenarios. Scenarios that do
+// not validate and names used twice are reported together.
+func Load(dir string) ([]Scenario, error) {
+	var paths []string
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, matches...)
+	}
+	sort.Strings(paths)
+
+	var scenarios []Scenario
+	var errs []error
+	seen := map[string]string{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var parsed file
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&parsed); err != nil {
+			errs = append(errs, fmt.Errorf("parsing %s: %w", path, err))
+			continue
+		}
+
+		for _, scenario := range parsed.Scenarios {
+			scenario.Source = path
+			if err := scenario.Validate(); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if previous, ok := seen[scenario.Name]; ok {
+				errs = append(errs, fmt.Errorf("scenario %q in %s is also defined in %s", scenario.Name, path, previous))
+				continue
+			}
+			seen[scenario.Name] = path
+			scenarios = append(scenarios, scenario)
+		}
+	}
+	return scenarios, errors.Join(errs...)
+}
+
+// Expand returns value with each {{name}} in its strings, map keys included, replaced by vars[name]
+func Expand(value any, vars map[string]string) any {
+	switch v := value.(type) {
+	case string:
+		for name, replacement := range vars {
+			v = strings.ReplaceAll(v, "{{"+name+"}}", replacement)
+		}
+		return v
+	case map[string]any:
+		expanded := make(map[string]any, len(v))
+		for key, item := range v {
+			expanded[Expand(key, vars).(string)] = Expand(item, vars)
+		}
+		return expanded
+	case []any:
+		expanded := make([]any, len(v))
+		for i, item := range v {
+			expanded[i] = Expand(item, vars)
+		}
+		return expanded
+	default:
+		return value
+	}
+}
+
+// BodyMismatches compares the fields want lists with a JSON response body, after expanding vars in want.
+// Numbers compare by value, so 10 in YAML matches 10.0 in JSON.
+func BodyMismatches(want map[string]any, body []byte, vars map[string]string) ([]string, error) {
+	if len(want) == 0 {
+		return nil, nil
+	}
+	var got map[string]any
+	if err := json.Unmarshal(body, &got); err != nil {
+		return nil, fmt.Errorf("response is not a JSON object: %w", err)
+	}
+
+	// A JSON round trip gives the expected values the types the response decodes to
+	data, err := json.Marshal(Expand(want, vars))
+	if err != nil {
+		return nil, err
+	}
+	var normalized map[string]any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+
+	var mismatches []string
+	for field, wantValue := range normalized {
+		gotValue, ok := got[field]
+		switch {
+		case !ok:
+			mismatches = append(mismatches, fmt.Sprintf("%s is missing, want %v", field, wantValue))
+		case !reflect.DeepEqual(wantValue, gotValue):
+			mismatches = append(mismatches, fmt.Sprintf("%s is %v, want %v", field, gotValue, wantValue))
+		}
+	}
+	sort.Strings(mismatches)
+	return mismatches, nil
+}
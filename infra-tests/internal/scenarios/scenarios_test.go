@@ -0,0 +1,124 @@
+package scenarios
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShippedScenariosLoad(t *testing.T) {
+	loaded, err := Load("../../testdata/scenarios")
+	require.NoError(t, err)
+
+	var workflows, requests int
+	for _, scenario := range loaded {
+		if scenario.IsWorkflow() {
+			workflows++
+		} else {
+			requests++
+		}
+	}
+	assert.Positive(t, workflows)
+	assert.Positive(t, requests)
+}
+
+func TestLoadReportsEveryProblem(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	write("a.yaml", `
+scenarios:
+  - name: Valid_Order
+    workflow: {orderId: "{{orderId}}"}
+    expect: {executionStatus: SUCCEEDED, terminalState: OrderSuccess}
+  - name: No_Terminal_State
+    workflow: {orderId: "{{orderId}}"}
+    expect: {executionStatus: FAILED}
+  - name: Bad_Request
+    request: {method: PATCH, path: products}
+    expect: {status: 200}
+`)
+	write("b.yml", `
+scenarios:
+  - name: Valid_Order
+    request: {method: GET, path: /health}
+    expect: {status: 200}
+`)
+	write("c.yaml", `
+scenarios:
+  - name: Misspelled
+    workflow: {orderId: x}
+    expect: {terminalstate: OrderSuccess}
+`)
+	write("notes.txt", "not a scenario")
+
+	loaded, err := Load(dir)
+	require.Len(t, loaded, 1)
+	assert.Equal(t, "Valid_Order", loaded[0].Name)
+	assert.Equal(t, filepath.Join(dir, "a.yaml"), loaded[0].Source)
+
+	require.Error(t, err)
+	assert.ErrorContains(t, err, `scenario "No_Terminal_State" in `+filepath.Join(dir, "a.yaml")+` expects no terminalState`)
+	assert.ErrorContains(t, err, `request method must be GET, POST, PUT, or DELETE, got "PATCH"; request path "products" does not start with /`)
+	assert.ErrorContains(t, err, `scenario "Valid_Order" in `+filepath.Join(dir, "b.yml")+` is also defined in `+filepath.Join(dir, "a.yaml"))
+	assert.ErrorContains(t, err, "parsing "+filepath.Join(dir, "c.yaml"), "unknown fields are typos")
+}
+
+func TestValidate(t *testing.T) {
+	order := map[string]any{"orderId": "{{orderId}}"}
+
+	assert.NoError(t, Scenario{Name: "Order", Workflow: order, Expect: Expectation{ExecutionStatus: ExecutionSucceeded, TerminalState: "OrderSuccess"}}.Validate())
+	assert.NoError(t, Scenario{Name: "Get", Request: &Request{Method: "GET", Path: "/products"}, Expect: Expectation{Status: 200}}.Validate())
+
+	assert.ErrorContains(t, Scenario{Workflow: order, Expect: Expectation{ExecutionStatus: ExecutionSucceeded, TerminalState: "OrderSuccess"}}.Validate(), "has no name")
+	assert.ErrorContains(t, Scenario{Name: "An order", Workflow: order, Expect: Expectation{ExecutionStatus: ExecutionSucceeded, TerminalState: "OrderSuccess"}}.Validate(), "must not contain spaces")
+	assert.ErrorContains(t, Scenario{Name: "Empty"}.Validate(), "neither a workflow input nor a request")
+	assert.ErrorContains(t, Scenario{Name: "Both", Workflow: order, Request: &Request{Method: "GET", Path: "/"}}.Validate(), "both a workflow input and a request")
+	assert.ErrorContains(t, Scenario{Name: "Mixed", Workflow: order, Expect: Expectation{ExecutionStatus: ExecutionSucceeded, TerminalState: "OrderSuccess", Status: 200}}.Validate(), "HTTP status or body from a workflow")
+	assert.ErrorContains(t, Scenario{Name: "Mixed", Request: &Request{Method: "GET", Path: "/"}, Expect: Expectation{Status: 200, Notification: "ORDER_CONFIRMATION"}}.Validate(), "notification from an API request")
+	assert.ErrorContains(t, Scenario{Name: "Unstated", Workflow: order, Expect: Expectation{TerminalState: "ProcessingFailed"}}.Validate(),
+		`executionStatus must be SUCCEEDED or FAILED, got ""`, "an order must say whether it is meant to fail")
+	assert.ErrorContains(t, Scenario{Name: "Audit", Workflow: order, Expect: Expectation{ExecutionStatus: ExecutionSucceeded, TerminalState: "OrderSuccess", Audit: []AuditEntry{{}}}}.Validate(), "audit record without an action")
+}
+
+func TestExpand(t *testing.T) {
+	input := map[string]any{
+		"orderId": "{{orderId}}",
+		"items":   []any{map[string]any{"productId": "{{namespace}}product-1", "quantity": 1}},
+		"note":    "{{unknown}} stays",
+		"tags":    map[string]any{"{{namespace}}key": nil},
+	}
+	expanded := Expand(input, map[string]string{"orderId": "itest-order-1", "namespace": "itest-"})
+
+	assert.Equal(t, map[string]any{
+		"orderId": "itest-order-1",
+		"items":   []any{map[string]any{"productId": "itest-product-1", "quantity": 1}},
+		"note":    "{{unknown}} stays",
+		"tags":    map[string]any{"itest-key": nil},
+	}, expanded)
+	assert.Equal(t, "{{orderId}}", input["orderId"], "the input is left as it was")
+}
+
+func TestBodyMismatches(t *testing.T) {
+	vars := map[string]string{"namespace": "itest-"}
+	body := []byte(`{"id":"p-1","name":"itest-widget","price":10.0,"tags":["a"]}`)
+
+	mismatches, err := BodyMismatches(map[string]any{"name": "{{namespace}}widget", "price": 10, "tags": []any{"a"}}, body, vars)
+	require.NoError(t, err)
+	assert.Empty(t, mismatches, "YAML integers match JSON numbers")
+
+	mismatches, err = BodyMismatches(map[string]any{"name": "widget", "price": 12.5, "stock": 3}, body, vars)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name is itest-widget, want widget", "price is 10, want 12.5", "stock is missing, want 3"}, mismatches)
+
+	_, err = BodyMismatches(map[string]any{"name": "widget"}, []byte(`[]`), vars)
+	assert.ErrorContains(t, err, "not a JSON object")
+
+	mismatches, err = BodyMismatches(nil, []byte(`not json`), vars)
+	require.NoError(t, err, "a scenario expecting no fields accepts any body")
+	assert.Empty(t, mismatches)
+}
@@ -0,0 +1,74 @@
+// Package seed loads product datasets and upserts them into the products table
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// DefaultNamespace is the id prefix applied to seeded items when none is configured
+const DefaultNamespace = "itest-"
+
+// SeededByAttribute marks items written by the seed tooling so they can be told apart from real data
+const SeededByAttribute = "seeded_by"
+
+// Product mirrors the item layout written by the product service
+type Product struct {
+	ID    string  `json:"id"`
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+// LoadDataset reads a JSON array of products from path
+func LoadDataset(path string) ([]Product, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var products []Product
+	if err := json.Unmarshal(data, &products); err != nil {
+		return nil, fmt.Errorf("parsing dataset %s: %w", path, err)
+	}
+
+	for i, product := range products {
+		if product.ID == "" || product.Name == "" {
+			return nil, fmt.Errorf("dataset %s: product %d is missing id or name", path, i)
+		}
+	}
+	return products, nil
+}
+
+// Upsert writes every product into tableName with its id prefixed by namespace.
+// Items are keyed deterministically, so running it repeatedly leaves the table unchanged.
+func Upsert(ctx context.Context, client *dynamodb.Client, tableName, namespace string, products []Product) (int, error) {
+	written := 0
+	for _, product := range products {
+		_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+			TableName: aws.String(tableName),
+			Item:      Item(namespace, product),
+		})
+		if err != nil {
+			return written, fmt.Errorf("upserting product %s into %s: %w", product.ID, tableName, err)
+		}
+		written++
+	}
+	return written, nil
+}
+
+// Item converts a product into the DynamoDB item stored for it under namespace
+func Item(namespace string, product Product) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"id":              &types.AttributeValueMemberS{Value: namespace + product.ID},
+		"name":            &types.AttributeValueMemberS{Value: product.Name},
+		"price":           &types.AttributeValueMemberN{Value: strconv.FormatFloat(product.Price, 'f', -1, 64)},
+		SeededByAttribute: &types.AttributeValueMemberS{Value: namespace},
+	}
+}
@@ -0,0 +1,30 @@
+package seed
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDataset(t *testing.T) {
+	products, err := LoadDataset("../../testdata/products.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, products)
+
+	for _, product := range products {
+		assert.NotEmpty(t, product.ID)
+		assert.NotEmpty(t, product.Name)
+		assert.Greater(t, product.Price, 0.0)
+	}
+}
+
+func TestItemAppliesNamespace(t *testing.T) {
+	item := Item("demo-", Product{ID: "widget", Name: "Widget", Price: 9.5})
+
+	assert.Equal(t, "demo-widget", item["id"].(*types.AttributeValueMemberS).Value)
+	assert.Equal(t, "Widget", item["name"].(*types.AttributeValueMemberS).Value)
+	assert.Equal(t, "9.5", item["price"].(*types.AttributeValueMemberN).Value)
+	assert.Equal(t, "demo-", item[SeededByAttribute].(*types.AttributeValueMemberS).Value)
+}
@@ -0,0 +1,44 @@
+package seed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/dynamolocal"
+	"github.com/lambda-java-template/tests/internal/tfspec"
+)
+
+// productsSpec returns the generated expectation for the products table
+func productsSpec(t *testing.T) tfspec.TableSpec {
+	for _, spec := range tfspec.Tables {
+		if spec.NameSuffix == "products" {
+			return spec
+		}
+	}
+	t.Fatal("products table missing from tfspec")
+	return tfspec.TableSpec{}
+}
+
+func TestUpsertIsIdempotent(t *testing.T) {
+	client := dynamolocal.ForTest(t)
+	tableName := "local-test-products"
+	require.NoError(t, dynamolocal.CreateTable(context.TODO(), client, tableName, productsSpec(t)))
+
+	products, err := LoadDataset("../../testdata/products.json")
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		written, err := Upsert(context.TODO(), client, tableName, DefaultNamespace, products)
+		require.NoError(t, err)
+		assert.Equal(t, len(products), written)
+	}
+
+	scan, err := client.Scan(context.TODO(), &dynamodb.ScanInput{TableName: aws.String(tableName)})
+	require.NoError(t, err)
+	assert.Len(t, scan.Items, len(products), "Repeated upserts must not duplicate items")
+}
@@ -0,0 +1,29 @@
+// Package shard deterministically partitions named validators across parallel runners
+package shard
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// Validate checks that index addresses one of total shards
+func Validate(index, total int) error {
+	if total < 1 {
+		return fmt.Errorf("shard total must be at least 1, got %d", total)
+	}
+	if index < 0 || index >= total {
+		return fmt.Errorf("shard index must be in [0, %d), got %d", total, index)
+	}
+	return nil
+}
+
+// Includes reports whether the validator called name belongs to shard index of total.
+// Assignment hashes the name, so adding a validator never moves the others between shards.
+func Includes(name string, index, total int) bool {
+	if total <= 1 {
+		return true
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(name))
+	return int(hash.Sum32()%uint32(total)) == index
+}
@@ -0,0 +1,35 @@
+package shard
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncludesAssignsEachNameToExactlyOneShard(t *testing.T) {
+	const total = 4
+	for i := 0; i < 50; i++ {
+		name := fmt.Sprintf("Validator_%d", i)
+
+		owners := 0
+		for index := 0; index < total; index++ {
+			if Includes(name, index, total) {
+				owners++
+			}
+		}
+		assert.Equal(t, 1, owners, "validator %s should belong to exactly one shard", name)
+	}
+}
+
+func TestIncludesWithSingleShard(t *testing.T) {
+	assert.True(t, Includes("Lambda_Functions_Validation", 0, 1))
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(0, 1))
+	assert.NoError(t, Validate(2, 3))
+	assert.Error(t, Validate(3, 3))
+	assert.Error(t, Validate(-1, 3))
+	assert.Error(t, Validate(0, 0))
+}
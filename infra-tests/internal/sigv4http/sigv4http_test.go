@@ -0,0 +1,42 @@
+package sigv4http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransportSignsRequests(t *testing.T) {
+	var received *http.Request
+	var receivedBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ := io.ReadAll(r.Body)
+		receivedBody = string(body)
+	}))
+	defer server.Close()
+
+	client := NewClient(credentials.NewStaticCredentialsProvider("AKIDEXAMPLE", "secret", "session-token"), "eu-west-1")
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/products", strings.NewReader(`{"name":"Widget"}`))
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	authorization := received.Header.Get("Authorization")
+	assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"), authorization)
+	assert.Contains(t, authorization, "/eu-west-1/execute-api/aws4_request")
+	assert.NotEmpty(t, received.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "session-token", received.Header.Get("X-Amz-Security-Token"))
+	assert.Equal(t, `{"name":"Widget"}`, receivedBody, "Signing must not consume the request body")
+
+	// The caller's request is left unsigned
+	assert.Empty(t, req.Header.Get("Authorization"))
+}
@@ -0,0 +1,62 @@
+// Package stress summarizes a batch of concurrent workflow executions
+package stress
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Outcome is how one execution of the batch ended
+type Outcome struct {
+	Succeeded bool
+	Duration  time.Duration
+}
+
+// Summary describes a batch of executions
+type Summary struct {
+	Executions int
+	Succeeded  int
+	P50        time.Duration
+	P95        time.Duration
+}
+
+// SuccessRate returns the fraction of executions that succeeded
+func (s Summary) SuccessRate() float64 {
+	if s.Executions == 0 {
+		return 0
+	}
+	return float64(s.Succeeded) / float64(s.Executions)
+}
+
+// Summarize counts successes and computes duration percentiles over every execution, failed ones included
+func Summarize(outcomes []Outcome) Summary {
+	summary := Summary{Executions: len(outcomes)}
+	durations := make([]time.Duration, 0, len(outcomes))
+	for _, outcome := range outcomes {
+		if outcome.Succeeded {
+			summary.Succeeded++
+		}
+		durations = append(durations, outcome.Duration)
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	summary.P50 = Percentile(durations, 50)
+	summary.P95 = Percentile(durations, 95)
+	return summary
+}
+
+// Percentile returns the nearest-rank percentile p (0-100] of sorted durations, or 0 when there are none
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
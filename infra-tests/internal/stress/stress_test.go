@@ -0,0 +1,33 @@
+package stress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarize(t *testing.T) {
+	var outcomes []Outcome
+	for i := 1; i <= 20; i++ {
+		outcomes = append(outcomes, Outcome{Succeeded: i != 7, Duration: time.Duration(i) * time.Second})
+	}
+
+	summary := Summarize(outcomes)
+	assert.Equal(t, Summary{Executions: 20, Succeeded: 19, P50: 10 * time.Second, P95: 19 * time.Second}, summary)
+	assert.InDelta(t, 0.95, summary.SuccessRate(), 1e-9)
+}
+
+func TestSummarizeEmpty(t *testing.T) {
+	summary := Summarize(nil)
+	assert.Equal(t, Summary{}, summary)
+	assert.Zero(t, summary.SuccessRate())
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}
+	assert.Equal(t, time.Second, Percentile(sorted, 0))
+	assert.Equal(t, 2*time.Second, Percentile(sorted, 50))
+	assert.Equal(t, 3*time.Second, Percentile(sorted, 95))
+	assert.Equal(t, 3*time.Second, Percentile(sorted, 100))
+}
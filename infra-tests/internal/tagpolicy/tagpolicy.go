@@ -0,0 +1,133 @@
+// Package tagpolicy declares the tags every resource of a deployment must carry and checks resources
+// against them, reporting every violation at once.
+//
+// Resources are found through the Resource Groups Tagging API by type rather than by tag, so a resource
+// that lost one required tag is still found by its name or its remaining tags. The API only knows resources
+// that carry, or once carried, at least one tag; a resource that was never tagged cannot be found this way.
+package tagpolicy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+
+	"github.com/lambda-java-template/tests/internal/inventory"
+)
+
+// ResourceTypes are the Tagging API type filters of every resource type the stack deploys
+var ResourceTypes = []string{
+	"lambda:function",
+	"dynamodb:table",
+	"states:stateMachine",
+	"apigateway",
+	"logs:log-group",
+	"cloudwatch:alarm",
+	"sns:topic",
+	"sqs",
+	"s3",
+	"events",
+	"kms:key",
+}
+
+// Rule requires a tag. An empty Allowed accepts any non-empty value.
+type Rule struct {
+	Key     string
+	Allowed []string
+}
+
+// Policy is the set of tags every resource must carry
+type Policy struct {
+	Rules []Rule
+}
+
+// Required is the policy of a deployment: the tags the AWS provider's default_tags put on every resource
+func Required(projectName, environment string) Policy {
+	return Policy{Rules: []Rule{
+		{Key: "Project", Allowed: []string{projectName}},
+		{Key: "Environment", Allowed: []string{environment}},
+		{Key: "ManagedBy", Allowed: []string{"terraform"}},
+	}}
+}
+
+// Keys returns the keys of the required tags
+func (p Policy) Keys() []string {
+	keys := make([]string, 0, len(p.Rules))
+	for _, rule := range p.Rules {
+		keys = append(keys, rule.Key)
+	}
+	return keys
+}
+
+// Violation is one required tag a resource is missing or carries with a value the policy does not allow
+type Violation struct {
+	ARN   string
+	Key   string
+	Value string
+	// Missing is set when the resource does not carry the tag at all
+	Missing bool
+}
+
+func (v Violation) String() string {
+	if v.Missing {
+		return fmt.Sprintf("%s is missing tag %s", v.ARN, v.Key)
+	}
+	return fmt.Sprintf("%s has tag %s=%q, which the policy does not allow", v.ARN, v.Key, v.Value)
+}
+
+// Check returns every violation of the policy by every resource, in resource order
+func (p Policy) Check(resources []inventory.Resource) []Violation {
+	var violations []Violation
+	for _, resource := range resources {
+		for _, rule := range p.Rules {
+			value, ok := resource.Tags[rule.Key]
+			switch {
+			case !ok || value == "":
+				violations = append(violations, Violation{ARN: resource.ARN, Key: rule.Key, Missing: true})
+			case len(rule.Allowed) > 0 && !slices.Contains(rule.Allowed, value):
+				violations = append(violations, Violation{ARN: resource.ARN, Key: rule.Key, Value: value})
+			}
+		}
+	}
+	return violations
+}
+
+// TaggingAPI is the subset of the Resource Groups Tagging API used to discover resources
+type TaggingAPI interface {
+	GetResources(ctx context.Context, params *resourcegroupstaggingapi.GetResourcesInput, optFns ...func(*resourcegroupstaggingapi.Options)) (*resourcegroupstaggingapi.GetResourcesOutput, error)
+}
+
+// Discover returns the resources of ResourceTypes that belong to a deployment: those whose name contains
+// baseName, such as lambda-java-template-dev, and those tagged with its project and environment
+func Discover(ctx context.Context, client TaggingAPI, baseName, projectName, environment string) ([]inventory.Resource, error) {
+	var resources []inventory.Resource
+	paginator := resourcegroupstaggingapi.NewGetResourcesPaginator(client, &resourcegroupstaggingapi.GetResourcesInput{
+		ResourceTypeFilters: ResourceTypes,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing resources by type: %w", err)
+		}
+
+		for _, mapping := range page.ResourceTagMappingList {
+			resource, err := inventory.NewResource(aws.ToString(mapping.ResourceARN))
+			if err != nil {
+				return nil, err
+			}
+			resource.Tags = make(map[string]string, len(mapping.Tags))
+			for _, tag := range mapping.Tags {
+				resource.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+
+			tagged := resource.Tags["Project"] == projectName && resource.Tags["Environment"] == environment
+			if tagged || strings.Contains(resource.Name, baseName+"-") || resource.Name == baseName {
+				resources = append(resources, resource)
+			}
+		}
+	}
+	return resources, nil
+}
@@ -0,0 +1,80 @@
+package tagpolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	taggingtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/inventory"
+)
+
+// fakeTagging serves one page of resources and records the request
+type fakeTagging struct {
+	mappings []taggingtypes.ResourceTagMapping
+	input    *resourcegroupstaggingapi.GetResourcesInput
+}
+
+func (f *fakeTagging) GetResources(_ context.Context, params *resourcegroupstaggingapi.GetResourcesInput, _ ...func(*resourcegroupstaggingapi.Options)) (*resourcegroupstaggingapi.GetResourcesOutput, error) {
+	f.input = params
+	return &resourcegroupstaggingapi.GetResourcesOutput{ResourceTagMappingList: f.mappings}, nil
+}
+
+func mapping(arn string, tags map[string]string) taggingtypes.ResourceTagMapping {
+	m := taggingtypes.ResourceTagMapping{ResourceARN: aws.String(arn)}
+	for key, value := range tags {
+		m.Tags = append(m.Tags, taggingtypes.Tag{Key: aws.String(key), Value: aws.String(value)})
+	}
+	return m
+}
+
+func TestCheck(t *testing.T) {
+	policy := Required("app", "dev")
+	resources := []inventory.Resource{
+		{ARN: "compliant", Tags: map[string]string{"Project": "app", "Environment": "dev", "ManagedBy": "terraform"}},
+		{ARN: "drifted", Tags: map[string]string{"Project": "app", "Environment": "prod", "ManagedBy": ""}},
+		{ARN: "untagged"},
+	}
+
+	var reported []string
+	for _, violation := range policy.Check(resources) {
+		reported = append(reported, violation.String())
+	}
+	assert.Equal(t, []string{
+		`drifted has tag Environment="prod", which the policy does not allow`,
+		"drifted is missing tag ManagedBy",
+		"untagged is missing tag Project",
+		"untagged is missing tag Environment",
+		"untagged is missing tag ManagedBy",
+	}, reported, "Every violation is reported in one pass")
+
+	anyOwner := Policy{Rules: []Rule{{Key: "Owner"}}}
+	assert.Empty(t, anyOwner.Check([]inventory.Resource{{ARN: "owned", Tags: map[string]string{"Owner": "payments"}}}),
+		"A rule without allowed values accepts any value")
+	assert.Equal(t, []string{"Project", "Environment", "ManagedBy"}, policy.Keys())
+}
+
+func TestDiscover(t *testing.T) {
+	client := &fakeTagging{mappings: []taggingtypes.ResourceTagMapping{
+		mapping("arn:aws:lambda:us-east-1:123456789012:function:app-dev-product-service", map[string]string{"Project": "app"}),
+		mapping("arn:aws:logs:us-east-1:123456789012:log-group:/aws/lambda/app-dev-product-service", map[string]string{"Team": "x"}),
+		mapping("arn:aws:apigateway:us-east-1::/apis/abc123", map[string]string{"Project": "app", "Environment": "dev"}),
+		mapping("arn:aws:dynamodb:us-east-1:123456789012:table/app-prod-products", map[string]string{"Project": "app", "Environment": "prod"}),
+		mapping("arn:aws:lambda:us-east-1:123456789012:function:app-dev2-product-service", nil),
+	}}
+
+	resources, err := Discover(context.TODO(), client, "app-dev", "app", "dev")
+	require.NoError(t, err)
+	var names []string
+	for _, resource := range resources {
+		names = append(names, resource.Name)
+	}
+	assert.Equal(t, []string{"app-dev-product-service", "/aws/lambda/app-dev-product-service", "abc123"}, names,
+		"Resources are matched by name even when their tags drifted, and by tags when their name is generated")
+	assert.Empty(t, client.input.TagFilters, "Filtering by tag would hide resources missing the tag")
+	assert.Equal(t, ResourceTypes, client.input.ResourceTypeFilters)
+}
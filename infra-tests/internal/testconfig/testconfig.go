@@ -0,0 +1,366 @@
+// Package testconfig loads the parameters that select the deployment under test.
+//
+// Values start from the template defaults, are overridden by an optional YAML or JSON file
+// named by TEST_CONFIG_FILE, and finally by individual environment variables:
+//
+//	TEST_PROJECT_NAME      project name used in resource names and tags
+//	TEST_ENVIRONMENT       environment (or namespace) used in resource names
+//	TEST_ENVIRONMENTS      comma-separated environments to run the suite against one after another
+//	TEST_AWS_REGION        region of the deployment (falls back to AWS_REGION)
+//	TEST_API_URL           API Gateway endpoint, skipping API discovery
+//	TEST_STATE_MACHINE_ARN state machine ARN, skipping state machine discovery
+//	TEST_TERRAFORM_DIR     Terraform working directory whose outputs are used for discovery
+//	TEST_TARGET            "aws" (default) or "localstack"
+//	TEST_LOCALSTACK_URL    LocalStack edge endpoint used when TEST_TARGET=localstack
+//	TEST_RESULTS_FILE      path the JSON results summary is written to
+//	TEST_POLL_INTERVAL     first wait between polls of eventually consistent state, e.g. "500ms"
+//	TEST_LOG_SHIPPING_ARN  destination ARN (or prefix) every log group must have a subscription filter to
+//	TEST_MAX_TRANSITIONS   most state transitions one workflow execution may take
+//	TEST_REVOKED_API_KEY   an API key the authorizer must deny, e.g. one rotated out of the key store
+//	TEST_ROLE_ARN          role in the target account assumed before any client is built
+//	TEST_ROLE_EXTERNAL_ID  external ID the role's trust policy requires
+//	TEST_ROLE_SESSION_NAME session name of the assumed role, shown in CloudTrail (default "infra-tests")
+//	TEST_EXPECTED_COMMIT   git commit every function must have been built from, as recorded in its build metadata
+package testconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
+	"gopkg.in/yaml.v3"
+
+	"github.com/lambda-java-template/tests/internal/ratelimit"
+	"github.com/lambda-java-template/tests/internal/wait"
+)
+
+// Config selects the deployment the suite validates
+type Config struct {
+	ProjectName     string `yaml:"projectName" json:"projectName"`
+	Environment     string `yaml:"environment" json:"environment"`
+	Environments    string `yaml:"environments" json:"environments"`
+	Region          string `yaml:"region" json:"region"`
+	APIURL          string `yaml:"apiUrl" json:"apiUrl"`
+	StateMachineARN string `yaml:"stateMachineArn" json:"stateMachineArn"`
+	TerraformDir    string `yaml:"terraformDir" json:"terraformDir"`
+	Target          string `yaml:"target" json:"target"`
+	LocalStackURL   string `yaml:"localstackUrl" json:"localstackUrl"`
+	ResultsFile     string `yaml:"resultsFile" json:"resultsFile"`
+	PollInterval    string `yaml:"pollInterval" json:"pollInterval"`
+	LogShippingARN  string `yaml:"logShippingArn" json:"logShippingArn"`
+	MaxTransitions  string `yaml:"maxTransitions" json:"maxTransitions"`
+	RevokedAPIKey   string `yaml:"revokedApiKey" json:"revokedApiKey"`
+	RoleARN         string `yaml:"roleArn" json:"roleArn"`
+	ExternalID      string `yaml:"externalId" json:"externalId"`
+	RoleSessionName string `yaml:"roleSessionName" json:"roleSessionName"`
+	ExpectedCommit  string `yaml:"expectedCommit" json:"expectedCommit"`
+}
+
+// DefaultRoleSessionName names the session of an assumed role when RoleSessionName is unset
+const DefaultRoleSessionName = "infra-tests"
+
+// roleSessionName matches the session names STS accepts
+var roleSessionName = regexp.MustCompile(`^[\w+=,.@-]{2,64}$`)
+
+// commitSHA matches a full or abbreviated git commit hash
+var commitSHA = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// Targets the suite can run against
+const (
+	TargetAWS        = "aws"
+	TargetLocalStack = "localstack"
+)
+
+// Feature names an AWS capability that not every target emulates
+type Feature string
+
+// Features validators gate on
+const (
+	FeatureXRay              Feature = "X-Ray tracing"
+	FeatureHTTPSEndpoints    Feature = "HTTPS API endpoints"
+	FeatureRuntimeManagement Feature = "Lambda runtime management"
+	FeatureRecursionControl  Feature = "Lambda recursive loop detection"
+	FeatureLogsInsights      Feature = "CloudWatch Logs Insights"
+	FeatureServiceMetrics    Feature = "AWS service CloudWatch metrics"
+	FeatureEmbeddedMetrics   Feature = "CloudWatch embedded metric format"
+	FeatureTrustedAdvisor    Feature = "Trusted Advisor"
+)
+
+// localStackUnsupported lists features LocalStack's community edition does not emulate
+var localStackUnsupported = map[Feature]bool{
+	FeatureXRay:              true,
+	FeatureHTTPSEndpoints:    true,
+	FeatureRuntimeManagement: true,
+	FeatureRecursionControl:  true,
+	FeatureLogsInsights:      true,
+	FeatureServiceMetrics:    true,
+	FeatureEmbeddedMetrics:   true,
+	FeatureTrustedAdvisor:    true,
+}
+
+// Default returns the configuration of the template's dev deployment
+func Default() Config {
+	return Config{
+		ProjectName: "lambda-java-template",
+		Environment: "dev",
+		Region:      "us-east-1",
+		Target:      TargetAWS,
+	}
+}
+
+// Load returns the defaults overlaid with TEST_CONFIG_FILE and TEST_* environment variables
+func Load() (Config, error) {
+	cfg := Default()
+
+	if path := os.Getenv("TEST_CONFIG_FILE"); path != "" {
+		if err := cfg.overlayFile(path); err != nil {
+			return cfg, err
+		}
+	}
+
+	overlay := func(target *string, names ...string) {
+		for _, name := range names {
+			if value := os.Getenv(name); value != "" {
+				*target = value
+				return
+			}
+		}
+	}
+	overlay(&cfg.ProjectName, "TEST_PROJECT_NAME")
+	overlay(&cfg.Environment, "TEST_ENVIRONMENT")
+	overlay(&cfg.Environments, "TEST_ENVIRONMENTS")
+	overlay(&cfg.Region, "TEST_AWS_REGION", "AWS_REGION")
+	overlay(&cfg.APIURL, "TEST_API_URL")
+	overlay(&cfg.StateMachineARN, "TEST_STATE_MACHINE_ARN")
+	overlay(&cfg.TerraformDir, "TEST_TERRAFORM_DIR")
+	overlay(&cfg.Target, "TEST_TARGET")
+	overlay(&cfg.LocalStackURL, "TEST_LOCALSTACK_URL")
+	overlay(&cfg.ResultsFile, "TEST_RESULTS_FILE")
+	overlay(&cfg.PollInterval, "TEST_POLL_INTERVAL")
+	overlay(&cfg.LogShippingARN, "TEST_LOG_SHIPPING_ARN")
+	overlay(&cfg.MaxTransitions, "TEST_MAX_TRANSITIONS")
+	overlay(&cfg.RevokedAPIKey, "TEST_REVOKED_API_KEY")
+	overlay(&cfg.RoleARN, "TEST_ROLE_ARN")
+	overlay(&cfg.ExternalID, "TEST_ROLE_EXTERNAL_ID")
+	overlay(&cfg.RoleSessionName, "TEST_ROLE_SESSION_NAME")
+	overlay(&cfg.ExpectedCommit, "TEST_EXPECTED_COMMIT")
+
+	if cfg.IsLocalStack() && cfg.LocalStackURL == "" {
+		cfg.LocalStackURL = "http://localhost:4566"
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// overlayFile replaces fields with the non-empty values of a YAML or JSON file
+func (c *Config) overlayFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading test config: %w", err)
+	}
+
+	// JSON is valid YAML, so one decoder handles both formats
+	var file Config
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("parsing test config %s: %w", path, err)
+	}
+
+	for _, field := range []struct {
+		target *string
+		value  string
+	}{
+		{&c.ProjectName, file.ProjectName},
+		{&c.Environment, file.Environment},
+		{&c.Environments, file.Environments},
+		{&c.Region, file.Region},
+		{&c.APIURL, file.APIURL},
+		{&c.StateMachineARN, file.StateMachineARN},
+		{&c.TerraformDir, file.TerraformDir},
+		{&c.Target, file.Target},
+		{&c.LocalStackURL, file.LocalStackURL},
+		{&c.ResultsFile, file.ResultsFile},
+		{&c.PollInterval, file.PollInterval},
+		{&c.LogShippingARN, file.LogShippingARN},
+		{&c.MaxTransitions, file.MaxTransitions},
+		{&c.RevokedAPIKey, file.RevokedAPIKey},
+		{&c.RoleARN, file.RoleARN},
+		{&c.ExternalID, file.ExternalID},
+		{&c.RoleSessionName, file.RoleSessionName},
+		{&c.ExpectedCommit, file.ExpectedCommit},
+	} {
+		if field.value != "" {
+			*field.target = field.value
+		}
+	}
+	return nil
+}
+
+// Validate reports missing required values
+func (c Config) Validate() error {
+	var missing []string
+	if c.ProjectName == "" {
+		missing = append(missing, "project name")
+	}
+	if c.Environment == "" {
+		missing = append(missing, "environment")
+	}
+	if c.Region == "" {
+		missing = append(missing, "region")
+	}
+	if len(missing) > 0 {
+		return errors.New("test config is missing " + strings.Join(missing, ", "))
+	}
+	if c.Target != TargetAWS && c.Target != TargetLocalStack {
+		return fmt.Errorf("test config target %q must be %q or %q", c.Target, TargetAWS, TargetLocalStack)
+	}
+	if c.IsLocalStack() && !strings.HasPrefix(c.LocalStackURL, "http") {
+		return fmt.Errorf("test config LocalStack URL %q must be an http(s) URL", c.LocalStackURL)
+	}
+	if c.PollInterval != "" {
+		if interval, err := time.ParseDuration(c.PollInterval); err != nil || interval <= 0 {
+			return fmt.Errorf("test config poll interval %q must be a positive duration", c.PollInterval)
+		}
+	}
+	if c.MaxTransitions != "" {
+		if budget, err := strconv.Atoi(c.MaxTransitions); err != nil || budget <= 0 {
+			return fmt.Errorf("test config max transitions %q must be a positive integer", c.MaxTransitions)
+		}
+	}
+	if c.RoleARN == "" && (c.ExternalID != "" || c.RoleSessionName != "") {
+		return errors.New("test config role external ID and session name need a role ARN")
+	}
+	if c.RoleARN != "" && (!strings.HasPrefix(c.RoleARN, "arn:") || !strings.Contains(c.RoleARN, ":role/")) {
+		return fmt.Errorf("test config role ARN %q is not an IAM role ARN", c.RoleARN)
+	}
+	if c.RoleSessionName != "" && !roleSessionName.MatchString(c.RoleSessionName) {
+		return fmt.Errorf("test config role session name %q must be 2-64 letters, digits, or +=,.@_-", c.RoleSessionName)
+	}
+	if c.ExpectedCommit != "" && !commitSHA.MatchString(c.ExpectedCommit) {
+		return fmt.Errorf("test config expected commit %q must be a git commit hash", c.ExpectedCommit)
+	}
+	if len(c.EnvironmentNames()) > 1 && (c.APIURL != "" || c.StateMachineARN != "") {
+		return errors.New("test config API URL and state machine ARN select one deployment, so they cannot be set with several environments")
+	}
+	if c.APIURL != "" && c.Supports(FeatureHTTPSEndpoints) && !strings.HasPrefix(c.APIURL, "https://") {
+		return fmt.Errorf("test config API URL %q must use https", c.APIURL)
+	}
+	return nil
+}
+
+// BaseName returns the "<project>-<environment>" prefix of resource names
+func (c Config) BaseName() string {
+	return fmt.Sprintf("%s-%s", c.ProjectName, c.Environment)
+}
+
+// EnvironmentNames returns the environments the suite runs against: those listed in Environments,
+// or only Environment when none are
+func (c Config) EnvironmentNames() []string {
+	var names []string
+	for _, name := range strings.Split(c.Environments, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return []string{c.Environment}
+	}
+	return names
+}
+
+// EnvironmentResultsFile returns where the results summary of one of several environments is written:
+// ResultsFile with the environment appended to its name, or "" when no summary is written
+func (c Config) EnvironmentResultsFile(environment string) string {
+	return EnvironmentFile(c.ResultsFile, environment)
+}
+
+// EnvironmentFile returns path with the environment appended to its name, e.g. results-dev.json,
+// or "" when path is empty
+func EnvironmentFile(path, environment string) string {
+	if path == "" {
+		return ""
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + environment + ext
+}
+
+// Backoff returns the polling backoff, starting at PollInterval when one is set
+func (c Config) Backoff() wait.Backoff {
+	backoff := wait.DefaultBackoff
+	if interval, err := time.ParseDuration(c.PollInterval); err == nil && interval > 0 {
+		backoff.Initial = interval
+	}
+	return backoff
+}
+
+// DefaultTransitionBudget is the most state transitions one workflow execution may take when
+// MaxTransitions is unset; a happy-path order takes about a dozen
+const DefaultTransitionBudget = 25
+
+// TransitionBudget returns the most state transitions one workflow execution may take
+func (c Config) TransitionBudget() int {
+	if budget, err := strconv.Atoi(c.MaxTransitions); err == nil && budget > 0 {
+		return budget
+	}
+	return DefaultTransitionBudget
+}
+
+// IsLocalStack reports whether the suite targets a LocalStack endpoint instead of AWS
+func (c Config) IsLocalStack() bool {
+	return c.Target == TargetLocalStack
+}
+
+// Supports reports whether the target emulates a feature
+func (c Config) Supports(feature Feature) bool {
+	return !c.IsLocalStack() || !localStackUnsupported[feature]
+}
+
+// AWSConfig loads AWS credentials for the configured region.
+// Against LocalStack every client is pointed at its endpoint with static dummy credentials.
+// With a RoleARN the loaded credentials only assume that role, so a central CI account can
+// validate deployments in other accounts; the assumed credentials are refreshed before they expire.
+// Every client's calls are paced by ratelimit.Shared, and throttled calls are retried in adaptive mode, which
+// slows the client down for as long as AWS keeps throttling it.
+func (c Config) AWSConfig(ctx context.Context) (aws.Config, error) {
+	options := []func(*config.LoadOptions) error{
+		config.WithRegion(c.Region),
+		config.WithAPIOptions([]func(*middleware.Stack) error{ratelimit.Shared.Middleware}),
+		config.WithRetryer(func() aws.Retryer { return retry.NewAdaptiveMode() }),
+	}
+	if c.IsLocalStack() {
+		options = append(options,
+			config.WithBaseEndpoint(c.LocalStackURL),
+			config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider("test", "test", "")),
+		)
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, options...)
+	if err != nil || c.RoleARN == "" {
+		return cfg, err
+	}
+
+	cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), c.RoleARN, c.assumeRoleOptions))
+	return cfg, nil
+}
+
+// assumeRoleOptions applies the configured session name and external ID to the role assumption
+func (c Config) assumeRoleOptions(options *stscreds.AssumeRoleOptions) {
+	options.RoleSessionName = c.RoleSessionName
+	if options.RoleSessionName == "" {
+		options.RoleSessionName = DefaultRoleSessionName
+	}
+	if c.ExternalID != "" {
+		options.ExternalID = aws.String(c.ExternalID)
+	}
+}
@@ -0,0 +1,175 @@
+package testconfig
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDefaults(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("TEST_TARGET", "")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, Default(), cfg)
+	assert.Equal(t, "lambda-java-template-dev", cfg.BaseName())
+}
+
+func TestLoadFileThenEnvironment(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "staging.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("environment: staging\nregion: eu-west-1\napiUrl: https://abc123.execute-api.eu-west-1.amazonaws.com\n"), 0o644))
+
+	t.Setenv("TEST_CONFIG_FILE", path)
+	t.Setenv("TEST_AWS_REGION", "eu-central-1")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "lambda-java-template", cfg.ProjectName, "Unset values keep their defaults")
+	assert.Equal(t, "staging", cfg.Environment)
+	assert.Equal(t, "eu-central-1", cfg.Region, "Environment variables override the file")
+	assert.Equal(t, "https://abc123.execute-api.eu-west-1.amazonaws.com", cfg.APIURL)
+}
+
+func TestLoadJSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"projectName": "orders", "environment": "prod"}`), 0o644))
+	t.Setenv("TEST_CONFIG_FILE", path)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "orders-prod", cfg.BaseName())
+}
+
+func TestValidate(t *testing.T) {
+	cfg := Default()
+	cfg.APIURL = "http://insecure.example.com"
+	assert.Error(t, cfg.Validate())
+
+	assert.EqualError(t, Config{Region: "us-east-1"}.Validate(), "test config is missing project name, environment")
+}
+
+func TestLocalStackTarget(t *testing.T) {
+	t.Setenv("TEST_TARGET", TargetLocalStack)
+	t.Setenv("TEST_API_URL", "http://abc123.execute-api.localhost.localstack.cloud:4566")
+
+	cfg, err := Load()
+	require.NoError(t, err, "LocalStack serves plain HTTP endpoints")
+	assert.True(t, cfg.IsLocalStack())
+	assert.Equal(t, "http://localhost:4566", cfg.LocalStackURL)
+	assert.False(t, cfg.Supports(FeatureXRay))
+	assert.True(t, Default().Supports(FeatureXRay))
+
+	awsCfg, err := cfg.AWSConfig(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, awsCfg.BaseEndpoint)
+	assert.Equal(t, "http://localhost:4566", *awsCfg.BaseEndpoint)
+
+	credentials, err := awsCfg.Credentials.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "test", credentials.AccessKeyID)
+}
+
+func TestValidateTarget(t *testing.T) {
+	cfg := Default()
+	cfg.Target = "azure"
+	assert.Error(t, cfg.Validate())
+}
+
+func TestPollInterval(t *testing.T) {
+	assert.Equal(t, time.Second, Default().Backoff().Initial)
+
+	t.Setenv("TEST_POLL_INTERVAL", "250ms")
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 250*time.Millisecond, cfg.Backoff().Initial)
+
+	cfg.PollInterval = "soon"
+	assert.Error(t, cfg.Validate())
+}
+
+func TestTransitionBudget(t *testing.T) {
+	assert.Equal(t, DefaultTransitionBudget, Default().TransitionBudget())
+
+	t.Setenv("TEST_MAX_TRANSITIONS", "40")
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 40, cfg.TransitionBudget())
+
+	cfg.MaxTransitions = "0"
+	assert.Error(t, cfg.Validate())
+}
+
+func TestEnvironmentNames(t *testing.T) {
+	assert.Equal(t, []string{"dev"}, Default().EnvironmentNames())
+
+	t.Setenv("TEST_ENVIRONMENTS", "dev, staging,,prod ")
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev", "staging", "prod"}, cfg.EnvironmentNames())
+
+	cfg.APIURL = "https://abc123.execute-api.us-east-1.amazonaws.com"
+	assert.Error(t, cfg.Validate(), "An explicit API URL cannot serve several environments")
+}
+
+func TestEnvironmentResultsFile(t *testing.T) {
+	cfg := Default()
+	assert.Empty(t, cfg.EnvironmentResultsFile("prod"))
+
+	cfg.ResultsFile = "out/results.json"
+	assert.Equal(t, "out/results-prod.json", cfg.EnvironmentResultsFile("prod"))
+}
+
+func TestAssumeRole(t *testing.T) {
+	t.Setenv("TEST_ROLE_ARN", "arn:aws:iam::123456789012:role/infra-tests")
+	t.Setenv("TEST_ROLE_EXTERNAL_ID", "ci-1234")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	awsCfg, err := cfg.AWSConfig(context.Background())
+	require.NoError(t, err)
+	cache, ok := awsCfg.Credentials.(*aws.CredentialsCache)
+	require.True(t, ok, "Assumed role credentials are cached")
+	assert.True(t, cache.IsCredentialsProvider(&stscreds.AssumeRoleProvider{}))
+
+	var options stscreds.AssumeRoleOptions
+	cfg.assumeRoleOptions(&options)
+	assert.Equal(t, DefaultRoleSessionName, options.RoleSessionName)
+	assert.Equal(t, "ci-1234", aws.ToString(options.ExternalID))
+
+	cfg.RoleSessionName = "github-run-42"
+	cfg.assumeRoleOptions(&options)
+	assert.Equal(t, "github-run-42", options.RoleSessionName)
+}
+
+func TestValidateRole(t *testing.T) {
+	cfg := Default()
+	cfg.ExternalID = "ci-1234"
+	assert.Error(t, cfg.Validate(), "An external ID needs a role to assume")
+
+	cfg.RoleARN = "arn:aws:iam::123456789012:user/ci"
+	assert.Error(t, cfg.Validate())
+
+	cfg.RoleARN = "arn:aws:iam::123456789012:role/infra-tests"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.RoleSessionName = "run #42"
+	assert.Error(t, cfg.Validate())
+}
+
+func TestValidateExpectedCommit(t *testing.T) {
+	cfg := Default()
+	cfg.ExpectedCommit = "d1e7014"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.ExpectedCommit = "main"
+	assert.Error(t, cfg.Validate(), "A branch name is not a commit")
+}
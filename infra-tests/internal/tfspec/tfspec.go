@@ -0,0 +1,77 @@
+// Package tfspec holds infrastructure expectations generated from the Terraform configuration.
+//
+// The data in tfspec_gen.go is written by cmd/genexpectations from the committed
+// `terraform show -json` snapshot and must not be edited by hand. After changing the
+// Terraform code, refresh the snapshot and regenerate:
+//
+//	(cd ../terraform && terraform show -json) > testdata/terraform-show-dev.json
+//	go generate ./internal/tfspec
+package tfspec
+
+//go:generate go run ../../cmd/genexpectations -input ../../testdata/terraform-show-dev.json -out tfspec_gen.go
+
+import "fmt"
+
+// FunctionSpec describes a Lambda function declared in Terraform
+type FunctionSpec struct {
+	// NameSuffix is the function name without the "<project>-<environment>-" prefix
+	NameSuffix    string
+	Runtime       string
+	Handler       string
+	Architectures []string
+	// Layers are the ARNs of the layer versions attached, in order
+	Layers          []string
+	MemorySize      int32
+	Timeout         int32
+	TracingMode     string
+	EnvironmentKeys []string
+	// ReservedConcurrency is the function's reserved concurrency, or -1 when it draws from the unreserved pool
+	ReservedConcurrency int32
+}
+
+// FunctionName returns the deployed name of the function in an environment
+func (f FunctionSpec) FunctionName(projectName, environment string) string {
+	return fmt.Sprintf("%s-%s-%s", projectName, environment, f.NameSuffix)
+}
+
+// IndexSpec describes a global secondary index
+type IndexSpec struct {
+	Name           string
+	HashKey        string
+	RangeKey       string
+	ProjectionType string
+}
+
+// TableSpec describes a DynamoDB table declared in Terraform
+type TableSpec struct {
+	// NameSuffix is the table name without the "<project>-<environment>-" prefix
+	NameSuffix             string
+	HashKey                string
+	RangeKey               string
+	BillingMode            string
+	GlobalSecondaryIndexes []IndexSpec
+	PointInTimeRecovery    bool
+	Encrypted              bool
+	TTLAttribute           string
+	StreamEnabled          bool
+}
+
+// TableName returns the deployed name of the table in an environment
+func (t TableSpec) TableName(projectName, environment string) string {
+	return fmt.Sprintf("%s-%s-%s", projectName, environment, t.NameSuffix)
+}
+
+// RouteSpec describes an API Gateway route declared in Terraform
+type RouteSpec struct {
+	RouteKey          string
+	AuthorizationType string
+}
+
+// RouteKeys returns the key of every route
+func RouteKeys() []string {
+	keys := make([]string, len(Routes))
+	for i, route := range Routes {
+		keys[i] = route.RouteKey
+	}
+	return keys
+}
@@ -0,0 +1,69 @@
+// Code generated by cmd/genexpectations; DO NOT EDIT.
+
+package tfspec
+
+// Functions are the Lambda functions declared in Terraform
+var Functions = []FunctionSpec{
+	{
+		NameSuffix:          "authorizer-service",
+		Runtime:             "java21",
+		Handler:             "software.amazonaws.example.product.AuthorizerHandler::handleRequest",
+		Architectures:       []string{"x86_64"},
+		Layers:              []string{},
+		MemorySize:          256,
+		Timeout:             30,
+		TracingMode:         "Active",
+		EnvironmentKeys:     []string{"ENVIRONMENT", "LOG_LEVEL"},
+		ReservedConcurrency: -1,
+	},
+	{
+		NameSuffix:          "product-service",
+		Runtime:             "java21",
+		Handler:             "org.springframework.boot.loader.launch.JarLauncher",
+		Architectures:       []string{"x86_64"},
+		Layers:              []string{},
+		MemorySize:          512,
+		Timeout:             30,
+		TracingMode:         "Active",
+		EnvironmentKeys:     []string{"AUDIT_TABLE_NAME", "ENVIRONMENT", "LOG_LEVEL", "MAIN_CLASS", "PRODUCTS_TABLE_NAME", "SPRING_CLOUD_FUNCTION_DEFINITION"},
+		ReservedConcurrency: -1,
+	},
+}
+
+// Tables are the DynamoDB tables declared in Terraform
+var Tables = []TableSpec{
+	{
+		NameSuffix:             "audit-logs",
+		HashKey:                "event_id",
+		RangeKey:               "timestamp",
+		BillingMode:            "PAY_PER_REQUEST",
+		GlobalSecondaryIndexes: []IndexSpec{},
+		PointInTimeRecovery:    false,
+		Encrypted:              true,
+		TTLAttribute:           "ttl",
+		StreamEnabled:          false,
+	},
+	{
+		NameSuffix:  "products",
+		HashKey:     "id",
+		RangeKey:    "",
+		BillingMode: "PAY_PER_REQUEST",
+		GlobalSecondaryIndexes: []IndexSpec{
+			{Name: "name-index", HashKey: "name", RangeKey: "", ProjectionType: "ALL"},
+		},
+		PointInTimeRecovery: true,
+		Encrypted:           true,
+		TTLAttribute:        "",
+		StreamEnabled:       false,
+	},
+}
+
+// Routes are the API Gateway routes declared in Terraform
+var Routes = []RouteSpec{
+	{RouteKey: "DELETE /products/{id}", AuthorizationType: "CUSTOM"},
+	{RouteKey: "GET /health", AuthorizationType: "NONE"},
+	{RouteKey: "GET /products", AuthorizationType: "CUSTOM"},
+	{RouteKey: "GET /products/{id}", AuthorizationType: "CUSTOM"},
+	{RouteKey: "POST /products", AuthorizationType: "CUSTOM"},
+	{RouteKey: "PUT /products/{id}", AuthorizationType: "CUSTOM"},
+}
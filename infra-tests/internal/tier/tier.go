@@ -0,0 +1,43 @@
+// Package tier groups validators into cumulative suites, so pre-merge runs stay fast and release gates go deep:
+// smoke runs fast read-only checks, full adds CRUD and workflow end-to-end runs, and soak adds load and chaos.
+package tier
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tier is a suite; each includes the validators of the tiers before it
+type Tier int
+
+// Tiers from the fastest to the deepest
+const (
+	Smoke Tier = iota
+	Full
+	Soak
+)
+
+// names are the flag values of each tier, in order
+var names = []string{"smoke", "full", "soak"}
+
+func (t Tier) String() string {
+	if t < Smoke || t > Soak {
+		return fmt.Sprintf("tier(%d)", int(t))
+	}
+	return names[t]
+}
+
+// Parse returns the tier a -suite flag value names
+func Parse(name string) (Tier, error) {
+	for i, candidate := range names {
+		if name == candidate {
+			return Tier(i), nil
+		}
+	}
+	return 0, fmt.Errorf("suite must be one of %s, got %q", strings.Join(names, ", "), name)
+}
+
+// Includes reports whether a suite runs a validator of tier validator
+func (t Tier) Includes(validator Tier) bool {
+	return validator <= t
+}
@@ -0,0 +1,31 @@
+package tier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, want := range []Tier{Smoke, Full, Soak} {
+		got, err := Parse(want.String())
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := Parse("nightly")
+	assert.EqualError(t, err, `suite must be one of smoke, full, soak, got "nightly"`)
+}
+
+func TestIncludesIsCumulative(t *testing.T) {
+	assert.True(t, Smoke.Includes(Smoke))
+	assert.False(t, Smoke.Includes(Full))
+
+	assert.True(t, Full.Includes(Smoke))
+	assert.True(t, Full.Includes(Full))
+	assert.False(t, Full.Includes(Soak))
+
+	assert.True(t, Soak.Includes(Smoke))
+	assert.True(t, Soak.Includes(Soak))
+}
@@ -0,0 +1,76 @@
+// Package triage explains why a workflow execution failed: the state it failed in with its error and cause,
+// and the part of the log of the Lambda function behind that state that was written while it ran.
+package triage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lambda-java-template/tests/internal/history"
+)
+
+// LogPadding widens the window a step's function logs are read from, since a function's log lines are
+// stamped by Lambda, not by Step Functions, and its first and last lines can fall just outside the step
+const LogPadding = 5 * time.Second
+
+// MaxLogLines is the most log lines an excerpt holds
+const MaxLogLines = 40
+
+// errorMarkers are what a log line reporting a failure contains
+var errorMarkers = []string{"ERROR", "Exception", "Task timed out", "Runtime exited"}
+
+// Summary describes the step an execution failed in
+func Summary(step history.Step) string {
+	summary := fmt.Sprintf("%s (%s)", step.State, step.Type)
+	if step.Retries > 0 {
+		summary += fmt.Sprintf(" after %d retries", step.Retries)
+	}
+	if step.Error != "" {
+		summary += ": " + step.Error
+	}
+	if step.Cause != "" {
+		summary += ": " + step.Cause
+	}
+	return summary
+}
+
+// Window returns the time range the log of a step's function is read from
+func Window(step history.Step) (start, end time.Time) {
+	return step.Entered.Add(-LogPadding), step.Entered.Add(step.Duration + LogPadding)
+}
+
+// LogGroup returns the log group of a Lambda function, given its name or ARN
+func LogGroup(function string) string {
+	if _, name, ok := strings.Cut(function, ":function:"); ok {
+		function, _, _ = strings.Cut(name, ":")
+	}
+	return "/aws/lambda/" + function
+}
+
+// Excerpt returns at most MaxLogLines of a function's log lines. When there are more, it keeps those around
+// the first line reporting a failure, or the last lines when none does.
+func Excerpt(lines []string) []string {
+	if len(lines) <= MaxLogLines {
+		return lines
+	}
+	for i, line := range lines {
+		if isError(line) {
+			// A quarter of the excerpt leads up to the error; the rest is the stack trace and what followed
+			start := max(0, i-MaxLogLines/4)
+			start = min(start, len(lines)-MaxLogLines)
+			return lines[start : start+MaxLogLines]
+		}
+	}
+	return lines[len(lines)-MaxLogLines:]
+}
+
+// isError reports whether a log line reports a failure
+func isError(line string) bool {
+	for _, marker := range errorMarkers {
+		if strings.Contains(line, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package triage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lambda-java-template/tests/internal/history"
+)
+
+func TestSummary(t *testing.T) {
+	assert.Equal(t, `ProcessPayment (Task) after 2 retries: PaymentError: {"errorMessage":"card declined"}`, Summary(history.Step{
+		State: "ProcessPayment", Type: "Task", Retries: 2, Failed: true, Error: "PaymentError", Cause: `{"errorMessage":"card declined"}`,
+	}))
+	assert.Equal(t, "PaymentDeclined (Fail)", Summary(history.Step{State: "PaymentDeclined", Type: "Fail", Failed: true}))
+}
+
+func TestWindow(t *testing.T) {
+	entered := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	start, end := Window(history.Step{Entered: entered, Duration: 3 * time.Second})
+	assert.Equal(t, entered.Add(-5*time.Second), start)
+	assert.Equal(t, entered.Add(8*time.Second), end)
+}
+
+func TestLogGroup(t *testing.T) {
+	assert.Equal(t, "/aws/lambda/app-dev-payment", LogGroup("arn:aws:lambda:us-east-1:123456789012:function:app-dev-payment"))
+	assert.Equal(t, "/aws/lambda/app-dev-payment", LogGroup("arn:aws:lambda:us-east-1:123456789012:function:app-dev-payment:live"))
+	assert.Equal(t, "/aws/lambda/app-dev-payment", LogGroup("app-dev-payment"))
+}
+
+func TestExcerpt(t *testing.T) {
+	lines := func(n int) []string {
+		var lines []string
+		for i := 0; i < n; i++ {
+			lines = append(lines, fmt.Sprintf("line %d", i))
+		}
+		return lines
+	}
+
+	assert.Equal(t, lines(3), Excerpt(lines(3)), "Short logs are kept whole")
+
+	quiet := Excerpt(lines(100))
+	assert.Len(t, quiet, MaxLogLines)
+	assert.Equal(t, "line 99", quiet[MaxLogLines-1], "Without an error the end of the log is kept")
+
+	failing := lines(100)
+	failing[50] = "ERROR PaymentHandler - charge failed"
+	excerpt := Excerpt(failing)
+	assert.Len(t, excerpt, MaxLogLines)
+	assert.Equal(t, "line 40", excerpt[0], "The excerpt starts a little before the error")
+	assert.Contains(t, excerpt, failing[50])
+
+	late := lines(100)
+	late[98] = "java.lang.IllegalStateException: stock is negative"
+	assert.Equal(t, "line 60", Excerpt(late)[0], "An error near the end still fills the excerpt")
+}
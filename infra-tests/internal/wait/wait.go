@@ -0,0 +1,63 @@
+// Package wait polls for eventually consistent state with exponential backoff and a deadline
+package wait
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrTimeout is returned when a condition still does not hold at the deadline
+var ErrTimeout = errors.New("condition not met before the deadline")
+
+// Backoff spaces out polls: the first wait is Initial, each later one Factor times longer, up to Max
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+	Factor  float64
+}
+
+// DefaultBackoff suits AWS control-plane reads: quick first retries without hammering the API over minutes
+var DefaultBackoff = Backoff{Initial: time.Second, Max: 15 * time.Second, Factor: 2}
+
+// Delay returns the wait after the given zero-based attempt
+func (b Backoff) Delay(attempt int) time.Duration {
+	delay := b.Initial
+	for i := 0; i < attempt && delay < b.Max; i++ {
+		delay = time.Duration(float64(delay) * b.Factor)
+	}
+	if b.Max > 0 && delay > b.Max {
+		return b.Max
+	}
+	return delay
+}
+
+// Condition reports whether the awaited state has been reached. An error stops polling.
+type Condition func(ctx context.Context) (bool, error)
+
+// Until checks condition until it holds, returns an error, or timeout passes.
+// The condition is always checked at least once, and once more at the deadline,
+// so a slow backoff never gives up without looking at the final state.
+func Until(ctx context.Context, timeout time.Duration, backoff Backoff, condition Condition) error {
+	deadline := time.Now().Add(timeout)
+
+	for attempt := 0; ; attempt++ {
+		done, err := condition(ctx)
+		if err != nil || done {
+			return err
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return ErrTimeout
+		}
+
+		timer := time.NewTimer(min(backoff.Delay(attempt), remaining))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fastBackoff keeps polling tests quick
+var fastBackoff = Backoff{Initial: time.Millisecond, Max: 5 * time.Millisecond, Factor: 2}
+
+func TestBackoffDelay(t *testing.T) {
+	backoff := Backoff{Initial: time.Second, Max: 10 * time.Second, Factor: 2}
+
+	assert.Equal(t, time.Second, backoff.Delay(0))
+	assert.Equal(t, 2*time.Second, backoff.Delay(1))
+	assert.Equal(t, 8*time.Second, backoff.Delay(3))
+	assert.Equal(t, 10*time.Second, backoff.Delay(4), "Delays are capped at Max")
+	assert.Equal(t, 10*time.Second, backoff.Delay(100))
+}
+
+func TestUntilReturnsOnceConditionHolds(t *testing.T) {
+	calls := 0
+	err := Until(context.Background(), time.Second, fastBackoff, func(context.Context) (bool, error) {
+		calls++
+		return calls == 3, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestUntilStopsOnError(t *testing.T) {
+	failure := errors.New("access denied")
+	calls := 0
+	err := Until(context.Background(), time.Second, fastBackoff, func(context.Context) (bool, error) {
+		calls++
+		return false, failure
+	})
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, 1, calls, "Errors are not retried")
+}
+
+func TestUntilTimesOut(t *testing.T) {
+	err := Until(context.Background(), 20*time.Millisecond, fastBackoff, func(context.Context) (bool, error) {
+		return false, nil
+	})
+	assert.ErrorIs(t, err, ErrTimeout)
+}
+
+func TestUntilChecksAgainAtDeadline(t *testing.T) {
+	// The first wait outlasts the timeout, so only the check at the deadline can see the state change
+	slow := Backoff{Initial: time.Minute, Max: time.Minute, Factor: 2}
+	started := time.Now()
+
+	err := Until(context.Background(), 20*time.Millisecond, slow, func(context.Context) (bool, error) {
+		return time.Since(started) >= 20*time.Millisecond, nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestUntilHonoursCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := Until(ctx, time.Minute, fastBackoff, func(context.Context) (bool, error) {
+		return false, nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
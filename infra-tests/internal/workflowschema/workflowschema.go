@@ -0,0 +1,136 @@
+// Package workflowschema checks the payloads the order-processing workflow's states pass each other against
+// JSON schemas, so a ResultPath that overwrites the order, a result placed under the wrong key, or a task that
+// returns the wrong shape fails the run even when the execution still reaches the terminal state it should.
+//
+// A schema file gives each state a contract naming the schemas of the input it is entered with, the result its
+// task returns, and the output it passes on; any of the three may be left out. The schemas are written as
+// OpenAPI 3.0 components and validated by internal/openapi.
+//
+//	states:
+//	  ValidateOrder:
+//	    input: OrderReference
+//	    result: ValidationResult
+//	    output: ValidatedOrder
+//	components:
+//	  schemas:
+//	    ValidationResult:
+//	      type: object
+//	      required: [valid]
+//	      properties:
+//	        valid: {type: boolean}
+package workflowschema
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/lambda-java-template/tests/internal/history"
+	"github.com/lambda-java-template/tests/internal/openapi"
+)
+
+// File is where the order-processing workflow's schemas live, relative to the suite
+const File = "testdata/workflow-payloads.yaml"
+
+// Contract names the schemas of one state's payloads
+type Contract struct {
+	Input  string `yaml:"input"`
+	Result string `yaml:"result"`
+	Output string `yaml:"output"`
+}
+
+// Schemas are the contracts of a workflow's states and the schemas they name
+type Schemas struct {
+	States map[string]Contract `yaml:"states"`
+
+	spec *openapi.Spec
+}
+
+// Load reads a schema file
+func Load(path string) (*Schemas, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading workflow schemas: %w", err)
+	}
+	schemas, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return schemas, nil
+}
+
+// Parse decodes a schema file and checks every schema a contract names is defined
+func Parse(data []byte) (*Schemas, error) {
+	var schemas Schemas
+	if err := yaml.Unmarshal(data, &schemas); err != nil {
+		return nil, fmt.Errorf("parsing workflow schemas: %w", err)
+	}
+	spec, err := openapi.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	schemas.spec = spec
+
+	for _, state := range sortedStates(schemas.States) {
+		contract := schemas.States[state]
+		for _, name := range []string{contract.Input, contract.Result, contract.Output} {
+			if _, ok := spec.Components.Schemas[name]; name != "" && !ok {
+				return nil, fmt.Errorf("state %s names undefined schema %s", state, name)
+			}
+		}
+	}
+	return &schemas, nil
+}
+
+// Check validates the payloads of an execution against the contracts of their states and describes every
+// mismatch. Payloads of states without a contract, and payloads a state never produced, are not checked.
+func (s *Schemas) Check(payloads []history.Payload) []string {
+	var problems []string
+	for _, payload := range payloads {
+		contract, ok := s.States[payload.State]
+		if !ok {
+			continue
+		}
+		for _, part := range []struct{ name, schema, body string }{
+			{"input", contract.Input, payload.Input},
+			{"result", contract.Result, payload.Result},
+			{"output", contract.Output, payload.Output},
+		} {
+			if part.schema == "" || part.body == "" {
+				continue
+			}
+			for _, problem := range s.spec.ValidateJSON([]byte(part.body), &openapi.Schema{Ref: "#/components/schemas/" + part.schema}) {
+				problems = append(problems, fmt.Sprintf("%s %s is not a %s: %s", payload.State, part.name, part.schema, problem))
+			}
+		}
+	}
+	return problems
+}
+
+// Unknown returns the states with a contract that are not among stateNames, sorted; a contract for a state the
+// definition lacks would never be checked
+func (s *Schemas) Unknown(stateNames []string) []string {
+	known := map[string]bool{}
+	for _, name := range stateNames {
+		known[name] = true
+	}
+	var unknown []string
+	for _, state := range sortedStates(s.States) {
+		if !known[state] {
+			unknown = append(unknown, state)
+		}
+	}
+	return unknown
+}
+
+// sortedStates returns the states with a contract, sorted
+func sortedStates(states map[string]Contract) []string {
+	names := make([]string, 0, len(states))
+	for name := range states {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,66 @@
+package workflowschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/history"
+)
+
+func TestCheck(t *testing.T) {
+	schemas, err := Load("../../" + File)
+	require.NoError(t, err)
+
+	order := `{"orderId":"o-1","customerId":"c-1","items":[{"productId":"p-1","quantity":1,"price":19.99}]}`
+	assert.Empty(t, schemas.Check([]history.Payload{
+		{
+			State:  "ValidateOrder",
+			Input:  `{"orderId":"o-1","customerId":"c-1","items":null}`,
+			Result: `{"valid":false,"errors":["no items"]}`,
+			Output: `{"orderId":"o-1","customerId":"c-1","items":null,"validationResult":{"valid":false,"errors":["no items"]}}`,
+		},
+		{
+			State:  "ProcessPayment",
+			Input:  order,
+			Result: `{"status":"APPROVED","transactionId":"t-1","amount":19.99}`,
+			Output: `{"orderId":"o-1","customerId":"c-1","items":[{"productId":"p-1","quantity":1,"price":19.99}],"paymentResult":{"status":"APPROVED","amount":19.99}}`,
+		},
+		{State: "ReserveInventory", Input: order},
+		{State: "PaymentApproved", Input: `[]`, Output: `"anything"`},
+	}), "States without a contract and payloads never produced are not checked")
+
+	assert.Equal(t, []string{
+		`ProcessPayment result is not a PaymentResult: $.status: "FAILED" is not one of [APPROVED DECLINED]`,
+		`ProcessPayment output is not a PaidOrder: $: missing required property "orderId"`,
+		`ProcessPayment output is not a PaidOrder: $: missing required property "customerId"`,
+		`ProcessPayment output is not a PaidOrder: $: missing required property "items"`,
+		`ProcessPayment output is not a PaidOrder: $: missing required property "paymentResult"`,
+	}, schemas.Check([]history.Payload{{
+		State:  "ProcessPayment",
+		Input:  order,
+		Result: `{"status":"FAILED","amount":19.99}`,
+		Output: `{"status":"FAILED","amount":19.99}`,
+	}}), "A ResultPath that replaces the order loses its fields")
+}
+
+func TestParseRejectsUndefinedSchemas(t *testing.T) {
+	_, err := Parse([]byte(`
+states:
+  ValidateOrder:
+    result: ValidationResult
+components:
+  schemas:
+    OrderReference: {type: object}
+`))
+	assert.EqualError(t, err, "state ValidateOrder names undefined schema ValidationResult")
+}
+
+func TestUnknown(t *testing.T) {
+	schemas, err := Load("../../" + File)
+	require.NoError(t, err)
+
+	assert.Empty(t, schemas.Unknown([]string{"PaymentApproved", "ProcessPayment", "ReserveInventory", "SendNotification", "ValidateOrder"}))
+	assert.Equal(t, []string{"ReserveInventory", "SendNotification"}, schemas.Unknown([]string{"ProcessPayment", "ValidateOrder"}))
+}
@@ -0,0 +1,130 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/expectations"
+	"github.com/lambda-java-template/tests/internal/inventory"
+	"github.com/lambda-java-template/tests/internal/orphans"
+	"github.com/lambda-java-template/tests/internal/tagpolicy"
+	"github.com/lambda-java-template/tests/internal/tfspec"
+)
+
+// validateResourceInventory validates that every function and table Terraform declares is tagged and inventoried
+func validateResourceInventory(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+
+	result, err := inventory.Collect(context.TODO(), inventory.NewClients(cfg), projectName, environment)
+	require.NoError(t, err, "Failed to build resource inventory")
+	t.Logf("Inventoried %d resources tagged Project=%s Environment=%s", len(result.Resources), projectName, environment)
+
+	inventoried := make(map[string]inventory.Resource, len(result.Resources))
+	for _, resource := range result.Resources {
+		inventoried[resource.Service+"/"+resource.Name] = resource
+	}
+
+	for _, function := range tfspec.Functions {
+		functionName := resolver.FunctionName(function.NameSuffix)
+		resource, found := inventoried["lambda/"+functionName]
+		if assert.True(t, found, "Function %s is missing from the tagged inventory", functionName) {
+			assert.Equal(t, function.Runtime, resource.Summary["runtime"], "Inventoried runtime of %s", functionName)
+		}
+	}
+
+	for _, table := range tfspec.Tables {
+		tableName := resolver.TableName(table.NameSuffix)
+		resource, found := inventoried["dynamodb/"+tableName]
+		if assert.True(t, found, "Table %s is missing from the tagged inventory", tableName) {
+			assert.Equal(t, expectations.For(environment).BillingMode, resource.Summary["billing mode"], "Inventoried billing mode of %s", tableName)
+		}
+	}
+}
+
+// validateInventorySnapshot writes a snapshot of every resource tagged with the project and environment to
+// -inventory-snapshots and fails for each resource added or removed since the previous snapshot there.
+// The new snapshot is written either way, so a deliberate change fails only the first run after it.
+func validateInventorySnapshot(t *testing.T, cfg aws.Config, projectName, environment string) {
+	if *inventorySnapshots == "" {
+		t.Skip("Skipping inventory snapshots; set -inventory-snapshots to a directory to take them")
+	}
+	result, err := inventory.Collect(context.TODO(), inventory.NewClients(cfg), projectName, environment)
+	require.NoError(t, err, "Failed to build resource inventory")
+	current := inventory.NewSnapshot(result)
+
+	previous, found, err := inventory.LatestSnapshot(*inventorySnapshots, projectName, environment)
+	require.NoError(t, err)
+	path, err := inventory.WriteSnapshot(*inventorySnapshots, current)
+	require.NoError(t, err)
+	if !found {
+		t.Logf("Wrote the first inventory snapshot of %s %s to %s; there is nothing to compare it with", projectName, environment, path)
+		return
+	}
+
+	diff := inventory.Compare(previous, current)
+	t.Logf("Compared %d resources with the snapshot of %s: %d added, %d removed, %d retagged",
+		len(current.Resources), previous.TakenAt.Format(time.RFC3339), len(diff.Added), len(diff.Removed), len(diff.Retagged))
+	for _, entry := range diff.Retagged {
+		t.Logf("Tags of %s changed to %v", entry.ARN, entry.Tags)
+	}
+	for _, entry := range diff.Added {
+		assert.Fail(t, "Unexpected resource", "%s %s was added since the snapshot of %s", entry.Type, entry.ARN, previous.TakenAt.Format(time.RFC3339))
+	}
+	for _, entry := range diff.Removed {
+		assert.Fail(t, "Resource disappeared", "%s %s was removed since the snapshot of %s", entry.Type, entry.ARN, previous.TakenAt.Format(time.RFC3339))
+	}
+}
+
+// validateOrphans fails for each tagged function, table, state machine, API, log group, or alarm of the
+// environment that Terraform state in TEST_TERRAFORM_DIR does not manage. cmd/sweep does the same across
+// every namespace and can delete what ephemeral namespaces leave behind.
+func validateOrphans(t *testing.T, cfg aws.Config, projectName, environment string) {
+	terraformDir := discover(cfg, projectName, environment).Settings().TerraformDir
+	if terraformDir == "" {
+		t.Skip("Skipping orphan detection; set TEST_TERRAFORM_DIR to compare with Terraform state")
+	}
+
+	state, err := orphans.ShowState(context.TODO(), terraformDir)
+	require.NoError(t, err)
+	managed, err := orphans.ManagedIdentifiers(state)
+	require.NoError(t, err)
+
+	result, err := inventory.Collect(context.TODO(), inventory.NewClients(cfg), projectName, environment)
+	require.NoError(t, err, "Failed to build resource inventory")
+	for _, orphan := range orphans.Find(result.Resources, managed) {
+		assert.Fail(t, "Orphaned resource", "%s is tagged Project=%s Environment=%s but is not in the Terraform state of %s",
+			orphan.ARN, projectName, environment, terraformDir)
+	}
+}
+
+// validateTagPolicy checks every resource of the deployment, found by type and name as well as by tag,
+// against tagpolicy.Required and reports all violations together
+func validateTagPolicy(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+	policy := tagpolicy.Required(projectName, environment)
+
+	resources, err := tagpolicy.Discover(context.TODO(), resourcegroupstaggingapi.NewFromConfig(cfg),
+		resolver.Settings().BaseName(), projectName, environment)
+	require.NoError(t, err)
+	require.NotEmpty(t, resources, "No resource of %s was found by type, name, or tag", resolver.Settings().BaseName())
+	for _, resource := range resources {
+		suiteResults.RecordTags(resource.ARN, resource.Tags, policy.Keys())
+	}
+
+	violations := policy.Check(resources)
+	if len(violations) > 0 {
+		report := make([]string, 0, len(violations))
+		for _, violation := range violations {
+			report = append(report, violation.String())
+		}
+		assert.Fail(t, "Tag policy violations", "%d violations across %d resources:\n%s",
+			len(violations), len(resources), strings.Join(report, "\n"))
+	}
+}
@@ -0,0 +1,100 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	logstypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/discovery"
+	"github.com/lambda-java-template/tests/internal/expectations"
+)
+
+// validateLogGroups checks the retention, encryption, and log shipping of every function and state machine log group
+func validateLogGroups(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+
+	logsClient := cloudwatchlogs.NewFromConfig(cfg)
+	lambdaClient := lambda.NewFromConfig(cfg)
+	retentionDays := expectations.For(environment).LogRetentionDays
+
+	functions := append([]string{"product-service", "authorizer-service"}, workflowFunctions...)
+	for _, suffix := range functions {
+		functionName := resolver.FunctionName(suffix)
+		t.Run(suffix, func(t *testing.T) {
+			requireFunction(t, lambdaClient, functionName)
+			validateLogGroup(t, logsClient, fmt.Sprintf("/aws/lambda/%s", functionName), retentionDays)
+		})
+	}
+
+	t.Run("order-processing", func(t *testing.T) {
+		stateMachineArn, err := resolver.StateMachineARN(context.TODO(), "order-processing")
+		if errors.Is(err, discovery.ErrNotFound) {
+			t.Skipf("%v", err)
+		}
+		require.NoError(t, err)
+
+		stateMachine, err := sfn.NewFromConfig(cfg).DescribeStateMachine(context.TODO(), &sfn.DescribeStateMachineInput{
+			StateMachineArn: aws.String(stateMachineArn),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, stateMachine.LoggingConfiguration, "State machine %s has no logging configuration", stateMachineArn)
+		require.NotEmpty(t, stateMachine.LoggingConfiguration.Destinations, "State machine %s does not log to CloudWatch Logs", stateMachineArn)
+
+		for _, destination := range stateMachine.LoggingConfiguration.Destinations {
+			if destination.CloudWatchLogsLogGroup == nil {
+				continue
+			}
+			logGroupArn := aws.ToString(destination.CloudWatchLogsLogGroup.LogGroupArn)
+			logGroup := logGroupNameFromArn(logGroupArn)
+			require.NotEmpty(t, logGroup, "Logging destination %s is not a log group", logGroupArn)
+			validateLogGroup(t, logsClient, logGroup, retentionDays)
+		}
+	})
+}
+
+// validateLogGroup checks that a log group exists, expires its events after retentionDays, is encrypted with KMS,
+// and forwards to the configured log shipping destination
+func validateLogGroup(t *testing.T, client *cloudwatchlogs.Client, name string, retentionDays int32) {
+	var group *logstypes.LogGroup
+	paginator := cloudwatchlogs.NewDescribeLogGroupsPaginator(client, &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String(name),
+	})
+	for group == nil && paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		require.NoError(t, err)
+
+		for i := range page.LogGroups {
+			if aws.ToString(page.LogGroups[i].LogGroupName) == name {
+				group = &page.LogGroups[i]
+				break
+			}
+		}
+	}
+	require.NotNil(t, group, "Log group %s not found", name)
+	recordResource(t, "logs", name)
+
+	if assert.NotNil(t, group.RetentionInDays, "Log group %s never expires its events", name) {
+		assert.Equal(t, retentionDays, aws.ToInt32(group.RetentionInDays), "Log group %s retention in days", name)
+	}
+	assert.NotEmpty(t, aws.ToString(group.KmsKeyId), "Log group %s is not encrypted with a KMS key (set log_kms_key_arn in Terraform)", name)
+
+	if suiteConfig.LogShippingARN == "" {
+		return
+	}
+	filters, err := client.DescribeSubscriptionFilters(context.TODO(), &cloudwatchlogs.DescribeSubscriptionFiltersInput{
+		LogGroupName: aws.String(name),
+	})
+	require.NoError(t, err)
+	assert.True(t, checks.ShipsTo(filters.SubscriptionFilters, suiteConfig.LogShippingARN),
+		"Log group %s has no subscription filter to %s", name, suiteConfig.LogShippingARN)
+}
@@ -0,0 +1,252 @@
+package test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/cassette"
+	"github.com/lambda-java-template/tests/internal/discovery"
+	"github.com/lambda-java-template/tests/internal/group"
+	"github.com/lambda-java-template/tests/internal/httpclient"
+	"github.com/lambda-java-template/tests/internal/load"
+	"github.com/lambda-java-template/tests/internal/preflight"
+	"github.com/lambda-java-template/tests/internal/ratelimit"
+	"github.com/lambda-java-template/tests/internal/results"
+	"github.com/lambda-java-template/tests/internal/shard"
+	"github.com/lambda-java-template/tests/internal/testconfig"
+	"github.com/lambda-java-template/tests/internal/tier"
+)
+
+var (
+	shardIndex = flag.Int("shard-index", 0, "Index of the shard of validators to run (0-based)")
+	shardTotal = flag.Int("shard-total", 1, "Number of shards the validators are split across")
+
+	quarantineRetries = flag.Int("quarantine-retries", 3, "Attempts made at each quarantined validator before reporting it as failed")
+
+	stressExecutions = flag.Int("stress-executions", 0, "Order workflow executions the stress test starts at once; 0 skips the stress test")
+
+	orderProperties   = flag.Int("order-properties", 0, "Random orders, valid and invalid, the property-based workflow check runs; 0 skips it")
+	orderPropertySeed = flag.Int("order-property-seed", 0, "Seed the property-based orders are drawn from; 0 picks one and logs it")
+
+	loadRPS         = flag.Int("load-rps", 5, "Requests per second the API load test sends across its endpoints")
+	loadDuration    = flag.Duration("load-duration", 10*time.Second, "How long the API load test sends requests for")
+	loadConcurrency = flag.Int("load-concurrency", 10, "Most API load test requests in flight at once")
+
+	sloP50       = flag.Duration("slo-p50", load.DefaultSLO.P50, "Highest p50 latency the API load test accepts per endpoint")
+	sloP90       = flag.Duration("slo-p90", load.DefaultSLO.P90, "Highest p90 latency the API load test accepts per endpoint")
+	sloP99       = flag.Duration("slo-p99", load.DefaultSLO.P99, "Highest p99 latency the API load test accepts per endpoint")
+	sloErrorRate = flag.Float64("slo-error-rate", load.DefaultSLO.MaxErrorRate, "Highest fraction of failed requests the API load test accepts per endpoint")
+
+	coldStarts       = flag.Int("cold-starts", 0, "Cold starts the benchmark forces per function; 0 skips the benchmark")
+	coldStartHistory = flag.String("cold-start-history", "testdata/cold-starts.json", "File the cold start benchmark appends each run's results to")
+
+	authorizerCache = flag.Bool("authorizer-cache", false, "Check cached authorizer decisions expire; new API keys are refused for the authorizer TTL while it runs")
+
+	throttleProbe = flag.Bool("throttle-probe", false, "Exceed the /health throttling limits and check the API answers 429; sends up to 5000 requests")
+	keyAbuseProbe = flag.Bool("key-abuse-probe", false, "Exceed the GET /products throttling limits with one API key and check the 429s reach CloudWatch; sends up to 5000 requests, each invoking the product service")
+
+	pitrRestore = flag.Bool("pitr-restore", false, "Restore each table with point-in-time recovery into a temporary table; restores take minutes and are billed")
+
+	chaosFaults = flag.Bool("chaos", false, "Break the payment function's role and concurrency while orders run; affects every order in the environment")
+
+	executionWindow = flag.Duration("execution-window", 24*time.Hour, "Trailing window the order workflow's execution success rate is measured and archived over")
+
+	failureModes = flag.Bool("failure-modes", false, "Set FAILURE_MODE on the workflow functions that declare it and check each forced branch; affects every order in the environment")
+
+	inventorySnapshots = flag.String("inventory-snapshots", "testdata/inventory", "Directory each run writes its inventory snapshot to and compares it with the previous one in; empty disables snapshots")
+
+	runtimeDeprecationDays = flag.Int("runtime-deprecation-days", 180, "Fail functions whose runtime is deprecated within this many days")
+
+	securityFindings = flag.Bool("security-findings", false, "Fail on HIGH or CRITICAL Security Hub findings on the project's resources that are newer than the baseline")
+	securityBaseline = flag.String("security-baseline", "testdata/security-findings.json", "File the accepted Security Hub findings are recorded in, with the environment appended to its name")
+
+	complianceReport = flag.String("compliance-report", "", "File the control coverage report is written to, as Markdown when it ends in .md and JSON otherwise")
+
+	updateGolden = flag.Bool("update", false, "Rewrite golden files in testdata/golden from the deployed resources")
+
+	httpTimeout = flag.Duration("http-timeout", httpclient.DefaultTimeout, "Longest each attempt at an API request may take, including reading the response")
+	httpRetries = flag.Int("http-retries", 3, "Times an idempotent API request is repeated after a connection error or 5xx response")
+
+	awsCallRate = flag.Float64("aws-call-rate", ratelimit.DefaultFallback.PerSecond, "AWS API calls per second each operation may make, apart from the repeated lookups internal/ratelimit paces lower; 0 leaves them unpaced")
+
+	suiteName = flag.String("suite", tier.Full.String(), "Validators to run: smoke (fast read-only checks), full (adds CRUD and workflow end-to-end runs), or soak (adds load and chaos)")
+	groupList = flag.String("group", "", "Comma-separated groups of validators to run, e.g. security,monitoring; empty runs every group")
+
+	maxParallel = flag.Int("max-parallel", 4, "Most groups of validators run at once; 1 runs every validator one after another")
+
+	cassetteMode = flag.String("cassette-mode", string(cassette.Off), "off sends AWS and API requests, record also saves them to -cassette, and replay answers them from it without credentials; needs -suite smoke")
+	cassettePath = flag.String("cassette", "testdata/cassettes/smoke.json", "File the smoke suite's AWS and API traffic is recorded to and replayed from, with the environment appended to its name")
+)
+
+// selectedSuite is the tier -suite names
+var selectedSuite tier.Tier
+
+// selectedGroups are the groups -group names
+var selectedGroups group.Selection
+
+// suiteConfig selects the deployment under test; see internal/testconfig for the variables it reads
+var suiteConfig testconfig.Config
+
+// suiteResults collects the JSON summary written to suiteConfig.ResultsFile
+var suiteResults *results.Collector
+
+// suiteHTTP sends validators' requests to the deployed API, retrying transient failures and recording each
+// request in suiteResults
+var suiteHTTP *httpclient.Client
+
+// TestMain parses suite-wide flags and loads the test config before any test runs
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	if err := shard.Validate(*shardIndex, *shardTotal); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if *maxParallel < 1 {
+		fmt.Fprintf(os.Stderr, "max-parallel must be at least 1, got %d\n", *maxParallel)
+		os.Exit(2)
+	}
+
+	var err error
+	selectedSuite, err = tier.Parse(*suiteName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	selectedGroups, err = group.Parse(*groupList)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	mode, err := cassette.ParseMode(*cassetteMode)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	// Set before any AWS client is created, since clients take the limiter when created.
+	// Replayed calls reach no quota, so they are not paced.
+	ratelimit.Shared = ratelimit.New(ratelimit.Limit{PerSecond: *awsCallRate, Burst: ratelimit.DefaultFallback.Burst}, ratelimit.Limits)
+	if mode == cassette.Replay {
+		ratelimit.Shared = ratelimit.New(ratelimit.Limit{}, nil)
+	}
+
+	suiteConfig, err = testconfig.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if err := openCassette(mode); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	suiteResults = results.NewCollector(suiteConfig.ProjectName, suiteConfig.Environment, suiteConfig.Region, suiteConfig.Target)
+	suiteHTTP = httpclient.New(httpclient.Options{
+		Timeout:    *httpTimeout,
+		Retries:    *httpRetries,
+		Transport:  cassetteTransport(),
+		OnExchange: recordRequest,
+	})
+
+	code := m.Run()
+
+	if suiteCassette != nil {
+		if err := suiteCassette.Save(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			if code == 0 {
+				code = 1
+			}
+		}
+	}
+
+	// Quarantine child processes report to their parent, which writes the summary,
+	// while each environment child writes the summary of its own environment
+	if suiteConfig.ResultsFile != "" && !inQuarantineChild() && !orchestratingEnvironments() {
+		recordQuarantineReport()
+		if err := suiteResults.WriteFile(suiteConfig.ResultsFile); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			if code == 0 {
+				code = 1
+			}
+		}
+	}
+	if *complianceReport != "" && !inQuarantineChild() && !orchestratingEnvironments() {
+		if err := writeComplianceReport(*complianceReport); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			if code == 0 {
+				code = 1
+			}
+		}
+	}
+	os.Exit(code)
+}
+
+// recordCheck wraps a validator so its outcome and duration appear in the results summary
+func recordCheck(name string, run func(t *testing.T)) func(t *testing.T) {
+	return func(t *testing.T) {
+		start := time.Now()
+		t.Cleanup(func() {
+			status := results.StatusOf(t)
+			if result, ok := quarantineReport.Result(name); ok && !result.Passed() {
+				status = results.StatusQuarantinedFailed
+			}
+			suiteResults.RecordCheck(name, status, time.Since(start))
+		})
+		run(t)
+	}
+}
+
+// recordRequest adds a request sent through suiteHTTP to the results summary
+func recordRequest(exchange httpclient.Exchange) {
+	suiteResults.RecordRequest(results.Request{
+		Method:       exchange.Method,
+		URL:          exchange.URL,
+		StatusCode:   exchange.StatusCode,
+		Attempts:     exchange.Attempts,
+		DurationMs:   exchange.Duration.Milliseconds(),
+		RequestBody:  exchange.RequestBody,
+		ResponseBody: exchange.ResponseBody,
+		Error:        exchange.Error,
+	})
+}
+
+// recordResource adds the outcome of the current test to the results summary under a resource
+func recordResource(t *testing.T, resourceType, name string) {
+	t.Cleanup(func() {
+		suiteResults.RecordResource(resourceType, name, results.StatusOf(t))
+	})
+}
+
+// discover returns the resolver for a deployment, shared across validators so lookups happen once
+func discover(cfg aws.Config, projectName, environment string) *discovery.Resolver {
+	settings := suiteConfig
+	settings.ProjectName, settings.Environment, settings.Region = projectName, environment, cfg.Region
+	return discovery.Shared(cfg, settings)
+}
+
+// requireFeature skips the test when the target does not emulate a feature
+func requireFeature(t *testing.T, feature testconfig.Feature) {
+	if !suiteConfig.Supports(feature) {
+		t.Skipf("%s is not available on the %s target", feature, suiteConfig.Target)
+	}
+}
+
+// requireAPI returns the deployed HTTP API, skips the test when the API is not deployed, or stops it on any other error
+func requireAPI(t *testing.T, resolver *discovery.Resolver) discovery.API {
+	api, err := resolver.API(context.TODO())
+	if preflight.IsNotFound(err) {
+		t.Skipf("Not deployed: %s (%s)", preflight.API, resolver.APIName())
+	}
+	require.NoError(t, err, "Failed to look up API Gateway %s", resolver.APIName())
+	return api
+}
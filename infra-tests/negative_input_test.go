@@ -0,0 +1,81 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/negative"
+)
+
+// validateNegativeInputs sends malformed JSON, oversized bodies, wrong content types, invalid UTF-8,
+// and path traversal ids to every products route, and fails on any response that is not a 4xx
+// with a structured error body, or that leaks a stack trace
+func validateNegativeInputs(t *testing.T, cfg aws.Config, projectName, environment string) {
+	if testing.Short() {
+		t.Skip("Skipping negative input cases in short mode")
+	}
+	resolver := discover(cfg, projectName, environment)
+
+	apiEndpoint := requireAPI(t, resolver).Endpoint
+	dynamoClient := dynamodb.NewFromConfig(cfg)
+	productsTable := resolver.TableName("products")
+	missingID := fmt.Sprintf("%snegative-%d", testNamespace(), time.Now().UnixNano())
+
+	for _, c := range negative.Cases(missingID) {
+		t.Run(c.Name, func(t *testing.T) {
+			status, body := sendRaw(t, c.Method, apiEndpoint+c.Path, c.ContentType, c.Body)
+
+			// A malformed product the service accepted anyway must not outlive the test
+			if status == http.StatusCreated {
+				var created struct {
+					ID string `json:"id"`
+				}
+				if json.Unmarshal(body, &created) == nil && created.ID != "" {
+					t.Cleanup(func() {
+						_, err := dynamoClient.DeleteItem(context.TODO(), &dynamodb.DeleteItemInput{
+							TableName: aws.String(productsTable),
+							Key:       map[string]dynamodbtypes.AttributeValue{"id": &dynamodbtypes.AttributeValueMemberS{Value: created.ID}},
+						})
+						assert.NoError(t, err, "Failed to remove product %s", created.ID)
+					})
+				}
+			}
+
+			for _, problem := range negative.Problems(status, body) {
+				assert.Fail(t, "Malformed request not rejected cleanly", "%s %s (%d byte body): %s\n%.500s",
+					c.Method, c.Path, len(c.Body), problem, body)
+			}
+		})
+	}
+}
+
+// sendRaw sends body as is with the suite's API key and returns the response. Unlike callAPI it
+// neither encodes the body nor normalizes the escaped path.
+func sendRaw(t *testing.T, method, url, contentType string, body []byte) (int, []byte) {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("x-api-key", fmt.Sprintf("infra-tests-%s", suiteConfig.Environment))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := suiteHTTP.Do(req)
+	require.NoError(t, err, "%s %s", method, url)
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, data
+}
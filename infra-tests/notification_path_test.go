@@ -0,0 +1,173 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/discovery"
+	"github.com/lambda-java-template/tests/internal/payloads"
+)
+
+// notificationDeliveryTimeout bounds how long an order may take to produce its confirmation notification
+const notificationDeliveryTimeout = 5 * time.Minute
+
+// validateNotificationPath runs an order through the workflow and waits for its confirmation on the notification topic
+func validateNotificationPath(t *testing.T, cfg aws.Config, projectName, environment string) {
+	if testing.Short() {
+		t.Skip("Skipping notification path test in short mode")
+	}
+	resolver := discover(cfg, projectName, environment)
+
+	topicArn := notificationTopicArn(t, sns.NewFromConfig(cfg), resolver)
+	if topicArn == "" {
+		t.Skipf("Notification topic %s is not deployed", resolver.ResourceName("notifications"))
+	}
+	stateMachineArn, err := resolver.StateMachineARN(context.TODO(), "order-processing")
+	if errors.Is(err, discovery.ErrNotFound) {
+		t.Skipf("%v", err)
+	}
+	require.NoError(t, err)
+	recordResource(t, "sns", topicArn)
+
+	queueUrl := subscribeTopicQueue(t, cfg, resolver, topicArn)
+
+	orderId := fmt.Sprintf("%sorder-%d", testNamespace(), time.Now().UnixNano())
+	order := payloads.SampleOrder(testNamespace(), orderId)
+	executeWorkflow(t, sfn.NewFromConfig(cfg), stateMachineArn, order)
+
+	confirmation := awaitNotification(t, sqs.NewFromConfig(cfg), queueUrl, topicArn, orderId, checks.NotificationOrderConfirmation)
+	require.NotNil(t, confirmation, "No %s notification for order %s on %s within %s",
+		checks.NotificationOrderConfirmation, orderId, topicArn, notificationDeliveryTimeout)
+
+	assert.Empty(t, checks.MissingNotificationFields(confirmation), "Notification for order %s is missing required fields", orderId)
+	assert.Equal(t, order.CustomerID, confirmation["customerId"])
+	if timestamp, ok := confirmation["timestamp"].(string); ok {
+		_, err := time.Parse(time.RFC3339, timestamp)
+		assert.NoError(t, err, "Notification timestamp %q is not RFC 3339", timestamp)
+	}
+}
+
+// subscribeTopicQueue subscribes a temporary queue to topicArn, so a test can read what the notification
+// function publishes, and returns its URL. The queue and subscription are removed when the test ends.
+func subscribeTopicQueue(t *testing.T, cfg aws.Config, resolver *discovery.Resolver, topicArn string) string {
+	snsClient := sns.NewFromConfig(cfg)
+	queueUrl, queueArn := createCaptureQueue(t, sqs.NewFromConfig(cfg), resolver.ResourceName(fmt.Sprintf("itest-notify-%d", time.Now().UnixNano())), topicArn)
+
+	subscription, err := snsClient.Subscribe(context.TODO(), &sns.SubscribeInput{
+		TopicArn:              aws.String(topicArn),
+		Protocol:              aws.String("sqs"),
+		Endpoint:              aws.String(queueArn),
+		ReturnSubscriptionArn: true,
+	})
+	require.NoError(t, err, "Failed to subscribe %s to %s", queueArn, topicArn)
+	t.Cleanup(func() {
+		_, err := snsClient.Unsubscribe(context.TODO(), &sns.UnsubscribeInput{SubscriptionArn: subscription.SubscriptionArn})
+		assert.NoError(t, err, "Failed to remove test subscription from %s", topicArn)
+	})
+	return queueUrl
+}
+
+// awaitNotification returns the first notification of notificationType for orderId delivered to the capture
+// queue, or nil when none arrives within notificationDeliveryTimeout
+func awaitNotification(t *testing.T, client *sqs.Client, queueUrl, topicArn, orderId, notificationType string) map[string]any {
+	deadline := time.Now().Add(notificationDeliveryTimeout)
+	for time.Now().Before(deadline) {
+		received, err := client.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(queueUrl),
+			MaxNumberOfMessages: 10,
+			WaitTimeSeconds:     20,
+		})
+		require.NoError(t, err)
+
+		for _, message := range received.Messages {
+			payload, err := checks.ParseNotification(aws.ToString(message.Body))
+			if err != nil {
+				t.Logf("Ignoring unparseable message on %s: %v", topicArn, err)
+				continue
+			}
+			// Other orders may be in flight on a shared environment
+			if payload["orderId"] == orderId && payload["notificationType"] == notificationType {
+				return payload
+			}
+		}
+	}
+	return nil
+}
+
+// notificationTopicArn returns the notification topic from the notification_topic_arn output or ListTopics, or ""
+func notificationTopicArn(t *testing.T, client *sns.Client, resolver *discovery.Resolver) string {
+	if arn := resolver.Output("notification_topic_arn"); arn != "" {
+		return arn
+	}
+
+	suffix := ":" + resolver.ResourceName("notifications")
+	paginator := sns.NewListTopicsPaginator(client, &sns.ListTopicsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		require.NoError(t, err)
+
+		for _, topic := range page.Topics {
+			if strings.HasSuffix(aws.ToString(topic.TopicArn), suffix) {
+				return *topic.TopicArn
+			}
+		}
+	}
+	return ""
+}
+
+// createCaptureQueue creates a queue that topicArn may deliver to and deletes it when the test ends
+func createCaptureQueue(t *testing.T, client *sqs.Client, name, topicArn string) (queueUrl, queueArn string) {
+	created, err := client.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: aws.String(name),
+		Attributes: map[string]string{
+			"SqsManagedSseEnabled":   "true",
+			"MessageRetentionPeriod": "900",
+		},
+	})
+	require.NoError(t, err, "Failed to create capture queue %s", name)
+	queueUrl = aws.ToString(created.QueueUrl)
+	t.Cleanup(func() {
+		_, err := client.DeleteQueue(context.TODO(), &sqs.DeleteQueueInput{QueueUrl: aws.String(queueUrl)})
+		assert.NoError(t, err, "Failed to delete capture queue %s", name)
+	})
+
+	attributes, err := client.GetQueueAttributes(context.TODO(), &sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(queueUrl),
+		AttributeNames: []sqstypes.QueueAttributeName{sqstypes.QueueAttributeNameQueueArn},
+	})
+	require.NoError(t, err)
+	queueArn = attributes.Attributes["QueueArn"]
+
+	policy, err := json.Marshal(map[string]any{
+		"Version": "2012-10-17",
+		"Statement": []map[string]any{{
+			"Effect":    "Allow",
+			"Principal": map[string]string{"Service": "sns.amazonaws.com"},
+			"Action":    "sqs:SendMessage",
+			"Resource":  queueArn,
+			"Condition": map[string]any{"ArnEquals": map[string]string{"aws:SourceArn": topicArn}},
+		}},
+	})
+	require.NoError(t, err)
+
+	_, err = client.SetQueueAttributes(context.TODO(), &sqs.SetQueueAttributesInput{
+		QueueUrl:   aws.String(queueUrl),
+		Attributes: map[string]string{"Policy": string(policy)},
+	})
+	require.NoError(t, err, "Failed to allow %s to deliver to %s", topicArn, name)
+	return queueUrl, queueArn
+}
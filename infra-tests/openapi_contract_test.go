@@ -0,0 +1,103 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/openapi"
+	"github.com/lambda-java-template/tests/internal/responses"
+)
+
+// validateOpenAPIContract compares the API Gateway export with the committed OpenAPI spec and
+// checks live responses of every product route against the spec's response schemas
+func validateOpenAPIContract(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+
+	spec, err := openapi.Load(openAPISpecPath)
+	require.NoError(t, err)
+	api := requireAPI(t, resolver)
+
+	t.Run("Deployed_Definition", func(t *testing.T) {
+		exported, err := apigatewayv2.NewFromConfig(cfg).ExportApi(context.TODO(), &apigatewayv2.ExportApiInput{
+			ApiId:             aws.String(api.ID),
+			OutputType:        aws.String("JSON"),
+			Specification:     aws.String("OAS30"),
+			IncludeExtensions: aws.Bool(false),
+		})
+		require.NoError(t, err)
+
+		deployed, err := openapi.Parse(exported.Body)
+		require.NoError(t, err, "Failed to parse the export of API %s", api.ID)
+		for _, difference := range openapi.Diff(spec, deployed) {
+			assert.Fail(t, "Deployed API differs from "+openAPISpecPath, difference)
+		}
+	})
+
+	t.Run("Live_Responses", func(t *testing.T) {
+		name := fmt.Sprintf("%scontract-%d", testNamespace(), time.Now().UnixNano())
+
+		status, body := callAPI(t, http.MethodGet, api.Endpoint+"/health", nil)
+		assertMatchesSpec(t, spec, "GET /health", status, body)
+
+		status, body = callAPI(t, http.MethodPost, api.Endpoint+"/products", map[string]any{"name": name, "price": 12.5, "category": "Contract"})
+		assertMatchesSpec(t, spec, "POST /products", status, body)
+		require.Equal(t, http.StatusCreated, status, "POST /products: %s", body)
+
+		var created responses.Product
+		decodeResponse(t, body, &created)
+
+		// Product ids are generated by the service, so namespace cleanup cannot find them
+		t.Cleanup(func() {
+			_, err := dynamodb.NewFromConfig(cfg).DeleteItem(context.TODO(), &dynamodb.DeleteItemInput{
+				TableName: aws.String(resolver.TableName("products")),
+				Key:       map[string]dynamodbtypes.AttributeValue{"id": &dynamodbtypes.AttributeValueMemberS{Value: created.ID}},
+			})
+			assert.NoError(t, err, "Failed to remove product %s", created.ID)
+		})
+		productURL := fmt.Sprintf("%s/products/%s", api.Endpoint, created.ID)
+
+		status, body = callAPI(t, http.MethodGet, api.Endpoint+"/products", nil)
+		assertMatchesSpec(t, spec, "GET /products", status, body)
+
+		status, body = callAPI(t, http.MethodGet, productURL, nil)
+		assertMatchesSpec(t, spec, "GET /products/{id}", status, body)
+
+		status, body = callAPI(t, http.MethodPut, productURL, map[string]any{"name": name + "-updated", "price": 15})
+		assertMatchesSpec(t, spec, "PUT /products/{id}", status, body)
+
+		status, body = callAPI(t, http.MethodDelete, productURL, nil)
+		assertMatchesSpec(t, spec, "DELETE /products/{id}", status, body)
+
+		status, body = callAPI(t, http.MethodGet, productURL, nil)
+		assert.Equal(t, http.StatusNotFound, status, "GET after DELETE")
+		assertMatchesSpec(t, spec, "GET /products/{id}", status, body)
+	})
+}
+
+// assertMatchesSpec fails when a response's status code is not documented for the route or its body
+// does not match the documented schema
+func assertMatchesSpec(t *testing.T, spec *openapi.Spec, route string, status int, body []byte) {
+	t.Helper()
+
+	schema, ok := spec.ResponseSchema(route, status)
+	if !assert.True(t, ok, "%s returned %d, which %s does not document: %s", route, status, openAPISpecPath, body) {
+		return
+	}
+	if schema == nil {
+		assert.Empty(t, body, "%s %d is documented without a body", route, status)
+		return
+	}
+	for _, problem := range spec.ValidateJSON(body, schema) {
+		assert.Fail(t, "Response does not match "+openAPISpecPath, "%s %d: %s\n%s", route, status, problem, body)
+	}
+}
@@ -0,0 +1,71 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+)
+
+// openAPISpecPath is the OpenAPI document committed with the product service
+const openAPISpecPath = "../openapi/product-api.yaml"
+
+// openAPIMethods lists the operation keys of an OpenAPI path item that map to API Gateway routes
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// validateOpenAPIRouteDrift compares the deployed API Gateway routes with the committed OpenAPI spec
+func validateOpenAPIRouteDrift(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+
+	apiClient := apigatewayv2.NewFromConfig(cfg)
+
+	specRoutes, err := loadOpenAPIRoutes(openAPISpecPath)
+	require.NoError(t, err, "Failed to load OpenAPI spec %s", openAPISpecPath)
+	require.NotEmpty(t, specRoutes, "OpenAPI spec %s declares no routes", openAPISpecPath)
+
+	deployedAPI := requireAPI(t, resolver)
+	apiId := deployedAPI.ID
+
+	deployedRoutes, err := checks.DeployedRoutes(context.TODO(), apiClient, apiId)
+	require.NoError(t, err)
+
+	onlyInSpec := checks.RouteDifference(specRoutes, deployedRoutes)
+	onlyDeployed := checks.RouteDifference(deployedRoutes, specRoutes)
+
+	assert.Empty(t, onlyInSpec, "Routes documented in %s but not deployed", openAPISpecPath)
+	assert.Empty(t, onlyDeployed, "Routes deployed but not documented in %s", openAPISpecPath)
+}
+
+// loadOpenAPIRoutes reads an OpenAPI document and returns its operations as API Gateway route keys
+func loadOpenAPIRoutes(path string) (map[string]bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec struct {
+		Paths map[string]map[string]interface{} `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	routes := make(map[string]bool)
+	for specPath, item := range spec.Paths {
+		for _, method := range openAPIMethods {
+			if _, ok := item[method]; ok {
+				routes[fmt.Sprintf("%s %s", strings.ToUpper(method), specPath)] = true
+			}
+		}
+	}
+	return routes, nil
+}
@@ -0,0 +1,112 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/discovery"
+	"github.com/lambda-java-template/tests/internal/history"
+	"github.com/lambda-java-template/tests/internal/orderprops"
+	"github.com/lambda-java-template/tests/internal/payloads"
+)
+
+// orderPropertySettleTime is how long notifications and audit records of the generated orders are given to
+// arrive once the last execution has ended
+const orderPropertySettleTime = time.Minute
+
+// orderPropertyRun is one generated order and how its execution ended
+type orderPropertyRun struct {
+	order     payloads.Order
+	execution string
+	terminal  string
+}
+
+// validateOrderProperties runs -order-properties random orders, valid and invalid, through the order-processing
+// state machine and fails when any breaks an invariant of internal/orderprops: an invalid order is charged or
+// completes, a valid one is rejected or makes a step fail, or an order is charged or notified more than once.
+// The orders are drawn from -order-property-seed, so a failing run can be repeated exactly.
+func validateOrderProperties(t *testing.T, cfg aws.Config, projectName, environment string) {
+	if *orderProperties <= 0 {
+		t.Skip("Skipping property-based orders; set -order-properties to run them")
+	}
+	if testing.Short() {
+		t.Skip("Skipping property-based orders in short mode")
+	}
+	resolver := discover(cfg, projectName, environment)
+
+	stateMachineArn, err := resolver.StateMachineARN(context.TODO(), "order-processing")
+	if errors.Is(err, discovery.ErrNotFound) {
+		t.Skipf("%v", err)
+	}
+	require.NoError(t, err)
+	client := sfn.NewFromConfig(cfg)
+	dynamoClient := dynamodb.NewFromConfig(cfg)
+	auditTable := resolver.TableName("audit-logs")
+	notifications := subscribeCaptureQueue(t, cfg, resolver)
+
+	seed := *orderPropertySeed
+	if seed == 0 {
+		seed = int(time.Now().UnixNano() % math.MaxInt32)
+	}
+	t.Logf("Generating %d orders from seed %d; pass -order-property-seed %d to repeat them", *orderProperties, seed, seed)
+
+	started := time.Now().UTC()
+	generator := orderprops.Orders(testNamespace())
+	runs := make([]orderPropertyRun, *orderProperties)
+	for i := range runs {
+		runs[i].order = generator.Example(seed + i)
+		runs[i].order.OrderID = fmt.Sprintf("%sproperty-%04d-%d", testNamespace(), i, started.UnixNano())
+	}
+
+	// Every execution is a parallel subtest, so all of them are started before any is waited on
+	t.Run("Executions", func(t *testing.T) {
+		for i := range runs {
+			t.Run(fmt.Sprintf("%04d", i), func(t *testing.T) {
+				t.Parallel()
+
+				execution := executeWorkflow(t, client, stateMachineArn, runs[i].order)
+				waitForExecution(t, client, execution, workflowExecutionTimeout)
+				runs[i].execution = execution.arn
+				runs[i].terminal = history.TerminalState(executionHistory(t, client, execution))
+			})
+		}
+	})
+
+	notificationTypes := map[string][]string{}
+	if notifications != nil {
+		for _, payload := range notifications.receive(t, orderPropertySettleTime) {
+			orderId, _ := payload["orderId"].(string)
+			notificationType, _ := payload["notificationType"].(string)
+			notificationTypes[orderId] = append(notificationTypes[orderId], notificationType)
+		}
+	} else {
+		// Audit records are written asynchronously by some services
+		time.Sleep(orderPropertySettleTime)
+	}
+
+	t.Run("Invariants", func(t *testing.T) {
+		for i, run := range runs {
+			if run.execution == "" {
+				continue
+			}
+			counts := checks.CountActions(auditRecords(t, dynamoClient, auditTable, run.order.OrderID, started.Add(-time.Minute)))
+			violations := orderprops.ChargeViolations(run.order, run.terminal, checks.MatchingActions(counts, "payment"))
+			if notifications != nil {
+				violations = append(violations, orderprops.NotificationViolations(run.terminal, notificationTypes[run.order.OrderID], checks.NotificationOrderConfirmation)...)
+			}
+			for _, violation := range violations {
+				t.Errorf("Order %04d of seed %d (%s), execution %s: %s", i, seed, orderprops.Describe(run.order), run.execution, violation)
+			}
+		}
+	})
+}
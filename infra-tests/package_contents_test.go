@@ -0,0 +1,63 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/artifacts"
+	"github.com/lambda-java-template/tests/internal/runtimes"
+)
+
+// mainClassVariable names the Spring Boot application class JarLauncher starts
+const mainClassVariable = "MAIN_CLASS"
+
+// validatePackageContents downloads the deployment package of every function of the deployment and checks
+// it can start: a custom runtime needs an executable bootstrap at its root, and a Java function needs its
+// handler class, and the MAIN_CLASS a Spring Boot launcher starts, in the package or its dependency JARs.
+// A typo in a handler string then fails here instead of on the first invocation.
+func validatePackageContents(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+	client := lambda.NewFromConfig(cfg)
+
+	for _, function := range deploymentFunctions(t, client, resolver) {
+		functionName := aws.ToString(function.FunctionName)
+		runtime := string(function.Runtime)
+		java := strings.HasPrefix(runtime, "java")
+		if function.PackageType == lambdatypes.PackageTypeImage || (!java && !runtimes.NeedsBootstrap(runtime)) {
+			continue
+		}
+
+		t.Run(functionName, func(t *testing.T) {
+			code, err := client.GetFunction(context.TODO(), &lambda.GetFunctionInput{FunctionName: aws.String(functionName)})
+			require.NoError(t, err)
+			require.NotNil(t, code.Code, "GetFunction returned no package location for %s", functionName)
+			archive, err := artifacts.Download(context.TODO(), http.DefaultClient, aws.ToString(code.Code.Location))
+			require.NoError(t, err, "Deployment package of %s", functionName)
+
+			if runtimes.NeedsBootstrap(runtime) {
+				for _, gap := range artifacts.BootstrapGaps(archive) {
+					assert.Fail(t, "Custom runtime cannot start", "%s (%s): %s", functionName, runtime, gap)
+				}
+				return
+			}
+
+			classes := []string{aws.ToString(function.Handler)}
+			if function.Environment != nil && function.Environment.Variables[mainClassVariable] != "" {
+				classes = append(classes, function.Environment.Variables[mainClassVariable])
+			}
+			gaps, err := artifacts.ClassGaps(archive, classes...)
+			require.NoError(t, err, "Deployment package of %s", functionName)
+			for _, gap := range gaps {
+				assert.Fail(t, "Handler class is missing", "%s (handler %s): %s", functionName, aws.ToString(function.Handler), gap)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/tfspec"
+)
+
+// pitrRestoreTimeout bounds one point-in-time restore; even an empty table takes several minutes
+const pitrRestoreTimeout = 30 * time.Minute
+
+// validatePointInTimeRestore restores every table with point-in-time recovery to its latest restorable time in
+// a temporary table named after the test namespace, checks the restored schema and indexes match the source,
+// and deletes it. Restores are billed by the size of the table, so this only runs with -pitr-restore.
+func validatePointInTimeRestore(t *testing.T, cfg aws.Config, projectName, environment string) {
+	if !*pitrRestore {
+		t.Skip("Skipping point-in-time restore; set -pitr-restore to run it")
+	}
+	resolver := discover(cfg, projectName, environment)
+	client := dynamodb.NewFromConfig(cfg)
+
+	for _, table := range tfspec.Tables {
+		sourceName := resolver.TableName(table.NameSuffix)
+		t.Run(table.NameSuffix, func(t *testing.T) {
+			// Restores take minutes each, so the tables restore at the same time
+			t.Parallel()
+
+			backups, err := client.DescribeContinuousBackups(context.TODO(), &dynamodb.DescribeContinuousBackupsInput{
+				TableName: aws.String(sourceName),
+			})
+			require.NoError(t, err)
+			recovery := backups.ContinuousBackupsDescription.PointInTimeRecoveryDescription
+			if recovery == nil || recovery.PointInTimeRecoveryStatus != dynamodbtypes.PointInTimeRecoveryStatusEnabled {
+				t.Skipf("Table %s has point-in-time recovery disabled", sourceName)
+			}
+
+			source, err := client.DescribeTable(context.TODO(), &dynamodb.DescribeTableInput{TableName: aws.String(sourceName)})
+			require.NoError(t, err)
+
+			targetName := fmt.Sprintf("%s-%srestore-%d", sourceName, testNamespace(), time.Now().Unix())
+			_, err = client.RestoreTableToPointInTime(context.TODO(), &dynamodb.RestoreTableToPointInTimeInput{
+				SourceTableName:          aws.String(sourceName),
+				TargetTableName:          aws.String(targetName),
+				UseLatestRestorableTime:  aws.Bool(true),
+				SSESpecificationOverride: checks.RestoreEncryption(*source.Table),
+			})
+			require.NoError(t, err, "Restoring %s to %s", sourceName, targetName)
+			t.Logf("Restoring %s to %s (latest restorable time %s)", sourceName, targetName, aws.ToTime(recovery.LatestRestorableDateTime))
+
+			// A table cannot be deleted while it is still being restored
+			t.Cleanup(func() {
+				waitForTableActive(t, client, targetName)
+				_, err := client.DeleteTable(context.TODO(), &dynamodb.DeleteTableInput{TableName: aws.String(targetName)})
+				assert.NoError(t, err, "Deleting restored table %s", targetName)
+			})
+
+			restored := waitForTableActive(t, client, targetName)
+			for _, gap := range checks.TableParityGaps(*source.Table, restored) {
+				assert.Fail(t, "Restored table differs from its source", "%s restored from %s: %s", targetName, sourceName, gap)
+			}
+		})
+	}
+}
+
+// waitForTableActive waits for a table being created or restored to become active and returns its description
+func waitForTableActive(t *testing.T, client *dynamodb.Client, tableName string) dynamodbtypes.TableDescription {
+	var table dynamodbtypes.TableDescription
+	waitFor(t, pitrRestoreTimeout, "table "+tableName+" to become active", func(ctx context.Context) (bool, error) {
+		described, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(tableName)})
+		if err != nil {
+			return false, err
+		}
+		table = *described.Table
+		return table.TableStatus == dynamodbtypes.TableStatusActive, nil
+	})
+	return table
+}
@@ -0,0 +1,110 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+
+	"github.com/lambda-java-template/tests/internal/discovery"
+	"github.com/lambda-java-template/tests/internal/preflight"
+)
+
+// validatorRequirements lists the components of the template each validator needs deployed. A validator
+// whose components are absent is skipped with the reason; validators missing here always run.
+var validatorRequirements = map[string][]preflight.Component{
+	"Lambda_Functions_Validation":    {preflight.ProductService, preflight.AuthorizerService},
+	"Direct_Invocation":              {preflight.ProductService, preflight.AuthorizerService},
+	"Deployment_Packages":            {preflight.ProductService, preflight.AuthorizerService},
+	"Build_Metadata":                 {preflight.ProductService, preflight.AuthorizerService},
+	"Version_Skew":                   {preflight.ProductService, preflight.API},
+	"Health_Check":                   {preflight.ProductService, preflight.API},
+	"DynamoDB_Tables_Validation":     {preflight.ProductsTable, preflight.AuditLogsTable},
+	"DynamoDB_PITR_Restore":          {preflight.ProductsTable},
+	"API_Gateway_Integration":        {preflight.API},
+	"Authorizer_Contract":            {preflight.AuthorizerService, preflight.API},
+	"Authorizer_Cache":               {preflight.AuthorizerService, preflight.API},
+	"Product_CRUD_Round_Trip":        {preflight.API, preflight.ProductsTable},
+	"Name_Index_Queries":             {preflight.API, preflight.ProductsTable},
+	"Negative_Inputs":                {preflight.API},
+	"API_Scenarios":                  {preflight.API, preflight.ProductsTable, preflight.AuditLogsTable},
+	"DynamoDB_Capacity":              {preflight.ProductsTable, preflight.AuditLogsTable},
+	"IAM_Route_Authorization":        {preflight.API},
+	"Route_Authorization_Matrix":     {preflight.API},
+	"OpenAPI_Route_Drift":            {preflight.API},
+	"OpenAPI_Contract":               {preflight.API},
+	"Access_Logs":                    {preflight.API},
+	"Access_Log_Analytics":           {preflight.API},
+	"Notification_Path":              {preflight.OrderWorkflow},
+	"Step_Functions_Definition":      {preflight.OrderWorkflow},
+	"Step_Functions_Branch_Coverage": {preflight.OrderWorkflow, preflight.AuditLogsTable},
+	"Chaos_Dependency_Failures":      {preflight.OrderWorkflow},
+	"Failure_Mode_Injection":         {preflight.OrderWorkflow},
+	"Order_Idempotency":              {preflight.OrderWorkflow, preflight.AuditLogsTable},
+	"Order_Properties":               {preflight.OrderWorkflow, preflight.AuditLogsTable},
+	"Workflow_Execution_SLO":         {preflight.OrderWorkflow},
+	"Audit_Completeness":             {preflight.OrderWorkflow, preflight.API, preflight.ProductsTable, preflight.AuditLogsTable},
+	"Step_Functions_Concurrency":     {preflight.OrderWorkflow},
+	"WAF_Protection":                 {preflight.API},
+	"Custom_Domain_TLS":              {preflight.API},
+	"API_Throttling":                 {preflight.API},
+	"API_Stage_Deployment":           {preflight.API},
+	"Performance_Validation":         {preflight.API},
+	"Cold_Start_Benchmark":           {preflight.ProductService, preflight.AuthorizerService},
+}
+
+// runPreflight looks up every component of the template once before validators run. A lookup that fails for
+// another reason than not-found is logged and the component treated as deployed, so its validators report the
+// real error rather than a skip.
+func runPreflight(t *testing.T, cfg aws.Config, resolver *discovery.Resolver) preflight.Result {
+	lambdaClient := lambda.NewFromConfig(cfg)
+	dynamoClient := dynamodb.NewFromConfig(cfg)
+
+	function := func(component preflight.Component, suffix string) preflight.Probe {
+		name := resolver.FunctionName(suffix)
+		return preflight.Probe{Component: component, Resource: name, Lookup: func(ctx context.Context) error {
+			_, err := lambdaClient.GetFunction(ctx, &lambda.GetFunctionInput{FunctionName: aws.String(name)})
+			return err
+		}}
+	}
+	table := func(component preflight.Component, suffix string) preflight.Probe {
+		name := resolver.TableName(suffix)
+		return preflight.Probe{Component: component, Resource: name, Lookup: func(ctx context.Context) error {
+			_, err := dynamoClient.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(name)})
+			return err
+		}}
+	}
+
+	result, err := preflight.Run(context.TODO(), []preflight.Probe{
+		function(preflight.ProductService, "product-service"),
+		function(preflight.AuthorizerService, "authorizer-service"),
+		table(preflight.ProductsTable, "products"),
+		table(preflight.AuditLogsTable, "audit-logs"),
+		{Component: preflight.API, Resource: resolver.APIName(), Lookup: func(ctx context.Context) error {
+			_, err := resolver.API(ctx)
+			return err
+		}},
+		{Component: preflight.OrderWorkflow, Resource: resolver.ResourceName("order-processing"), Lookup: func(ctx context.Context) error {
+			_, err := resolver.StateMachineARN(ctx, "order-processing")
+			return err
+		}},
+	})
+	if err != nil {
+		t.Logf("Preflight could not look up every component; their validators run and report the error:\n%v", err)
+	}
+	return result
+}
+
+// skipAbsent skips a validator whose required components are not deployed, recording each one as missing
+func skipAbsent(t *testing.T, name string, deployed preflight.Result) {
+	absent := deployed.Absent(validatorRequirements[name])
+	if len(absent) == 0 {
+		return
+	}
+	for _, component := range absent {
+		suiteResults.RecordMissing(string(component), deployed.Missing[component], name)
+	}
+	t.Skip(deployed.SkipReason(absent))
+}
@@ -0,0 +1,176 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/responses"
+)
+
+// auditWriteTimeout bounds how long the audit records of a mutation may take to appear
+const auditWriteTimeout = time.Minute
+
+// validateProductCRUD creates, reads, queries, updates, and deletes a product through the API
+// and checks the products table after each step
+func validateProductCRUD(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+
+	apiEndpoint := requireAPI(t, resolver).Endpoint
+	dynamoClient := dynamodb.NewFromConfig(cfg)
+	productsTable := resolver.TableName("products")
+
+	started := time.Now().UTC()
+	name := fmt.Sprintf("%scrud-%d", testNamespace(), started.UnixNano())
+
+	// Create
+	status, body := callAPI(t, http.MethodPost, apiEndpoint+"/products", map[string]any{"name": name, "price": 12.5})
+	require.Equal(t, http.StatusCreated, status, "POST /products: %s", body)
+
+	var created responses.Product
+	decodeResponse(t, body, &created)
+	assert.Equal(t, name, created.Name)
+	assert.Equal(t, 12.5, created.Price)
+
+	// Product ids are generated by the service, so namespace cleanup cannot find them
+	t.Cleanup(func() {
+		_, err := dynamoClient.DeleteItem(context.TODO(), &dynamodb.DeleteItemInput{
+			TableName: aws.String(productsTable),
+			Key:       map[string]dynamodbtypes.AttributeValue{"id": &dynamodbtypes.AttributeValueMemberS{Value: created.ID}},
+		})
+		assert.NoError(t, err, "Failed to remove product %s", created.ID)
+	})
+	productURL := fmt.Sprintf("%s/products/%s", apiEndpoint, created.ID)
+
+	t.Run("Get_By_Id", func(t *testing.T) {
+		status, body := callAPI(t, http.MethodGet, productURL, nil)
+		require.Equal(t, http.StatusOK, status, "GET %s: %s", productURL, body)
+		assert.JSONEq(t, fmt.Sprintf(`{"id":%q,"name":%q,"price":12.5}`, created.ID, name), string(body))
+	})
+
+	t.Run("Query_Name_Index", func(t *testing.T) {
+		// Global secondary indexes are eventually consistent with the table
+		var items []map[string]dynamodbtypes.AttributeValue
+		waitFor(t, 30*time.Second, fmt.Sprintf("name-index entry for %q", name), func(ctx context.Context) (bool, error) {
+			result, err := dynamoClient.Query(ctx, &dynamodb.QueryInput{
+				TableName:              aws.String(productsTable),
+				IndexName:              aws.String("name-index"),
+				KeyConditionExpression: aws.String("#name = :name"),
+				ExpressionAttributeNames: map[string]string{
+					"#name": "name",
+				},
+				ExpressionAttributeValues: map[string]dynamodbtypes.AttributeValue{
+					":name": &dynamodbtypes.AttributeValueMemberS{Value: name},
+				},
+			})
+			if err != nil {
+				return false, err
+			}
+			items = result.Items
+			return len(items) > 0, nil
+		})
+		require.Len(t, items, 1, "name-index query for %q", name)
+		assert.Equal(t, &dynamodbtypes.AttributeValueMemberS{Value: created.ID}, items[0]["id"])
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		status, body := callAPI(t, http.MethodPut, productURL, map[string]any{"name": name + "-updated", "price": 15})
+		require.Equal(t, http.StatusOK, status, "PUT %s: %s", productURL, body)
+		assert.JSONEq(t, fmt.Sprintf(`{"id":%q,"name":%q,"price":15}`, created.ID, name+"-updated"), string(body))
+
+		status, body = callAPI(t, http.MethodGet, productURL, nil)
+		require.Equal(t, http.StatusOK, status)
+		var stored responses.Product
+		decodeResponse(t, body, &stored)
+		assert.Equal(t, name+"-updated", stored.Name, "GET after PUT returns the stored update")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		status, body := callAPI(t, http.MethodDelete, productURL, nil)
+		assert.Contains(t, []int{http.StatusOK, http.StatusNoContent}, status, "DELETE %s: %s", productURL, body)
+
+		status, body = callAPI(t, http.MethodGet, productURL, nil)
+		assert.Equal(t, http.StatusNotFound, status, "GET after DELETE")
+		decodeResponse(t, body, &responses.ErrorResponse{})
+	})
+}
+
+// auditRecords returns the audit records written since a time that reference an entity.
+// Audit timestamps are ISO 8601 strings, so they compare in time order.
+func auditRecords(t *testing.T, client *dynamodb.Client, tableName, entityId string, since time.Time) []map[string]dynamodbtypes.AttributeValue {
+	var records []map[string]dynamodbtypes.AttributeValue
+	paginator := dynamodb.NewScanPaginator(client, &dynamodb.ScanInput{
+		TableName:                aws.String(tableName),
+		FilterExpression:         aws.String("#timestamp >= :since"),
+		ExpressionAttributeNames: map[string]string{"#timestamp": "timestamp"},
+		ExpressionAttributeValues: map[string]dynamodbtypes.AttributeValue{
+			":since": &dynamodbtypes.AttributeValueMemberS{Value: since.Format(time.RFC3339)},
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		require.NoError(t, err)
+
+		for _, item := range page.Items {
+			if checks.ItemReferences(item, entityId) {
+				records = append(records, item)
+			}
+		}
+	}
+	return records
+}
+
+// callAPI sends a request with an API key and a JSON body, when one is given, and returns the response
+func callAPI(t *testing.T, method, url string, body any) (int, []byte) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, url, reader)
+	require.NoError(t, err)
+	req.Header.Set("x-api-key", fmt.Sprintf("infra-tests-%s", suiteConfig.Environment))
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := suiteHTTP.Do(req)
+	require.NoError(t, err, "%s %s", method, url)
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, data
+}
+
+// getURL sends GET url without an API key and returns the status code and body
+func getURL(t *testing.T, url string) (int, []byte) {
+	resp, err := suiteHTTP.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, data
+}
+
+// decodeResponse fails the test unless body decodes into target with every required field
+// present, of the right JSON type, and valid
+func decodeResponse(t *testing.T, body []byte, target responses.Body) {
+	t.Helper()
+	require.NoError(t, responses.Decode(body, target), "Response body: %s", body)
+}
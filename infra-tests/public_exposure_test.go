@@ -0,0 +1,92 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/discovery"
+)
+
+// validatePublicExposure lists every function named after the deployment, tagged or not, and fails for a
+// function URL with AuthType NONE and for resource policy statements that let callers other than the
+// deployment's API and state machine invoke it
+func validatePublicExposure(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+	client := lambda.NewFromConfig(cfg)
+	functions := deploymentFunctions(t, client, resolver)
+
+	// Invocations scoped to the deployment's own API and state machine are expected
+	var allowedSources []string
+	if stateMachineArn, err := resolver.StateMachineARN(context.TODO(), "order-processing"); err == nil {
+		allowedSources = append(allowedSources, stateMachineArn)
+	}
+	api, apiErr := resolver.API(context.TODO())
+
+	for _, function := range functions {
+		functionName := aws.ToString(function.FunctionName)
+		t.Run(functionName, func(t *testing.T) {
+			sources := append([]string{}, allowedSources...)
+			if apiErr == nil {
+				functionArn, err := arn.Parse(aws.ToString(function.FunctionArn))
+				require.NoError(t, err)
+				sources = append(sources, fmt.Sprintf("arn:%s:execute-api:%s:%s:%s/", functionArn.Partition, functionArn.Region, functionArn.AccountID, api.ID))
+			}
+
+			urls := lambda.NewListFunctionUrlConfigsPaginator(client, &lambda.ListFunctionUrlConfigsInput{FunctionName: aws.String(functionName)})
+			for urls.HasMorePages() {
+				page, err := urls.NextPage(context.TODO())
+				require.NoError(t, err)
+				for _, url := range page.FunctionUrlConfigs {
+					for _, gap := range checks.FunctionURLGaps(aws.ToString(url.FunctionUrl), string(url.AuthType)) {
+						assert.Fail(t, "Function is publicly invokable", "%s: %s", functionName, gap)
+					}
+				}
+			}
+
+			policy, err := client.GetPolicy(context.TODO(), &lambda.GetPolicyInput{FunctionName: aws.String(functionName)})
+			var notFound *lambdatypes.ResourceNotFoundException
+			if errors.As(err, &notFound) {
+				// Without a resource policy only principals of the account can invoke it
+				return
+			}
+			require.NoError(t, err)
+			gaps, err := checks.PublicInvokeGaps(aws.ToString(policy.Policy), sources)
+			require.NoError(t, err, "Resource policy of %s", functionName)
+			for _, gap := range gaps {
+				assert.Fail(t, "Function is publicly invokable", "%s: %s", functionName, gap)
+			}
+		})
+	}
+}
+
+// deploymentFunctions returns every function named after the deployment, whether or not it is tagged or
+// declared in Terraform, and skips the test when there are none
+func deploymentFunctions(t *testing.T, client *lambda.Client, resolver *discovery.Resolver) []lambdatypes.FunctionConfiguration {
+	prefix := resolver.Settings().BaseName() + "-"
+	var functions []lambdatypes.FunctionConfiguration
+	paginator := lambda.NewListFunctionsPaginator(client, &lambda.ListFunctionsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		require.NoError(t, err)
+		for _, function := range page.Functions {
+			if strings.HasPrefix(aws.ToString(function.FunctionName), prefix) {
+				functions = append(functions, function)
+			}
+		}
+	}
+	if len(functions) == 0 {
+		t.Skipf("No function is named %s*", prefix)
+	}
+	return functions
+}
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/lambda-java-template/tests/internal/quarantine"
+	"github.com/lambda-java-template/tests/internal/results"
+)
+
+// quarantineChildEnv marks a process started to run a single quarantined validator
+const quarantineChildEnv = "INFRA_TESTS_QUARANTINE_CHILD"
+
+// quarantinedValidators are known to be eventually consistent. They are retried,
+// their flake rates are reported separately, and they never fail the run.
+var quarantinedValidators = map[string]bool{
+	"Access_Log_Analytics": true,
+}
+
+// quarantineReport collects the outcome of every quarantined validator in this run
+var quarantineReport quarantine.Report
+
+// inQuarantineChild reports whether this process is running one quarantined validator on behalf of a parent
+func inQuarantineChild() bool {
+	return os.Getenv(quarantineChildEnv) == "1"
+}
+
+// runQuarantined runs a validator of parent in a child test process until it passes or retries run out.
+// Each attempt gets a fresh process so a failed attempt cannot mark the parent test as failed.
+func runQuarantined(t *testing.T, parent, name string) {
+	args := append([]string{"-test.run", fmt.Sprintf("^%s$/^%s$", parent, name), "-test.v"}, suiteFlags()...)
+	result := quarantine.Run(name, *quarantineRetries, func() (bool, string) {
+		cmd := exec.Command(os.Args[0], args...)
+		cmd.Env = append(os.Environ(), quarantineChildEnv+"=1")
+		output, err := cmd.CombinedOutput()
+		return err == nil, string(output)
+	})
+	quarantineReport.Add(result)
+
+	if !result.Passed() {
+		t.Logf("QUARANTINED FAILURE %s; output of last attempt:\n%s", result, result.LastOutput)
+		return
+	}
+	t.Logf("QUARANTINED %s", result)
+}
+
+// recordQuarantineReport adds the attempts made at every quarantined validator to the results summary
+func recordQuarantineReport() {
+	for _, result := range quarantineReport.Results() {
+		suiteResults.RecordQuarantined(results.Quarantined{
+			Name:      result.Name,
+			Status:    result.Status(),
+			Attempts:  result.Attempts,
+			Failures:  result.Failures,
+			FlakeRate: result.FlakeRate(),
+		})
+	}
+}
+
+// suiteFlags returns the suite's own flags set on this process, such as -suite and -http-timeout, so a child
+// runs its validator with the same settings. The testing package's -test.* flags are left to the caller.
+func suiteFlags() []string {
+	var args []string
+	flag.Visit(func(f *flag.Flag) {
+		if !strings.HasPrefix(f.Name, "test.") {
+			args = append(args, fmt.Sprintf("-%s=%s", f.Name, f.Value))
+		}
+	})
+	return args
+}
+
+// logQuarantineReport prints the flake statistics of every quarantined validator that ran
+func logQuarantineReport(t *testing.T) {
+	results := quarantineReport.Results()
+	if len(results) == 0 {
+		return
+	}
+
+	t.Log("Quarantined validator report:")
+	for _, result := range results {
+		t.Logf("  %s", result)
+	}
+}
@@ -0,0 +1,63 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/testconfig"
+)
+
+// validateRecursiveLoopDetection validates that event-driven functions terminate recursive loops
+func validateRecursiveLoopDetection(t *testing.T, cfg aws.Config, projectName, environment string) {
+	requireFeature(t, testconfig.FeatureRecursionControl)
+	resolver := discover(cfg, projectName, environment)
+
+	lambdaClient := lambda.NewFromConfig(cfg)
+	prefix := resolver.Settings().BaseName() + "-"
+
+	var functionNames []string
+	paginator := lambda.NewListFunctionsPaginator(lambdaClient, &lambda.ListFunctionsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		require.NoError(t, err)
+
+		for _, function := range page.Functions {
+			if strings.HasPrefix(*function.FunctionName, prefix) {
+				functionNames = append(functionNames, *function.FunctionName)
+			}
+		}
+	}
+	require.NotEmpty(t, functionNames, "No functions found with prefix %s", prefix)
+
+	checked := 0
+	for _, functionName := range functionNames {
+		source, err := checks.EventLoopSource(context.TODO(), lambdaClient, functionName)
+		require.NoError(t, err)
+		if source == "" {
+			continue
+		}
+		checked++
+
+		t.Run(functionName, func(t *testing.T) {
+			recursion, err := lambdaClient.GetFunctionRecursionConfig(context.TODO(), &lambda.GetFunctionRecursionConfigInput{
+				FunctionName: aws.String(functionName),
+			})
+			require.NoError(t, err, "Failed to get recursion config for %s", functionName)
+
+			assert.Equal(t, lambdatypes.RecursiveLoopTerminate, recursion.RecursiveLoop,
+				"Function %s is triggered by %s but does not terminate recursive loops", functionName, source)
+		})
+	}
+
+	if checked == 0 {
+		t.Logf("No functions with prefix %s consume EventBridge, SQS, or SNS events", prefix)
+	}
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/expectations"
+)
+
+// authorizationProbePathValue replaces path parameters when probing protected routes without credentials
+const authorizationProbePathValue = "itest-authorization-probe"
+
+// validateRouteAuthorizationMatrix lists every route of the API, checks its authorization against the
+// environment profile's PublicRoutes allowlist and ProtectedRoutes denylist, and calls each protected route
+// without credentials. A route on neither list counts as protected, so a new route cannot ship unauthenticated
+// without being allowlisted.
+func validateRouteAuthorizationMatrix(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+	apiClient := apigatewayv2.NewFromConfig(cfg)
+	api := requireAPI(t, resolver)
+
+	var routes []types.Route
+	var nextToken *string
+	for {
+		page, err := apiClient.GetRoutes(context.TODO(), &apigatewayv2.GetRoutesInput{
+			ApiId:     aws.String(api.ID),
+			NextToken: nextToken,
+		})
+		require.NoError(t, err)
+		routes = append(routes, page.Items...)
+		if nextToken = page.NextToken; nextToken == nil {
+			break
+		}
+	}
+
+	profile := expectations.For(environment)
+	matrix, gaps := checks.AuthorizationMatrix(routes, profile.PublicRoutes, profile.ProtectedRoutes)
+	for _, access := range matrix {
+		t.Logf("%-24s %-8s public=%t", access.RouteKey, access.AuthorizationType, access.Public)
+	}
+
+	t.Run("Configuration", func(t *testing.T) {
+		for _, gap := range gaps {
+			assert.Fail(t, "Route authorization", "API %s: %s", resolver.APIName(), gap)
+		}
+	})
+
+	t.Run("Unauthenticated_Probes", func(t *testing.T) {
+		for _, access := range matrix {
+			// Reserved routes such as $default have no method and path to call
+			if access.Public || strings.HasPrefix(access.RouteKey, "$") {
+				continue
+			}
+			t.Run(access.RouteKey, func(t *testing.T) {
+				method, path := routeRequest(access.RouteKey, authorizationProbePathValue)
+				req, err := http.NewRequest(method, api.Endpoint+path, nil)
+				require.NoError(t, err)
+
+				resp, err := suiteHTTP.Do(req)
+				require.NoError(t, err, "%s %s", method, path)
+				resp.Body.Close()
+
+				// API Gateway rejects a missing identity source with 401 before invoking the authorizer;
+				// IAM routes answer unsigned requests with 403
+				want := http.StatusUnauthorized
+				if access.AuthorizationType == types.AuthorizationTypeAwsIam {
+					want = http.StatusForbidden
+				}
+				assert.Equal(t, want, resp.StatusCode, "%s %s without credentials", method, path)
+			})
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/runtimes"
+	"github.com/lambda-java-template/tests/internal/tfspec"
+)
+
+// validateRuntimeDeprecation checks every function of the deployment: its runtime must be more than
+// -runtime-deprecation-days from deprecation, and its layers must exist and match the versions Terraform declares
+func validateRuntimeDeprecation(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+	client := lambda.NewFromConfig(cfg)
+	window := time.Duration(*runtimeDeprecationDays) * 24 * time.Hour
+	prefix := resolver.Settings().BaseName() + "-"
+
+	declared := map[string]tfspec.FunctionSpec{}
+	for _, function := range tfspec.Functions {
+		declared[function.NameSuffix] = function
+	}
+
+	for _, function := range deploymentFunctions(t, client, resolver) {
+		functionName := aws.ToString(function.FunctionName)
+		t.Run(functionName, func(t *testing.T) {
+			runtime := string(function.Runtime)
+			for _, gap := range runtimes.DeprecationGaps(runtime, time.Now(), window) {
+				assert.Fail(t, "Runtime is deprecated or about to be", "%s: %s", functionName, gap)
+			}
+
+			var attached []string
+			for _, layer := range function.Layers {
+				layerArn := aws.ToString(layer.Arn)
+				attached = append(attached, layerArn)
+				_, err := client.GetLayerVersionByArn(context.TODO(), &lambda.GetLayerVersionByArnInput{Arn: aws.String(layerArn)})
+				var notFound *lambdatypes.ResourceNotFoundException
+				if errors.As(err, &notFound) {
+					assert.Fail(t, "Layer does not exist", "%s uses layer %s, which was deleted", functionName, layerArn)
+					continue
+				}
+				require.NoError(t, err, "Failed to get layer %s of %s", layerArn, functionName)
+			}
+			if spec, ok := declared[strings.TrimPrefix(functionName, prefix)]; ok {
+				for _, gap := range runtimes.LayerGaps(attached, spec.Layers) {
+					assert.Fail(t, "Layers differ from Terraform", "%s: %s", functionName, gap)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/expectations"
+	"github.com/lambda-java-template/tests/internal/testconfig"
+)
+
+// validateRuntimeManagement validates each function's runtime update mode against the environment's profile
+func validateRuntimeManagement(t *testing.T, cfg aws.Config, projectName, environment string) {
+	requireFeature(t, testconfig.FeatureRuntimeManagement)
+	resolver := discover(cfg, projectName, environment)
+
+	lambdaClient := lambda.NewFromConfig(cfg)
+
+	allowedModes := expectations.For(environment).RuntimeUpdateModes
+
+	functions := []string{
+		resolver.FunctionName("product-service"),
+		resolver.FunctionName("authorizer-service"),
+	}
+
+	for _, functionName := range functions {
+		t.Run(functionName, func(t *testing.T) {
+			runtimeConfig, err := lambdaClient.GetRuntimeManagementConfig(context.TODO(), &lambda.GetRuntimeManagementConfigInput{
+				FunctionName: aws.String(functionName),
+			})
+			require.NoError(t, err, "Failed to get runtime management config for %s", functionName)
+
+			assert.Contains(t, allowedModes, runtimeConfig.UpdateRuntimeOn,
+				"Runtime update mode %s is not allowed in %s", runtimeConfig.UpdateRuntimeOn, environment)
+
+			// Manual mode is only deliberate when it pins a specific runtime version
+			if runtimeConfig.UpdateRuntimeOn == lambdatypes.UpdateRuntimeOnManual {
+				require.NotNil(t, runtimeConfig.RuntimeVersionArn, "Manual runtime mode requires a pinned runtime version ARN")
+				assert.Contains(t, *runtimeConfig.RuntimeVersionArn, ":runtime:")
+			}
+		})
+	}
+}
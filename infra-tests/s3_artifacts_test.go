@@ -0,0 +1,102 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/discovery"
+	"github.com/lambda-java-template/tests/internal/tfspec"
+)
+
+// validateArtifactBucket validates the bucket Lambda JARs are deployed from
+func validateArtifactBucket(t *testing.T, cfg aws.Config, resolver *discovery.Resolver) {
+	s3Client := s3.NewFromConfig(cfg)
+	bucket := artifactBucketName(t, s3Client, resolver)
+	recordResource(t, "s3", bucket)
+
+	t.Run("Encryption", func(t *testing.T) {
+		encryption, err := s3Client.GetBucketEncryption(context.TODO(), &s3.GetBucketEncryptionInput{
+			Bucket: aws.String(bucket),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, encryption.ServerSideEncryptionConfiguration)
+
+		var algorithms []string
+		for _, rule := range encryption.ServerSideEncryptionConfiguration.Rules {
+			if rule.ApplyServerSideEncryptionByDefault != nil {
+				algorithms = append(algorithms, string(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm))
+			}
+		}
+		assert.NotEmpty(t, algorithms, "Bucket %s has no default encryption", bucket)
+	})
+
+	t.Run("Versioning", func(t *testing.T) {
+		versioning, err := s3Client.GetBucketVersioning(context.TODO(), &s3.GetBucketVersioningInput{
+			Bucket: aws.String(bucket),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, s3types.BucketVersioningStatusEnabled, versioning.Status, "Bucket %s must be versioned so deployments can be rolled back", bucket)
+	})
+
+	t.Run("Public_Access_Block", func(t *testing.T) {
+		block, err := s3Client.GetPublicAccessBlock(context.TODO(), &s3.GetPublicAccessBlockInput{
+			Bucket: aws.String(bucket),
+		})
+		require.NoError(t, err, "Bucket %s has no public access block", bucket)
+		assert.True(t, checks.BlocksPublicAccess(block.PublicAccessBlockConfiguration), "Bucket %s does not block all public access", bucket)
+	})
+
+	t.Run("Artifact_Lifecycle", func(t *testing.T) {
+		lifecycle, err := s3Client.GetBucketLifecycleConfiguration(context.TODO(), &s3.GetBucketLifecycleConfigurationInput{
+			Bucket: aws.String(bucket),
+		})
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchLifecycleConfiguration" {
+			assert.Fail(t, "No lifecycle configuration", "Bucket %s keeps every superseded artifact forever", bucket)
+			return
+		}
+		require.NoError(t, err)
+		assert.True(t, checks.ExpiresNoncurrentVersions(lifecycle.Rules), "Bucket %s has no rule expiring superseded artifact versions", bucket)
+	})
+
+	t.Run("Function_Code_Objects", func(t *testing.T) {
+		for _, function := range tfspec.Functions {
+			key := checks.ArtifactKey(function.NameSuffix)
+			_, err := s3Client.HeadObject(context.TODO(), &s3.HeadObjectInput{
+				Bucket: aws.String(bucket),
+				Key:    aws.String(key),
+			})
+			assert.NoError(t, err, "Code object s3://%s/%s of %s does not exist", bucket, key, resolver.FunctionName(function.NameSuffix))
+		}
+	})
+}
+
+// artifactBucketName returns the artifact bucket from the lambda_artifacts_bucket_name output or ListBuckets.
+// The bucket name ends in a random suffix, so it cannot be derived from the naming convention alone.
+func artifactBucketName(t *testing.T, client *s3.Client, resolver *discovery.Resolver) string {
+	if bucket := resolver.Output("lambda_artifacts_bucket_name"); bucket != "" {
+		return bucket
+	}
+
+	prefix := resolver.ResourceName("lambda-artifacts-")
+	buckets, err := client.ListBuckets(context.TODO(), &s3.ListBucketsInput{})
+	require.NoError(t, err)
+
+	for _, bucket := range buckets.Buckets {
+		if strings.HasPrefix(aws.ToString(bucket.Name), prefix) {
+			return *bucket.Name
+		}
+	}
+	require.FailNow(t, "Artifact bucket not found", "No bucket with prefix %s", prefix)
+	return ""
+}
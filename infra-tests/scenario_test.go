@@ -0,0 +1,156 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/discovery"
+	"github.com/lambda-java-template/tests/internal/scenarios"
+)
+
+// loadScenarios returns the workflow or the API scenarios in testdata/scenarios
+func loadScenarios(t *testing.T, workflows bool) []scenarios.Scenario {
+	loaded, err := scenarios.Load(scenarios.Dir)
+	require.NoError(t, err, "Scenarios in %s are invalid", scenarios.Dir)
+
+	var selected []scenarios.Scenario
+	for _, scenario := range loaded {
+		if scenario.IsWorkflow() == workflows {
+			selected = append(selected, scenario)
+		}
+	}
+	return selected
+}
+
+// scenarioVars are the values of the placeholders a scenario's strings may hold
+func scenarioVars(orderId string) map[string]string {
+	return map[string]string{
+		"namespace": testNamespace(),
+		"orderId":   orderId,
+		"unique":    fmt.Sprint(time.Now().UnixNano()),
+	}
+}
+
+// validateAPIScenarios sends the request of each API scenario in testdata/scenarios and checks the response
+// status, the response fields the scenario lists, and the audit records it expects
+func validateAPIScenarios(t *testing.T, cfg aws.Config, projectName, environment string) {
+	if testing.Short() {
+		t.Skip("Skipping API scenarios in short mode")
+	}
+	resolver := discover(cfg, projectName, environment)
+
+	apiEndpoint := requireAPI(t, resolver).Endpoint
+	dynamoClient := dynamodb.NewFromConfig(cfg)
+
+	for _, scenario := range loadScenarios(t, false) {
+		t.Run(scenario.Name, func(t *testing.T) {
+			started := time.Now().UTC()
+			vars := scenarioVars("")
+			request := scenario.Request
+			url := apiEndpoint + scenarios.Expand(request.Path, vars).(string)
+
+			status, body := callAPI(t, request.Method, url, scenarios.Expand(request.Body, vars))
+
+			var created struct {
+				ID string `json:"id"`
+			}
+			_ = json.Unmarshal(body, &created)
+			// Products the scenario created must not outlive it
+			if request.Method == http.MethodPost && status == http.StatusCreated && created.ID != "" {
+				t.Cleanup(func() {
+					_, err := dynamoClient.DeleteItem(context.TODO(), &dynamodb.DeleteItemInput{
+						TableName: aws.String(resolver.TableName("products")),
+						Key:       map[string]dynamodbtypes.AttributeValue{"id": &dynamodbtypes.AttributeValueMemberS{Value: created.ID}},
+					})
+					assert.NoError(t, err, "Failed to remove product %s", created.ID)
+				})
+			}
+
+			require.Equal(t, scenario.Expect.Status, status, "%s %s (%s): %s", request.Method, url, scenario.Source, body)
+			mismatches, err := scenarios.BodyMismatches(scenario.Expect.Body, body, vars)
+			require.NoError(t, err, "%s %s: %s", request.Method, url, body)
+			for _, mismatch := range mismatches {
+				t.Errorf("%s %s (%s): %s", request.Method, url, scenario.Source, mismatch)
+			}
+
+			if len(scenario.Expect.Audit) > 0 {
+				require.NotEmpty(t, created.ID, "Scenario %s expects audit records, but the response names no product id: %s", scenario.Name, body)
+				assertScenarioAudit(t, cfg, resolver, scenario, created.ID, started)
+			}
+		})
+	}
+}
+
+// scenarioNotifications captures the notifications of one workflow scenario; nil when it expects none
+type scenarioNotifications struct {
+	client           *sqs.Client
+	queueUrl         string
+	topicArn         string
+	notificationType string
+}
+
+// captureScenarioNotifications subscribes a capture queue to the notification topic when the scenario expects a
+// notification. Without a deployed topic the notification is left unchecked and the rest of the scenario runs.
+func captureScenarioNotifications(t *testing.T, cfg aws.Config, resolver *discovery.Resolver, scenario scenarios.Scenario) *scenarioNotifications {
+	if scenario.Expect.Notification == "" {
+		return nil
+	}
+	topicArn := notificationTopicArn(t, sns.NewFromConfig(cfg), resolver)
+	if topicArn == "" {
+		t.Logf("Not checking the %s notification: topic %s is not deployed", scenario.Expect.Notification, resolver.ResourceName("notifications"))
+		return nil
+	}
+	return &scenarioNotifications{
+		client:           sqs.NewFromConfig(cfg),
+		queueUrl:         subscribeTopicQueue(t, cfg, resolver, topicArn),
+		topicArn:         topicArn,
+		notificationType: scenario.Expect.Notification,
+	}
+}
+
+// assertReceived fails the test unless the expected notification for orderId arrives
+func (n *scenarioNotifications) assertReceived(t *testing.T, orderId string) {
+	if n == nil {
+		return
+	}
+	notification := awaitNotification(t, n.client, n.queueUrl, n.topicArn, orderId, n.notificationType)
+	if assert.NotNil(t, notification, "No %s notification for order %s on %s within %s", n.notificationType, orderId, n.topicArn, notificationDeliveryTimeout) {
+		assert.Empty(t, checks.MissingNotificationFields(notification), "Notification for order %s is missing required fields", orderId)
+	}
+}
+
+// assertScenarioAudit waits for the audit records a scenario expects for entity, one each, written since started
+func assertScenarioAudit(t *testing.T, cfg aws.Config, resolver *discovery.Resolver, scenario scenarios.Scenario, entity string, started time.Time) {
+	if len(scenario.Expect.Audit) == 0 {
+		return
+	}
+	dynamoClient := dynamodb.NewFromConfig(cfg)
+	auditTable := resolver.TableName("audit-logs")
+
+	var actions []checks.AuditedAction
+	for _, entry := range scenario.Expect.Audit {
+		actions = append(actions, checks.AuditedAction{Entity: entity, Action: entry.Action, Before: entry.Before, After: entry.After})
+	}
+
+	var gaps []string
+	assertEventually(t, auditWriteTimeout, func() bool {
+		gaps = checks.AuditGaps(actions, auditRecords(t, dynamoClient, auditTable, entity, started.Add(-time.Minute)))
+		return len(gaps) == 0
+	}, "Audit log %s lacks the records scenario %s expects", auditTable, scenario.Name)
+	for _, gap := range gaps {
+		t.Errorf("Audit log %s: %s", auditTable, gap)
+	}
+}
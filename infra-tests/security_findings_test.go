@@ -0,0 +1,112 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/securityhub"
+	securityhubtypes "github.com/aws/aws-sdk-go-v2/service/securityhub/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/findings"
+	"github.com/lambda-java-template/tests/internal/inventory"
+	"github.com/lambda-java-template/tests/internal/testconfig"
+)
+
+// validateSecurityFindings fails for each active HIGH or CRITICAL Security Hub finding, GuardDuty's included,
+// on a resource tagged with the project and environment that was created after the baseline in
+// -security-baseline and is not accepted by it. The first run records the baseline instead of failing;
+// delete the file to accept the current findings. Security Hub must be enabled, so this only runs with -security-findings.
+func validateSecurityFindings(t *testing.T, cfg aws.Config, projectName, environment string) {
+	if !*securityFindings {
+		t.Skip("Skipping the Security Hub gate; set -security-findings to run it")
+	}
+	baselinePath := testconfig.EnvironmentFile(*securityBaseline, environment)
+
+	result, err := inventory.Collect(context.TODO(), inventory.NewClients(cfg), projectName, environment)
+	require.NoError(t, err, "Failed to build resource inventory")
+	arns := make([]string, 0, len(result.Resources))
+	for _, resource := range result.Resources {
+		arns = append(arns, resource.ARN)
+	}
+	if len(arns) == 0 {
+		t.Skipf("No resource is tagged Project=%s Environment=%s", projectName, environment)
+	}
+
+	client := securityhub.NewFromConfig(cfg)
+	var active []findings.Finding
+	for _, batch := range findings.Batches(arns) {
+		found, err := activeFindings(context.TODO(), client, batch)
+		var notEnabled *securityhubtypes.InvalidAccessException
+		if errors.As(err, &notEnabled) {
+			t.Skipf("Security Hub is not enabled in %s: %v", cfg.Region, err)
+		}
+		require.NoError(t, err)
+		active = append(active, found...)
+	}
+
+	baseline, found, err := findings.LoadBaseline(baselinePath)
+	require.NoError(t, err)
+	if !found {
+		require.NoError(t, findings.WriteBaseline(baselinePath, findings.NewBaseline(active, time.Now())))
+		t.Logf("Recorded %d active findings on %d resources as the baseline in %s", len(active), len(arns), baselinePath)
+		return
+	}
+
+	fresh := findings.New(active, baseline)
+	t.Logf("%d active findings on %d resources, %d new HIGH or CRITICAL since the baseline of %s",
+		len(active), len(arns), len(fresh), baseline.RecordedAt.Format(time.RFC3339))
+	for _, finding := range fresh {
+		assert.Fail(t, "New security finding", "%s", finding)
+	}
+}
+
+// activeFindings returns the active, unresolved findings on any of the resources, at most findings.MaxFilterValues of them
+func activeFindings(ctx context.Context, client *securityhub.Client, arns []string) ([]findings.Finding, error) {
+	filters := &securityhubtypes.AwsSecurityFindingFilters{
+		RecordState: []securityhubtypes.StringFilter{{Value: aws.String("ACTIVE"), Comparison: securityhubtypes.StringFilterComparisonEquals}},
+		WorkflowStatus: []securityhubtypes.StringFilter{
+			{Value: aws.String("NEW"), Comparison: securityhubtypes.StringFilterComparisonEquals},
+			{Value: aws.String("NOTIFIED"), Comparison: securityhubtypes.StringFilterComparisonEquals},
+		},
+	}
+	for _, severity := range findings.GatedSeverities {
+		filters.SeverityLabel = append(filters.SeverityLabel, securityhubtypes.StringFilter{Value: aws.String(severity), Comparison: securityhubtypes.StringFilterComparisonEquals})
+	}
+	for _, arn := range arns {
+		filters.ResourceId = append(filters.ResourceId, securityhubtypes.StringFilter{Value: aws.String(arn), Comparison: securityhubtypes.StringFilterComparisonEquals})
+	}
+
+	var active []findings.Finding
+	paginator := securityhub.NewGetFindingsPaginator(client, &securityhub.GetFindingsInput{Filters: filters})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, finding := range page.Findings {
+			createdAt, err := time.Parse(time.RFC3339, aws.ToString(finding.CreatedAt))
+			if err != nil {
+				return nil, err
+			}
+			converted := findings.Finding{
+				ID:        aws.ToString(finding.Id),
+				Title:     aws.ToString(finding.Title),
+				Product:   aws.ToString(finding.ProductName),
+				CreatedAt: createdAt,
+			}
+			if finding.Severity != nil {
+				converted.Severity = string(finding.Severity.Label)
+			}
+			if len(finding.Resources) > 0 {
+				converted.Resource = aws.ToString(finding.Resources[0].Id)
+			}
+			active = append(active, converted)
+		}
+	}
+	return active, nil
+}
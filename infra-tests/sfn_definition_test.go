@@ -0,0 +1,109 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/asl"
+	"github.com/lambda-java-template/tests/internal/discovery"
+)
+
+// stateMachineGoldenFile is the checked-in, normalized definition of the order-processing state machine
+var stateMachineGoldenFile = filepath.Join("testdata", "golden", "order-processing.asl.json")
+
+// validateStateMachineDefinition compares the deployed order-processing definition with its golden file,
+// lints its error handling, timeouts, and heartbeats, and checks that every Lambda function it invokes exists
+// and is given up on by its state before the function's own timeout
+func validateStateMachineDefinition(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+
+	definition := deployedDefinition(t, cfg, resolver, "order-processing")
+
+	t.Run("Matches_Golden_File", func(t *testing.T) {
+		validateGoldenDefinition(t, definition, resolver.Settings().BaseName())
+	})
+
+	parsed, err := asl.Parse([]byte(definition))
+	require.NoError(t, err)
+
+	t.Run("Error_Handling", func(t *testing.T) {
+		for _, finding := range asl.Lint(parsed) {
+			assert.Fail(t, "State machine lint", "%s", finding)
+		}
+	})
+
+	t.Run("Lambda_References", func(t *testing.T) {
+		lambdaClient := lambda.NewFromConfig(cfg)
+		for functionArn, states := range asl.LambdaReferences(parsed) {
+			function, err := lambdaClient.GetFunction(context.TODO(), &lambda.GetFunctionInput{FunctionName: aws.String(functionArn)})
+			var notFound *lambdatypes.ResourceNotFoundException
+			if errors.As(err, &notFound) {
+				assert.Fail(t, "Missing function", "%v invoke %s, which does not exist", states, functionArn)
+				continue
+			}
+			if !assert.NoError(t, err, "Failed to look up %s", functionArn) {
+				continue
+			}
+
+			// A state that waits as long as the function runs leaves a hung invocation holding the execution
+			// for the full Lambda timeout before the Catch path is taken
+			functionTimeout := int(aws.ToInt32(function.Configuration.Timeout))
+			for _, path := range states {
+				if state, ok := asl.Lookup(parsed, path); ok && state.TimeoutSeconds >= functionTimeout {
+					assert.Fail(t, "Task outlasts its function", "%s has TimeoutSeconds %d, not below the %ds timeout of %s",
+						path, state.TimeoutSeconds, functionTimeout, functionArn)
+				}
+			}
+		}
+	})
+}
+
+// validateGoldenDefinition compares a definition with stateMachineGoldenFile once both are normalized.
+// Run with -update after an intentional workflow change to rewrite the golden file.
+func validateGoldenDefinition(t *testing.T, definition, baseName string) {
+	normalized, err := asl.Normalize([]byte(definition), baseName)
+	require.NoError(t, err)
+
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll(filepath.Dir(stateMachineGoldenFile), 0o755))
+		require.NoError(t, os.WriteFile(stateMachineGoldenFile, append(normalized, '\n'), 0o644))
+		t.Logf("Updated %s", stateMachineGoldenFile)
+		return
+	}
+
+	golden, err := os.ReadFile(stateMachineGoldenFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		require.Failf(t, "Missing golden file", "No golden definition at %s; run with -update against a deployed workflow and commit it", stateMachineGoldenFile)
+	}
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(golden), string(normalized),
+		"Deployed definition differs from %s; run with -update if the change is intended", stateMachineGoldenFile)
+}
+
+// deployedDefinition returns the ASL definition of a state machine, skipping the test when it is not deployed
+func deployedDefinition(t *testing.T, cfg aws.Config, resolver *discovery.Resolver, suffix string) string {
+	stateMachineArn, err := resolver.StateMachineARN(context.TODO(), suffix)
+	if errors.Is(err, discovery.ErrNotFound) {
+		t.Skipf("%v", err)
+	}
+	require.NoError(t, err)
+	recordResource(t, "states", stateMachineArn)
+
+	stateMachine, err := sfn.NewFromConfig(cfg).DescribeStateMachine(context.TODO(), &sfn.DescribeStateMachineInput{
+		StateMachineArn: aws.String(stateMachineArn),
+	})
+	require.NoError(t, err)
+	return aws.ToString(stateMachine.Definition)
+}
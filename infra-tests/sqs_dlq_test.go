@@ -0,0 +1,175 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+)
+
+// workflowFunctions are the "<project>-<environment>-<suffix>" functions run as order workflow steps
+var workflowFunctions = []string{"order-validation", "payment", "inventory", "notification"}
+
+// validateQueues validates SQS queue settings and that failed asynchronous invocations are kept
+func validateQueues(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+
+	sqsClient := sqs.NewFromConfig(cfg)
+	lambdaClient := lambda.NewFromConfig(cfg)
+	prefix := resolver.Settings().BaseName() + "-"
+
+	// Queue attributes keyed by queue ARN
+	queues := make(map[string]map[string]string)
+	paginator := sqs.NewListQueuesPaginator(sqsClient, &sqs.ListQueuesInput{
+		QueueNamePrefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		require.NoError(t, err)
+
+		for _, queueUrl := range page.QueueUrls {
+			attributes, err := sqsClient.GetQueueAttributes(context.TODO(), &sqs.GetQueueAttributesInput{
+				QueueUrl:       aws.String(queueUrl),
+				AttributeNames: []sqstypes.QueueAttributeName{sqstypes.QueueAttributeNameAll},
+			})
+			require.NoError(t, err, "Failed to read attributes of %s", queueUrl)
+			queues[attributes.Attributes["QueueArn"]] = attributes.Attributes
+		}
+	}
+
+	// Queues that receive another queue's failures are dead-letter queues and need no redrive of their own
+	deadLetterQueues := make(map[string]bool)
+	for _, attributes := range queues {
+		if document := attributes["RedrivePolicy"]; document != "" {
+			if policy, err := checks.ParseRedrivePolicy(document); err == nil {
+				deadLetterQueues[policy.DeadLetterTargetArn] = true
+			}
+		}
+	}
+	for arn := range queues {
+		if strings.HasSuffix(arn, "-dlq") {
+			deadLetterQueues[arn] = true
+		}
+	}
+
+	t.Run("Queue_Encryption", func(t *testing.T) {
+		if len(queues) == 0 {
+			t.Skipf("No queues found with prefix %s", prefix)
+		}
+		for arn, attributes := range queues {
+			recordResource(t, "sqs", arn)
+			assert.True(t, checks.QueueEncrypted(attributes), "Queue %s is not encrypted at rest", arn)
+		}
+	})
+
+	t.Run("Queue_Redrive_Policies", func(t *testing.T) {
+		if len(queues) == 0 {
+			t.Skipf("No queues found with prefix %s", prefix)
+		}
+		for arn, attributes := range queues {
+			if deadLetterQueues[arn] {
+				continue
+			}
+
+			document := attributes["RedrivePolicy"]
+			if !assert.NotEmpty(t, document, "Queue %s has no redrive policy, so poison messages are retried forever", arn) {
+				continue
+			}
+			policy, err := checks.ParseRedrivePolicy(document)
+			require.NoError(t, err, "Queue %s", arn)
+
+			_, known := queues[policy.DeadLetterTargetArn]
+			assert.True(t, known, "Queue %s redrives to %s, which is not a queue of this environment", arn, policy.DeadLetterTargetArn)
+			assert.Greater(t, policy.MaxReceiveCount, 0, "Queue %s redrive policy has no maxReceiveCount", arn)
+		}
+	})
+
+	t.Run("Visibility_Timeout_Covers_Function_Timeout", func(t *testing.T) {
+		checked := 0
+		functions := lambda.NewListFunctionsPaginator(lambdaClient, &lambda.ListFunctionsInput{})
+		for functions.HasMorePages() {
+			page, err := functions.NextPage(context.TODO())
+			require.NoError(t, err)
+
+			for _, function := range page.Functions {
+				if !strings.HasPrefix(aws.ToString(function.FunctionName), prefix) {
+					continue
+				}
+
+				mappings, err := lambdaClient.ListEventSourceMappings(context.TODO(), &lambda.ListEventSourceMappingsInput{
+					FunctionName: function.FunctionName,
+				})
+				require.NoError(t, err)
+
+				for _, mapping := range mappings.EventSourceMappings {
+					queueArn := aws.ToString(mapping.EventSourceArn)
+					if !strings.Contains(queueArn, ":sqs:") {
+						continue
+					}
+					checked++
+
+					attributes, found := queues[queueArn]
+					if !assert.True(t, found, "Function %s consumes %s, which is not a queue of this environment", *function.FunctionName, queueArn) {
+						continue
+					}
+					visibilityTimeout, err := strconv.Atoi(attributes["VisibilityTimeout"])
+					require.NoError(t, err, "Queue %s visibility timeout", queueArn)
+
+					minimum := checks.MinVisibilityTimeout(aws.ToInt32(function.Timeout))
+					assert.GreaterOrEqual(t, visibilityTimeout, minimum,
+						"Queue %s visibility timeout %ds is under %dx the %ds timeout of %s",
+						queueArn, visibilityTimeout, checks.VisibilityTimeoutFactor, aws.ToInt32(function.Timeout), *function.FunctionName)
+				}
+			}
+		}
+
+		if checked == 0 {
+			t.Skipf("No functions with prefix %s consume SQS queues", prefix)
+		}
+	})
+
+	t.Run("Workflow_Function_Failure_Destinations", func(t *testing.T) {
+		for _, suffix := range workflowFunctions {
+			functionName := resolver.FunctionName(suffix)
+			t.Run(suffix, func(t *testing.T) {
+				function, err := lambdaClient.GetFunctionConfiguration(context.TODO(), &lambda.GetFunctionConfigurationInput{
+					FunctionName: aws.String(functionName),
+				})
+				var notFound *lambdatypes.ResourceNotFoundException
+				if errors.As(err, &notFound) {
+					t.Skipf("Workflow function %s is not deployed", functionName)
+				}
+				require.NoError(t, err)
+
+				if function.DeadLetterConfig != nil && aws.ToString(function.DeadLetterConfig.TargetArn) != "" {
+					return
+				}
+
+				invokeConfig, err := lambdaClient.GetFunctionEventInvokeConfig(context.TODO(), &lambda.GetFunctionEventInvokeConfigInput{
+					FunctionName: aws.String(functionName),
+				})
+				if errors.As(err, &notFound) {
+					assert.Fail(t, "No failure handling", "Function %s has neither a dead-letter queue nor an on-failure destination", functionName)
+					return
+				}
+				require.NoError(t, err)
+
+				hasDestination := invokeConfig.DestinationConfig != nil &&
+					invokeConfig.DestinationConfig.OnFailure != nil &&
+					aws.ToString(invokeConfig.DestinationConfig.OnFailure.Destination) != ""
+				assert.True(t, hasDestination, "Function %s has neither a dead-letter queue nor an on-failure destination", functionName)
+			})
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/expectations"
+	"github.com/lambda-java-template/tests/internal/openapi"
+)
+
+// validateStageDeployment checks the $default stage deploys every change on its own and serves the API's
+// latest deployment, that the deployment holds the routes the API is configured with, and that no route
+// points at an integration that has been deleted
+func validateStageDeployment(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+	apiClient := apigatewayv2.NewFromConfig(cfg)
+	api := requireAPI(t, resolver)
+
+	stage, err := apiClient.GetStage(context.TODO(), &apigatewayv2.GetStageInput{
+		ApiId:     aws.String(api.ID),
+		StageName: aws.String("$default"),
+	})
+	require.NoError(t, err)
+
+	t.Run("Stage_Settings", func(t *testing.T) {
+		for _, gap := range checks.StageGaps(stage, expectations.For(environment).StageVariables) {
+			assert.Fail(t, "Stage drift", "Stage $default of %s: %s", resolver.APIName(), gap)
+		}
+	})
+
+	t.Run("Latest_Deployment", func(t *testing.T) {
+		var deployments []types.Deployment
+		var nextToken *string
+		for {
+			page, err := apiClient.GetDeployments(context.TODO(), &apigatewayv2.GetDeploymentsInput{
+				ApiId:     aws.String(api.ID),
+				NextToken: nextToken,
+			})
+			require.NoError(t, err)
+			deployments = append(deployments, page.Items...)
+			if nextToken = page.NextToken; nextToken == nil {
+				break
+			}
+		}
+
+		for _, gap := range checks.DeploymentGaps(aws.ToString(stage.DeploymentId), deployments) {
+			assert.Fail(t, "Stale deployment", "Stage $default of %s: %s", resolver.APIName(), gap)
+		}
+	})
+
+	t.Run("Deployed_Routes", func(t *testing.T) {
+		// Exporting a stage gives the definition it serves; without one, the API's current configuration
+		export := func(stageName *string) []string {
+			exported, err := apiClient.ExportApi(context.TODO(), &apigatewayv2.ExportApiInput{
+				ApiId:             aws.String(api.ID),
+				OutputType:        aws.String("JSON"),
+				Specification:     aws.String("OAS30"),
+				IncludeExtensions: aws.Bool(false),
+				StageName:         stageName,
+			})
+			require.NoError(t, err)
+			spec, err := openapi.Parse(exported.Body)
+			require.NoError(t, err, "Failed to parse the export of API %s", api.ID)
+			return spec.Routes()
+		}
+
+		for _, gap := range checks.UndeployedRoutes(export(aws.String("$default")), export(nil)) {
+			assert.Fail(t, "Undeployed route change", "Stage $default of %s: %s", resolver.APIName(), gap)
+		}
+	})
+
+	t.Run("Orphan_Routes", func(t *testing.T) {
+		var routes []types.Route
+		var nextToken *string
+		for {
+			page, err := apiClient.GetRoutes(context.TODO(), &apigatewayv2.GetRoutesInput{
+				ApiId:     aws.String(api.ID),
+				NextToken: nextToken,
+			})
+			require.NoError(t, err)
+			routes = append(routes, page.Items...)
+			if nextToken = page.NextToken; nextToken == nil {
+				break
+			}
+		}
+
+		var integrations []types.Integration
+		nextToken = nil
+		for {
+			page, err := apiClient.GetIntegrations(context.TODO(), &apigatewayv2.GetIntegrationsInput{
+				ApiId:     aws.String(api.ID),
+				NextToken: nextToken,
+			})
+			require.NoError(t, err)
+			integrations = append(integrations, page.Items...)
+			if nextToken = page.NextToken; nextToken == nil {
+				break
+			}
+		}
+
+		for _, orphan := range checks.OrphanRoutes(routes, integrations) {
+			assert.Fail(t, "Orphan route", "API %s: %s", resolver.APIName(), orphan)
+		}
+	})
+}
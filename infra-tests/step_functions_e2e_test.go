@@ -0,0 +1,238 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	sfntypes "github.com/aws/aws-sdk-go-v2/service/sfn/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/asl"
+	"github.com/lambda-java-template/tests/internal/history"
+	"github.com/lambda-java-template/tests/internal/scenarios"
+	"github.com/lambda-java-template/tests/internal/workflowschema"
+)
+
+// workflowExecutionTimeout bounds how long one order may take to reach a terminal state
+const workflowExecutionTimeout = 5 * time.Minute
+
+// validateWorkflowBranches runs every workflow scenario in testdata/scenarios against the order-processing
+// state machine and fails when a scenario ends with the wrong status or in the wrong state, misses the
+// notification or audit records it expects, takes more state transitions than the budget, passes a payload
+// between states that breaks the contracts in testdata/workflow-payloads.yaml, a terminal state of the
+// definition is never reached, or the business metrics the executions should have raised did not rise
+func validateWorkflowBranches(t *testing.T, cfg aws.Config, projectName, environment string) {
+	if testing.Short() {
+		t.Skip("Skipping workflow branch coverage in short mode")
+	}
+	resolver := discover(cfg, projectName, environment)
+	workflowScenarios := loadScenarios(t, true)
+	payloadSchemas, err := workflowschema.Load(workflowschema.File)
+	require.NoError(t, err)
+
+	definition, err := asl.Parse([]byte(deployedDefinition(t, cfg, resolver, "order-processing")))
+	require.NoError(t, err)
+	stateMachineArn, err := resolver.StateMachineARN(context.TODO(), "order-processing")
+	require.NoError(t, err)
+	client := sfn.NewFromConfig(cfg)
+	budget := resolver.Settings().TransitionBudget()
+
+	var mu sync.Mutex
+	// Executions ending in each terminal state
+	reached := map[string]int{}
+	runStarted := time.Now().UTC()
+
+	// Executions run concurrently; the group returns once every scenario has finished
+	t.Run("Scenarios", func(t *testing.T) {
+		for _, scenario := range workflowScenarios {
+			t.Run(scenario.Name, func(t *testing.T) {
+				t.Parallel()
+
+				started := time.Now().UTC()
+				orderId := fmt.Sprintf("%sbranch-%d", testNamespace(), started.UnixNano())
+				vars := scenarioVars(orderId)
+				notifications := captureScenarioNotifications(t, cfg, resolver, scenario)
+
+				execution := executeWorkflow(t, client, stateMachineArn, scenarios.Expand(scenario.Workflow, vars))
+				waitForExecution(t, client, execution, workflowExecutionTimeout)
+
+				events := executionHistoryWithData(t, client, execution)
+				recordExecutionDiagram(t, scenario.Name, execution, events)
+				terminal := history.TerminalState(events)
+				for _, run := range history.MapRuns(events) {
+					t.Logf("Map state %s ran %d iterations through %v", run.State, run.Iterations, run.IteratorStates)
+				}
+
+				mu.Lock()
+				reached[terminal]++
+				mu.Unlock()
+
+				// An unexpected outcome is explained before it fails the scenario, so a broken happy path shows the
+				// state, error, and log behind it instead of a bare status
+				if string(execution.status) != scenario.Expect.ExecutionStatus || terminal != scenario.Expect.TerminalState {
+					triageExecution(t, cfg, definition, execution, events)
+				}
+				require.Equal(t, scenario.Expect.ExecutionStatus, string(execution.status), "Execution %s visited %v", execution.arn, history.EnteredStates(events))
+				assert.Equal(t, scenario.Expect.TerminalState, terminal, "Execution %s visited %v", execution.arn, history.EnteredStates(events))
+
+				// Retry storms and loops show up as transitions, which is what STANDARD workflows are billed by
+				transitions := history.Transitions(events)
+				suiteResults.RecordTransitions(scenario.Name, execution.arn, transitions, budget)
+				assert.LessOrEqual(t, transitions, budget,
+					"Execution %s took %d state transitions through %v; raise TEST_MAX_TRANSITIONS if the workflow grew on purpose",
+					execution.arn, transitions, history.EnteredStates(events))
+
+				// A payload that breaks its contract can still reach the expected terminal state, such as when a
+				// ResultPath overwrites the order and a later Choice falls through to its Default
+				for _, problem := range payloadSchemas.Check(history.Payloads(events)) {
+					t.Errorf("Execution %s: %s", execution.arn, problem)
+				}
+
+				notifications.assertReceived(t, orderId)
+				assertScenarioAudit(t, cfg, resolver, scenario, orderId, started)
+			})
+		}
+	})
+
+	t.Run("Coverage", func(t *testing.T) {
+		for _, terminal := range asl.TerminalStates(definition) {
+			assert.Positive(t, reached[terminal], "No scenario reached terminal state %s", terminal)
+		}
+		assert.Empty(t, payloadSchemas.Unknown(asl.StateNames(definition)),
+			"%s has contracts for states the deployed definition lacks; rename them to match it", workflowschema.File)
+	})
+
+	t.Run("Business_Metrics", func(t *testing.T) {
+		assertBusinessMetrics(t, cfg, environment, runStarted, reached)
+	})
+}
+
+// workflowExecution is an execution of a STANDARD or EXPRESS state machine
+type workflowExecution struct {
+	arn     string
+	express bool
+
+	// Set once the execution has stopped
+	status   sfntypes.ExecutionStatus
+	output   string
+	error    string
+	cause    string
+	duration time.Duration
+}
+
+// stateMachineTypes caches whether each state machine is STANDARD or EXPRESS
+var stateMachineTypes sync.Map
+
+// executeWorkflow starts an execution with input. EXPRESS state machines run synchronously,
+// so their executions have already stopped when this returns.
+func executeWorkflow(t *testing.T, client *sfn.Client, stateMachineArn string, input any) *workflowExecution {
+	payload, err := json.Marshal(input)
+	require.NoError(t, err)
+	name := fmt.Sprintf("itest-%d", time.Now().UnixNano())
+
+	if stateMachineType(t, client, stateMachineArn) == sfntypes.StateMachineTypeExpress {
+		result, err := client.StartSyncExecution(context.TODO(), &sfn.StartSyncExecutionInput{
+			StateMachineArn: aws.String(stateMachineArn),
+			Name:            aws.String(name),
+			Input:           aws.String(string(payload)),
+		})
+		require.NoError(t, err, "Failed to run %s", stateMachineArn)
+		return &workflowExecution{
+			arn:      aws.ToString(result.ExecutionArn),
+			express:  true,
+			status:   sfntypes.ExecutionStatus(result.Status),
+			output:   aws.ToString(result.Output),
+			error:    aws.ToString(result.Error),
+			cause:    aws.ToString(result.Cause),
+			duration: aws.ToTime(result.StopDate).Sub(aws.ToTime(result.StartDate)),
+		}
+	}
+
+	started, err := client.StartExecution(context.TODO(), &sfn.StartExecutionInput{
+		StateMachineArn: aws.String(stateMachineArn),
+		Name:            aws.String(name),
+		Input:           aws.String(string(payload)),
+	})
+	require.NoError(t, err, "Failed to start %s", stateMachineArn)
+	return &workflowExecution{arn: aws.ToString(started.ExecutionArn)}
+}
+
+// stateMachineType returns whether a state machine is STANDARD or EXPRESS
+func stateMachineType(t *testing.T, client *sfn.Client, stateMachineArn string) sfntypes.StateMachineType {
+	if cached, ok := stateMachineTypes.Load(stateMachineArn); ok {
+		return cached.(sfntypes.StateMachineType)
+	}
+
+	stateMachine, err := client.DescribeStateMachine(context.TODO(), &sfn.DescribeStateMachineInput{
+		StateMachineArn: aws.String(stateMachineArn),
+	})
+	require.NoError(t, err)
+	stateMachineTypes.Store(stateMachineArn, stateMachine.Type)
+	return stateMachine.Type
+}
+
+// waitForExecution waits until an execution stops running and records how it ended
+func waitForExecution(t *testing.T, client *sfn.Client, execution *workflowExecution, timeout time.Duration) *workflowExecution {
+	if execution.express {
+		return execution
+	}
+
+	waitFor(t, timeout, fmt.Sprintf("execution %s to finish", execution.arn), func(ctx context.Context) (bool, error) {
+		described, err := client.DescribeExecution(ctx, &sfn.DescribeExecutionInput{ExecutionArn: aws.String(execution.arn)})
+		if err != nil {
+			return false, err
+		}
+		execution.status = described.Status
+		execution.output = aws.ToString(described.Output)
+		execution.error = aws.ToString(described.Error)
+		execution.cause = aws.ToString(described.Cause)
+		if described.StopDate != nil {
+			execution.duration = described.StopDate.Sub(aws.ToTime(described.StartDate))
+		}
+		return described.Status != sfntypes.ExecutionStatusRunning, nil
+	})
+	return execution
+}
+
+// executionHistoryWithData returns the history of a STANDARD execution with the payloads its states passed on
+func executionHistoryWithData(t *testing.T, client *sfn.Client, execution *workflowExecution) []sfntypes.HistoryEvent {
+	if execution.express {
+		t.Skipf("Execution %s is EXPRESS and has no execution history", execution.arn)
+	}
+
+	events, err := history.LoadWithData(context.TODO(), client, execution.arn)
+	require.NoError(t, err)
+	return events
+}
+
+// executionHistory returns the history of a STANDARD execution.
+// EXPRESS executions keep no history in Step Functions, so tests that need one are skipped.
+func executionHistory(t *testing.T, client *sfn.Client, execution *workflowExecution) []sfntypes.HistoryEvent {
+	if execution.express {
+		t.Skipf("Execution %s is EXPRESS and has no execution history", execution.arn)
+	}
+
+	events, err := history.Load(context.TODO(), client, execution.arn)
+	require.NoError(t, err)
+	return events
+}
+
+// recordExecutionDiagram adds a Mermaid diagram of the states an execution went through to the results summary,
+// and logs it if the test fails: the failed state, its retries, and its cause are quicker to find there than in
+// the raw events
+func recordExecutionDiagram(t *testing.T, name string, execution *workflowExecution, events []sfntypes.HistoryEvent) {
+	diagram := history.Mermaid(history.Steps(events))
+	suiteResults.RecordExecution(name, execution.arn, diagram)
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("States of execution %s:\n%s", execution.arn, diagram)
+		}
+	})
+}
@@ -0,0 +1,153 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	sfntypes "github.com/aws/aws-sdk-go-v2/service/sfn/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/discovery"
+	"github.com/lambda-java-template/tests/internal/payloads"
+	"github.com/lambda-java-template/tests/internal/stress"
+)
+
+// stressMetricsTimeout bounds how long Lambda metrics may take to account for a stress run
+const stressMetricsTimeout = 5 * time.Minute
+
+// validateWorkflowConcurrency starts -stress-executions orders at once and fails unless every one succeeds
+// without any workflow function being throttled. It is skipped unless the flag is set.
+func validateWorkflowConcurrency(t *testing.T, cfg aws.Config, projectName, environment string) {
+	if *stressExecutions <= 0 {
+		t.Skip("Skipping workflow stress test; set -stress-executions to run it")
+	}
+	if testing.Short() {
+		t.Skip("Skipping workflow stress test in short mode")
+	}
+	resolver := discover(cfg, projectName, environment)
+
+	stateMachineArn, err := resolver.StateMachineARN(context.TODO(), "order-processing")
+	if errors.Is(err, discovery.ErrNotFound) {
+		t.Skipf("%v", err)
+	}
+	require.NoError(t, err)
+	client := sfn.NewFromConfig(cfg)
+
+	var mu sync.Mutex
+	var outcomes []stress.Outcome
+	start := time.Now()
+
+	// Every execution is a parallel subtest, so all of them are started before any is waited on
+	t.Run("Executions", func(t *testing.T) {
+		for i := 0; i < *stressExecutions; i++ {
+			t.Run(fmt.Sprintf("%04d", i), func(t *testing.T) {
+				t.Parallel()
+
+				orderId := fmt.Sprintf("%sstress-%04d-%d", testNamespace(), i, time.Now().UnixNano())
+				execution := executeWorkflow(t, client, stateMachineArn, payloads.SampleOrder(testNamespace(), orderId))
+				waitForExecution(t, client, execution, workflowExecutionTimeout)
+
+				mu.Lock()
+				outcomes = append(outcomes, stress.Outcome{
+					Succeeded: execution.status == sfntypes.ExecutionStatusSucceeded,
+					Duration:  execution.duration,
+				})
+				mu.Unlock()
+
+				if execution.status != sfntypes.ExecutionStatusSucceeded {
+					t.Logf("Execution %s ended %s: %s %s", execution.arn, execution.status, execution.error, execution.cause)
+				}
+			})
+		}
+	})
+	end := time.Now()
+
+	t.Run("Summary", func(t *testing.T) {
+		summary := stress.Summarize(outcomes)
+		t.Logf("%d/%d executions succeeded (%.1f%%), p50 %s, p95 %s",
+			summary.Succeeded, *stressExecutions, summary.SuccessRate()*100, summary.P50, summary.P95)
+		suiteResults.RecordLatency("order workflow p50", summary.P50)
+		suiteResults.RecordLatency("order workflow p95", summary.P95)
+
+		assert.Equal(t, *stressExecutions, summary.Succeeded, "Not every concurrent execution succeeded")
+	})
+
+	t.Run("Throttling", func(t *testing.T) {
+		lambdaClient := lambda.NewFromConfig(cfg)
+		metricsClient := cloudwatch.NewFromConfig(cfg)
+
+		for _, suffix := range workflowFunctions {
+			functionName := resolver.FunctionName(suffix)
+			t.Run(suffix, func(t *testing.T) {
+				requireFunction(t, lambdaClient, functionName)
+
+				// Every successful order invokes each workflow function, so the metrics are complete
+				// once the invocations of the run have been counted
+				var invocations, throttles float64
+				waitFor(t, stressMetricsTimeout, fmt.Sprintf("Lambda metrics of %s to cover the run", functionName), func(ctx context.Context) (bool, error) {
+					var err error
+					invocations, throttles, err = lambdaUsage(ctx, metricsClient, functionName, start, end)
+					return invocations >= float64(*stressExecutions), err
+				})
+
+				t.Logf("%s: %.0f invocations, %.0f throttles", functionName, invocations, throttles)
+				assert.Zero(t, throttles, "%s was throttled %.0f times; raise its reserved concurrency or the account limit", functionName, throttles)
+			})
+		}
+	})
+}
+
+// lambdaUsage sums the Invocations and Throttles metrics of a function over a time range
+func lambdaUsage(ctx context.Context, client *cloudwatch.Client, functionName string, start, end time.Time) (invocations, throttles float64, err error) {
+	// Metrics are stored per minute, so the range is widened to whole minutes on both sides
+	start = start.Truncate(time.Minute)
+	end = end.Truncate(time.Minute).Add(time.Minute)
+	period := int32(math.Ceil(end.Sub(start).Minutes())) * 60
+
+	query := func(id, metric string) cwtypes.MetricDataQuery {
+		return cwtypes.MetricDataQuery{
+			Id: aws.String(id),
+			MetricStat: &cwtypes.MetricStat{
+				Metric: &cwtypes.Metric{
+					Namespace:  aws.String("AWS/Lambda"),
+					MetricName: aws.String(metric),
+					Dimensions: []cwtypes.Dimension{{Name: aws.String("FunctionName"), Value: aws.String(functionName)}},
+				},
+				Period: aws.Int32(period),
+				Stat:   aws.String("Sum"),
+			},
+		}
+	}
+
+	output, err := client.GetMetricData(ctx, &cloudwatch.GetMetricDataInput{
+		StartTime:         aws.Time(start),
+		EndTime:           aws.Time(end),
+		MetricDataQueries: []cwtypes.MetricDataQuery{query("invocations", "Invocations"), query("throttles", "Throttles")},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, result := range output.MetricDataResults {
+		for _, value := range result.Values {
+			switch aws.ToString(result.Id) {
+			case "invocations":
+				invocations += value
+			case "throttles":
+				throttles += value
+			}
+		}
+	}
+	return invocations, throttles, nil
+}
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/cleanup"
+	"github.com/lambda-java-template/tests/internal/discovery"
+	"github.com/lambda-java-template/tests/internal/fixtures"
+	"github.com/lambda-java-template/tests/internal/seed"
+)
+
+// testNamespace returns the id prefix used for data written by the suite
+func testNamespace() string {
+	if namespace := os.Getenv("TEST_NAMESPACE"); namespace != "" {
+		return namespace
+	}
+	return seed.DefaultNamespace
+}
+
+// seedTestData upserts the dataset named by TEST_SEED_DATASET into the products table.
+// Seeding is skipped when no dataset is configured.
+func seedTestData(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+
+	datasetPath := os.Getenv("TEST_SEED_DATASET")
+	if datasetPath == "" {
+		return
+	}
+
+	products, err := seed.LoadDataset(datasetPath)
+	require.NoError(t, err, "Failed to load seed dataset %s", datasetPath)
+
+	tableName := resolver.TableName("products")
+	written, err := seed.Upsert(context.TODO(), dynamodb.NewFromConfig(cfg), tableName, testNamespace(), products)
+	require.NoError(t, err, "Failed to seed table %s", tableName)
+
+	t.Logf("Seeded %d products into %s with namespace %q", written, tableName, testNamespace())
+}
+
+// cleanupTestData removes everything written under the test namespace.
+// It runs when the suite seeded data or TEST_CLEANUP=true is set.
+func cleanupTestData(t *testing.T, cfg aws.Config, projectName, environment string) {
+	if os.Getenv("TEST_SEED_DATASET") == "" && os.Getenv("TEST_CLEANUP") != "true" {
+		return
+	}
+
+	target := cleanupTarget(discover(cfg, projectName, environment))
+	result, err := cleanup.Namespace(context.TODO(), dynamodb.NewFromConfig(cfg), sfn.NewFromConfig(cfg), cloudwatchlogs.NewFromConfig(cfg), target, testNamespace())
+	assert.NoError(t, err, "Failed to clean up test namespace %q", testNamespace())
+
+	t.Logf("Removed %d products, %d audit records, and %d log streams and stopped %d executions with namespace %q",
+		result.Products, result.AuditLogs, result.LogStreams, result.Executions, testNamespace())
+}
+
+// cleanupTarget names the resources of the deployment resolver finds, so TEST_CONFIG_FILE and
+// TEST_STATE_MACHINE_ARN apply to cleanup as they do to the validators
+func cleanupTarget(resolver *discovery.Resolver) cleanup.Target {
+	stateMachine := resolver.ResourceName("order-processing")
+	if arn := resolver.Settings().StateMachineARN; arn != "" {
+		stateMachine = arn[strings.LastIndex(arn, ":")+1:]
+	}
+	return cleanup.Target{
+		ProductsTable:    resolver.TableName("products"),
+		AuditTable:       resolver.TableName("audit-logs"),
+		StateMachineName: stateMachine,
+		LogGroups: []string{
+			fmt.Sprintf("/aws/lambda/%s", resolver.FunctionName("product-service")),
+			fmt.Sprintf("/aws/lambda/%s", resolver.FunctionName("authorizer-service")),
+		},
+	}
+}
+
+// seedFixtures returns a fixture set for t in the deployed tables. Its items are removed when t ends.
+func seedFixtures(t *testing.T, cfg aws.Config, resolver *discovery.Resolver) *fixtures.Set {
+	return fixtures.New(t, dynamodb.NewFromConfig(cfg), cleanupTarget(resolver), testNamespace())
+}
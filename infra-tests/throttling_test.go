@@ -0,0 +1,256 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/httpclient"
+	"github.com/lambda-java-template/tests/internal/load"
+	"github.com/lambda-java-template/tests/internal/testconfig"
+	"github.com/lambda-java-template/tests/internal/wait"
+)
+
+// Throttle probe sizing: enough requests in flight to outpace the refill of the token bucket,
+// and a cap on the total so a high rate limit cannot turn the probe into a load test
+const (
+	throttleProbeConcurrency = 200
+	throttleProbeMaxRequests = 5000
+)
+
+// throttleProbeRoute is unauthenticated, so the probe exercises only the stage limits
+const throttleProbeRoute = "GET /health"
+
+// keyAbuseRoute is a protected route, so the key abuse probe passes the authorizer before it is throttled
+const keyAbuseRoute = "GET /products"
+
+// throttleMetricDelay bounds how long API Gateway may take to publish the 4xx metric counting throttled requests
+const throttleMetricDelay = 5 * time.Minute
+
+// throttleResponse is one response of the throttle probe
+type throttleResponse struct {
+	status     int
+	body       []byte
+	retryAfter string
+}
+
+// validateThrottling checks the $default stage's default throttling limits and per-route overrides.
+// With -throttle-probe it also exceeds the limits of /health and checks the API answers 429, then
+// serves requests again once the wait it asked for has passed. With -key-abuse-probe it exceeds the
+// limits of GET /products with a single API key and checks the 429s are counted in CloudWatch.
+// HTTP APIs have no usage plans, so stage and route limits are the only throttling they offer; they
+// apply to every caller together, not to each key.
+func validateThrottling(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+	apiClient := apigatewayv2.NewFromConfig(cfg)
+	api := requireAPI(t, resolver)
+
+	stage, err := apiClient.GetStage(context.TODO(), &apigatewayv2.GetStageInput{
+		ApiId:     aws.String(api.ID),
+		StageName: aws.String("$default"),
+	})
+	require.NoError(t, err)
+
+	t.Run("Stage_Limits", func(t *testing.T) {
+		var routeKeys []string
+		var nextToken *string
+		for {
+			routes, err := apiClient.GetRoutes(context.TODO(), &apigatewayv2.GetRoutesInput{
+				ApiId:     aws.String(api.ID),
+				NextToken: nextToken,
+			})
+			require.NoError(t, err)
+			for _, route := range routes.Items {
+				routeKeys = append(routeKeys, aws.ToString(route.RouteKey))
+			}
+			if routes.NextToken == nil {
+				break
+			}
+			nextToken = routes.NextToken
+		}
+
+		for _, gap := range checks.ThrottlingGaps(stage.DefaultRouteSettings, stage.RouteSettings, routeKeys) {
+			assert.Fail(t, "Unsafe throttling", "Stage $default of %s: %s", resolver.APIName(), gap)
+		}
+	})
+
+	t.Run("Rate_Limit_Probe", func(t *testing.T) {
+		if !*throttleProbe {
+			t.Skip("Skipping throttle probe; set -throttle-probe to run it")
+		}
+
+		limits, total := probeSize(t, stage, throttleProbeRoute)
+
+		healthURL := api.Endpoint + "/health"
+		responses := burst(t, healthURL, nil, total, throttleProbeConcurrency)
+
+		statuses := map[int]int{}
+		var throttled []throttleResponse
+		for _, response := range responses {
+			statuses[response.status]++
+			if response.status == http.StatusTooManyRequests {
+				throttled = append(throttled, response)
+			}
+		}
+		t.Logf("%d requests to %s: %v", total, healthURL, statuses)
+		require.NotEmpty(t, throttled, "No request was throttled beyond a burst of %d and %v rps",
+			aws.ToInt32(limits.ThrottlingBurstLimit), aws.ToFloat64(limits.ThrottlingRateLimit))
+		for status := range statuses {
+			assert.Contains(t, []int{http.StatusOK, http.StatusTooManyRequests}, status, "Throttling must reject with 429, not %d", status)
+		}
+
+		wait := time.Second
+		for _, response := range throttled {
+			assert.JSONEq(t, `{"message":"Too Many Requests"}`, string(response.body))
+			if response.retryAfter == "" {
+				continue
+			}
+			retryAfter, err := load.RetryAfter(response.retryAfter, time.Now())
+			if assert.NoError(t, err) && retryAfter > wait {
+				wait = retryAfter
+			}
+		}
+
+		// Once the bucket has refilled, the same client must be served again
+		time.Sleep(wait)
+		resp, err := suiteHTTP.Get(healthURL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "GET %s %s after being throttled", healthURL, wait)
+	})
+
+	t.Run("Key_Abuse_Probe", func(t *testing.T) {
+		if !*keyAbuseProbe {
+			t.Skip("Skipping key abuse probe; set -key-abuse-probe to run it")
+		}
+		limits, total := probeSize(t, stage, keyAbuseRoute)
+
+		productsURL := api.Endpoint + "/products"
+		key := http.Header{"X-Api-Key": []string{fmt.Sprintf("infra-tests-%s", suiteConfig.Environment)}}
+		start := time.Now()
+		responses := burst(t, productsURL, key, total, throttleProbeConcurrency)
+
+		statuses := map[int]int{}
+		for _, response := range responses {
+			statuses[response.status]++
+		}
+		t.Logf("%d requests to %s with one API key: %v", total, productsURL, statuses)
+		require.Positive(t, statuses[http.StatusOK], "No request with the key was served before throttling began")
+		require.Positive(t, statuses[http.StatusTooManyRequests], "No request with the key was throttled beyond a burst of %d and %v rps",
+			aws.ToInt32(limits.ThrottlingBurstLimit), aws.ToFloat64(limits.ThrottlingRateLimit))
+		for status := range statuses {
+			assert.Contains(t, []int{http.StatusOK, http.StatusTooManyRequests}, status, "Throttling must reject with 429, not %d", status)
+		}
+
+		t.Run("Throttle_Metric", func(t *testing.T) {
+			requireFeature(t, testconfig.FeatureServiceMetrics)
+			assertThrottlesCounted(t, cfg, api.ID, start, statuses[http.StatusTooManyRequests])
+		})
+	})
+}
+
+// probeSize returns the throttling limits of route and how many requests exceed them, skipping t when the route
+// has none or exceeding them takes more than throttleProbeMaxRequests
+func probeSize(t *testing.T, stage *apigatewayv2.GetStageOutput, route string) (*types.RouteSettings, int) {
+	limits := stage.DefaultRouteSettings
+	if override, ok := stage.RouteSettings[route]; ok {
+		limits = &override
+	}
+	if limits == nil || limits.ThrottlingRateLimit == nil || limits.ThrottlingBurstLimit == nil {
+		t.Skipf("%s has no throttling limits to exceed", route)
+	}
+	total := int(aws.ToInt32(limits.ThrottlingBurstLimit)) + 2*int(math.Ceil(aws.ToFloat64(limits.ThrottlingRateLimit)))
+	if total > throttleProbeMaxRequests {
+		t.Skipf("Exceeding a burst of %d and %v rps takes more than %d requests", aws.ToInt32(limits.ThrottlingBurstLimit),
+			aws.ToFloat64(limits.ThrottlingRateLimit), throttleProbeMaxRequests)
+	}
+	return limits, total
+}
+
+// assertThrottlesCounted fails unless the API's 4xx metric rose by at least throttled since start. HTTP APIs
+// publish no throttle count of their own; a 429 is counted in 4xx by ApiId, as are other callers' 4xx responses.
+func assertThrottlesCounted(t *testing.T, cfg aws.Config, apiID string, start time.Time, throttled int) {
+	client := cloudwatch.NewFromConfig(cfg)
+
+	var counted float64
+	err := wait.Until(context.TODO(), throttleMetricDelay, metricsBackoff, func(ctx context.Context) (bool, error) {
+		// Datapoints are stamped with the start of their minute, so the range opens a minute early
+		stats, err := client.GetMetricStatistics(ctx, &cloudwatch.GetMetricStatisticsInput{
+			Namespace:  aws.String("AWS/ApiGateway"),
+			MetricName: aws.String("4xx"),
+			Dimensions: []cwtypes.Dimension{{Name: aws.String("ApiId"), Value: aws.String(apiID)}},
+			StartTime:  aws.Time(start.Add(-time.Minute)),
+			EndTime:    aws.Time(time.Now().Add(time.Minute)),
+			Period:     aws.Int32(60),
+			Statistics: []cwtypes.Statistic{cwtypes.StatisticSum},
+		})
+		if err != nil {
+			return false, err
+		}
+
+		counted = 0
+		for _, datapoint := range stats.Datapoints {
+			counted += aws.ToFloat64(datapoint.Sum)
+		}
+		return counted >= float64(throttled), nil
+	})
+	if !errors.Is(err, wait.ErrTimeout) {
+		require.NoError(t, err)
+		return
+	}
+	t.Errorf("AWS/ApiGateway 4xx of API %s rose by %.0f within %s, fewer than the %d requests throttled", apiID, counted, throttleMetricDelay, throttled)
+}
+
+// burst sends total GET requests to url with header and at most concurrency in flight, and returns every response.
+// Its requests are sent once and left out of the results summary, so the burst is exactly total requests.
+func burst(t *testing.T, url string, header http.Header, total, concurrency int) []throttleResponse {
+	client := httpclient.New(httpclient.Options{Timeout: *httpTimeout})
+	var (
+		mu        sync.Mutex
+		responses []throttleResponse
+		wg        sync.WaitGroup
+	)
+	slots := make(chan struct{}, concurrency)
+	for i := 0; i < total; i++ {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() { <-slots; wg.Done() }()
+
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+			maps.Copy(req.Header, header)
+			resp, err := client.Do(req)
+			if !assert.NoError(t, err, "GET %s", url) {
+				return
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+
+			mu.Lock()
+			responses = append(responses, throttleResponse{status: resp.StatusCode, body: body, retryAfter: resp.Header.Get("Retry-After")})
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return responses
+}
@@ -0,0 +1,50 @@
+package test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/support"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/testconfig"
+)
+
+// supportRegion is the only region serving the Support API
+const supportRegion = "us-east-1"
+
+// trustedAdvisorCategories are the check categories relevant to the template's architecture
+var trustedAdvisorCategories = map[string]bool{
+	"fault_tolerance": true,
+	"performance":     true,
+	"security":        true,
+	"cost_optimizing": true,
+}
+
+// validateTrustedAdvisor reports failing Trusted Advisor checks for project resources as warnings.
+// It is opt-in through TEST_TRUSTED_ADVISOR=true because the Support API needs a paid support plan.
+func validateTrustedAdvisor(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+
+	if os.Getenv("TEST_TRUSTED_ADVISOR") != "true" {
+		t.Skip("Trusted Advisor checks are disabled; set TEST_TRUSTED_ADVISOR=true to enable")
+	}
+	requireFeature(t, testconfig.FeatureTrustedAdvisor)
+
+	client := support.NewFromConfig(cfg, func(o *support.Options) { o.Region = supportRegion })
+	resourcePrefix := resolver.Settings().BaseName()
+
+	warnings, err := checks.TrustedAdvisorWarnings(context.TODO(), client, trustedAdvisorCategories, resourcePrefix)
+	if checks.SubscriptionRequired(err) {
+		t.Skip("Account support plan does not include the Trusted Advisor API")
+	}
+	require.NoError(t, err, "Failed to read Trusted Advisor checks")
+
+	for _, warning := range warnings {
+		t.Logf("WARNING %s", warning)
+	}
+	t.Logf("Trusted Advisor reported %d warnings for %s resources", len(warnings), resourcePrefix)
+}
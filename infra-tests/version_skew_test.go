@@ -0,0 +1,67 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/buildinfo"
+	"github.com/lambda-java-template/tests/internal/healthcheck"
+	"github.com/lambda-java-template/tests/internal/responses"
+)
+
+// healthSamples is how many GET /health responses are read; during a rollout, requests can reach both versions
+const healthSamples = 5
+
+// validateVersionSkew checks every function of the deployment reports the same application version, through
+// its APP_VERSION environment variable or GitSha tag, and that GET /health answers with that version too.
+// A mixed-version deployment is expected mid-rollout, so this fails a run that started before one finished.
+func validateVersionSkew(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+	client := lambda.NewFromConfig(cfg)
+
+	var reported []buildinfo.Reported
+	for _, function := range deploymentFunctions(t, client, resolver) {
+		functionName := aws.ToString(function.FunctionName)
+		tags, err := client.ListTags(context.TODO(), &lambda.ListTagsInput{Resource: function.FunctionArn})
+		require.NoError(t, err, "Failed to list tags of %s", functionName)
+
+		var variables map[string]string
+		if function.Environment != nil {
+			variables = function.Environment.Variables
+		}
+		if version, ok := buildinfo.FunctionVersion(functionName, variables, tags.Tags); ok {
+			reported = append(reported, version)
+		} else {
+			t.Logf("%s reports no version through %s or its %s tag", functionName, buildinfo.EnvVersion, buildinfo.TagGitSHA)
+		}
+	}
+
+	// The product service serves /health
+	api := requireAPI(t, resolver)
+	healthFunction := resolver.FunctionName("product-service")
+	for range healthSamples {
+		status, body := callAPI(t, http.MethodGet, api.Endpoint+"/health", nil)
+		// A degraded dependency answers 503 but still reports the version; Health_Check reports the dependency
+		require.Contains(t, []int{http.StatusOK, http.StatusServiceUnavailable}, status, "GET /health: %s", body)
+		var health responses.HealthResponse
+		decodeResponse(t, body, &health)
+		if health.Version == "" || health.Version == healthcheck.UnknownVersion {
+			break
+		}
+		reported = append(reported, buildinfo.Reported{Function: healthFunction, Version: health.Version, Source: buildinfo.SourceHealth})
+	}
+
+	if len(reported) == 0 {
+		t.Skip("No function reports an application version; pass build_sha to Terraform to record one")
+	}
+	if groups := buildinfo.Skew(reported); groups != nil {
+		assert.Fail(t, "Functions run different versions", "%d versions are deployed, as during a partial rollout:\n%s",
+			len(groups), buildinfo.DescribeSkew(groups))
+	}
+}
@@ -0,0 +1,186 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+	"github.com/lambda-java-template/tests/internal/coldstart"
+)
+
+// vpcColdStartAllowance is how far a VPC-attached function's p95 cold start may rise above the previous
+// benchmark run before the rise is blamed on network interface setup
+const vpcColdStartAllowance = 500 * time.Millisecond
+
+// validateVPCNetworking checks every VPC-attached function: subnets in at least two Availability Zones,
+// security groups that allow HTTPS out, and a route to DynamoDB and Step Functions through VPC endpoints or
+// a NAT gateway. With -cold-starts it also forces cold starts and fails when the p95 rose more than
+// vpcColdStartAllowance over the last run in -cold-start-history. The template's functions are not
+// VPC-attached, so this is skipped until they are.
+func validateVPCNetworking(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+	lambdaClient := lambda.NewFromConfig(cfg)
+	ec2Client := ec2.NewFromConfig(cfg)
+
+	attached := map[string]*lambdatypes.VpcConfigResponse{}
+	var suffixes []string
+	for _, suffix := range append([]string{"product-service", "authorizer-service"}, workflowFunctions...) {
+		config, err := lambdaClient.GetFunctionConfiguration(context.TODO(), &lambda.GetFunctionConfigurationInput{
+			FunctionName: aws.String(resolver.FunctionName(suffix)),
+		})
+		var notFound *lambdatypes.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			continue
+		}
+		require.NoError(t, err)
+		if config.VpcConfig != nil && len(config.VpcConfig.SubnetIds) > 0 {
+			attached[suffix] = config.VpcConfig
+			suffixes = append(suffixes, suffix)
+		}
+	}
+	if len(attached) == 0 {
+		t.Skip("No function of the deployment is VPC-attached")
+	}
+
+	history, err := coldstart.LoadHistory(*coldStartHistory)
+	require.NoError(t, err)
+	events := coldStartEvents()
+
+	for _, suffix := range suffixes {
+		vpcConfig := attached[suffix]
+		functionName := resolver.FunctionName(suffix)
+
+		t.Run(suffix, func(t *testing.T) {
+			t.Run("Subnet_Spread", func(t *testing.T) {
+				subnets, err := ec2Client.DescribeSubnets(context.TODO(), &ec2.DescribeSubnetsInput{SubnetIds: vpcConfig.SubnetIds})
+				require.NoError(t, err)
+				zones := map[string]string{}
+				for _, subnet := range subnets.Subnets {
+					zones[aws.ToString(subnet.SubnetId)] = aws.ToString(subnet.AvailabilityZone)
+				}
+				for _, gap := range checks.ZoneSpreadGaps(zones) {
+					assert.Fail(t, "Function is exposed to one Availability Zone", "%s: %s", functionName, gap)
+				}
+			})
+
+			t.Run("Security_Group_Egress", func(t *testing.T) {
+				groups, err := ec2Client.DescribeSecurityGroups(context.TODO(), &ec2.DescribeSecurityGroupsInput{GroupIds: vpcConfig.SecurityGroupIds})
+				require.NoError(t, err)
+				var rules []checks.EgressRule
+				for _, group := range groups.SecurityGroups {
+					for _, permission := range group.IpPermissionsEgress {
+						rules = append(rules, egressRule(permission))
+					}
+				}
+				for _, gap := range checks.EgressGaps(rules) {
+					assert.Fail(t, "Function cannot reach AWS APIs", "%s (security groups %v): %s", functionName, vpcConfig.SecurityGroupIds, gap)
+				}
+			})
+
+			t.Run("Private_Access", func(t *testing.T) {
+				vpcId := aws.ToString(vpcConfig.VpcId)
+				inVPC := []ec2types.Filter{{Name: aws.String("vpc-id"), Values: []string{vpcId}}}
+
+				var endpointServices []string
+				endpoints := ec2.NewDescribeVpcEndpointsPaginator(ec2Client, &ec2.DescribeVpcEndpointsInput{Filters: inVPC})
+				for endpoints.HasMorePages() {
+					page, err := endpoints.NextPage(context.TODO())
+					require.NoError(t, err)
+					for _, endpoint := range page.VpcEndpoints {
+						if endpoint.State == ec2types.StateAvailable {
+							endpointServices = append(endpointServices, aws.ToString(endpoint.ServiceName))
+						}
+					}
+				}
+
+				var routeTables []ec2types.RouteTable
+				tables := ec2.NewDescribeRouteTablesPaginator(ec2Client, &ec2.DescribeRouteTablesInput{Filters: inVPC})
+				for tables.HasMorePages() {
+					page, err := tables.NextPage(context.TODO())
+					require.NoError(t, err)
+					routeTables = append(routeTables, page.RouteTables...)
+				}
+				natRoute := true
+				for _, subnet := range vpcConfig.SubnetIds {
+					natRoute = natRoute && routesThroughNAT(routeTables, subnet)
+				}
+
+				for _, gap := range checks.PrivateAccessGaps(cfg.Region, endpointServices, natRoute) {
+					assert.Fail(t, "Function cannot reach a dependency", "%s in %s: %s", functionName, vpcId, gap)
+				}
+			})
+
+			t.Run("Cold_Start", func(t *testing.T) {
+				if *coldStarts <= 0 {
+					t.Skip("Skipping VPC cold starts; set -cold-starts to run them")
+				}
+				previous, ok := coldstart.Previous(history, environment, suffix)
+				if !ok {
+					t.Skipf("No previous cold start of %s in %s to compare with", suffix, *coldStartHistory)
+				}
+
+				result := coldstart.Summarize(forceColdStarts(t, lambdaClient, functionName, events[suffix], *coldStarts))
+				t.Logf("%s: cold start p95 %dms over %d samples (previous run: p95 %dms)", functionName, result.P95Ms, result.Samples, previous.P95Ms)
+				for _, gap := range checks.ColdStartRegressionGaps(result.P95Ms, previous.P95Ms, vpcColdStartAllowance.Milliseconds()) {
+					assert.Fail(t, "VPC cold start regression", "%s: %s", functionName, gap)
+				}
+			})
+		})
+	}
+}
+
+// egressRule converts an outbound security group permission for checks.EgressGaps
+func egressRule(permission ec2types.IpPermission) checks.EgressRule {
+	rule := checks.EgressRule{
+		Protocol: aws.ToString(permission.IpProtocol),
+		FromPort: aws.ToInt32(permission.FromPort),
+		ToPort:   aws.ToInt32(permission.ToPort),
+	}
+	for _, ipRange := range permission.IpRanges {
+		rule.Destinations = append(rule.Destinations, aws.ToString(ipRange.CidrIp))
+	}
+	for _, ipRange := range permission.Ipv6Ranges {
+		rule.Destinations = append(rule.Destinations, aws.ToString(ipRange.CidrIpv6))
+	}
+	for _, prefixList := range permission.PrefixListIds {
+		rule.Destinations = append(rule.Destinations, aws.ToString(prefixList.PrefixListId))
+	}
+	for _, pair := range permission.UserIdGroupPairs {
+		rule.Destinations = append(rule.Destinations, aws.ToString(pair.GroupId))
+	}
+	return rule
+}
+
+// routesThroughNAT reports whether the route table of a subnet, its own or else the VPC's main table,
+// sends internet traffic to a NAT gateway
+func routesThroughNAT(routeTables []ec2types.RouteTable, subnetId string) bool {
+	var table *ec2types.RouteTable
+	for i, candidate := range routeTables {
+		for _, association := range candidate.Associations {
+			if aws.ToString(association.SubnetId) == subnetId {
+				table = &routeTables[i]
+			} else if table == nil && aws.ToBool(association.Main) {
+				table = &routeTables[i]
+			}
+		}
+	}
+	if table == nil {
+		return false
+	}
+	for _, route := range table.Routes {
+		if aws.ToString(route.DestinationCidrBlock) == "0.0.0.0/0" && route.NatGatewayId != nil {
+			return true
+		}
+	}
+	return false
+}
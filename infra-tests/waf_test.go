@@ -0,0 +1,99 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	cloudfronttypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2"
+	wafv2types "github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/checks"
+)
+
+// sqlInjectionProbe is a query string no legitimate client sends and the SQLi rule group blocks
+const sqlInjectionProbe = "id=1%27%20OR%20%271%27%3D%271%27%3B--"
+
+// validateWAF checks the web ACL protecting the API enforces the required AWS managed rule groups and
+// blocks an obviously malicious request. HTTP APIs cannot be associated with a web ACL directly, so the
+// ACL must sit on a CloudFront distribution in front of the API; without one the check is skipped.
+func validateWAF(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+
+	endpoint, err := url.Parse(requireAPI(t, resolver).Endpoint)
+	require.NoError(t, err)
+
+	distribution := findDistribution(t, cloudfront.NewFromConfig(cfg), endpoint.Host)
+	if distribution == nil {
+		t.Skipf("No CloudFront distribution has %s as an origin, so no web ACL can protect the HTTP API", endpoint.Host)
+	}
+	webACLArn := aws.ToString(distribution.WebACLId)
+	require.NotEmpty(t, webACLArn, "CloudFront distribution %s in front of the API has no web ACL", aws.ToString(distribution.Id))
+
+	t.Run("Managed_Rule_Groups", func(t *testing.T) {
+		name, id, ok := checks.ParseWebACLARN(webACLArn)
+		require.True(t, ok, "Distribution %s uses classic WAF web ACL %s; migrate it to WAFv2", aws.ToString(distribution.Id), webACLArn)
+
+		// Web ACLs of CloudFront distributions are global and only served from us-east-1
+		client := wafv2.NewFromConfig(cfg, func(o *wafv2.Options) { o.Region = "us-east-1" })
+		acl, err := client.GetWebACL(context.TODO(), &wafv2.GetWebACLInput{
+			Name:  aws.String(name),
+			Id:    aws.String(id),
+			Scope: wafv2types.ScopeCloudfront,
+		})
+		require.NoError(t, err)
+
+		var groups []checks.ManagedRuleGroup
+		for _, rule := range acl.WebACL.Rules {
+			if rule.Statement == nil || rule.Statement.ManagedRuleGroupStatement == nil {
+				continue
+			}
+			statement := rule.Statement.ManagedRuleGroupStatement
+			groups = append(groups, checks.ManagedRuleGroup{
+				Vendor:    aws.ToString(statement.VendorName),
+				Name:      aws.ToString(statement.Name),
+				CountOnly: rule.OverrideAction != nil && rule.OverrideAction.Count != nil,
+			})
+		}
+		for _, gap := range checks.WebACLGaps(groups, checks.RequiredManagedRuleGroups) {
+			assert.Fail(t, "Web ACL does not protect the API", "%s: %s", name, gap)
+		}
+	})
+
+	t.Run("Blocks_SQL_Injection", func(t *testing.T) {
+		probeURL := fmt.Sprintf("https://%s/products?%s", aws.ToString(distribution.DomainName), sqlInjectionProbe)
+		status, body := callAPI(t, http.MethodGet, probeURL, nil)
+		assert.Equal(t, http.StatusForbidden, status, "GET %s was not blocked: %.200s", probeURL, body)
+	})
+}
+
+// findDistribution returns the CloudFront distribution with an origin on host, or nil when there is none
+func findDistribution(t *testing.T, client *cloudfront.Client, host string) *cloudfronttypes.DistributionSummary {
+	paginator := cloudfront.NewListDistributionsPaginator(client, &cloudfront.ListDistributionsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		require.NoError(t, err)
+		if page.DistributionList == nil {
+			continue
+		}
+
+		for i, distribution := range page.DistributionList.Items {
+			if distribution.Origins == nil {
+				continue
+			}
+			for _, origin := range distribution.Origins.Items {
+				if aws.ToString(origin.DomainName) == host {
+					return &page.DistributionList.Items[i]
+				}
+			}
+		}
+	}
+	return nil
+}
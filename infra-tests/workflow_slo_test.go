@@ -0,0 +1,98 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	sfntypes "github.com/aws/aws-sdk-go-v2/service/sfn/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lambda-java-template/tests/internal/discovery"
+	"github.com/lambda-java-template/tests/internal/executions"
+	"github.com/lambda-java-template/tests/internal/expectations"
+	"github.com/lambda-java-template/tests/internal/results"
+)
+
+// maxListedFailures is how many unsuccessful executions a missed objective names
+const maxListedFailures = 10
+
+// validateWorkflowSLO lists the order-processing state machine's executions started within -execution-window,
+// archives them in the results file, and fails when fewer succeeded than the environment profile's
+// WorkflowSuccessRate. The suite's own executions fail on purpose and are left out; a window with fewer than
+// executions.DefaultMinExecutions finished runs is archived but not judged.
+func validateWorkflowSLO(t *testing.T, cfg aws.Config, projectName, environment string) {
+	resolver := discover(cfg, projectName, environment)
+
+	stateMachineArn, err := resolver.StateMachineARN(context.TODO(), "order-processing")
+	if errors.Is(err, discovery.ErrNotFound) {
+		t.Skipf("%v", err)
+	}
+	require.NoError(t, err)
+	client := sfn.NewFromConfig(cfg)
+	if stateMachineType(t, client, stateMachineArn) == sfntypes.StateMachineTypeExpress {
+		t.Skip("EXPRESS state machines keep no execution list; their outcomes are in CloudWatch Logs")
+	}
+
+	end := time.Now().UTC()
+	start := end.Add(-*executionWindow)
+	listed, err := executions.List(context.TODO(), client, stateMachineArn, start)
+	require.NoError(t, err, "Failed to list executions of %s", stateMachineArn)
+	listed = executions.Exclude(listed, executions.SuitePrefixes)
+
+	summary := executions.Summarize(listed)
+	objective := executions.SLO{
+		MinSuccessRate: expectations.For(environment).WorkflowSuccessRate,
+		MinExecutions:  executions.DefaultMinExecutions,
+	}
+	suiteResults.RecordWorkflowRuns(workflowRuns(resolver.ResourceName("order-processing"), start, end, listed, summary, objective))
+	t.Logf("%d executions in the last %s: %d succeeded, %d failed, %d timed out, %d aborted, %d running",
+		len(listed), *executionWindow, summary.Succeeded, summary.Failed, summary.TimedOut, summary.Aborted, summary.Running)
+
+	if !objective.Enforced(summary) {
+		t.Logf("Success rate not judged: %s sets %.2f and %d of %d executions needed have finished",
+			environment, objective.MinSuccessRate, summary.Finished(), objective.MinExecutions)
+		return
+	}
+	if violation := objective.Violation(summary); violation != "" {
+		unsuccessful := executions.Unsuccessful(listed)
+		if len(unsuccessful) > maxListedFailures {
+			unsuccessful = unsuccessful[len(unsuccessful)-maxListedFailures:]
+		}
+		for _, execution := range unsuccessful {
+			t.Logf("%s %s at %s", execution.Status, execution.ARN, execution.Started.Format(time.RFC3339))
+		}
+		t.Errorf("%s: %s", stateMachineArn, violation)
+	}
+}
+
+// workflowRuns converts listed executions of stateMachine and their summary into a results entry
+func workflowRuns(stateMachine string, start, end time.Time, listed []executions.Execution, summary executions.Summary, objective executions.SLO) results.WorkflowRuns {
+	runs := results.WorkflowRuns{
+		StateMachine: stateMachine,
+		WindowStart:  start,
+		WindowEnd:    end,
+		Succeeded:    summary.Succeeded,
+		Failed:       summary.Failed,
+		TimedOut:     summary.TimedOut,
+		Aborted:      summary.Aborted,
+		Running:      summary.Running,
+		SuccessRate:  summary.SuccessRate(),
+		Runs:         make([]results.WorkflowRun, 0, len(listed)),
+	}
+	if objective.Enforced(summary) {
+		runs.Objective = objective.MinSuccessRate
+	}
+	for _, execution := range listed {
+		run := results.WorkflowRun{Name: execution.Name, Status: string(execution.Status), StartedAt: execution.Started.UTC()}
+		if !execution.Stopped.IsZero() {
+			run.StoppedAt = aws.Time(execution.Stopped.UTC())
+			run.DurationMs = execution.Duration().Milliseconds()
+		}
+		runs.Runs = append(runs.Runs, run)
+	}
+	return runs
+}
@@ -0,0 +1,66 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	sfntypes "github.com/aws/aws-sdk-go-v2/service/sfn/types"
+
+	"github.com/lambda-java-template/tests/internal/asl"
+	"github.com/lambda-java-template/tests/internal/chaos"
+	"github.com/lambda-java-template/tests/internal/history"
+	"github.com/lambda-java-template/tests/internal/triage"
+)
+
+// maxTriageLogLines bounds how many log lines are read for one failed state; the excerpt is cut from these
+const maxTriageLogLines = 1000
+
+// triageExecution logs why an execution failed: the state it failed in with its error and cause, and an
+// excerpt of what the Lambda function behind that state logged while it ran
+func triageExecution(t *testing.T, cfg aws.Config, definition asl.Definition, execution *workflowExecution, events []sfntypes.HistoryEvent) {
+	step, ok := history.FailedStep(history.Steps(events))
+	if !ok {
+		t.Logf("Execution %s ended %s without a failed state: %s %s", execution.arn, execution.status, execution.error, execution.cause)
+		return
+	}
+	t.Logf("Execution %s failed in %s", execution.arn, triage.Summary(step))
+
+	function := chaos.FunctionForState(asl.LambdaReferences(definition), step.State)
+	if function == "" {
+		return
+	}
+	start, end := triage.Window(step)
+	lines, err := lambdaLogLines(cloudwatchlogs.NewFromConfig(cfg), triage.LogGroup(function), start.UnixMilli(), end.UnixMilli())
+	if err != nil {
+		t.Logf("Could not read the log of %s: %v", function, err)
+		return
+	}
+	if len(lines) == 0 {
+		t.Logf("%s logged nothing while %s ran", function, step.State)
+		return
+	}
+	t.Logf("%s logged while %s ran:\n%s", function, step.State, strings.Join(triage.Excerpt(lines), "\n"))
+}
+
+// lambdaLogLines returns the lines a log group received between two times, in milliseconds since the epoch
+func lambdaLogLines(client *cloudwatchlogs.Client, logGroup string, start, end int64) ([]string, error) {
+	var lines []string
+	paginator := cloudwatchlogs.NewFilterLogEventsPaginator(client, &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName: aws.String(logGroup),
+		StartTime:    aws.Int64(start),
+		EndTime:      aws.Int64(end),
+	})
+	for paginator.HasMorePages() && len(lines) < maxTriageLogLines {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range page.Events {
+			lines = append(lines, strings.TrimRight(aws.ToString(event.Message), "\n"))
+		}
+	}
+	return lines, nil
+}
@@ -0,0 +1,103 @@
+package software.amazonaws.example.product;
+
+import software.amazon.awssdk.services.dynamodb.DynamoDbClient;
+import software.amazon.awssdk.services.dynamodb.model.TableStatus;
+import software.amazon.awssdk.services.eventbridge.EventBridgeClient;
+
+import java.time.Duration;
+import java.time.Instant;
+import java.util.LinkedHashMap;
+import java.util.Map;
+
+/**
+ * Deep health check of the product service.
+ *
+ * Probes every dependency the service needs and reports each one's status, the build the function
+ * runs and how long its execution environment has been up. The response schema is HealthResponse
+ * in openapi/product-api.yaml; the infrastructure tests validate live responses against it.
+ */
+public class HealthCheck {
+
+    /**
+     * Checks one dependency, throwing when it is unreachable or not ready.
+     */
+    @FunctionalInterface
+    public interface Probe {
+        void check() throws Exception;
+    }
+
+    static final String UNKNOWN_VERSION = "unknown";
+
+    private static final Instant STARTED_AT = Instant.now();
+
+    private final Map<String, Probe> probes;
+    private final String version;
+
+    public HealthCheck(Map<String, Probe> probes, String version) {
+        this.probes = new LinkedHashMap<>(probes);
+        this.version = version == null || version.isBlank() ? UNKNOWN_VERSION : version;
+    }
+
+    /**
+     * Builds the check from the function's environment: the products table always, and the event bus
+     * when EVENT_BUS_NAME is set.
+     */
+    public static HealthCheck fromEnvironment() {
+        Map<String, Probe> probes = new LinkedHashMap<>();
+
+        String tableName = System.getenv("PRODUCTS_TABLE_NAME");
+        DynamoDbClient dynamoDbClient = DynamoDbClient.create();
+        probes.put("dynamodb", () -> {
+            TableStatus status = dynamoDbClient.describeTable(r -> r.tableName(tableName)).table().tableStatus();
+            if (status != TableStatus.ACTIVE) {
+                throw new IllegalStateException("table " + tableName + " is " + status);
+            }
+        });
+
+        String eventBusName = System.getenv("EVENT_BUS_NAME");
+        if (eventBusName != null && !eventBusName.isBlank()) {
+            EventBridgeClient eventBridgeClient = EventBridgeClient.create();
+            probes.put("eventbridge", () -> eventBridgeClient.describeEventBus(r -> r.name(eventBusName)));
+        }
+
+        return new HealthCheck(probes, System.getenv("APP_VERSION"));
+    }
+
+    /**
+     * Runs every probe and returns the health response body.
+     */
+    public Map<String, Object> check() {
+        Map<String, Object> dependencies = new LinkedHashMap<>();
+        boolean healthy = true;
+
+        for (Map.Entry<String, Probe> probe : probes.entrySet()) {
+            Map<String, Object> dependency = new LinkedHashMap<>();
+            Instant start = Instant.now();
+            try {
+                probe.getValue().check();
+                dependency.put("status", "ok");
+            } catch (Exception e) {
+                healthy = false;
+                dependency.put("status", "degraded");
+                dependency.put("error", e.getMessage() != null ? e.getMessage() : e.getClass().getSimpleName());
+            }
+            dependency.put("latencyMs", Duration.between(start, Instant.now()).toMillis());
+            dependencies.put(probe.getKey(), dependency);
+        }
+
+        Map<String, Object> body = new LinkedHashMap<>();
+        body.put("status", healthy ? "healthy" : "degraded");
+        body.put("service", "product-service");
+        body.put("version", version);
+        body.put("uptimeSeconds", Duration.between(STARTED_AT, Instant.now()).toSeconds());
+        body.put("dependencies", dependencies);
+        return body;
+    }
+
+    /**
+     * Whether a body returned by {@link #check()} reports every dependency as ok.
+     */
+    public static boolean isHealthy(Map<String, Object> body) {
+        return "healthy".equals(body.get("status"));
+    }
+}
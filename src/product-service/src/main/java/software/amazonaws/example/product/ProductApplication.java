@@ -40,4 +40,12 @@ public class ProductApplication {
             SpringBootProductHandler springBootProductHandler) {
         return springBootProductHandler;
     }
+    
+    /**
+     * Deep health check behind GET /health, probing the dependencies the environment configures.
+     */
+    @Bean
+    public HealthCheck healthCheck() {
+        return HealthCheck.fromEnvironment();
+    }
 }
\ No newline at end of file
@@ -28,11 +28,17 @@ import java.util.function.Function;
 public class SpringBootProductHandler implements Function<APIGatewayV2HTTPEvent, APIGatewayV2HTTPResponse> {
     
     private final ProductService productService;
+    private final HealthCheck healthCheck;
     private final ObjectMapper objectMapper;
     
-    @Autowired
     public SpringBootProductHandler(ProductService productService) {
+        this(productService, new HealthCheck(Map.of(), System.getenv("APP_VERSION")));
+    }
+    
+    @Autowired
+    public SpringBootProductHandler(ProductService productService, HealthCheck healthCheck) {
         this.productService = productService;
+        this.healthCheck = healthCheck;
         this.objectMapper = new ObjectMapper();
     }
     
@@ -98,7 +104,8 @@ public class SpringBootProductHandler implements Function<APIGatewayV2HTTPEvent,
     
     private APIGatewayV2HTTPResponse handleGetRequest(APIGatewayV2HTTPEvent request, String path) throws JsonProcessingException {
         if (path.equals("/health")) {
-            return createSuccessResponse(Map.of("status", "healthy", "service", "product-service"));
+            Map<String, Object> health = healthCheck.check();
+            return createSuccessResponse(health, HealthCheck.isHealthy(health) ? 200 : 503);
         }
         
         if (path.startsWith("/products/")) {
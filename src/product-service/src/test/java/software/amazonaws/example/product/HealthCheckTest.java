@@ -0,0 +1,57 @@
+package software.amazonaws.example.product;
+
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+
+import java.util.LinkedHashMap;
+import java.util.Map;
+
+import static org.assertj.core.api.Assertions.assertThat;
+
+@DisplayName("HealthCheck")
+class HealthCheckTest {
+
+    @Test
+    @DisplayName("should be healthy when every probe succeeds")
+    void shouldBeHealthyWhenEveryProbeSucceeds() {
+        HealthCheck healthCheck = new HealthCheck(Map.of("dynamodb", () -> { }), "d1e7014");
+
+        Map<String, Object> body = healthCheck.check();
+
+        assertThat(HealthCheck.isHealthy(body)).isTrue();
+        assertThat(body).containsEntry("service", "product-service");
+        assertThat(body).containsEntry("version", "d1e7014");
+        assertThat((Long) body.get("uptimeSeconds")).isNotNegative();
+        assertThat(dependency(body, "dynamodb")).containsEntry("status", "ok").containsKey("latencyMs");
+    }
+
+    @Test
+    @DisplayName("should be degraded when any probe fails")
+    void shouldBeDegradedWhenAnyProbeFails() {
+        Map<String, HealthCheck.Probe> probes = new LinkedHashMap<>();
+        probes.put("dynamodb", () -> { });
+        probes.put("eventbridge", () -> {
+            throw new IllegalStateException("event bus orders not found");
+        });
+
+        Map<String, Object> body = new HealthCheck(probes, "d1e7014").check();
+
+        assertThat(body).containsEntry("status", "degraded");
+        assertThat(dependency(body, "dynamodb")).containsEntry("status", "ok");
+        assertThat(dependency(body, "eventbridge"))
+                .containsEntry("status", "degraded")
+                .containsEntry("error", "event bus orders not found");
+    }
+
+    @Test
+    @DisplayName("should report an unknown version when none is deployed")
+    void shouldReportUnknownVersionWhenNoneIsDeployed() {
+        assertThat(new HealthCheck(Map.of(), null).check()).containsEntry("version", "unknown");
+        assertThat(new HealthCheck(Map.of(), " ").check()).containsEntry("version", "unknown");
+    }
+
+    @SuppressWarnings("unchecked")
+    private static Map<String, Object> dependency(Map<String, Object> body, String name) {
+        return ((Map<String, Map<String, Object>>) body.get("dependencies")).get(name);
+    }
+}
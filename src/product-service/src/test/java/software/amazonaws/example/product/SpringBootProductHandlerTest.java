@@ -88,6 +88,26 @@ class SpringBootProductHandlerTest {
             assertThat(body).containsEntry("service", "product-service");
         }
         
+        @Test
+        @DisplayName("should return 503 when a dependency is degraded")
+        void shouldReturnServiceUnavailableWhenDependencyIsDegraded() throws Exception {
+            // Given
+            HealthCheck healthCheck = new HealthCheck(Map.of("dynamodb", () -> {
+                throw new IllegalStateException("table products is UPDATING");
+            }), "d1e7014");
+            handler = new SpringBootProductHandler(productService, healthCheck);
+            APIGatewayV2HTTPEvent request = createRequest("GET", "/health", null, null);
+            
+            // When
+            APIGatewayV2HTTPResponse response = handler.apply(request);
+            
+            // Then
+            assertThat(response.getStatusCode()).isEqualTo(503);
+            Map<String, Object> body = objectMapper.readValue(response.getBody(), new TypeReference<Map<String, Object>>() {});
+            assertThat(body).containsEntry("status", "degraded");
+            assertThat(body).containsKeys("version", "uptimeSeconds", "dependencies");
+        }
+        
         @Test
         @DisplayName("should include correlation ID in response headers")
         void shouldIncludeCorrelationIdInResponseHeaders() {
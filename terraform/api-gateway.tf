@@ -33,7 +33,7 @@ module "api_gateway" {
       integration = {
         uri                    = module.lambda_functions[route.func_key].lambda_function_invoke_arn
         payload_format_version = "2.0"
-        timeout_milliseconds   = 30000
+        timeout_milliseconds   = local.api_integration_timeout_ms
       }
       authorization_type = route.auth ? "CUSTOM" : "NONE"
       authorizer_key     = route.auth ? "api_key" : null
@@ -53,6 +53,30 @@ module "api_gateway" {
     }
   }
 
+  # Access logs (JSON, one entry per request)
+  stage_access_log_settings = {
+    create_log_group = false
+    destination_arn  = aws_cloudwatch_log_group.api_access_logs.arn
+    format = jsonencode({
+      requestId          = "$context.requestId"
+      routeKey           = "$context.routeKey"
+      status             = "$context.status"
+      integrationLatency = "$context.integrationLatency"
+      responseLatency    = "$context.responseLatency"
+      sourceIp           = "$context.identity.sourceIp"
+      userAgent          = "$context.identity.userAgent"
+      errorMessage       = "$context.error.message"
+    })
+  }
+
+  tags = local.common_tags
+}
+
+resource "aws_cloudwatch_log_group" "api_access_logs" {
+  name              = "/aws/apigateway/${local.function_base_name}-api"
+  retention_in_days = var.log_retention_days
+  kms_key_id        = var.log_kms_key_arn != "" ? var.log_kms_key_arn : null
+
   tags = local.common_tags
 }
 
@@ -340,6 +340,96 @@ resource "aws_cloudwatch_metric_alarm" "api_gateway_latency" {
   tags = local.common_tags
 }
 
+# Anomaly detection alarms on latency: fire when latency rises above the band CloudWatch learned for the
+# metric, catching slowdowns well below the static thresholds above
+resource "aws_cloudwatch_metric_alarm" "lambda_duration_anomaly" {
+  for_each = local.lambda_functions
+
+  alarm_name          = "${each.value.name}-duration-anomaly"
+  comparison_operator = "GreaterThanUpperThreshold"
+  evaluation_periods  = "3"
+  threshold_metric_id = "band"
+  alarm_description   = "This metric monitors lambda duration for ${each.value.name} against its anomaly detection band"
+  alarm_actions       = [aws_sns_topic.alerts.arn]
+
+  metric_query {
+    id          = "band"
+    expression  = "ANOMALY_DETECTION_BAND(duration, 2)"
+    label       = "Duration (expected)"
+    return_data = true
+  }
+
+  metric_query {
+    id          = "duration"
+    return_data = true
+
+    metric {
+      metric_name = "Duration"
+      namespace   = "AWS/Lambda"
+      period      = 300
+      stat        = "Average"
+
+      dimensions = {
+        FunctionName = each.value.name
+      }
+    }
+  }
+
+  tags = local.common_tags
+}
+
+resource "aws_cloudwatch_metric_alarm" "api_gateway_latency_anomaly" {
+  alarm_name          = "${local.api_gateway_name}-latency-anomaly"
+  comparison_operator = "GreaterThanUpperThreshold"
+  evaluation_periods  = "3"
+  threshold_metric_id = "band"
+  alarm_description   = "This metric monitors API Gateway latency against its anomaly detection band"
+  alarm_actions       = [aws_sns_topic.alerts.arn]
+
+  metric_query {
+    id          = "band"
+    expression  = "ANOMALY_DETECTION_BAND(latency, 2)"
+    label       = "Latency (expected)"
+    return_data = true
+  }
+
+  metric_query {
+    id          = "latency"
+    return_data = true
+
+    metric {
+      metric_name = "Latency"
+      namespace   = "AWS/ApiGateway"
+      period      = 300
+      stat        = "Average"
+
+      dimensions = {
+        ApiName = local.api_gateway_name
+      }
+    }
+  }
+
+  tags = local.common_tags
+}
+
+# Service-level health rolls up the alarms users notice: function errors, API 5xx errors, and unusual latency
+resource "aws_cloudwatch_composite_alarm" "service_health" {
+  alarm_name        = "${local.function_base_name}-service-health"
+  alarm_description = "Any function error rate, API 5xx, or latency anomaly alarm is firing"
+  alarm_actions     = [aws_sns_topic.alerts.arn]
+  ok_actions        = [aws_sns_topic.alerts.arn]
+
+  alarm_rule = join(" OR ", [
+    for name in concat(
+      [for alarm in aws_cloudwatch_metric_alarm.lambda_error_rate : alarm.alarm_name],
+      [for alarm in aws_cloudwatch_metric_alarm.lambda_duration_anomaly : alarm.alarm_name],
+      [aws_cloudwatch_metric_alarm.api_gateway_5xx_errors.alarm_name, aws_cloudwatch_metric_alarm.api_gateway_latency_anomaly.alarm_name],
+    ) : "ALARM(\"${name}\")"
+  ])
+
+  tags = local.common_tags
+}
+
 # SNS Topic for CloudWatch Alarms
 resource "aws_sns_topic" "alerts" {
   name = "${local.function_base_name}-alerts"
@@ -347,12 +437,22 @@ resource "aws_sns_topic" "alerts" {
   tags = local.common_tags
 }
 
+# Alarms only page someone once the topic has a confirmed subscriber
+resource "aws_sns_topic_subscription" "alerts_email" {
+  count = var.alert_email != "" ? 1 : 0
+
+  topic_arn = aws_sns_topic.alerts.arn
+  protocol  = "email"
+  endpoint  = var.alert_email
+}
+
 # CloudWatch Log Groups with proper retention
 resource "aws_cloudwatch_log_group" "lambda_logs" {
   for_each = local.lambda_functions
 
   name              = "/aws/lambda/${each.value.name}"
   retention_in_days = var.log_retention_days
+  kms_key_id        = var.log_kms_key_arn != "" ? var.log_kms_key_arn : null
 
   tags = local.common_tags
 }
@@ -67,6 +67,7 @@ module "audit_logs_table" {
   ttl_enabled        = true
 
   server_side_encryption_enabled = true
+  point_in_time_recovery_enabled = local.require_point_in_time_recovery
 
   tags = local.common_tags
 }
\ No newline at end of file
@@ -8,7 +8,7 @@ module "lambda_functions" {
   description   = "Serverless function for ${each.key} endpoint"
   handler       = each.value.handler
   runtime       = each.value.runtime
-  architectures = ["x86_64"]
+  architectures = [var.lambda_architecture]
 
   # Skip handler for native runtime (provided.al2)
   skip_destroy = false
@@ -22,14 +22,14 @@ module "lambda_functions" {
   timeout     = local.lambda_timeout
   memory_size = local.lambda_memory
 
-  environment_variables = {
+  environment_variables = merge({
     ENVIRONMENT                      = local.environment
     LOG_LEVEL                        = "INFO"
     PRODUCTS_TABLE_NAME              = module.products_table.dynamodb_table_id
     AUDIT_TABLE_NAME                 = module.audit_logs_table.dynamodb_table_id
     SPRING_CLOUD_FUNCTION_DEFINITION = "springBootProductHandler"
     MAIN_CLASS                       = "software.amazonaws.example.product.ProductApplication"
-  }
+  }, local.version_environment)
 
   # CloudWatch Logs
   attach_cloudwatch_logs_policy     = true
@@ -50,7 +50,8 @@ module "lambda_functions" {
         "dynamodb:UpdateItem",
         "dynamodb:DeleteItem",
         "dynamodb:Query",
-        "dynamodb:Scan"
+        "dynamodb:Scan",
+        "dynamodb:DescribeTable" # GET /health probes the products table
       ]
       resources = [
         module.products_table.dynamodb_table_arn,
@@ -61,7 +62,7 @@ module "lambda_functions" {
     }
   }
 
-  tags = local.common_tags
+  tags = merge(local.common_tags, local.build_tags)
 }
 
 # Authorizer Lambda (separate module since it doesn't need routes)
@@ -73,7 +74,7 @@ module "lambda_authorizer" {
   description   = "API Key authorizer for API Gateway"
   handler       = local.lambda_functions.authorizer_service.handler
   runtime       = local.lambda_functions.authorizer_service.runtime
-  architectures = ["x86_64"]
+  architectures = [var.lambda_architecture]
 
   # Skip handler for native runtime (provided.al2)
   skip_destroy = false
@@ -87,10 +88,10 @@ module "lambda_authorizer" {
   timeout     = local.lambda_timeout
   memory_size = 256 # Authorizer can use less memory
 
-  environment_variables = {
+  environment_variables = merge({
     ENVIRONMENT = local.environment
     LOG_LEVEL   = "INFO"
-  }
+  }, local.version_environment)
 
   # CloudWatch Logs
   attach_cloudwatch_logs_policy     = true
@@ -100,7 +101,7 @@ module "lambda_authorizer" {
   tracing_mode          = local.xray_tracing ? "Active" : "PassThrough"
   attach_tracing_policy = local.xray_tracing
 
-  tags = local.common_tags
+  tags = merge(local.common_tags, local.build_tags)
 }
 
 
@@ -6,6 +6,10 @@ locals {
   namespace        = var.namespace
   is_ephemeral_env = var.is_ephemeral
 
+  # Supplied by the caller, since timestamp() would re-date the namespace on every plan;
+  # the created_at output refuses an ephemeral namespace without it
+  created_at = var.created_at
+
   # Computed values
   actual_namespace   = local.namespace != "" ? local.namespace : local.environment
   function_base_name = "${local.project_name}-${local.actual_namespace}"
@@ -21,9 +25,16 @@ locals {
   dynamodb_read_capacity  = var.billing_mode == "PROVISIONED" ? var.read_capacity : null
   dynamodb_write_capacity = var.billing_mode == "PROVISIONED" ? var.write_capacity : null
 
+  # Production-like environments must be able to restore every table
+  require_point_in_time_recovery = contains(["staging", "prod"], local.environment)
+
   # API Gateway configuration
   api_gateway_name = "${local.function_base_name}-api"
 
+  # API Gateway gives up a second before the function would, within the HTTP API range of 50 ms to 30 s,
+  # so a slow invocation always ends in the gateway's timeout response instead of racing the function's timeout
+  api_integration_timeout_ms = max(50, min(30000, (local.lambda_timeout - 1) * 1000))
+
   # DynamoDB table configurations for monitoring
   table_configurations = {
     products = {
@@ -66,5 +77,14 @@ locals {
     Namespace   = local.actual_namespace
     ManagedBy   = "terraform"
     Ephemeral   = local.is_ephemeral_env ? "true" : "false"
-  }, var.additional_tags)
+  }, local.created_at != "" ? { CreatedAt = local.created_at } : {}, var.additional_tags)
+
+  # Build metadata the integration tests compare deployed functions against
+  build_tags = var.build_sha == "" ? {} : merge({
+    GitSha            = var.build_sha
+    BuildArchitecture = var.lambda_architecture
+  }, var.build_time != "" ? { BuildTime = var.build_time } : {})
+
+  # Application version each function reports, e.g. in the product service's /health response
+  version_environment = var.build_sha == "" ? {} : { APP_VERSION = var.build_sha }
 }
\ No newline at end of file
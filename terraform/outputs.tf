@@ -49,4 +49,14 @@ output "sns_alerts_topic_arn" {
 output "lambda_artifacts_bucket_name" {
   description = "Name of the S3 bucket for Lambda deployment artifacts"
   value       = module.lambda_artifacts_bucket.s3_bucket_id
-}
\ No newline at end of file
+}
+
+output "created_at" {
+  description = "Creation time tagged as CreatedAt on an ephemeral namespace"
+  value       = local.created_at
+
+  precondition {
+    condition     = !local.is_ephemeral_env || local.created_at != ""
+    error_message = "An ephemeral namespace needs created_at, e.g. -var created_at=$(date -u +%Y-%m-%dT%H:%M:%SZ), so cmd/janitor can expire it."
+  }
+}
@@ -10,6 +10,18 @@ module "lambda_artifacts_bucket" {
     enabled = true
   }
 
+  # Superseded JARs are kept for rollbacks, then expired
+  lifecycle_rule = [
+    {
+      id      = "expire-superseded-artifacts"
+      enabled = true
+
+      noncurrent_version_expiration = {
+        noncurrent_days = 30
+      }
+    }
+  ]
+
   # Server-side encryption
   server_side_encryption_configuration = {
     rule = {
@@ -38,7 +38,48 @@ variable "is_ephemeral" {
   default     = false
 }
 
+variable "created_at" {
+  description = "RFC 3339 creation time of an ephemeral namespace, tagged as CreatedAt so cmd/janitor can expire it; required when is_ephemeral is set"
+  type        = string
+  default     = ""
+  validation {
+    condition     = var.created_at == "" || can(formatdate("YYYY", var.created_at))
+    error_message = "created_at must be an RFC 3339 timestamp such as 2024-05-01T12:00:00Z."
+  }
+}
+
+# Build metadata recorded on every function by the pipeline
+variable "build_sha" {
+  description = "Git commit the deployment packages were built from, tagged as GitSha"
+  type        = string
+  default     = ""
+  validation {
+    condition     = can(regex("^([0-9a-f]{7,40})?$", var.build_sha))
+    error_message = "build_sha must be a git commit hash."
+  }
+}
+
+variable "build_time" {
+  description = "RFC 3339 time the deployment packages were built, tagged as BuildTime"
+  type        = string
+  default     = ""
+  validation {
+    condition     = var.build_time == "" || can(formatdate("YYYY", var.build_time))
+    error_message = "build_time must be an RFC 3339 timestamp such as 2024-05-01T12:00:00Z."
+  }
+}
+
 # Environment-specific Lambda configuration
+variable "lambda_architecture" {
+  description = "Instruction set architecture of every function; the packages must be built for it"
+  type        = string
+  default     = "x86_64"
+  validation {
+    condition     = contains(["x86_64", "arm64"], var.lambda_architecture)
+    error_message = "lambda_architecture must be x86_64 or arm64."
+  }
+}
+
 variable "function_memory" {
   description = "Memory allocation for Lambda functions"
   type        = number
@@ -75,6 +116,18 @@ variable "log_retention_days" {
   }
 }
 
+variable "alert_email" {
+  description = "Email address subscribed to the CloudWatch alarm topic; empty leaves the topic without subscribers"
+  type        = string
+  default     = ""
+}
+
+variable "log_kms_key_arn" {
+  description = "KMS key ARN used to encrypt Lambda log groups; the key policy must allow the CloudWatch Logs service principal"
+  type        = string
+  default     = ""
+}
+
 # DynamoDB configuration
 variable "billing_mode" {
   description = "DynamoDB billing mode"